@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/notify"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
 	"github.com/amankhys/multi_vendor_ecommerce_go/repository"
 	"github.com/amankhys/multi_vendor_ecommerce_go/repository/db"
@@ -15,13 +16,17 @@ import (
 func OrdersCron() {
 	var dbConn = repository.NewDBConfig("cancel orders")
 	var DB = db.New(dbConn)
+	notifier, err := notify.New(dbConn)
+	if err != nil {
+		log.Fatal("error setting up notifications: ", err)
+	}
 	for {
-		cancelVoidOrders(DB)
+		cancelVoidOrders(DB, notifier)
 		time.Sleep(10 * time.Minute)
 	}
 }
 
-func cancelVoidOrders(DB *db.Queries) {
+func cancelVoidOrders(DB *db.Queries, notifier *notify.Notifier) {
 	orders, err := DB.GetAllOrders(context.TODO())
 	if err != nil {
 		log.Error("error fetching orders inn CancelVoidOrders:", err.Error())
@@ -50,6 +55,11 @@ func cancelVoidOrders(DB *db.Queries) {
 			orderItems, err := DB.CancelOrderByID(context.TODO(), o.ID)
 			if err != nil {
 				log.Error("error cancelling order in CancelVoidOrders:", err.Error())
+			} else {
+				notifier.Log(context.TODO(), o.UserID, notify.KindOrderCancelled, notify.Data{
+					OrderID: uuid.NullUUID{UUID: o.ID, Valid: true},
+					Reason:  "The online payment was not completed in time.",
+				})
 			}
 
 			payment, err := DB.CancelPaymentByOrderID(context.TODO(), o.ID)
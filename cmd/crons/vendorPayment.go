@@ -6,22 +6,31 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/notify"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
 	"github.com/amankhys/multi_vendor_ecommerce_go/repository"
 	"github.com/amankhys/multi_vendor_ecommerce_go/repository/db"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 )
 
 func PaymentRoutine() {
 	var dbConn = repository.NewDBConfig("vendor payments")
 	var DB = db.New(dbConn)
+	// seller payouts go to the audit log of the payment service
+	var auditLog = audit.New(dbConn, "payment_audit_logs")
+	notifier, err := notify.New(dbConn)
+	if err != nil {
+		log.Fatal("error setting up notifications: ", err)
+	}
 	for {
-		updateVendorPaymentsAndSellerWallet(DB)
+		updateVendorPaymentsAndSellerWallet(DB, auditLog, notifier)
 		time.Sleep(3 * time.Hour)
 	}
 }
 
-func updateVendorPaymentsAndSellerWallet(DB *db.Queries) {
+func updateVendorPaymentsAndSellerWallet(DB *db.Queries, auditLog *audit.Logger, notifier *notify.Notifier) {
 	orderItems, err := DB.GetAllOrderItemsForAdmin(context.TODO())
 	if err != nil {
 		log.Error("error fetching orderItems in payment go routine")
@@ -59,6 +68,17 @@ func updateVendorPaymentsAndSellerWallet(DB *db.Queries) {
 				log.Error("error  updating wallet savings in payment go routine")
 				continue
 			}
+			auditLog.RecordSystem(context.TODO(), audit.Entry{
+				Action:     audit.ActionWalletCredit,
+				EntityType: audit.EntityWallet,
+				EntityID:   vp.SellerID.String(),
+				After:      map[string]any{"credit": vp.CreditAmount, "reason": "vendor payment", "vendor_payment_id": vp.ID},
+			})
+			notifier.Log(context.TODO(), vp.SellerID, notify.KindPayoutReceived, notify.Data{
+				OrderID:     uuid.NullUUID{UUID: oi.OrderID, Valid: true},
+				OrderItemID: uuid.NullUUID{UUID: oi.ID, Valid: true},
+				Amount:      vp.CreditAmount,
+			})
 
 			msg := fmt.Sprintf("updated vp: %s from status %s to %s", vp.ID.String(), vp.Status, updatedVP.Status)
 			log.Info(msg)
@@ -0,0 +1,419 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// csv columns shared by import and export so an exported file can be edited and imported back.
+// rows with an id update that product, rows without one add a new product.
+// categories are separated by ';'
+var catalogueHeader = []string{"id", "name", "description", "price", "stock", "categories"}
+
+const maxImportFileSize = 5 << 20 // 5MB
+const maxImportRows = 5000
+
+type importRowReport struct {
+	Row       int       `json:"row"`
+	ProductID uuid.UUID `json:"product_id,omitempty"`
+	Action    string    `json:"action,omitempty"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+type importRow struct {
+	id          uuid.UUID
+	name        string
+	description string
+	price       float64
+	stock       int
+	categories  []string
+}
+
+// parseCatalogueRow validates a single csv record, the errors are collected
+// instead of returned early so the seller can fix the whole row in one go
+func parseCatalogueRow(record []string) (importRow, []string) {
+	var row importRow
+	var Err []string
+	if len(record) != len(catalogueHeader) {
+		return row, []string{fmt.Sprintf("expected %d columns, got %d", len(catalogueHeader), len(record))}
+	}
+	if idStr := strings.TrimSpace(record[0]); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			Err = append(Err, "invalid id")
+		}
+		row.id = id
+	}
+	row.name = strings.TrimSpace(record[1])
+	if !validators.ValidateProductName(row.name) {
+		Err = append(Err, "invalid name")
+	}
+	row.description = strings.TrimSpace(record[2])
+	price, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+	if err != nil || !validators.ValidateProductPrice(price) {
+		Err = append(Err, "invalid price")
+	}
+	row.price = price
+	stock, err := strconv.Atoi(strings.TrimSpace(record[4]))
+	if err != nil || !validators.ValidateProductStock(stock) {
+		Err = append(Err, "invalid stock")
+	}
+	row.stock = stock
+	for _, c := range strings.Split(record[5], ";") {
+		if c = strings.ToLower(strings.TrimSpace(c)); c != "" {
+			row.categories = append(row.categories, c)
+		}
+	}
+	return row, Err
+}
+
+func (s *Seller) ImportProductsHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	if !checkSellerApproved(w, user.ID) {
+		return
+	}
+	// once for the file, rows that add products need it like AddProductHandler does
+	if !checkSellerAddress(w, user.ID) {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize+(1<<20))
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		http.Error(w, "invalid multipart form or file larger than 5MB", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	file, fh, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "no csv uploaded, use the form field 'file'", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	// the file is read and checked up front so a broken csv is rejected right away,
+	// only the db work is left to the background job
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		http.Error(w, "unable to read csv header", http.StatusBadRequest)
+		return
+	}
+	for i, h := range catalogueHeader {
+		if i >= len(header) || strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))) != h {
+			http.Error(w, "invalid csv header, expected: "+strings.Join(catalogueHeader, ","), http.StatusBadRequest)
+			return
+		}
+	}
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			http.Error(w, "invalid csv: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		records = append(records, record)
+		if len(records) > maxImportRows {
+			http.Error(w, fmt.Sprintf("csv has more than %d rows, split it into smaller files", maxImportRows), http.StatusBadRequest)
+			return
+		}
+	}
+	if len(records) == 0 {
+		http.Error(w, "csv has no product rows", http.StatusBadRequest)
+		return
+	}
+
+	job, err := s.DB.AddProductImportJob(context.TODO(), db.AddProductImportJobParams{
+		SellerID:  user.ID,
+		FileName:  fh.Filename,
+		TotalRows: int32(len(records)),
+	})
+	if err != nil {
+		log.Warn("error adding import job in ImportProductsHandler:", err.Error())
+		http.Error(w, "internal error starting import", http.StatusInternalServerError)
+		return
+	}
+	go s.runProductImport(job, records)
+
+	var resp struct {
+		JobID     uuid.UUID `json:"job_id"`
+		Status    string    `json:"status"`
+		TotalRows int       `json:"total_rows"`
+		Message   string    `json:"message"`
+	}
+	resp.JobID = job.ID
+	resp.Status = job.Status
+	resp.TotalRows = int(job.TotalRows)
+	resp.Message = "import started, check the progress at /seller/products/import/status?job_id=" + job.ID.String()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// runProductImport applies the rows one by one, every row is its own
+// transaction so one bad row doesn't undo the rest of the file
+func (s *Seller) runProductImport(job db.ProductImportJob, records [][]string) {
+	ctx := context.Background()
+	start := time.Now()
+	_, err := s.DB.UpdateProductImportJobStatus(ctx, db.UpdateProductImportJobStatusParams{
+		ID:     job.ID,
+		Status: utils.StatusImportJobProcessing,
+	})
+	if err != nil {
+		log.Warn("error marking import job ", job.ID, " as processing:", err.Error())
+	}
+
+	var report = []importRowReport{}
+	var created, updated, failed int32
+	for i, record := range records {
+		// line 1 is the header
+		line := i + 2
+		row, Err := parseCatalogueRow(record)
+		if len(Err) > 0 {
+			failed++
+			report = append(report, importRowReport{Row: line, Errors: Err})
+			continue
+		}
+		productID, action, err := s.importRow(ctx, job.SellerID, row)
+		if err != nil {
+			failed++
+			report = append(report, importRowReport{Row: line, Errors: []string{err.Error()}})
+			continue
+		}
+		if action == "created" {
+			created++
+		} else {
+			updated++
+		}
+		report = append(report, importRowReport{Row: line, ProductID: productID, Action: action})
+	}
+
+	status := utils.StatusImportJobCompleted
+	if created+updated == 0 {
+		status = utils.StatusImportJobFailed
+	}
+	reportJSON, err := json.Marshal(report)
+	if err != nil {
+		log.Warn("error marshalling import report for job ", job.ID, ":", err.Error())
+		reportJSON = []byte("[]")
+	}
+	_, err = s.DB.CompleteProductImportJob(ctx, db.CompleteProductImportJobParams{
+		ID:          job.ID,
+		Status:      status,
+		CreatedRows: created,
+		UpdatedRows: updated,
+		FailedRows:  failed,
+		Report:      reportJSON,
+	})
+	if err != nil {
+		log.Error("error completing import job ", job.ID, ":", err.Error())
+		return
+	}
+	log.Infof("import job %s done in %s: %d created, %d updated, %d failed", job.ID, time.Since(start).Round(time.Millisecond), created, updated, failed)
+}
+
+// FailInterruptedImports marks the imports the service was running when it last
+// stopped as failed, call it once from the service main before serving
+func FailInterruptedImports() {
+	// row 0 is the whole file
+	reportJSON, err := json.Marshal([]importRowReport{{Errors: []string{
+		"the import was interrupted by a restart. rows before that may have been saved, " +
+			"check your products and upload the remaining rows again",
+	}}})
+	if err != nil {
+		log.Error("error marshalling interrupted import report:", err.Error())
+		return
+	}
+	n, err := DB.FailUnfinishedProductImportJobs(context.TODO(), reportJSON)
+	if err != nil {
+		log.Error("error failing interrupted import jobs:", err.Error())
+		return
+	}
+	if n > 0 {
+		log.Warnf("marked %d interrupted import jobs as failed", n)
+	}
+}
+
+var errImportInternal = errors.New("internal error saving the row, try again")
+
+// importRow adds or updates the product of a validated row along with its categories
+func (s *Seller) importRow(ctx context.Context, sellerID uuid.UUID, row importRow) (uuid.UUID, string, error) {
+	for _, c := range row.categories {
+		_, err := s.DB.GetCategoryByName(ctx, c)
+		if err == sql.ErrNoRows {
+			return uuid.Nil, "", errors.New("unknown category: " + c)
+		} else if err != nil {
+			log.Warn("error fetching category in import:", err.Error())
+			return uuid.Nil, "", errImportInternal
+		}
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Warn("error starting transaction in import:", err.Error())
+		return uuid.Nil, "", errImportInternal
+	}
+	defer tx.Rollback()
+	qtx := s.DB.WithTx(tx)
+
+	var product db.Product
+	var action string
+	if row.id == uuid.Nil {
+		product, err = qtx.AddProduct(ctx, db.AddProductParams{
+			Name:        row.name,
+			Description: row.description,
+			Price:       row.price,
+			Stock:       int32(row.stock),
+			SellerID:    sellerID,
+		})
+		action = "created"
+	} else {
+		var existing db.Product
+		existing, err = qtx.GetProductByID(ctx, row.id)
+		if err == sql.ErrNoRows || (err == nil && existing.SellerID != sellerID) {
+			return uuid.Nil, "", errors.New("no product with this id in your catalogue")
+		} else if err != nil {
+			log.Warn("error fetching product in import:", err.Error())
+			return uuid.Nil, "", errImportInternal
+		}
+		product, err = qtx.EditProductByID(ctx, db.EditProductByIDParams{
+			ID:          row.id,
+			Name:        row.name,
+			Description: row.description,
+			Price:       row.price,
+			Stock:       int32(row.stock),
+		})
+		action = "updated"
+	}
+	if err != nil {
+		log.Warn("error saving product in import:", err.Error())
+		return uuid.Nil, "", errImportInternal
+	}
+
+	if err = qtx.DeleteAllCategoriesForProductByID(ctx, product.ID); err != nil {
+		log.Warn("error removing product categories in import:", err.Error())
+		return uuid.Nil, "", errImportInternal
+	}
+	for _, c := range row.categories {
+		_, err = qtx.AddProductToCategoryByCategoryName(ctx, db.AddProductToCategoryByCategoryNameParams{
+			ProductID:    product.ID,
+			CategoryName: c,
+		})
+		if err != nil {
+			log.Warn("error adding product to category in import:", err.Error())
+			return uuid.Nil, "", errImportInternal
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing row in import:", err.Error())
+		return uuid.Nil, "", errImportInternal
+	}
+	return product.ID, action, nil
+}
+
+func (s *Seller) ImportStatusHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+
+	// without a job_id list every import of the seller
+	jobIDStr := r.URL.Query().Get("job_id")
+	if jobIDStr == "" {
+		jobs, err := s.DB.GetProductImportJobsBySellerID(context.TODO(), user.ID)
+		if err != nil {
+			log.Warn("error fetching import jobs in ImportStatusHandler:", err.Error())
+			http.Error(w, "internal error fetching import jobs", http.StatusInternalServerError)
+			return
+		}
+		var resp struct {
+			Data    []db.GetProductImportJobsBySellerIDRow `json:"data"`
+			Message string                                 `json:"message"`
+		}
+		resp.Data = jobs
+		resp.Message = "successfully fetched import jobs"
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	jobID, err := uuid.Parse(jobIDStr)
+	if err != nil {
+		http.Error(w, "invalid job_id", http.StatusBadRequest)
+		return
+	}
+	job, err := s.DB.GetProductImportJobByIDAndSellerID(context.TODO(), db.GetProductImportJobByIDAndSellerIDParams{
+		ID:       jobID,
+		SellerID: user.ID,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid job_id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching import job in ImportStatusHandler:", err.Error())
+		http.Error(w, "internal error fetching import job", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data    db.ProductImportJob `json:"data"`
+		Message string              `json:"message"`
+	}
+	resp.Data = job
+	resp.Message = "import is " + job.Status
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) ExportProductsHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	products, err := s.DB.GetProductsWithCategoriesBySellerID(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error fetching products in ExportProductsHandler:", err.Error())
+		http.Error(w, "internal error fetching products", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=products_%s.csv", time.Now().Format("2006-01-02")))
+	writer := csv.NewWriter(w)
+	writer.Write(catalogueHeader)
+	for _, p := range products {
+		writer.Write([]string{
+			p.ID.String(),
+			p.Name,
+			p.Description,
+			strconv.FormatFloat(p.Price, 'f', 2, 64),
+			strconv.Itoa(int(p.Stock)),
+			p.Categories,
+		})
+	}
+	writer.Flush()
+	if err = writer.Error(); err != nil {
+		log.Warn("error writing csv in ExportProductsHandler:", err.Error())
+	}
+}
@@ -0,0 +1,427 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type respCategoryNode struct {
+	ID       uuid.UUID           `json:"id"`
+	Name     string              `json:"name"`
+	Slug     string              `json:"slug"`
+	Children []*respCategoryNode `json:"children"`
+}
+
+type respAttribute struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"category_id"`
+	Name       string    `json:"name"`
+	Label      string    `json:"label"`
+	DataType   string    `json:"data_type"`
+	Options    []string  `json:"options"`
+	IsRequired bool      `json:"is_required"`
+}
+
+type respAttributeValue struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	DataType string `json:"data_type"`
+	Value    string `json:"value"`
+}
+
+func toRespAttribute(a db.CategoryAttribute) respAttribute {
+	return respAttribute{
+		ID:         a.ID,
+		CategoryID: a.CategoryID,
+		Name:       a.Name,
+		Label:      a.Label,
+		DataType:   a.DataType,
+		Options:    a.Options,
+		IsRequired: a.IsRequired,
+	}
+}
+
+// productAttributeValues fetches the attribute values filled in for a product
+func productAttributeValues(q *db.Queries, productID uuid.UUID) []respAttributeValue {
+	values, err := q.GetProductAttributeValuesByProductID(context.TODO(), productID)
+	if err != nil {
+		log.Warn("error fetching attribute values of product ", productID, ":", err.Error())
+	}
+	var respValues = []respAttributeValue{}
+	for _, v := range values {
+		respValues = append(respValues, respAttributeValue{
+			Name:     v.Name,
+			Label:    v.Label,
+			DataType: v.DataType,
+			Value:    v.Value,
+		})
+	}
+	return respValues
+}
+
+// buildCategoryTree nests the flat category list under their parents.
+// categories whose parent is deleted end up at the root
+func buildCategoryTree(categories []db.Category) []*respCategoryNode {
+	nodes := make(map[uuid.UUID]*respCategoryNode)
+	for _, c := range categories {
+		nodes[c.ID] = &respCategoryNode{ID: c.ID, Name: c.Name, Slug: c.Slug, Children: []*respCategoryNode{}}
+	}
+
+	var roots = []*respCategoryNode{}
+	for _, c := range categories {
+		parent, ok := nodes[c.ParentID.UUID]
+		if c.ParentID.Valid && ok {
+			parent.Children = append(parent.Children, nodes[c.ID])
+		} else {
+			roots = append(roots, nodes[c.ID])
+		}
+	}
+
+	var sortNodes func([]*respCategoryNode)
+	sortNodes = func(n []*respCategoryNode) {
+		sort.Slice(n, func(i, j int) bool { return n[i].Name < n[j].Name })
+		for _, c := range n {
+			sortNodes(c.Children)
+		}
+	}
+	sortNodes(roots)
+	return roots
+}
+
+func (u *User) CategoryTreeHandler(w http.ResponseWriter, r *http.Request) {
+	categories, err := u.DB.GetAllCategories(context.TODO())
+	if err != nil {
+		log.Warn("error fetching categories in CategoryTreeHandler:", err.Error())
+		http.Error(w, "internal error fetching categories", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data    []*respCategoryNode `json:"data"`
+		Message string              `json:"message"`
+	}
+	resp.Data = buildCategoryTree(categories)
+	resp.Message = "successfully fetched category tree"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CategoryAttributesHandler lists the attributes of a category including the inherited ones,
+// users filter on them with attr_<name>=<value> and sellers fill them in for their products
+func (u *User) CategoryAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	slug := r.URL.Query().Get("slug")
+	category, err := u.DB.GetCategoryBySlug(context.TODO(), slug)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid category slug", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching category by slug in CategoryAttributesHandler:", err.Error())
+		http.Error(w, "internal error fetching category", http.StatusInternalServerError)
+		return
+	}
+	attributes, err := u.DB.GetCategoryAttributesWithAncestorsByCategoryID(context.TODO(), category.ID)
+	if err != nil {
+		log.Warn("error fetching category attributes in CategoryAttributesHandler:", err.Error())
+		http.Error(w, "internal error fetching category attributes", http.StatusInternalServerError)
+		return
+	}
+	var respAttributes = []respAttribute{}
+	for _, a := range attributes {
+		respAttributes = append(respAttributes, toRespAttribute(a))
+	}
+	var resp struct {
+		Data    []respAttribute `json:"data"`
+		Message string          `json:"message"`
+	}
+	resp.Data = respAttributes
+	resp.Message = "successfully fetched attributes of category:" + category.Name
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) SetProductAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID  uuid.UUID         `json:"product_id"`
+		Attributes map[string]string `json:"attributes"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, req.ProductID) {
+		return
+	}
+
+	attributes, err := s.DB.GetAttributesForProductByID(context.TODO(), req.ProductID)
+	if err != nil {
+		log.Warn("error fetching attributes of product in SetProductAttributesHandler:", err.Error())
+		http.Error(w, "internal error fetching product attributes", http.StatusInternalServerError)
+		return
+	}
+
+	// validate every value against its attribute type before touching the db
+	var Err []string
+	var values []db.UpsertProductAttributeValueParams
+	known := make(map[string]bool)
+	for _, a := range attributes {
+		known[a.Name] = true
+		raw, ok := req.Attributes[a.Name]
+		if !ok {
+			if a.IsRequired {
+				Err = append(Err, "attribute "+a.Name+" is required")
+			}
+			continue
+		}
+		value, ok := validators.ValidateAttributeValue(a.DataType, a.Options, raw)
+		if !ok {
+			msg := "invalid value for " + a.Name + ", expected " + a.DataType
+			if a.DataType == utils.AttributeTypeEnum {
+				msg += " one of: " + strings.Join(a.Options, ", ")
+			}
+			Err = append(Err, msg)
+			continue
+		}
+		values = append(values, db.UpsertProductAttributeValueParams{
+			ProductID:   req.ProductID,
+			AttributeID: a.ID,
+			Value:       value,
+		})
+	}
+	for name := range req.Attributes {
+		if !known[name] {
+			Err = append(Err, "attribute "+name+" does not apply to the product's categories")
+		}
+	}
+	if len(Err) > 0 {
+		var resp struct {
+			Err []string `json:"errors"`
+		}
+		resp.Err = Err
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in SetProductAttributesHandler:", err.Error())
+		http.Error(w, "internal error saving product attributes", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := s.DB.WithTx(tx)
+	if err = qtx.DeleteProductAttributeValuesByProductID(context.TODO(), req.ProductID); err != nil {
+		log.Warn("error removing old attribute values in SetProductAttributesHandler:", err.Error())
+		http.Error(w, "internal error saving product attributes", http.StatusInternalServerError)
+		return
+	}
+	for _, v := range values {
+		if _, err = qtx.UpsertProductAttributeValue(context.TODO(), v); err != nil {
+			log.Warn("error saving attribute value in SetProductAttributesHandler:", err.Error())
+			http.Error(w, "internal error saving product attributes", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing attribute values in SetProductAttributesHandler:", err.Error())
+		http.Error(w, "internal error saving product attributes", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Data    []respAttributeValue `json:"data"`
+		Message string               `json:"message"`
+	}
+	resp.Data = productAttributeValues(s.DB, req.ProductID)
+	resp.Message = "successfully updated product attributes"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *Admin) EditCategoryParentHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Slug       string `json:"slug"`
+		ParentSlug string `json:"parent_slug"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	category, err := a.DB.GetCategoryBySlug(context.TODO(), req.Slug)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid category slug", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching category in EditCategoryParentHandler:", err.Error())
+		http.Error(w, "internal error fetching category", http.StatusInternalServerError)
+		return
+	}
+
+	// empty parent_slug moves the category to the root
+	var arg db.EditCategoryParentBySlugParams
+	arg.Slug = category.Slug
+	if req.ParentSlug != "" {
+		parent, err := a.DB.GetCategoryBySlug(context.TODO(), req.ParentSlug)
+		if err == sql.ErrNoRows {
+			http.Error(w, "invalid parent slug", http.StatusBadRequest)
+			return
+		} else if err != nil {
+			log.Warn("error fetching parent category in EditCategoryParentHandler:", err.Error())
+			http.Error(w, "internal error fetching category", http.StatusInternalServerError)
+			return
+		}
+		// a category can't be moved under itself or one of its own children
+		isDescendant, err := a.DB.IsCategoryDescendant(context.TODO(), db.IsCategoryDescendantParams{
+			CategoryID:   category.ID,
+			DescendantID: parent.ID,
+		})
+		if err != nil {
+			log.Warn("error checking category descendants in EditCategoryParentHandler:", err.Error())
+			http.Error(w, "internal error moving category", http.StatusInternalServerError)
+			return
+		} else if isDescendant {
+			http.Error(w, "cannot move a category under itself or its sub categories", http.StatusBadRequest)
+			return
+		}
+		arg.ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+	}
+	before := category.ParentID
+	category, err = a.DB.EditCategoryParentBySlug(context.TODO(), arg)
+	if err != nil {
+		log.Warn("error updating category parent in EditCategoryParentHandler:", err.Error())
+		http.Error(w, "internal error moving category", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCategoryEdit,
+		EntityType: audit.EntityCategory,
+		EntityID:   category.ID.String(),
+		Before:     map[string]any{"parent_id": before},
+		After:      map[string]any{"parent_id": category.ParentID},
+	})
+	log.Infof("moved category: %s under: %s", category.Slug, req.ParentSlug)
+	var resp struct {
+		Data    db.Category `json:"data"`
+		Message string      `json:"message"`
+	}
+	resp.Data = category
+	resp.Message = "successfully moved category"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *Admin) AddCategoryAttributeHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CategorySlug string   `json:"category_slug"`
+		Name         string   `json:"name"`
+		Label        string   `json:"label"`
+		DataType     string   `json:"data_type"`
+		Options      []string `json:"options"`
+		IsRequired   bool     `json:"is_required"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+
+	var Err []string
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	if !validators.ValidateAttributeName(req.Name) {
+		Err = append(Err, "invalid attribute name, use lowercase letters, digits and underscores")
+	}
+	if strings.TrimSpace(req.Label) == "" {
+		req.Label = req.Name
+	}
+	if !validators.ValidateAttributeType(req.DataType) {
+		Err = append(Err, "invalid data_type, allowed types are text, number, boolean and enum")
+	}
+	var options = []string{}
+	for _, o := range req.Options {
+		if o = strings.TrimSpace(o); o != "" {
+			options = append(options, o)
+		}
+	}
+	if req.DataType == utils.AttributeTypeEnum && len(options) == 0 {
+		Err = append(Err, "enum attributes need at least one option")
+	} else if req.DataType != utils.AttributeTypeEnum {
+		options = []string{}
+	}
+	if len(Err) > 0 {
+		http.Error(w, strings.Join(Err, "; "), http.StatusBadRequest)
+		return
+	}
+
+	category, err := a.DB.GetCategoryBySlug(context.TODO(), req.CategorySlug)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid category slug", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching category in AddCategoryAttributeHandler:", err.Error())
+		http.Error(w, "internal error fetching category", http.StatusInternalServerError)
+		return
+	}
+	attribute, err := a.DB.AddCategoryAttribute(context.TODO(), db.AddCategoryAttributeParams{
+		CategoryID: category.ID,
+		Name:       req.Name,
+		Label:      strings.TrimSpace(req.Label),
+		DataType:   req.DataType,
+		Options:    options,
+		IsRequired: req.IsRequired,
+	})
+	if err != nil {
+		log.Warn("error adding category attribute in AddCategoryAttributeHandler:", err.Error())
+		http.Error(w, "failed to add attribute, it may already exist for the category", http.StatusBadRequest)
+		return
+	}
+	log.Infof("added attribute: %s to category: %s", attribute.Name, category.Name)
+	var resp struct {
+		Data    respAttribute `json:"data"`
+		Message string        `json:"message"`
+	}
+	resp.Data = toRespAttribute(attribute)
+	resp.Message = "successfully added attribute to category:" + category.Name
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *Admin) DeleteCategoryAttributeHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID uuid.UUID `json:"id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	attribute, err := a.DB.DeleteCategoryAttributeByID(context.TODO(), req.ID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid attribute id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error deleting category attribute in DeleteCategoryAttributeHandler:", err.Error())
+		http.Error(w, "internal error deleting attribute", http.StatusInternalServerError)
+		return
+	}
+	log.Infof("deleted category attribute: %s", attribute.Name)
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("attribute: " + attribute.Name + " deleted"))
+}
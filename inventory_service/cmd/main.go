@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"inventory_service"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Try to load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Note: .env file not found, relying on environment variables")
+	}
+
+	// stopped on SIGINT/SIGTERM, the crons and servers below shut down with it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mux := http.NewServeMux()
+	inventoryservice.RegisterRoutes(mux)
+	inventoryservice.FailInterruptedImports()
+
+	// grpc server for the other services
+	grpcPort := "50052"
+	if p := os.Getenv(envname.GRPCPort); p != "" {
+		grpcPort = p
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	grpcServer := inventoryservice.NewGRPCServer()
+	go func() {
+		log.Printf("Starting inventory_service grpc server on port %s", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// back in stock and price drop mails
+	cronDone := make(chan struct{})
+	go func() {
+		inventoryservice.StockAlertsCron(ctx)
+		close(cronDone)
+	}()
+
+	port := "7778"
+	if p := os.Getenv("PORT"); p != "" {
+		port = p
+	}
+	srv := &http.Server{Addr: ":" + port, Handler: mux}
+	go func() {
+		log.Printf("Starting inventory_service on port %s", port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down inventory_service")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Println("error shutting down http server:", err)
+	}
+	grpcServer.GracefulStop()
+	<-cronDone
+}
@@ -0,0 +1,53 @@
+-- queries for the account data export and deletion of the user service
+
+-- name: GetReviewsByUserID :many
+select r.id, r.product_id, p.name as product_name, r.rating, r.comment, r.is_deleted, r.is_edited,
+r.is_verified, r.moderation_status, r.created_at, r.updated_at
+from reviews r
+inner join products p
+on r.product_id = p.id
+where r.user_id = $1
+order by r.created_at;
+
+-- name: GetWishListItemsByUserID :many
+select l.id as list_id, l.name as list_name, l.is_default, w.product_id, p.name as product_name,
+w.price_at_add, w.created_at
+from wishlists w
+inner join wishlist_lists l
+on w.list_id = l.id
+inner join products p
+on w.product_id = p.id
+where w.user_id = $1
+order by l.created_at, w.created_at;
+
+-- name: GetStockSubscriptionsByUserID :many
+select s.product_id, p.name as product_name, s.email, s.notified_at, s.created_at
+from stock_subscriptions s
+inner join products p
+on s.product_id = p.id
+where s.user_id = $1
+order by s.created_at;
+
+-- the rating stays so product ratings don't change, the text and images go
+-- name: EraseReviewsByUserID :exec
+update reviews
+set comment = null, updated_at = current_timestamp
+where user_id = $1;
+
+-- name: DeleteReviewImagesByUserID :many
+delete from review_images
+where review_id in (select id from reviews where user_id = $1)
+returning image_key, thumbnail_key;
+
+-- name: DeleteReviewFlagsByUserID :exec
+delete from review_flags
+where user_id = $1;
+
+-- items of the lists are deleted with them
+-- name: DeleteWishListsByUserID :exec
+delete from wishlist_lists
+where user_id = $1;
+
+-- name: DeleteStockSubscriptionsByUserID :exec
+delete from stock_subscriptions
+where user_id = $1;
@@ -13,11 +13,34 @@ where id = $1 and is_deleted = false;
 select * from categories
 where name = $1 and is_deleted = false;
 
+-- name: GetCategoryBySlug :one
+select * from categories
+where slug = $1 and is_deleted = false;
+
 -- name: AddCateogry :one
 insert into categories
-(name) values ($1)
+(name, slug, parent_id) values ($1, $2, $3)
+returning *;
+
+-- name: EditCategoryParentBySlug :one
+update categories
+set parent_id = @parent_id, updated_at = current_timestamp
+where slug = @slug and is_deleted = false
 returning *;
 
+-- name: IsCategoryDescendant :one
+-- true when @descendant_id is @category_id itself or somewhere below it
+with recursive tree as (
+    select cat.id from categories cat
+    where cat.id = @category_id
+    union
+    select c.id from categories c
+    inner join tree t
+    on c.parent_id = t.id
+)
+select count(*) > 0 as is_descendant from tree t
+where t.id = @descendant_id::uuid;
+
 -- name: DeleteCategoryByName :one
 update categories
 set is_deleted = true, updated_at = current_timestamp
@@ -26,7 +49,7 @@ returning *;
 
 -- name: EditCategoryNameByName :one
 update categories
-set name = @new_name, updated_at = current_timestamp
+set name = @new_name, slug = @new_slug, updated_at = current_timestamp
 where name = @name and is_deleted = false
 returning *;
 
@@ -41,9 +64,84 @@ on ci.category_id = c.id
 where ci.product_id = $1 and c.is_deleted = false;
 
 -- name: GetProductsByCategoryName :many
-select p.id, p.name, p.description, p.price, p.stock, p.seller_id, p.created_at, p.updated_at from category_items ci
+-- products of the category and all of its sub categories, by name or slug
+with recursive tree as (
+    select cat.id from categories cat
+    where (cat.name = @category or cat.slug = @category) and cat.is_deleted = false
+    union
+    select c.id from categories c
+    inner join tree t
+    on c.parent_id = t.id
+    where c.is_deleted = false
+)
+select distinct p.id, p.name, p.description, p.price, p.stock, p.seller_id, p.created_at, p.updated_at from category_items ci
 inner join products p
 on ci.product_id = p.id
-inner join categories c
-on ci.category_id = c.id
-where c.name = $1 and c.is_deleted = false and p.is_deleted = false;
\ No newline at end of file
+where ci.category_id in (select t.id from tree t) and p.is_deleted = false;
+
+-- name: AddCategoryAttribute :one
+insert into category_attributes
+(category_id, name, label, data_type, options, is_required)
+values
+($1, $2, $3, $4, $5, $6)
+returning *;
+
+-- name: GetCategoryAttributeByID :one
+select * from category_attributes
+where id = $1 and is_deleted = false;
+
+-- name: DeleteCategoryAttributeByID :one
+update category_attributes
+set is_deleted = true, updated_at = current_timestamp
+where id = $1 and is_deleted = false
+returning *;
+
+-- name: GetCategoryAttributesWithAncestorsByCategoryID :many
+-- a category inherits the attributes of every parent above it
+with recursive ancestors as (
+    select cat.id, cat.parent_id from categories cat
+    where cat.id = $1
+    union
+    select c.id, c.parent_id from categories c
+    inner join ancestors a
+    on c.id = a.parent_id
+)
+select ca.* from category_attributes ca
+where ca.category_id in (select a.id from ancestors a) and ca.is_deleted = false
+order by ca.name;
+
+-- name: GetAttributesForProductByID :many
+-- attributes of every category the product is in along with their parents
+with recursive ancestors as (
+    select c.id, c.parent_id from categories c
+    inner join category_items ci
+    on ci.category_id = c.id
+    where ci.product_id = $1 and c.is_deleted = false
+    union
+    select c.id, c.parent_id from categories c
+    inner join ancestors a
+    on c.id = a.parent_id
+)
+select ca.* from category_attributes ca
+where ca.category_id in (select a.id from ancestors a) and ca.is_deleted = false
+order by ca.name;
+
+-- name: UpsertProductAttributeValue :one
+insert into product_attribute_values
+(product_id, attribute_id, value)
+values
+($1, $2, $3)
+on conflict (product_id, attribute_id)
+do update set value = excluded.value, updated_at = current_timestamp
+returning *;
+
+-- name: DeleteProductAttributeValuesByProductID :exec
+delete from product_attribute_values
+where product_id = $1;
+
+-- name: GetProductAttributeValuesByProductID :many
+select ca.name, ca.label, ca.data_type, pav.value from product_attribute_values pav
+inner join category_attributes ca
+on pav.attribute_id = ca.id
+where pav.product_id = $1 and ca.is_deleted = false
+order by ca.name;
@@ -0,0 +1,41 @@
+-- name: AddProductImage :one
+insert into product_images
+(product_id, image_url, image_key, thumbnail_url, thumbnail_key, position)
+values
+($1, $2, $3, $4, $5, (select count(*) from product_images where product_id = $1))
+returning *;
+
+-- name: GetProductImagesByProductID :many
+select * from product_images
+where product_id = $1
+order by position, created_at;
+
+-- name: GetProductImagesByProductIDs :many
+select * from product_images
+where product_id = any(@product_ids::uuid[])
+order by product_id, position, created_at;
+
+-- name: GetProductImageByID :one
+select * from product_images
+where id = $1;
+
+-- name: GetProductImageCountByProductID :one
+select count(*) from product_images
+where product_id = $1;
+
+-- name: UpdateProductImagePosition :one
+update product_images
+set position = @position, updated_at = current_timestamp
+where id = @id and product_id = @product_id
+returning *;
+
+-- name: DeleteProductImageByID :one
+delete from product_images
+where id = $1
+returning *;
+
+-- name: ShiftProductImagePositionsAfter :exec
+-- close the gap left behind by a deleted image
+update product_images
+set position = position - 1, updated_at = current_timestamp
+where product_id = @product_id and position > @position;
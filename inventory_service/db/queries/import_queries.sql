@@ -0,0 +1,48 @@
+-- name: AddProductImportJob :one
+insert into product_import_jobs
+(seller_id, file_name, total_rows)
+values
+($1, $2, $3)
+returning *;
+
+-- name: UpdateProductImportJobStatus :one
+update product_import_jobs
+set status = @status, updated_at = current_timestamp
+where id = @id
+returning *;
+
+-- name: CompleteProductImportJob :one
+update product_import_jobs
+set status = @status, created_rows = @created_rows, updated_rows = @updated_rows, failed_rows = @failed_rows,
+report = @report, updated_at = current_timestamp, completed_at = current_timestamp
+where id = @id
+returning *;
+
+-- the rows of an import only live in the service running it, a job it left
+-- unfinished when it stopped can't be picked up again
+-- name: FailUnfinishedProductImportJobs :execrows
+update product_import_jobs
+set status = 'failed', report = @report, updated_at = current_timestamp, completed_at = current_timestamp
+where status in ('pending', 'processing');
+
+-- name: GetProductImportJobByIDAndSellerID :one
+select * from product_import_jobs
+where id = $1 and seller_id = $2;
+
+-- name: GetProductImportJobsBySellerID :many
+select id, seller_id, file_name, status, total_rows, created_rows, updated_rows, failed_rows, created_at, updated_at, completed_at
+from product_import_jobs
+where seller_id = $1
+order by created_at desc;
+
+-- name: GetProductsWithCategoriesBySellerID :many
+select p.id, p.name, p.description, p.price, p.stock,
+coalesce(string_agg(c.name, ';' order by c.name) filter (where c.id is not null), '')::text as categories
+from products p
+left join category_items ci
+on ci.product_id = p.id
+left join categories c
+on ci.category_id = c.id and c.is_deleted = false
+where p.seller_id = $1 and p.is_deleted = false
+group by p.id
+order by p.created_at;
@@ -0,0 +1,83 @@
+-- effective price of a product is coalesce(product_price_at(p.id, <moment>), p.price),
+-- see the product_price_at function in the schema
+
+-- name: GetProductPricing :one
+select p.price as regular_price, p.mrp,
+coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as effective_price,
+-- end of the running sale, null when there is none
+(select pp.ends_at from product_prices pp
+ where pp.product_id = p.id and pp.kind = 'sale' and pp.is_cancelled = false
+ and pp.starts_at <= current_timestamp and pp.ends_at > current_timestamp
+ order by pp.ends_at limit 1) as sale_ends_at
+from products p
+where p.id = $1;
+
+-- name: GetProductPricingByIDs :many
+select p.id, p.price as regular_price, p.mrp,
+coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as effective_price,
+-- end of the running sale, null when there is none
+(select pp.ends_at from product_prices pp
+ where pp.product_id = p.id and pp.kind = 'sale' and pp.is_cancelled = false
+ and pp.starts_at <= current_timestamp and pp.ends_at > current_timestamp
+ order by pp.ends_at limit 1) as sale_ends_at
+from products p
+where p.id = any(@product_ids::uuid[]);
+
+-- name: GetProductPriceAt :one
+select coalesce(product_price_at(p.id, @at::timestamptz), p.price)::float8 as price
+from products p
+where p.id = @product_id;
+
+-- name: GetProductPricesByProductID :many
+select * from product_prices
+where product_id = $1
+order by starts_at desc
+limit 100;
+
+-- name: GetOverlappingSaleCount :one
+select count(*) from product_prices
+where product_id = @product_id and kind = 'sale' and is_cancelled = false
+and starts_at < @ends_at::timestamptz and ends_at > @starts_at::timestamptz;
+
+-- name: AddProductSale :one
+insert into product_prices
+(product_id, kind, price, starts_at, ends_at)
+values
+(@product_id, 'sale', @price, @starts_at, @ends_at)
+returning *;
+
+-- name: GetProductSaleByID :one
+select * from product_prices
+where id = $1 and kind = 'sale';
+
+-- name: CancelProductSaleByID :one
+-- a sale that has not started is cancelled, a running one is ended right away
+update product_prices
+set is_cancelled = (starts_at > current_timestamp),
+ends_at = (case when starts_at > current_timestamp then ends_at else current_timestamp end)
+where id = $1 and kind = 'sale' and is_cancelled = false and ends_at > current_timestamp
+returning *;
+
+-- name: EditProductMRPByID :one
+update products
+set mrp = $2, updated_at = current_timestamp
+where id = $1 and is_deleted = false
+returning *;
+
+-- name: GetDuePriceAlerts :many
+-- rows whose price took effect and haven't been alerted for, with the effective
+-- price just before and at the moment they took effect
+select pp.id, pp.product_id, p.name as product_name,
+coalesce(product_price_at(pp.product_id, pp.starts_at - interval '1 microsecond'), p.price)::float8 as old_price,
+coalesce(product_price_at(pp.product_id, pp.starts_at), p.price)::float8 as new_price
+from product_prices pp
+inner join products p
+on pp.product_id = p.id
+where pp.alerted_at is null and pp.is_cancelled = false and pp.starts_at <= current_timestamp and p.is_deleted = false
+order by pp.starts_at
+limit 100;
+
+-- name: MarkPriceAlerted :exec
+update product_prices
+set alerted_at = current_timestamp
+where id = $1;
@@ -27,13 +27,13 @@ returning *;
 
 -- name: DecProductStockByID :one
 update products
-set stock = stock - @dec_quantity, updated_at = current_timestamp
+set stock = stock - @dec_quantity, sold_count = sold_count + @dec_quantity, updated_at = current_timestamp
 where id = @product_id and stock >= @dec_quantity
 returning *;
 
 -- name: IncProductStockByID :one
 update products
-set stock = stock + @inc_quantity, updated_at = current_timestamp
+set stock = stock + @inc_quantity, sold_count = greatest(sold_count - @inc_quantity, 0), updated_at = current_timestamp
 where id = @product_id
 returning *;
 
@@ -74,40 +74,53 @@ where p.id = $1;
 
 -- name: AddProductReviewWithCommment :one
 insert into reviews
-(user_id, product_id, rating, comment)
+(user_id, product_id, rating, comment, is_verified)
 values
-($1, $2, $3, $4)
+($1, $2, $3, $4, $5)
 returning *;
 
 -- name: AddProductReviewWithoutComment :one
 insert into reviews
-(user_id, product_id, rating)
+(user_id, product_id, rating, is_verified)
 values
-($1, $2, $3)
+($1, $2, $3, $4)
 returning *;
 
 -- name: GetProductReviews :many
+-- hidden reviews stay with their author but are not shown on the product
 select * from reviews
-where product_id = $1;
+where product_id = $1 and is_deleted = false and moderation_status <> 'hidden'
+order by is_verified desc, created_at desc;
 
 -- name: GetProductAverageRatingAndTotalRating :one
 select avg(rating) as average_rating, count(*) as total_rating
 from reviews 
-where product_id = $1;
+where product_id = $1 and is_deleted = false and moderation_status <> 'hidden';
+
+-- name: GetProductRatingHistogram :many
+select rating, count(*) as count
+from reviews
+where product_id = $1 and is_deleted = false and moderation_status <> 'hidden'
+group by rating
+order by rating desc;
 
 -- name: GetReviewByUserAndProductID :one
 select * from reviews
-where user_id = $1 and product_id = $2;
+where user_id = $1 and product_id = $2 and is_deleted = false;
+
+-- name: GetReviewByID :one
+select * from reviews
+where id = $1 and is_deleted = false;
 
 -- name: EditProductReviewByUserAndProductID :one
+update reviews
+set rating = @rating, comment = @comment, is_edited = true, updated_at = current_timestamp
+where user_id = @user_id and product_id = @product_id and is_deleted = false
+returning *;
 
+-- name: DeleteProductReviewByUserAndProductID :one
+update reviews
+set is_deleted = true, updated_at = current_timestamp
+where user_id = $1 and product_id = $2 and is_deleted = false
+returning *;
 
---------------------------------------------------
--- take the sellerID from the product
--- then fetch the seller via that sellerID via a grpc client
--- name: GetSellerByProductID :one
--- SELECT u.id, u.name, u.email, u.phone, u.role, u.is_blocked, u.email_verified, u.user_verified, u.gst_no, u.about
--- FROM  products p
--- INNER JOIN  users u
--- on p.seller_id = u.id and u.role = 'seller' and p.is_deleted = false
--- where p.id = $1;
\ No newline at end of file
@@ -0,0 +1,53 @@
+-- name: AddReviewImage :one
+insert into review_images
+(review_id, image_url, image_key, thumbnail_url, thumbnail_key)
+values
+($1, $2, $3, $4, $5)
+returning *;
+
+-- name: GetReviewImageCountByReviewID :one
+select count(*) from review_images
+where review_id = $1;
+
+-- name: GetReviewImagesByReviewIDs :many
+select * from review_images
+where review_id = any(@review_ids::uuid[])
+order by review_id, created_at;
+
+-- name: AddReviewFlag :one
+insert into review_flags
+(review_id, user_id, reason)
+values
+($1, $2, $3)
+on conflict (review_id, user_id) do nothing
+returning *;
+
+-- name: MarkReviewFlagged :exec
+-- a review the admin already approved or hid keeps its status until a new flag comes in
+update reviews
+set moderation_status = 'flagged', updated_at = current_timestamp
+where id = $1 and moderation_status = 'visible';
+
+-- name: GetFlaggedReviews :many
+select r.id, r.user_id, r.product_id, p.name as product_name, r.rating, r.comment, r.is_verified, r.created_at,
+count(f.id) as flag_count, array_agg(f.reason order by f.created_at)::text[] as reasons
+from reviews r
+inner join products p
+on r.product_id = p.id
+inner join review_flags f
+on f.review_id = r.id and f.is_resolved = false
+where r.moderation_status = 'flagged' and r.is_deleted = false
+group by r.id, p.name
+order by flag_count desc, r.created_at
+limit @limit_count offset @offset_count;
+
+-- name: SetReviewModerationStatus :one
+update reviews
+set moderation_status = @moderation_status, updated_at = current_timestamp
+where id = @id and is_deleted = false
+returning *;
+
+-- name: ResolveReviewFlags :exec
+update review_flags
+set is_resolved = true
+where review_id = $1 and is_resolved = false;
@@ -0,0 +1,124 @@
+-- the filters below are repeated in every search query so the facets
+-- are counted over the same set of products the listing returns.
+-- empty query / categories / attributes means the filter is off.
+-- attributes are "name=value" pairs and a product has to match all of them.
+-- seller_id is only set for the seller storefront
+
+-- name: SearchProducts :many
+with recursive category_tree as (
+    select cat.id from categories cat
+    where (cat.name = any(@categories::text[]) or cat.slug = any(@categories::text[])) and cat.is_deleted = false
+    union
+    select c.id from categories c
+    inner join category_tree t
+    on c.parent_id = t.id
+    where c.is_deleted = false
+), ratings as (
+    select product_id, avg(rating)::float8 as average_rating, count(*) as rating_count
+    from reviews
+    where is_deleted = false and moderation_status <> 'hidden'
+    group by product_id
+), matched as (
+    -- price is the effective price, sale prices included
+    select p.id, p.name, p.description, coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as price, p.stock, p.sold_count, p.seller_id, p.created_at, p.updated_at,
+    coalesce(r.average_rating, 0)::float8 as average_rating,
+    coalesce(r.rating_count, 0)::bigint as rating_count,
+    (case when @query::text = '' then 0
+          else ts_rank(to_tsvector('english', p.name || ' ' || p.description), websearch_to_tsquery('english', @query::text)) + similarity(p.name, @query::text)
+     end)::float8 as relevance
+    from products p
+    left join ratings r
+    on r.product_id = p.id
+    where p.is_deleted = false
+    and (sqlc.narg('seller_id')::uuid is null or p.seller_id = sqlc.narg('seller_id')::uuid)
+    and (@query::text = ''
+        or to_tsvector('english', p.name || ' ' || p.description) @@ websearch_to_tsquery('english', @query::text)
+        or p.name % @query::text)
+    and coalesce(product_price_at(p.id, current_timestamp), p.price) >= @price_min::float8 and coalesce(product_price_at(p.id, current_timestamp), p.price) <= @price_max::float8
+    and (cardinality(@categories::text[]) = 0 or exists (
+        select 1 from category_items ci
+        where ci.product_id = p.id and ci.category_id in (select ct.id from category_tree ct)
+    ))
+    and not exists (
+        select 1 from unnest(@attributes::text[]) as f(pair)
+        where not exists (
+            select 1 from product_attribute_values pav
+            inner join category_attributes ca
+            on pav.attribute_id = ca.id
+            where pav.product_id = p.id and lower(ca.name || '=' || pav.value) = lower(f.pair)
+        )
+    )
+)
+select *, count(*) over() as total_count
+from matched
+order by
+    -- out of stock products always go after the available ones
+    (stock = 0),
+    case when @sort::text = 'price_asc' then price end asc,
+    case when @sort::text = 'price_desc' then price end desc,
+    case when @sort::text = 'rating' then average_rating end desc,
+    case when @sort::text = 'best_selling' then sold_count end desc,
+    case when @sort::text = 'relevance' then relevance end desc,
+    created_at desc,
+    id
+limit @page_limit offset @page_offset;
+
+-- name: SearchProductCategoryFacets :many
+-- category counts ignore the category filter itself so the other options stay visible
+select c.name, count(distinct p.id) as product_count
+from products p
+inner join category_items ci
+on ci.product_id = p.id
+inner join categories c
+on ci.category_id = c.id
+where p.is_deleted = false and c.is_deleted = false
+and (sqlc.narg('seller_id')::uuid is null or p.seller_id = sqlc.narg('seller_id')::uuid)
+and (@query::text = ''
+    or to_tsvector('english', p.name || ' ' || p.description) @@ websearch_to_tsquery('english', @query::text)
+    or p.name % @query::text)
+and coalesce(product_price_at(p.id, current_timestamp), p.price) >= @price_min::float8 and coalesce(product_price_at(p.id, current_timestamp), p.price) <= @price_max::float8
+and not exists (
+    select 1 from unnest(@attributes::text[]) as f(pair)
+    where not exists (
+        select 1 from product_attribute_values pav
+        inner join category_attributes ca
+        on pav.attribute_id = ca.id
+        where pav.product_id = p.id and lower(ca.name || '=' || pav.value) = lower(f.pair)
+    )
+)
+group by c.name
+order by product_count desc, c.name;
+
+-- name: SearchProductPriceFacets :many
+-- bucket 0 is below the first bound, bucket n is at or above the last one
+with recursive category_tree as (
+    select cat.id from categories cat
+    where (cat.name = any(@categories::text[]) or cat.slug = any(@categories::text[])) and cat.is_deleted = false
+    union
+    select c.id from categories c
+    inner join category_tree t
+    on c.parent_id = t.id
+    where c.is_deleted = false
+)
+select width_bucket(coalesce(product_price_at(p.id, current_timestamp), p.price)::float8, @bounds::float8[]) as bucket, count(*) as product_count
+from products p
+where p.is_deleted = false
+and (sqlc.narg('seller_id')::uuid is null or p.seller_id = sqlc.narg('seller_id')::uuid)
+and (@query::text = ''
+    or to_tsvector('english', p.name || ' ' || p.description) @@ websearch_to_tsquery('english', @query::text)
+    or p.name % @query::text)
+and (cardinality(@categories::text[]) = 0 or exists (
+    select 1 from category_items ci
+    where ci.product_id = p.id and ci.category_id in (select ct.id from category_tree ct)
+))
+and not exists (
+    select 1 from unnest(@attributes::text[]) as f(pair)
+    where not exists (
+        select 1 from product_attribute_values pav
+        inner join category_attributes ca
+        on pav.attribute_id = ca.id
+        where pav.product_id = p.id and lower(ca.name || '=' || pav.value) = lower(f.pair)
+    )
+)
+group by bucket
+order by bucket;
@@ -0,0 +1,47 @@
+-- name: GetSellerShippingSettings :one
+select * from seller_shipping_settings
+where seller_id = $1;
+
+-- name: UpsertSellerShippingSettings :one
+insert into seller_shipping_settings
+(seller_id, handling_days)
+values
+($1, $2)
+on conflict (seller_id) do update
+set handling_days = excluded.handling_days, updated_at = current_timestamp
+returning *;
+
+-- name: UpsertSellerShippingRule :one
+insert into seller_shipping_settings
+(seller_id, shipping_rule, flat_fee, free_shipping_above)
+values
+($1, $2, $3, $4)
+on conflict (seller_id) do update
+set shipping_rule = excluded.shipping_rule, flat_fee = excluded.flat_fee,
+free_shipping_above = excluded.free_shipping_above, updated_at = current_timestamp
+returning *;
+
+-- name: GetSellerServiceAreas :many
+select * from seller_service_areas
+where seller_id = $1
+order by pincode_from;
+
+-- name: AddSellerServiceArea :one
+insert into seller_service_areas
+(seller_id, pincode_from, pincode_to)
+values
+($1, $2, $3)
+returning *;
+
+-- name: DeleteSellerServiceAreas :exec
+delete from seller_service_areas
+where seller_id = $1;
+
+-- name: GetSellerShipsToPincode :one
+-- true when the pincode is in one of the seller's ranges or the seller has none
+select (not exists (
+    select 1 from seller_service_areas a where a.seller_id = $1
+) or exists (
+    select 1 from seller_service_areas a
+    where a.seller_id = $1 and sqlc.arg(pincode)::int between a.pincode_from and a.pincode_to
+))::bool as ships;
@@ -0,0 +1,67 @@
+-- name: EditProductLowStockThresholdByID :one
+update products
+set low_stock_threshold = @low_stock_threshold, updated_at = current_timestamp
+where id = @id and is_deleted = false
+returning *;
+
+-- name: EditProductWeightByID :one
+update products
+set weight_grams = @weight_grams, updated_at = current_timestamp
+where id = @id and is_deleted = false
+returning *;
+
+-- name: GetLowStockProductsBySellerID :many
+-- out of stock products come first
+select * from products
+where seller_id = $1 and is_deleted = false and stock <= low_stock_threshold
+order by stock, name;
+
+-- name: GetStockAlertsBySellerID :many
+select sa.*, p.name as product_name from stock_alerts sa
+inner join products p
+on sa.product_id = p.id
+where sa.seller_id = @seller_id and (sa.is_read = false or @include_read::boolean)
+order by sa.created_at desc
+limit 100;
+
+-- name: MarkStockAlertsReadBySellerID :exec
+update stock_alerts
+set is_read = true
+where seller_id = $1 and is_read = false;
+
+-- name: GetUnprocessedStockAlerts :many
+select sa.*, p.name as product_name from stock_alerts sa
+inner join products p
+on sa.product_id = p.id
+where sa.processed_at is null
+order by sa.created_at
+limit 100;
+
+-- name: MarkStockAlertProcessed :exec
+update stock_alerts
+set processed_at = current_timestamp
+where id = $1;
+
+-- name: AddStockSubscription :one
+-- subscribing again after being notified starts a fresh subscription
+insert into stock_subscriptions
+(user_id, product_id, email)
+values
+($1, $2, $3)
+on conflict (user_id, product_id)
+do update set email = excluded.email, notified_at = null, created_at = current_timestamp
+returning *;
+
+-- name: DeleteStockSubscription :one
+delete from stock_subscriptions
+where user_id = $1 and product_id = $2
+returning *;
+
+-- name: GetPendingStockSubscriptionsByProductID :many
+select * from stock_subscriptions
+where product_id = $1 and notified_at is null;
+
+-- name: MarkStockSubscriptionNotified :exec
+update stock_subscriptions
+set notified_at = current_timestamp
+where id = $1;
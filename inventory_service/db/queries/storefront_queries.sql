@@ -0,0 +1,24 @@
+-- name: GetSellerStorefront :one
+select * from seller_storefronts
+where seller_id = $1;
+
+-- name: UpsertSellerStorefront :one
+insert into seller_storefronts
+(seller_id, return_window_days, return_policy)
+values
+($1, $2, $3)
+on conflict (seller_id) do update
+set return_window_days = excluded.return_window_days, return_policy = excluded.return_policy, updated_at = current_timestamp
+returning *;
+
+-- name: GetSellerRatingSummary :one
+-- rating of the seller is over the reviews of all their products
+select coalesce(avg(r.rating), 0)::float8 as average_rating, count(r.id) as rating_count
+from reviews r
+inner join products p
+on r.product_id = p.id
+where p.seller_id = $1 and p.is_deleted = false and r.is_deleted = false and r.moderation_status <> 'hidden';
+
+-- name: GetSellerProductCount :one
+select count(*) from products
+where seller_id = $1 and is_deleted = false;
@@ -1,32 +1,93 @@
--- name: GetAllWishListItemsWithProductNameByUserID :many
-select w.*, p.name as product_name from wishlists w
+-- name: GetOrCreateDefaultWishList :one
+-- the no-op update makes returning give back the existing row
+insert into wishlist_lists
+(user_id, name, is_default)
+values
+($1, 'default', true)
+on conflict (user_id, name) do update
+set name = excluded.name
+returning *;
+
+-- name: GetWishListsByUserID :many
+select l.*, count(w.id) as item_count
+from wishlist_lists l
+left join wishlists w
+on w.list_id = l.id
+where l.user_id = $1
+group by l.id
+order by l.is_default desc, l.created_at;
+
+-- name: GetWishListByID :one
+select * from wishlist_lists
+where id = $1 and user_id = $2;
+
+-- name: GetWishListByShareToken :one
+select * from wishlist_lists
+where share_token = $1;
+
+-- name: AddWishList :one
+insert into wishlist_lists
+(user_id, name)
+values
+($1, $2)
+returning *;
+
+-- name: EditWishList :one
+update wishlist_lists
+set name = @name, alerts_enabled = @alerts_enabled, updated_at = current_timestamp
+where id = @id and user_id = @user_id
+returning *;
+
+-- name: SetWishListShareToken :one
+-- a null token stops sharing, a new token invalidates the old link
+update wishlist_lists
+set share_token = @share_token, updated_at = current_timestamp
+where id = @id and user_id = @user_id
+returning *;
+
+-- name: DeleteWishList :execrows
+delete from wishlist_lists
+where id = $1 and user_id = $2 and is_default = false;
+
+-- name: GetWishListItemsByListID :many
+select w.*, p.name as product_name, coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as current_price, p.stock, p.is_deleted
+from wishlists w
 inner join products p
 on w.product_id = p.id
-where w.user_id = @user_id;
+where w.list_id = $1
+order by w.created_at desc;
 
--- name: GetAllWishListItemsByUserID :many
+-- name: GetWishListItemByListAndProductID :one
 select * from wishlists
-where user_id = $1;
+where list_id = $1 and product_id = $2;
 
--- name: GetWishListItemByUserAndProductID :one
-select * from wishlists
-where user_id = $1 and product_id = $2;
-
--- name: DeleteWishListItemByUserAndProductID :execrows
+-- name: DeleteWishListItemByListAndProductID :execrows
 delete from wishlists
-where user_id = $1 and product_id = $2;
+where list_id = $1 and product_id = $2;
 
 -- name: AddWishListItem :one
 insert into wishlists
-(user_id, product_id)
+(user_id, list_id, product_id, price_at_add)
 values
-($1, $2)
+($1, $2, $3, $4)
 returning *;
 
+-- name: DeleteAllWishListItemsByListID :exec
+delete from wishlists
+where list_id = $1;
+
 -- name: DeleteAllWishListItemsByUserID :exec
 delete from wishlists
 where user_id = $1;
 
+-- name: GetWishListAlertUserIDsByProductID :many
+-- a user with the product in several lists is only alerted once
+select distinct w.user_id
+from wishlists w
+inner join wishlist_lists l
+on w.list_id = l.id
+where w.product_id = $1 and l.alerts_enabled = true;
+
 -- -- name: AddAllWishListItemsToCarts :many
 -- with cte AS
 -- (select w.* from wishlists w where w.user_id = @user_id)
@@ -1,11 +1,34 @@
+-- Extensions
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 -- Categories Table
 CREATE TABLE IF NOT EXISTS categories (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     name TEXT NOT NULL UNIQUE CHECK (name ~* '^[a-z0-9]+[a-z0-9 ]*$'),
+    slug TEXT NOT NULL UNIQUE CHECK (slug ~ '^[a-z0-9]+(-[a-z0-9]+)*$'),
+    parent_id UUID REFERENCES categories(id) CHECK (parent_id <> id),
     is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
 );
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
+
+-- Category Attributes Table
+-- typed attributes sellers fill in for products of the category and its children
+CREATE TABLE IF NOT EXISTS category_attributes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    category_id UUID NOT NULL REFERENCES categories(id),
+    name TEXT NOT NULL CHECK (name ~ '^[a-z][a-z0-9_]*$'),
+    label TEXT NOT NULL,
+    data_type TEXT NOT NULL CHECK (data_type IN ('text', 'number', 'boolean', 'enum')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    is_required BOOLEAN NOT NULL DEFAULT FALSE,
+    is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at),
+    CONSTRAINT category_attributes_category_name_unique UNIQUE (category_id, name),
+    CHECK (data_type <> 'enum' OR cardinality(options) > 0)
+);
 
 -- Category Items Table
 CREATE TABLE IF NOT EXISTS category_items (
@@ -23,21 +46,48 @@ CREATE TABLE IF NOT EXISTS products (
     name TEXT NOT NULL CHECK (name ~* '^[a-zA-Z0-9]{3,}[a-zA-Z0-9 ]*$'),
     description TEXT NOT NULL,
     price NUMERIC(10,2) NOT NULL CHECK (price > 0),
+    -- maximum retail price, shown struck through next to a lower selling price
+    mrp NUMERIC(10,2) CHECK (mrp > 0),
     stock INTEGER NOT NULL CHECK (stock >= 0),
+    sold_count INTEGER NOT NULL DEFAULT 0 CHECK (sold_count >= 0),
+    low_stock_threshold INTEGER NOT NULL DEFAULT 5 CHECK (low_stock_threshold >= 0),
+    -- packed weight, used by sellers charging shipping by weight
+    weight_grams INTEGER NOT NULL DEFAULT 500 CHECK (weight_grams > 0 AND weight_grams <= 100000),
     seller_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
 );
+-- full text search over name and description, the search queries use the same expression
+CREATE INDEX IF NOT EXISTS products_search_idx ON products USING GIN (to_tsvector('english', name || ' ' || description));
+-- trigram index for fuzzy name matching
+CREATE INDEX IF NOT EXISTS products_name_trgm_idx ON products USING GIN (name gin_trgm_ops);
+
+-- Product Attribute Values Table
+-- values are stored as text and validated against the attribute data_type before insert
+CREATE TABLE IF NOT EXISTS product_attribute_values (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    attribute_id UUID NOT NULL REFERENCES category_attributes(id),
+    value TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at),
+    CONSTRAINT product_attribute_values_product_attribute_unique UNIQUE (product_id, attribute_id)
+);
 
 -- Product Images Table
 CREATE TABLE IF NOT EXISTS product_images (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
     image_url TEXT NOT NULL,
+    image_key TEXT NOT NULL,
+    thumbnail_url TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0 CHECK (position >= 0),
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
 );
+CREATE INDEX IF NOT EXISTS product_images_product_id_position_idx ON product_images (product_id, position);
 
 -- Reviews Table
 CREATE TABLE IF NOT EXISTS reviews (
@@ -48,16 +98,242 @@ CREATE TABLE IF NOT EXISTS reviews (
     comment TEXT,
     is_deleted BOOLEAN NOT NULL DEFAULT FALSE,
     is_edited BOOLEAN NOT NULL DEFAULT FALSE,
+    -- set when the payment service had a delivered order item for the user and product
+    is_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    moderation_status TEXT NOT NULL DEFAULT 'visible' CHECK (moderation_status IN ('visible', 'flagged', 'hidden')),
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
 );
+CREATE INDEX IF NOT EXISTS reviews_product_id_idx ON reviews (product_id);
+
+-- Review Images Table
+CREATE TABLE IF NOT EXISTS review_images (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    image_url TEXT NOT NULL,
+    image_key TEXT NOT NULL,
+    thumbnail_url TEXT NOT NULL,
+    thumbnail_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS review_images_review_id_idx ON review_images (review_id);
+
+-- Review Flags Table
+CREATE TABLE IF NOT EXISTS review_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    is_resolved BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (review_id, user_id)
+);
 
 
 -- Wishlists Table
+-- named lists of a user, every user gets a 'default' list on first use.
+-- share_token is set only while the list is shared as a read only link
+CREATE TABLE IF NOT EXISTS wishlist_lists (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (length(name) BETWEEN 1 AND 50),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    alerts_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    share_token TEXT UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at),
+    CONSTRAINT wishlist_lists_user_id_name_unique UNIQUE(user_id, name)
+);
+
 CREATE TABLE IF NOT EXISTS wishlists (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    list_id UUID NOT NULL REFERENCES wishlist_lists(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price_at_add NUMERIC(10,2) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT wishlists_list_id_product_id_unique UNIQUE(list_id, product_id)
+);
+CREATE INDEX IF NOT EXISTS wishlists_product_id_idx ON wishlists (product_id);
+
+-- Product Import Jobs Table
+-- one row per csv import, report holds the per row errors
+CREATE TABLE IF NOT EXISTS product_import_jobs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seller_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'processing', 'completed', 'failed')) DEFAULT 'pending',
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    created_rows INTEGER NOT NULL DEFAULT 0,
+    updated_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    report JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at),
+    completed_at TIMESTAMPTZ
+);
+
+-- Stock Alerts Table
+-- filled by the products_stock_alert trigger so every stock change is covered,
+-- whichever query or service made it
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    seller_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('low_stock', 'out_of_stock', 'back_in_stock')),
+    stock INTEGER NOT NULL,
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    processed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS stock_alerts_seller_id_idx ON stock_alerts (seller_id, created_at);
+
+CREATE OR REPLACE FUNCTION products_stock_alert() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.stock = 0 AND OLD.stock > 0 THEN
+        INSERT INTO stock_alerts (product_id, seller_id, kind, stock) VALUES (NEW.id, NEW.seller_id, 'out_of_stock', NEW.stock);
+    ELSIF NEW.stock > 0 AND OLD.stock = 0 THEN
+        INSERT INTO stock_alerts (product_id, seller_id, kind, stock) VALUES (NEW.id, NEW.seller_id, 'back_in_stock', NEW.stock);
+    ELSIF NEW.stock <= NEW.low_stock_threshold AND OLD.stock > NEW.low_stock_threshold THEN
+        INSERT INTO stock_alerts (product_id, seller_id, kind, stock) VALUES (NEW.id, NEW.seller_id, 'low_stock', NEW.stock);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS products_stock_alert_trigger ON products;
+CREATE TRIGGER products_stock_alert_trigger
+AFTER UPDATE OF stock ON products
+FOR EACH ROW EXECUTE FUNCTION products_stock_alert();
+
+-- Stock Subscriptions Table
+-- users waiting for an out of stock product, notified_at is set once the mail is sent
+CREATE TABLE IF NOT EXISTS stock_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT stock_subscriptions_user_product_unique UNIQUE (user_id, product_id)
+);
+
+-- Seller Storefronts Table
+-- storefront settings of a seller, the profile itself lives in the user service
+CREATE TABLE IF NOT EXISTS seller_storefronts (
+    seller_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    return_window_days INTEGER NOT NULL DEFAULT 7 CHECK (return_window_days BETWEEN 0 AND 90),
+    return_policy TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
+);
+
+-- Seller Shipping Settings Table
+-- handling_days is the working days a seller takes to ship an order, 1 for sellers without a row.
+-- shipping_rule is how the seller charges shipping on their part of an order: free, a flat fee
+-- or by weight and zone (pkg/delivery). free_shipping_above waives the charge from that subtotal
+CREATE TABLE IF NOT EXISTS seller_shipping_settings (
+    seller_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    handling_days INTEGER NOT NULL DEFAULT 1 CHECK (handling_days BETWEEN 0 AND 30),
+    shipping_rule TEXT NOT NULL DEFAULT 'free' CHECK (shipping_rule IN ('free', 'flat', 'weight')),
+    flat_fee NUMERIC(10,2) NOT NULL DEFAULT 0 CHECK (flat_fee >= 0),
+    free_shipping_above NUMERIC(10,2) CHECK (free_shipping_above > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
+);
+
+-- Seller Service Areas Table
+-- inclusive pincode ranges a seller ships to. a seller without any ships everywhere
+CREATE TABLE IF NOT EXISTS seller_service_areas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seller_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    pincode_from INTEGER NOT NULL CHECK (pincode_from BETWEEN 100000 AND 999999),
+    pincode_to INTEGER NOT NULL CHECK (pincode_to BETWEEN 100000 AND 999999),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (pincode_from <= pincode_to)
+);
+
+CREATE INDEX IF NOT EXISTS seller_service_areas_seller_idx ON seller_service_areas(seller_id);
+
+-- Product Prices Table
+-- history of regular prices, written by the products_price_change trigger whenever
+-- products.price is set, and the sale prices scheduled by sellers.
+-- alerted_at is set once wishlist price drop alerts for the row have gone out
+CREATE TABLE IF NOT EXISTS product_prices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('regular', 'sale')),
+    price NUMERIC(10,2) NOT NULL CHECK (price > 0),
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMPTZ CHECK (ends_at > starts_at),
+    is_cancelled BOOLEAN NOT NULL DEFAULT FALSE,
+    alerted_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    CONSTRAINT cart_user_id_product_id_unique UNIQUE(user_id, product_id)
+    -- regular prices run until the next one, sales always have an end
+    CHECK ((kind = 'regular') = (ends_at IS NULL))
 );
+CREATE INDEX IF NOT EXISTS product_prices_product_id_idx ON product_prices (product_id, kind, starts_at);
+
+CREATE OR REPLACE FUNCTION products_price_change() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        -- no alerts for the first price of a product
+        INSERT INTO product_prices (product_id, kind, price, alerted_at) VALUES (NEW.id, 'regular', NEW.price, CURRENT_TIMESTAMP);
+    ELSIF NEW.price <> OLD.price THEN
+        INSERT INTO product_prices (product_id, kind, price) VALUES (NEW.id, 'regular', NEW.price);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS products_price_change_trigger ON products;
+CREATE TRIGGER products_price_change_trigger
+AFTER INSERT OR UPDATE OF price ON products
+FOR EACH ROW EXECUTE FUNCTION products_price_change();
+
+-- product_price_at is the price a product sold for at the given moment, the lowest of
+-- the regular price then and any sale running then. null for products without history,
+-- callers fall back to products.price
+CREATE OR REPLACE FUNCTION product_price_at(p_id UUID, at TIMESTAMPTZ) RETURNS NUMERIC AS $$
+    SELECT LEAST(
+        (SELECT pp.price FROM product_prices pp
+         WHERE pp.product_id = p_id AND pp.kind = 'regular' AND pp.starts_at <= at
+         ORDER BY pp.starts_at DESC LIMIT 1),
+        (SELECT min(pp.price) FROM product_prices pp
+         WHERE pp.product_id = p_id AND pp.kind = 'sale' AND pp.is_cancelled = FALSE
+         AND pp.starts_at <= at AND (pp.ends_at IS NULL OR pp.ends_at > at))
+    )
+$$ LANGUAGE sql STABLE;
+
+-- append only log of administrative and financial actions (pkg/audit)
+CREATE TABLE IF NOT EXISTS inventory_audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- null for actions of crons
+    actor_id UUID,
+    actor_role TEXT NOT NULL,
+    impersonator_id UUID,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_inventory_audit_logs_created_at ON inventory_audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_inventory_audit_logs_entity ON inventory_audit_logs(entity_type, entity_id);
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log % is append only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS inventory_audit_logs_append_only ON inventory_audit_logs;
+CREATE TRIGGER inventory_audit_logs_append_only BEFORE UPDATE OR DELETE ON inventory_audit_logs
+FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+DROP TRIGGER IF EXISTS inventory_audit_logs_no_truncate ON inventory_audit_logs;
+CREATE TRIGGER inventory_audit_logs_no_truncate BEFORE TRUNCATE ON inventory_audit_logs
+FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
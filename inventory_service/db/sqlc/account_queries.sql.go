@@ -0,0 +1,251 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: account_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const deleteReviewFlagsByUserID = `-- name: DeleteReviewFlagsByUserID :exec
+delete from review_flags
+where user_id = $1
+`
+
+func (q *Queries) DeleteReviewFlagsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteReviewFlagsByUserIDStmt, deleteReviewFlagsByUserID, userID)
+	return err
+}
+
+const deleteReviewImagesByUserID = `-- name: DeleteReviewImagesByUserID :many
+delete from review_images
+where review_id in (select id from reviews where user_id = $1)
+returning image_key, thumbnail_key
+`
+
+type DeleteReviewImagesByUserIDRow struct {
+	ImageKey     string `json:"image_key"`
+	ThumbnailKey string `json:"thumbnail_key"`
+}
+
+func (q *Queries) DeleteReviewImagesByUserID(ctx context.Context, userID uuid.UUID) ([]DeleteReviewImagesByUserIDRow, error) {
+	rows, err := q.query(ctx, q.deleteReviewImagesByUserIDStmt, deleteReviewImagesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []DeleteReviewImagesByUserIDRow{}
+	for rows.Next() {
+		var i DeleteReviewImagesByUserIDRow
+		if err := rows.Scan(&i.ImageKey, &i.ThumbnailKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteStockSubscriptionsByUserID = `-- name: DeleteStockSubscriptionsByUserID :exec
+delete from stock_subscriptions
+where user_id = $1
+`
+
+func (q *Queries) DeleteStockSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteStockSubscriptionsByUserIDStmt, deleteStockSubscriptionsByUserID, userID)
+	return err
+}
+
+const deleteWishListsByUserID = `-- name: DeleteWishListsByUserID :exec
+delete from wishlist_lists
+where user_id = $1
+`
+
+// items of the lists are deleted with them
+func (q *Queries) DeleteWishListsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteWishListsByUserIDStmt, deleteWishListsByUserID, userID)
+	return err
+}
+
+const eraseReviewsByUserID = `-- name: EraseReviewsByUserID :exec
+update reviews
+set comment = null, updated_at = current_timestamp
+where user_id = $1
+`
+
+// the rating stays so product ratings don't change, the text and images go
+func (q *Queries) EraseReviewsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.eraseReviewsByUserIDStmt, eraseReviewsByUserID, userID)
+	return err
+}
+
+const getReviewsByUserID = `-- name: GetReviewsByUserID :many
+
+select r.id, r.product_id, p.name as product_name, r.rating, r.comment, r.is_deleted, r.is_edited,
+r.is_verified, r.moderation_status, r.created_at, r.updated_at
+from reviews r
+inner join products p
+on r.product_id = p.id
+where r.user_id = $1
+order by r.created_at
+`
+
+type GetReviewsByUserIDRow struct {
+	ID               uuid.UUID      `json:"id"`
+	ProductID        uuid.UUID      `json:"product_id"`
+	ProductName      string         `json:"product_name"`
+	Rating           int32          `json:"rating"`
+	Comment          sql.NullString `json:"comment"`
+	IsDeleted        bool           `json:"is_deleted"`
+	IsEdited         bool           `json:"is_edited"`
+	IsVerified       bool           `json:"is_verified"`
+	ModerationStatus string         `json:"moderation_status"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+// queries for the account data export and deletion of the user service
+func (q *Queries) GetReviewsByUserID(ctx context.Context, userID uuid.UUID) ([]GetReviewsByUserIDRow, error) {
+	rows, err := q.query(ctx, q.getReviewsByUserIDStmt, getReviewsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetReviewsByUserIDRow{}
+	for rows.Next() {
+		var i GetReviewsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.Rating,
+			&i.Comment,
+			&i.IsDeleted,
+			&i.IsEdited,
+			&i.IsVerified,
+			&i.ModerationStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStockSubscriptionsByUserID = `-- name: GetStockSubscriptionsByUserID :many
+select s.product_id, p.name as product_name, s.email, s.notified_at, s.created_at
+from stock_subscriptions s
+inner join products p
+on s.product_id = p.id
+where s.user_id = $1
+order by s.created_at
+`
+
+type GetStockSubscriptionsByUserIDRow struct {
+	ProductID   uuid.UUID    `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	Email       string       `json:"email"`
+	NotifiedAt  sql.NullTime `json:"notified_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+func (q *Queries) GetStockSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]GetStockSubscriptionsByUserIDRow, error) {
+	rows, err := q.query(ctx, q.getStockSubscriptionsByUserIDStmt, getStockSubscriptionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStockSubscriptionsByUserIDRow{}
+	for rows.Next() {
+		var i GetStockSubscriptionsByUserIDRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.ProductName,
+			&i.Email,
+			&i.NotifiedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWishListItemsByUserID = `-- name: GetWishListItemsByUserID :many
+select l.id as list_id, l.name as list_name, l.is_default, w.product_id, p.name as product_name,
+w.price_at_add, w.created_at
+from wishlists w
+inner join wishlist_lists l
+on w.list_id = l.id
+inner join products p
+on w.product_id = p.id
+where w.user_id = $1
+order by l.created_at, w.created_at
+`
+
+type GetWishListItemsByUserIDRow struct {
+	ListID      uuid.UUID `json:"list_id"`
+	ListName    string    `json:"list_name"`
+	IsDefault   bool      `json:"is_default"`
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	PriceAtAdd  float64   `json:"price_at_add"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (q *Queries) GetWishListItemsByUserID(ctx context.Context, userID uuid.UUID) ([]GetWishListItemsByUserIDRow, error) {
+	rows, err := q.query(ctx, q.getWishListItemsByUserIDStmt, getWishListItemsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetWishListItemsByUserIDRow{}
+	for rows.Next() {
+		var i GetWishListItemsByUserIDRow
+		if err := rows.Scan(
+			&i.ListID,
+			&i.ListName,
+			&i.IsDefault,
+			&i.ProductID,
+			&i.ProductName,
+			&i.PriceAtAdd,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
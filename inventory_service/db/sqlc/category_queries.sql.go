@@ -10,20 +10,71 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+const addCategoryAttribute = `-- name: AddCategoryAttribute :one
+insert into category_attributes
+(category_id, name, label, data_type, options, is_required)
+values
+($1, $2, $3, $4, $5, $6)
+returning id, category_id, name, label, data_type, options, is_required, is_deleted, created_at, updated_at
+`
+
+type AddCategoryAttributeParams struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Name       string    `json:"name"`
+	Label      string    `json:"label"`
+	DataType   string    `json:"data_type"`
+	Options    []string  `json:"options"`
+	IsRequired bool      `json:"is_required"`
+}
+
+func (q *Queries) AddCategoryAttribute(ctx context.Context, arg AddCategoryAttributeParams) (CategoryAttribute, error) {
+	row := q.queryRow(ctx, q.addCategoryAttributeStmt, addCategoryAttribute,
+		arg.CategoryID,
+		arg.Name,
+		arg.Label,
+		arg.DataType,
+		pq.Array(arg.Options),
+		arg.IsRequired,
+	)
+	var i CategoryAttribute
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Label,
+		&i.DataType,
+		pq.Array(&i.Options),
+		&i.IsRequired,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const addCateogry = `-- name: AddCateogry :one
 insert into categories
-(name) values ($1)
-returning id, name, is_deleted, created_at, updated_at
+(name, slug, parent_id) values ($1, $2, $3)
+returning id, name, slug, parent_id, is_deleted, created_at, updated_at
 `
 
-func (q *Queries) AddCateogry(ctx context.Context, name string) (Category, error) {
-	row := q.queryRow(ctx, q.addCateogryStmt, addCateogry, name)
+type AddCateogryParams struct {
+	Name     string        `json:"name"`
+	Slug     string        `json:"slug"`
+	ParentID uuid.NullUUID `json:"parent_id"`
+}
+
+func (q *Queries) AddCateogry(ctx context.Context, arg AddCateogryParams) (Category, error) {
+	row := q.queryRow(ctx, q.addCateogryStmt, addCateogry, arg.Name, arg.Slug, arg.ParentID)
 	var i Category
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
+		&i.Slug,
+		&i.ParentID,
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -41,11 +92,36 @@ func (q *Queries) DeleteAllCategoriesForProductByID(ctx context.Context, product
 	return err
 }
 
+const deleteCategoryAttributeByID = `-- name: DeleteCategoryAttributeByID :one
+update category_attributes
+set is_deleted = true, updated_at = current_timestamp
+where id = $1 and is_deleted = false
+returning id, category_id, name, label, data_type, options, is_required, is_deleted, created_at, updated_at
+`
+
+func (q *Queries) DeleteCategoryAttributeByID(ctx context.Context, id uuid.UUID) (CategoryAttribute, error) {
+	row := q.queryRow(ctx, q.deleteCategoryAttributeByIDStmt, deleteCategoryAttributeByID, id)
+	var i CategoryAttribute
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Label,
+		&i.DataType,
+		pq.Array(&i.Options),
+		&i.IsRequired,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const deleteCategoryByName = `-- name: DeleteCategoryByName :one
 update categories
 set is_deleted = true, updated_at = current_timestamp
 where name = $1
-returning id, name, is_deleted, created_at, updated_at
+returning id, name, slug, parent_id, is_deleted, created_at, updated_at
 `
 
 func (q *Queries) DeleteCategoryByName(ctx context.Context, name string) (Category, error) {
@@ -54,6 +130,8 @@ func (q *Queries) DeleteCategoryByName(ctx context.Context, name string) (Catego
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
+		&i.Slug,
+		&i.ParentID,
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -61,24 +139,64 @@ func (q *Queries) DeleteCategoryByName(ctx context.Context, name string) (Catego
 	return i, err
 }
 
+const deleteProductAttributeValuesByProductID = `-- name: DeleteProductAttributeValuesByProductID :exec
+delete from product_attribute_values
+where product_id = $1
+`
+
+func (q *Queries) DeleteProductAttributeValuesByProductID(ctx context.Context, productID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteProductAttributeValuesByProductIDStmt, deleteProductAttributeValuesByProductID, productID)
+	return err
+}
+
 const editCategoryNameByName = `-- name: EditCategoryNameByName :one
 update categories
-set name = $1, updated_at = current_timestamp
-where name = $2 and is_deleted = false
-returning id, name, is_deleted, created_at, updated_at
+set name = $1, slug = $2, updated_at = current_timestamp
+where name = $3 and is_deleted = false
+returning id, name, slug, parent_id, is_deleted, created_at, updated_at
 `
 
 type EditCategoryNameByNameParams struct {
 	NewName string `json:"new_name"`
+	NewSlug string `json:"new_slug"`
 	Name    string `json:"name"`
 }
 
 func (q *Queries) EditCategoryNameByName(ctx context.Context, arg EditCategoryNameByNameParams) (Category, error) {
-	row := q.queryRow(ctx, q.editCategoryNameByNameStmt, editCategoryNameByName, arg.NewName, arg.Name)
+	row := q.queryRow(ctx, q.editCategoryNameByNameStmt, editCategoryNameByName, arg.NewName, arg.NewSlug, arg.Name)
 	var i Category
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
+		&i.Slug,
+		&i.ParentID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const editCategoryParentBySlug = `-- name: EditCategoryParentBySlug :one
+update categories
+set parent_id = $1, updated_at = current_timestamp
+where slug = $2 and is_deleted = false
+returning id, name, slug, parent_id, is_deleted, created_at, updated_at
+`
+
+type EditCategoryParentBySlugParams struct {
+	ParentID uuid.NullUUID `json:"parent_id"`
+	Slug     string        `json:"slug"`
+}
+
+func (q *Queries) EditCategoryParentBySlug(ctx context.Context, arg EditCategoryParentBySlugParams) (Category, error) {
+	row := q.queryRow(ctx, q.editCategoryParentBySlugStmt, editCategoryParentBySlug, arg.ParentID, arg.Slug)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.ParentID,
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -87,7 +205,7 @@ func (q *Queries) EditCategoryNameByName(ctx context.Context, arg EditCategoryNa
 }
 
 const getAllCategories = `-- name: GetAllCategories :many
-select id, name, is_deleted, created_at, updated_at from categories
+select id, name, slug, parent_id, is_deleted, created_at, updated_at from categories
 where is_deleted = false
 `
 
@@ -103,6 +221,8 @@ func (q *Queries) GetAllCategories(ctx context.Context) ([]Category, error) {
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
+			&i.Slug,
+			&i.ParentID,
 			&i.IsDeleted,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -121,7 +241,7 @@ func (q *Queries) GetAllCategories(ctx context.Context) ([]Category, error) {
 }
 
 const getAllCategoriesForAdmin = `-- name: GetAllCategoriesForAdmin :many
-select id, name, is_deleted, created_at, updated_at from categories
+select id, name, slug, parent_id, is_deleted, created_at, updated_at from categories
 `
 
 func (q *Queries) GetAllCategoriesForAdmin(ctx context.Context) ([]Category, error) {
@@ -136,6 +256,131 @@ func (q *Queries) GetAllCategoriesForAdmin(ctx context.Context) ([]Category, err
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
+			&i.Slug,
+			&i.ParentID,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttributesForProductByID = `-- name: GetAttributesForProductByID :many
+with recursive ancestors as (
+    select c.id, c.parent_id from categories c
+    inner join category_items ci
+    on ci.category_id = c.id
+    where ci.product_id = $1 and c.is_deleted = false
+    union
+    select c.id, c.parent_id from categories c
+    inner join ancestors a
+    on c.id = a.parent_id
+)
+select ca.id, ca.category_id, ca.name, ca.label, ca.data_type, ca.options, ca.is_required, ca.is_deleted, ca.created_at, ca.updated_at from category_attributes ca
+where ca.category_id in (select a.id from ancestors a) and ca.is_deleted = false
+order by ca.name
+`
+
+// attributes of every category the product is in along with their parents
+func (q *Queries) GetAttributesForProductByID(ctx context.Context, productID uuid.UUID) ([]CategoryAttribute, error) {
+	rows, err := q.query(ctx, q.getAttributesForProductByIDStmt, getAttributesForProductByID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CategoryAttribute{}
+	for rows.Next() {
+		var i CategoryAttribute
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Label,
+			&i.DataType,
+			pq.Array(&i.Options),
+			&i.IsRequired,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCategoryAttributeByID = `-- name: GetCategoryAttributeByID :one
+select id, category_id, name, label, data_type, options, is_required, is_deleted, created_at, updated_at from category_attributes
+where id = $1 and is_deleted = false
+`
+
+func (q *Queries) GetCategoryAttributeByID(ctx context.Context, id uuid.UUID) (CategoryAttribute, error) {
+	row := q.queryRow(ctx, q.getCategoryAttributeByIDStmt, getCategoryAttributeByID, id)
+	var i CategoryAttribute
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryID,
+		&i.Name,
+		&i.Label,
+		&i.DataType,
+		pq.Array(&i.Options),
+		&i.IsRequired,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCategoryAttributesWithAncestorsByCategoryID = `-- name: GetCategoryAttributesWithAncestorsByCategoryID :many
+with recursive ancestors as (
+    select cat.id, cat.parent_id from categories cat
+    where cat.id = $1
+    union
+    select c.id, c.parent_id from categories c
+    inner join ancestors a
+    on c.id = a.parent_id
+)
+select ca.id, ca.category_id, ca.name, ca.label, ca.data_type, ca.options, ca.is_required, ca.is_deleted, ca.created_at, ca.updated_at from category_attributes ca
+where ca.category_id in (select a.id from ancestors a) and ca.is_deleted = false
+order by ca.name
+`
+
+// a category inherits the attributes of every parent above it
+func (q *Queries) GetCategoryAttributesWithAncestorsByCategoryID(ctx context.Context, id uuid.UUID) ([]CategoryAttribute, error) {
+	rows, err := q.query(ctx, q.getCategoryAttributesWithAncestorsByCategoryIDStmt, getCategoryAttributesWithAncestorsByCategoryID, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CategoryAttribute{}
+	for rows.Next() {
+		var i CategoryAttribute
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryID,
+			&i.Name,
+			&i.Label,
+			&i.DataType,
+			pq.Array(&i.Options),
+			&i.IsRequired,
 			&i.IsDeleted,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -154,7 +399,7 @@ func (q *Queries) GetAllCategoriesForAdmin(ctx context.Context) ([]Category, err
 }
 
 const getCategoryByID = `-- name: GetCategoryByID :one
-select id, name, is_deleted, created_at, updated_at from categories
+select id, name, slug, parent_id, is_deleted, created_at, updated_at from categories
 where id = $1 and is_deleted = false
 `
 
@@ -164,6 +409,8 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id uuid.UUID) (Category,
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
+		&i.Slug,
+		&i.ParentID,
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -172,7 +419,7 @@ func (q *Queries) GetCategoryByID(ctx context.Context, id uuid.UUID) (Category,
 }
 
 const getCategoryByName = `-- name: GetCategoryByName :one
-select id, name, is_deleted, created_at, updated_at from categories
+select id, name, slug, parent_id, is_deleted, created_at, updated_at from categories
 where name = $1 and is_deleted = false
 `
 
@@ -182,6 +429,28 @@ func (q *Queries) GetCategoryByName(ctx context.Context, name string) (Category,
 	err := row.Scan(
 		&i.ID,
 		&i.Name,
+		&i.Slug,
+		&i.ParentID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCategoryBySlug = `-- name: GetCategoryBySlug :one
+select id, name, slug, parent_id, is_deleted, created_at, updated_at from categories
+where slug = $1 and is_deleted = false
+`
+
+func (q *Queries) GetCategoryBySlug(ctx context.Context, slug string) (Category, error) {
+	row := q.queryRow(ctx, q.getCategoryBySlugStmt, getCategoryBySlug, slug)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Slug,
+		&i.ParentID,
 		&i.IsDeleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -219,13 +488,63 @@ func (q *Queries) GetCategoryNamesOfProductByID(ctx context.Context, productID u
 	return items, nil
 }
 
+const getProductAttributeValuesByProductID = `-- name: GetProductAttributeValuesByProductID :many
+select ca.name, ca.label, ca.data_type, pav.value from product_attribute_values pav
+inner join category_attributes ca
+on pav.attribute_id = ca.id
+where pav.product_id = $1 and ca.is_deleted = false
+order by ca.name
+`
+
+type GetProductAttributeValuesByProductIDRow struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	DataType string `json:"data_type"`
+	Value    string `json:"value"`
+}
+
+func (q *Queries) GetProductAttributeValuesByProductID(ctx context.Context, productID uuid.UUID) ([]GetProductAttributeValuesByProductIDRow, error) {
+	rows, err := q.query(ctx, q.getProductAttributeValuesByProductIDStmt, getProductAttributeValuesByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductAttributeValuesByProductIDRow{}
+	for rows.Next() {
+		var i GetProductAttributeValuesByProductIDRow
+		if err := rows.Scan(
+			&i.Name,
+			&i.Label,
+			&i.DataType,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProductsByCategoryName = `-- name: GetProductsByCategoryName :many
-select p.id, p.name, p.description, p.price, p.stock, p.seller_id, p.created_at, p.updated_at from category_items ci
+with recursive tree as (
+    select cat.id from categories cat
+    where (cat.name = $1 or cat.slug = $1) and cat.is_deleted = false
+    union
+    select c.id from categories c
+    inner join tree t
+    on c.parent_id = t.id
+    where c.is_deleted = false
+)
+select distinct p.id, p.name, p.description, p.price, p.stock, p.seller_id, p.created_at, p.updated_at from category_items ci
 inner join products p
 on ci.product_id = p.id
-inner join categories c
-on ci.category_id = c.id
-where c.name = $1 and c.is_deleted = false and p.is_deleted = false
+where ci.category_id in (select t.id from tree t) and p.is_deleted = false
 `
 
 type GetProductsByCategoryNameRow struct {
@@ -239,8 +558,9 @@ type GetProductsByCategoryNameRow struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
-func (q *Queries) GetProductsByCategoryName(ctx context.Context, name string) ([]GetProductsByCategoryNameRow, error) {
-	rows, err := q.query(ctx, q.getProductsByCategoryNameStmt, getProductsByCategoryName, name)
+// products of the category and all of its sub categories, by name or slug
+func (q *Queries) GetProductsByCategoryName(ctx context.Context, category string) ([]GetProductsByCategoryNameRow, error) {
+	rows, err := q.query(ctx, q.getProductsByCategoryNameStmt, getProductsByCategoryName, category)
 	if err != nil {
 		return nil, err
 	}
@@ -270,3 +590,59 @@ func (q *Queries) GetProductsByCategoryName(ctx context.Context, name string) ([
 	}
 	return items, nil
 }
+
+const isCategoryDescendant = `-- name: IsCategoryDescendant :one
+with recursive tree as (
+    select cat.id from categories cat
+    where cat.id = $2
+    union
+    select c.id from categories c
+    inner join tree t
+    on c.parent_id = t.id
+)
+select count(*) > 0 as is_descendant from tree t
+where t.id = $1::uuid
+`
+
+type IsCategoryDescendantParams struct {
+	DescendantID uuid.UUID `json:"descendant_id"`
+	CategoryID   uuid.UUID `json:"category_id"`
+}
+
+// true when @descendant_id is @category_id itself or somewhere below it
+func (q *Queries) IsCategoryDescendant(ctx context.Context, arg IsCategoryDescendantParams) (bool, error) {
+	row := q.queryRow(ctx, q.isCategoryDescendantStmt, isCategoryDescendant, arg.DescendantID, arg.CategoryID)
+	var is_descendant bool
+	err := row.Scan(&is_descendant)
+	return is_descendant, err
+}
+
+const upsertProductAttributeValue = `-- name: UpsertProductAttributeValue :one
+insert into product_attribute_values
+(product_id, attribute_id, value)
+values
+($1, $2, $3)
+on conflict (product_id, attribute_id)
+do update set value = excluded.value, updated_at = current_timestamp
+returning id, product_id, attribute_id, value, created_at, updated_at
+`
+
+type UpsertProductAttributeValueParams struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	AttributeID uuid.UUID `json:"attribute_id"`
+	Value       string    `json:"value"`
+}
+
+func (q *Queries) UpsertProductAttributeValue(ctx context.Context, arg UpsertProductAttributeValueParams) (ProductAttributeValue, error) {
+	row := q.queryRow(ctx, q.upsertProductAttributeValueStmt, upsertProductAttributeValue, arg.ProductID, arg.AttributeID, arg.Value)
+	var i ProductAttributeValue
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.AttributeID,
+		&i.Value,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -24,54 +24,147 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addCategoryAttributeStmt, err = db.PrepareContext(ctx, addCategoryAttribute); err != nil {
+		return nil, fmt.Errorf("error preparing query AddCategoryAttribute: %w", err)
+	}
 	if q.addCateogryStmt, err = db.PrepareContext(ctx, addCateogry); err != nil {
 		return nil, fmt.Errorf("error preparing query AddCateogry: %w", err)
 	}
 	if q.addProductStmt, err = db.PrepareContext(ctx, addProduct); err != nil {
 		return nil, fmt.Errorf("error preparing query AddProduct: %w", err)
 	}
+	if q.addProductImageStmt, err = db.PrepareContext(ctx, addProductImage); err != nil {
+		return nil, fmt.Errorf("error preparing query AddProductImage: %w", err)
+	}
+	if q.addProductImportJobStmt, err = db.PrepareContext(ctx, addProductImportJob); err != nil {
+		return nil, fmt.Errorf("error preparing query AddProductImportJob: %w", err)
+	}
 	if q.addProductReviewWithCommmentStmt, err = db.PrepareContext(ctx, addProductReviewWithCommment); err != nil {
 		return nil, fmt.Errorf("error preparing query AddProductReviewWithCommment: %w", err)
 	}
 	if q.addProductReviewWithoutCommentStmt, err = db.PrepareContext(ctx, addProductReviewWithoutComment); err != nil {
 		return nil, fmt.Errorf("error preparing query AddProductReviewWithoutComment: %w", err)
 	}
+	if q.addProductSaleStmt, err = db.PrepareContext(ctx, addProductSale); err != nil {
+		return nil, fmt.Errorf("error preparing query AddProductSale: %w", err)
+	}
 	if q.addProductToCategoryByCategoryNameStmt, err = db.PrepareContext(ctx, addProductToCategoryByCategoryName); err != nil {
 		return nil, fmt.Errorf("error preparing query AddProductToCategoryByCategoryName: %w", err)
 	}
 	if q.addProductToCategoryByIDStmt, err = db.PrepareContext(ctx, addProductToCategoryByID); err != nil {
 		return nil, fmt.Errorf("error preparing query AddProductToCategoryByID: %w", err)
 	}
+	if q.addReviewFlagStmt, err = db.PrepareContext(ctx, addReviewFlag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddReviewFlag: %w", err)
+	}
+	if q.addReviewImageStmt, err = db.PrepareContext(ctx, addReviewImage); err != nil {
+		return nil, fmt.Errorf("error preparing query AddReviewImage: %w", err)
+	}
+	if q.addSellerServiceAreaStmt, err = db.PrepareContext(ctx, addSellerServiceArea); err != nil {
+		return nil, fmt.Errorf("error preparing query AddSellerServiceArea: %w", err)
+	}
+	if q.addStockSubscriptionStmt, err = db.PrepareContext(ctx, addStockSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query AddStockSubscription: %w", err)
+	}
+	if q.addWishListStmt, err = db.PrepareContext(ctx, addWishList); err != nil {
+		return nil, fmt.Errorf("error preparing query AddWishList: %w", err)
+	}
 	if q.addWishListItemStmt, err = db.PrepareContext(ctx, addWishListItem); err != nil {
 		return nil, fmt.Errorf("error preparing query AddWishListItem: %w", err)
 	}
+	if q.cancelProductSaleByIDStmt, err = db.PrepareContext(ctx, cancelProductSaleByID); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelProductSaleByID: %w", err)
+	}
+	if q.completeProductImportJobStmt, err = db.PrepareContext(ctx, completeProductImportJob); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteProductImportJob: %w", err)
+	}
 	if q.decProductStockByIDStmt, err = db.PrepareContext(ctx, decProductStockByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DecProductStockByID: %w", err)
 	}
 	if q.deleteAllCategoriesForProductByIDStmt, err = db.PrepareContext(ctx, deleteAllCategoriesForProductByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAllCategoriesForProductByID: %w", err)
 	}
+	if q.deleteAllWishListItemsByListIDStmt, err = db.PrepareContext(ctx, deleteAllWishListItemsByListID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAllWishListItemsByListID: %w", err)
+	}
 	if q.deleteAllWishListItemsByUserIDStmt, err = db.PrepareContext(ctx, deleteAllWishListItemsByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAllWishListItemsByUserID: %w", err)
 	}
+	if q.deleteCategoryAttributeByIDStmt, err = db.PrepareContext(ctx, deleteCategoryAttributeByID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCategoryAttributeByID: %w", err)
+	}
 	if q.deleteCategoryByNameStmt, err = db.PrepareContext(ctx, deleteCategoryByName); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCategoryByName: %w", err)
 	}
+	if q.deleteProductAttributeValuesByProductIDStmt, err = db.PrepareContext(ctx, deleteProductAttributeValuesByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteProductAttributeValuesByProductID: %w", err)
+	}
 	if q.deleteProductByIDStmt, err = db.PrepareContext(ctx, deleteProductByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteProductByID: %w", err)
 	}
+	if q.deleteProductImageByIDStmt, err = db.PrepareContext(ctx, deleteProductImageByID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteProductImageByID: %w", err)
+	}
+	if q.deleteProductReviewByUserAndProductIDStmt, err = db.PrepareContext(ctx, deleteProductReviewByUserAndProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteProductReviewByUserAndProductID: %w", err)
+	}
 	if q.deleteProductsBySellerIDStmt, err = db.PrepareContext(ctx, deleteProductsBySellerID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteProductsBySellerID: %w", err)
 	}
-	if q.deleteWishListItemByUserAndProductIDStmt, err = db.PrepareContext(ctx, deleteWishListItemByUserAndProductID); err != nil {
-		return nil, fmt.Errorf("error preparing query DeleteWishListItemByUserAndProductID: %w", err)
+	if q.deleteReviewFlagsByUserIDStmt, err = db.PrepareContext(ctx, deleteReviewFlagsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReviewFlagsByUserID: %w", err)
+	}
+	if q.deleteReviewImagesByUserIDStmt, err = db.PrepareContext(ctx, deleteReviewImagesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteReviewImagesByUserID: %w", err)
+	}
+	if q.deleteSellerServiceAreasStmt, err = db.PrepareContext(ctx, deleteSellerServiceAreas); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSellerServiceAreas: %w", err)
+	}
+	if q.deleteStockSubscriptionStmt, err = db.PrepareContext(ctx, deleteStockSubscription); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStockSubscription: %w", err)
+	}
+	if q.deleteStockSubscriptionsByUserIDStmt, err = db.PrepareContext(ctx, deleteStockSubscriptionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteStockSubscriptionsByUserID: %w", err)
+	}
+	if q.deleteWishListStmt, err = db.PrepareContext(ctx, deleteWishList); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWishList: %w", err)
+	}
+	if q.deleteWishListItemByListAndProductIDStmt, err = db.PrepareContext(ctx, deleteWishListItemByListAndProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWishListItemByListAndProductID: %w", err)
+	}
+	if q.deleteWishListsByUserIDStmt, err = db.PrepareContext(ctx, deleteWishListsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteWishListsByUserID: %w", err)
 	}
 	if q.editCategoryNameByNameStmt, err = db.PrepareContext(ctx, editCategoryNameByName); err != nil {
 		return nil, fmt.Errorf("error preparing query EditCategoryNameByName: %w", err)
 	}
+	if q.editCategoryParentBySlugStmt, err = db.PrepareContext(ctx, editCategoryParentBySlug); err != nil {
+		return nil, fmt.Errorf("error preparing query EditCategoryParentBySlug: %w", err)
+	}
 	if q.editProductByIDStmt, err = db.PrepareContext(ctx, editProductByID); err != nil {
 		return nil, fmt.Errorf("error preparing query EditProductByID: %w", err)
 	}
+	if q.editProductLowStockThresholdByIDStmt, err = db.PrepareContext(ctx, editProductLowStockThresholdByID); err != nil {
+		return nil, fmt.Errorf("error preparing query EditProductLowStockThresholdByID: %w", err)
+	}
+	if q.editProductMRPByIDStmt, err = db.PrepareContext(ctx, editProductMRPByID); err != nil {
+		return nil, fmt.Errorf("error preparing query EditProductMRPByID: %w", err)
+	}
+	if q.editProductReviewByUserAndProductIDStmt, err = db.PrepareContext(ctx, editProductReviewByUserAndProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query EditProductReviewByUserAndProductID: %w", err)
+	}
+	if q.editProductWeightByIDStmt, err = db.PrepareContext(ctx, editProductWeightByID); err != nil {
+		return nil, fmt.Errorf("error preparing query EditProductWeightByID: %w", err)
+	}
+	if q.editWishListStmt, err = db.PrepareContext(ctx, editWishList); err != nil {
+		return nil, fmt.Errorf("error preparing query EditWishList: %w", err)
+	}
+	if q.eraseReviewsByUserIDStmt, err = db.PrepareContext(ctx, eraseReviewsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query EraseReviewsByUserID: %w", err)
+	}
+	if q.failUnfinishedProductImportJobsStmt, err = db.PrepareContext(ctx, failUnfinishedProductImportJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query FailUnfinishedProductImportJobs: %w", err)
+	}
 	if q.getAllCategoriesStmt, err = db.PrepareContext(ctx, getAllCategories); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllCategories: %w", err)
 	}
@@ -84,11 +177,14 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAllProductsForAdminStmt, err = db.PrepareContext(ctx, getAllProductsForAdmin); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllProductsForAdmin: %w", err)
 	}
-	if q.getAllWishListItemsByUserIDStmt, err = db.PrepareContext(ctx, getAllWishListItemsByUserID); err != nil {
-		return nil, fmt.Errorf("error preparing query GetAllWishListItemsByUserID: %w", err)
+	if q.getAttributesForProductByIDStmt, err = db.PrepareContext(ctx, getAttributesForProductByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAttributesForProductByID: %w", err)
 	}
-	if q.getAllWishListItemsWithProductNameByUserIDStmt, err = db.PrepareContext(ctx, getAllWishListItemsWithProductNameByUserID); err != nil {
-		return nil, fmt.Errorf("error preparing query GetAllWishListItemsWithProductNameByUserID: %w", err)
+	if q.getCategoryAttributeByIDStmt, err = db.PrepareContext(ctx, getCategoryAttributeByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCategoryAttributeByID: %w", err)
+	}
+	if q.getCategoryAttributesWithAncestorsByCategoryIDStmt, err = db.PrepareContext(ctx, getCategoryAttributesWithAncestorsByCategoryID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCategoryAttributesWithAncestorsByCategoryID: %w", err)
 	}
 	if q.getCategoryByIDStmt, err = db.PrepareContext(ctx, getCategoryByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCategoryByID: %w", err)
@@ -96,41 +192,223 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getCategoryByNameStmt, err = db.PrepareContext(ctx, getCategoryByName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCategoryByName: %w", err)
 	}
+	if q.getCategoryBySlugStmt, err = db.PrepareContext(ctx, getCategoryBySlug); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCategoryBySlug: %w", err)
+	}
 	if q.getCategoryNamesOfProductByIDStmt, err = db.PrepareContext(ctx, getCategoryNamesOfProductByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCategoryNamesOfProductByID: %w", err)
 	}
+	if q.getDuePriceAlertsStmt, err = db.PrepareContext(ctx, getDuePriceAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDuePriceAlerts: %w", err)
+	}
+	if q.getFlaggedReviewsStmt, err = db.PrepareContext(ctx, getFlaggedReviews); err != nil {
+		return nil, fmt.Errorf("error preparing query GetFlaggedReviews: %w", err)
+	}
+	if q.getLowStockProductsBySellerIDStmt, err = db.PrepareContext(ctx, getLowStockProductsBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLowStockProductsBySellerID: %w", err)
+	}
+	if q.getOrCreateDefaultWishListStmt, err = db.PrepareContext(ctx, getOrCreateDefaultWishList); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrCreateDefaultWishList: %w", err)
+	}
+	if q.getOverlappingSaleCountStmt, err = db.PrepareContext(ctx, getOverlappingSaleCount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOverlappingSaleCount: %w", err)
+	}
+	if q.getPendingStockSubscriptionsByProductIDStmt, err = db.PrepareContext(ctx, getPendingStockSubscriptionsByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPendingStockSubscriptionsByProductID: %w", err)
+	}
 	if q.getProductAndCategoryNameByIDStmt, err = db.PrepareContext(ctx, getProductAndCategoryNameByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductAndCategoryNameByID: %w", err)
 	}
+	if q.getProductAttributeValuesByProductIDStmt, err = db.PrepareContext(ctx, getProductAttributeValuesByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductAttributeValuesByProductID: %w", err)
+	}
 	if q.getProductAverageRatingAndTotalRatingStmt, err = db.PrepareContext(ctx, getProductAverageRatingAndTotalRating); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductAverageRatingAndTotalRating: %w", err)
 	}
 	if q.getProductByIDStmt, err = db.PrepareContext(ctx, getProductByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductByID: %w", err)
 	}
+	if q.getProductImageByIDStmt, err = db.PrepareContext(ctx, getProductImageByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductImageByID: %w", err)
+	}
+	if q.getProductImageCountByProductIDStmt, err = db.PrepareContext(ctx, getProductImageCountByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductImageCountByProductID: %w", err)
+	}
+	if q.getProductImagesByProductIDStmt, err = db.PrepareContext(ctx, getProductImagesByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductImagesByProductID: %w", err)
+	}
+	if q.getProductImagesByProductIDsStmt, err = db.PrepareContext(ctx, getProductImagesByProductIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductImagesByProductIDs: %w", err)
+	}
+	if q.getProductImportJobByIDAndSellerIDStmt, err = db.PrepareContext(ctx, getProductImportJobByIDAndSellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductImportJobByIDAndSellerID: %w", err)
+	}
+	if q.getProductImportJobsBySellerIDStmt, err = db.PrepareContext(ctx, getProductImportJobsBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductImportJobsBySellerID: %w", err)
+	}
+	if q.getProductPriceAtStmt, err = db.PrepareContext(ctx, getProductPriceAt); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductPriceAt: %w", err)
+	}
+	if q.getProductPricesByProductIDStmt, err = db.PrepareContext(ctx, getProductPricesByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductPricesByProductID: %w", err)
+	}
+	if q.getProductPricingStmt, err = db.PrepareContext(ctx, getProductPricing); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductPricing: %w", err)
+	}
+	if q.getProductPricingByIDsStmt, err = db.PrepareContext(ctx, getProductPricingByIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductPricingByIDs: %w", err)
+	}
+	if q.getProductRatingHistogramStmt, err = db.PrepareContext(ctx, getProductRatingHistogram); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductRatingHistogram: %w", err)
+	}
 	if q.getProductReviewsStmt, err = db.PrepareContext(ctx, getProductReviews); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductReviews: %w", err)
 	}
+	if q.getProductSaleByIDStmt, err = db.PrepareContext(ctx, getProductSaleByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductSaleByID: %w", err)
+	}
 	if q.getProductsByCategoryNameStmt, err = db.PrepareContext(ctx, getProductsByCategoryName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductsByCategoryName: %w", err)
 	}
 	if q.getProductsBySellerIDStmt, err = db.PrepareContext(ctx, getProductsBySellerID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductsBySellerID: %w", err)
 	}
+	if q.getProductsWithCategoriesBySellerIDStmt, err = db.PrepareContext(ctx, getProductsWithCategoriesBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetProductsWithCategoriesBySellerID: %w", err)
+	}
+	if q.getReviewByIDStmt, err = db.PrepareContext(ctx, getReviewByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReviewByID: %w", err)
+	}
 	if q.getReviewByUserAndProductIDStmt, err = db.PrepareContext(ctx, getReviewByUserAndProductID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReviewByUserAndProductID: %w", err)
 	}
-	if q.getWishListItemByUserAndProductIDStmt, err = db.PrepareContext(ctx, getWishListItemByUserAndProductID); err != nil {
-		return nil, fmt.Errorf("error preparing query GetWishListItemByUserAndProductID: %w", err)
+	if q.getReviewImageCountByReviewIDStmt, err = db.PrepareContext(ctx, getReviewImageCountByReviewID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReviewImageCountByReviewID: %w", err)
+	}
+	if q.getReviewImagesByReviewIDsStmt, err = db.PrepareContext(ctx, getReviewImagesByReviewIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReviewImagesByReviewIDs: %w", err)
+	}
+	if q.getReviewsByUserIDStmt, err = db.PrepareContext(ctx, getReviewsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReviewsByUserID: %w", err)
+	}
+	if q.getSellerProductCountStmt, err = db.PrepareContext(ctx, getSellerProductCount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerProductCount: %w", err)
+	}
+	if q.getSellerRatingSummaryStmt, err = db.PrepareContext(ctx, getSellerRatingSummary); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerRatingSummary: %w", err)
+	}
+	if q.getSellerServiceAreasStmt, err = db.PrepareContext(ctx, getSellerServiceAreas); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerServiceAreas: %w", err)
+	}
+	if q.getSellerShippingSettingsStmt, err = db.PrepareContext(ctx, getSellerShippingSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerShippingSettings: %w", err)
+	}
+	if q.getSellerShipsToPincodeStmt, err = db.PrepareContext(ctx, getSellerShipsToPincode); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerShipsToPincode: %w", err)
+	}
+	if q.getSellerStorefrontStmt, err = db.PrepareContext(ctx, getSellerStorefront); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerStorefront: %w", err)
+	}
+	if q.getStockAlertsBySellerIDStmt, err = db.PrepareContext(ctx, getStockAlertsBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockAlertsBySellerID: %w", err)
+	}
+	if q.getStockSubscriptionsByUserIDStmt, err = db.PrepareContext(ctx, getStockSubscriptionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetStockSubscriptionsByUserID: %w", err)
+	}
+	if q.getUnprocessedStockAlertsStmt, err = db.PrepareContext(ctx, getUnprocessedStockAlerts); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUnprocessedStockAlerts: %w", err)
+	}
+	if q.getWishListAlertUserIDsByProductIDStmt, err = db.PrepareContext(ctx, getWishListAlertUserIDsByProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListAlertUserIDsByProductID: %w", err)
+	}
+	if q.getWishListByIDStmt, err = db.PrepareContext(ctx, getWishListByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListByID: %w", err)
+	}
+	if q.getWishListByShareTokenStmt, err = db.PrepareContext(ctx, getWishListByShareToken); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListByShareToken: %w", err)
+	}
+	if q.getWishListItemByListAndProductIDStmt, err = db.PrepareContext(ctx, getWishListItemByListAndProductID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListItemByListAndProductID: %w", err)
+	}
+	if q.getWishListItemsByListIDStmt, err = db.PrepareContext(ctx, getWishListItemsByListID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListItemsByListID: %w", err)
+	}
+	if q.getWishListItemsByUserIDStmt, err = db.PrepareContext(ctx, getWishListItemsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListItemsByUserID: %w", err)
+	}
+	if q.getWishListsByUserIDStmt, err = db.PrepareContext(ctx, getWishListsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWishListsByUserID: %w", err)
 	}
 	if q.incProductStockByIDStmt, err = db.PrepareContext(ctx, incProductStockByID); err != nil {
 		return nil, fmt.Errorf("error preparing query IncProductStockByID: %w", err)
 	}
+	if q.isCategoryDescendantStmt, err = db.PrepareContext(ctx, isCategoryDescendant); err != nil {
+		return nil, fmt.Errorf("error preparing query IsCategoryDescendant: %w", err)
+	}
+	if q.markPriceAlertedStmt, err = db.PrepareContext(ctx, markPriceAlerted); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkPriceAlerted: %w", err)
+	}
+	if q.markReviewFlaggedStmt, err = db.PrepareContext(ctx, markReviewFlagged); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkReviewFlagged: %w", err)
+	}
+	if q.markStockAlertProcessedStmt, err = db.PrepareContext(ctx, markStockAlertProcessed); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkStockAlertProcessed: %w", err)
+	}
+	if q.markStockAlertsReadBySellerIDStmt, err = db.PrepareContext(ctx, markStockAlertsReadBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkStockAlertsReadBySellerID: %w", err)
+	}
+	if q.markStockSubscriptionNotifiedStmt, err = db.PrepareContext(ctx, markStockSubscriptionNotified); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkStockSubscriptionNotified: %w", err)
+	}
+	if q.resolveReviewFlagsStmt, err = db.PrepareContext(ctx, resolveReviewFlags); err != nil {
+		return nil, fmt.Errorf("error preparing query ResolveReviewFlags: %w", err)
+	}
+	if q.searchProductCategoryFacetsStmt, err = db.PrepareContext(ctx, searchProductCategoryFacets); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchProductCategoryFacets: %w", err)
+	}
+	if q.searchProductPriceFacetsStmt, err = db.PrepareContext(ctx, searchProductPriceFacets); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchProductPriceFacets: %w", err)
+	}
+	if q.searchProductsStmt, err = db.PrepareContext(ctx, searchProducts); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchProducts: %w", err)
+	}
+	if q.setReviewModerationStatusStmt, err = db.PrepareContext(ctx, setReviewModerationStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query SetReviewModerationStatus: %w", err)
+	}
+	if q.setWishListShareTokenStmt, err = db.PrepareContext(ctx, setWishListShareToken); err != nil {
+		return nil, fmt.Errorf("error preparing query SetWishListShareToken: %w", err)
+	}
+	if q.shiftProductImagePositionsAfterStmt, err = db.PrepareContext(ctx, shiftProductImagePositionsAfter); err != nil {
+		return nil, fmt.Errorf("error preparing query ShiftProductImagePositionsAfter: %w", err)
+	}
+	if q.updateProductImagePositionStmt, err = db.PrepareContext(ctx, updateProductImagePosition); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateProductImagePosition: %w", err)
+	}
+	if q.updateProductImportJobStatusStmt, err = db.PrepareContext(ctx, updateProductImportJobStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateProductImportJobStatus: %w", err)
+	}
+	if q.upsertProductAttributeValueStmt, err = db.PrepareContext(ctx, upsertProductAttributeValue); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertProductAttributeValue: %w", err)
+	}
+	if q.upsertSellerShippingRuleStmt, err = db.PrepareContext(ctx, upsertSellerShippingRule); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertSellerShippingRule: %w", err)
+	}
+	if q.upsertSellerShippingSettingsStmt, err = db.PrepareContext(ctx, upsertSellerShippingSettings); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertSellerShippingSettings: %w", err)
+	}
+	if q.upsertSellerStorefrontStmt, err = db.PrepareContext(ctx, upsertSellerStorefront); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertSellerStorefront: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addCategoryAttributeStmt != nil {
+		if cerr := q.addCategoryAttributeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addCategoryAttributeStmt: %w", cerr)
+		}
+	}
 	if q.addCateogryStmt != nil {
 		if cerr := q.addCateogryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addCateogryStmt: %w", cerr)
@@ -141,6 +419,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addProductStmt: %w", cerr)
 		}
 	}
+	if q.addProductImageStmt != nil {
+		if cerr := q.addProductImageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addProductImageStmt: %w", cerr)
+		}
+	}
+	if q.addProductImportJobStmt != nil {
+		if cerr := q.addProductImportJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addProductImportJobStmt: %w", cerr)
+		}
+	}
 	if q.addProductReviewWithCommmentStmt != nil {
 		if cerr := q.addProductReviewWithCommmentStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addProductReviewWithCommmentStmt: %w", cerr)
@@ -151,6 +439,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addProductReviewWithoutCommentStmt: %w", cerr)
 		}
 	}
+	if q.addProductSaleStmt != nil {
+		if cerr := q.addProductSaleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addProductSaleStmt: %w", cerr)
+		}
+	}
 	if q.addProductToCategoryByCategoryNameStmt != nil {
 		if cerr := q.addProductToCategoryByCategoryNameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addProductToCategoryByCategoryNameStmt: %w", cerr)
@@ -161,11 +454,46 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addProductToCategoryByIDStmt: %w", cerr)
 		}
 	}
+	if q.addReviewFlagStmt != nil {
+		if cerr := q.addReviewFlagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addReviewFlagStmt: %w", cerr)
+		}
+	}
+	if q.addReviewImageStmt != nil {
+		if cerr := q.addReviewImageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addReviewImageStmt: %w", cerr)
+		}
+	}
+	if q.addSellerServiceAreaStmt != nil {
+		if cerr := q.addSellerServiceAreaStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addSellerServiceAreaStmt: %w", cerr)
+		}
+	}
+	if q.addStockSubscriptionStmt != nil {
+		if cerr := q.addStockSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addStockSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.addWishListStmt != nil {
+		if cerr := q.addWishListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addWishListStmt: %w", cerr)
+		}
+	}
 	if q.addWishListItemStmt != nil {
 		if cerr := q.addWishListItemStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addWishListItemStmt: %w", cerr)
 		}
 	}
+	if q.cancelProductSaleByIDStmt != nil {
+		if cerr := q.cancelProductSaleByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelProductSaleByIDStmt: %w", cerr)
+		}
+	}
+	if q.completeProductImportJobStmt != nil {
+		if cerr := q.completeProductImportJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeProductImportJobStmt: %w", cerr)
+		}
+	}
 	if q.decProductStockByIDStmt != nil {
 		if cerr := q.decProductStockByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing decProductStockByIDStmt: %w", cerr)
@@ -176,29 +504,89 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteAllCategoriesForProductByIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteAllWishListItemsByListIDStmt != nil {
+		if cerr := q.deleteAllWishListItemsByListIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAllWishListItemsByListIDStmt: %w", cerr)
+		}
+	}
 	if q.deleteAllWishListItemsByUserIDStmt != nil {
 		if cerr := q.deleteAllWishListItemsByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAllWishListItemsByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteCategoryAttributeByIDStmt != nil {
+		if cerr := q.deleteCategoryAttributeByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCategoryAttributeByIDStmt: %w", cerr)
+		}
+	}
 	if q.deleteCategoryByNameStmt != nil {
 		if cerr := q.deleteCategoryByNameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCategoryByNameStmt: %w", cerr)
 		}
 	}
+	if q.deleteProductAttributeValuesByProductIDStmt != nil {
+		if cerr := q.deleteProductAttributeValuesByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteProductAttributeValuesByProductIDStmt: %w", cerr)
+		}
+	}
 	if q.deleteProductByIDStmt != nil {
 		if cerr := q.deleteProductByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteProductByIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteProductImageByIDStmt != nil {
+		if cerr := q.deleteProductImageByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteProductImageByIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteProductReviewByUserAndProductIDStmt != nil {
+		if cerr := q.deleteProductReviewByUserAndProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteProductReviewByUserAndProductIDStmt: %w", cerr)
+		}
+	}
 	if q.deleteProductsBySellerIDStmt != nil {
 		if cerr := q.deleteProductsBySellerIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteProductsBySellerIDStmt: %w", cerr)
 		}
 	}
-	if q.deleteWishListItemByUserAndProductIDStmt != nil {
-		if cerr := q.deleteWishListItemByUserAndProductIDStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing deleteWishListItemByUserAndProductIDStmt: %w", cerr)
+	if q.deleteReviewFlagsByUserIDStmt != nil {
+		if cerr := q.deleteReviewFlagsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReviewFlagsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteReviewImagesByUserIDStmt != nil {
+		if cerr := q.deleteReviewImagesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteReviewImagesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteSellerServiceAreasStmt != nil {
+		if cerr := q.deleteSellerServiceAreasStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSellerServiceAreasStmt: %w", cerr)
+		}
+	}
+	if q.deleteStockSubscriptionStmt != nil {
+		if cerr := q.deleteStockSubscriptionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStockSubscriptionStmt: %w", cerr)
+		}
+	}
+	if q.deleteStockSubscriptionsByUserIDStmt != nil {
+		if cerr := q.deleteStockSubscriptionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteStockSubscriptionsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteWishListStmt != nil {
+		if cerr := q.deleteWishListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWishListStmt: %w", cerr)
+		}
+	}
+	if q.deleteWishListItemByListAndProductIDStmt != nil {
+		if cerr := q.deleteWishListItemByListAndProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWishListItemByListAndProductIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteWishListsByUserIDStmt != nil {
+		if cerr := q.deleteWishListsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteWishListsByUserIDStmt: %w", cerr)
 		}
 	}
 	if q.editCategoryNameByNameStmt != nil {
@@ -206,11 +594,51 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing editCategoryNameByNameStmt: %w", cerr)
 		}
 	}
+	if q.editCategoryParentBySlugStmt != nil {
+		if cerr := q.editCategoryParentBySlugStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editCategoryParentBySlugStmt: %w", cerr)
+		}
+	}
 	if q.editProductByIDStmt != nil {
 		if cerr := q.editProductByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing editProductByIDStmt: %w", cerr)
 		}
 	}
+	if q.editProductLowStockThresholdByIDStmt != nil {
+		if cerr := q.editProductLowStockThresholdByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editProductLowStockThresholdByIDStmt: %w", cerr)
+		}
+	}
+	if q.editProductMRPByIDStmt != nil {
+		if cerr := q.editProductMRPByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editProductMRPByIDStmt: %w", cerr)
+		}
+	}
+	if q.editProductReviewByUserAndProductIDStmt != nil {
+		if cerr := q.editProductReviewByUserAndProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editProductReviewByUserAndProductIDStmt: %w", cerr)
+		}
+	}
+	if q.editProductWeightByIDStmt != nil {
+		if cerr := q.editProductWeightByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editProductWeightByIDStmt: %w", cerr)
+		}
+	}
+	if q.editWishListStmt != nil {
+		if cerr := q.editWishListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing editWishListStmt: %w", cerr)
+		}
+	}
+	if q.eraseReviewsByUserIDStmt != nil {
+		if cerr := q.eraseReviewsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing eraseReviewsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.failUnfinishedProductImportJobsStmt != nil {
+		if cerr := q.failUnfinishedProductImportJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing failUnfinishedProductImportJobsStmt: %w", cerr)
+		}
+	}
 	if q.getAllCategoriesStmt != nil {
 		if cerr := q.getAllCategoriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAllCategoriesStmt: %w", cerr)
@@ -231,14 +659,19 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAllProductsForAdminStmt: %w", cerr)
 		}
 	}
-	if q.getAllWishListItemsByUserIDStmt != nil {
-		if cerr := q.getAllWishListItemsByUserIDStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing getAllWishListItemsByUserIDStmt: %w", cerr)
+	if q.getAttributesForProductByIDStmt != nil {
+		if cerr := q.getAttributesForProductByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAttributesForProductByIDStmt: %w", cerr)
+		}
+	}
+	if q.getCategoryAttributeByIDStmt != nil {
+		if cerr := q.getCategoryAttributeByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCategoryAttributeByIDStmt: %w", cerr)
 		}
 	}
-	if q.getAllWishListItemsWithProductNameByUserIDStmt != nil {
-		if cerr := q.getAllWishListItemsWithProductNameByUserIDStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing getAllWishListItemsWithProductNameByUserIDStmt: %w", cerr)
+	if q.getCategoryAttributesWithAncestorsByCategoryIDStmt != nil {
+		if cerr := q.getCategoryAttributesWithAncestorsByCategoryIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCategoryAttributesWithAncestorsByCategoryIDStmt: %w", cerr)
 		}
 	}
 	if q.getCategoryByIDStmt != nil {
@@ -251,16 +684,56 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getCategoryByNameStmt: %w", cerr)
 		}
 	}
+	if q.getCategoryBySlugStmt != nil {
+		if cerr := q.getCategoryBySlugStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCategoryBySlugStmt: %w", cerr)
+		}
+	}
 	if q.getCategoryNamesOfProductByIDStmt != nil {
 		if cerr := q.getCategoryNamesOfProductByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCategoryNamesOfProductByIDStmt: %w", cerr)
 		}
 	}
+	if q.getDuePriceAlertsStmt != nil {
+		if cerr := q.getDuePriceAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDuePriceAlertsStmt: %w", cerr)
+		}
+	}
+	if q.getFlaggedReviewsStmt != nil {
+		if cerr := q.getFlaggedReviewsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getFlaggedReviewsStmt: %w", cerr)
+		}
+	}
+	if q.getLowStockProductsBySellerIDStmt != nil {
+		if cerr := q.getLowStockProductsBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLowStockProductsBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getOrCreateDefaultWishListStmt != nil {
+		if cerr := q.getOrCreateDefaultWishListStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrCreateDefaultWishListStmt: %w", cerr)
+		}
+	}
+	if q.getOverlappingSaleCountStmt != nil {
+		if cerr := q.getOverlappingSaleCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOverlappingSaleCountStmt: %w", cerr)
+		}
+	}
+	if q.getPendingStockSubscriptionsByProductIDStmt != nil {
+		if cerr := q.getPendingStockSubscriptionsByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPendingStockSubscriptionsByProductIDStmt: %w", cerr)
+		}
+	}
 	if q.getProductAndCategoryNameByIDStmt != nil {
 		if cerr := q.getProductAndCategoryNameByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProductAndCategoryNameByIDStmt: %w", cerr)
 		}
 	}
+	if q.getProductAttributeValuesByProductIDStmt != nil {
+		if cerr := q.getProductAttributeValuesByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductAttributeValuesByProductIDStmt: %w", cerr)
+		}
+	}
 	if q.getProductAverageRatingAndTotalRatingStmt != nil {
 		if cerr := q.getProductAverageRatingAndTotalRatingStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProductAverageRatingAndTotalRatingStmt: %w", cerr)
@@ -271,11 +744,71 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getProductByIDStmt: %w", cerr)
 		}
 	}
+	if q.getProductImageByIDStmt != nil {
+		if cerr := q.getProductImageByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductImageByIDStmt: %w", cerr)
+		}
+	}
+	if q.getProductImageCountByProductIDStmt != nil {
+		if cerr := q.getProductImageCountByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductImageCountByProductIDStmt: %w", cerr)
+		}
+	}
+	if q.getProductImagesByProductIDStmt != nil {
+		if cerr := q.getProductImagesByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductImagesByProductIDStmt: %w", cerr)
+		}
+	}
+	if q.getProductImagesByProductIDsStmt != nil {
+		if cerr := q.getProductImagesByProductIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductImagesByProductIDsStmt: %w", cerr)
+		}
+	}
+	if q.getProductImportJobByIDAndSellerIDStmt != nil {
+		if cerr := q.getProductImportJobByIDAndSellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductImportJobByIDAndSellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getProductImportJobsBySellerIDStmt != nil {
+		if cerr := q.getProductImportJobsBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductImportJobsBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getProductPriceAtStmt != nil {
+		if cerr := q.getProductPriceAtStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductPriceAtStmt: %w", cerr)
+		}
+	}
+	if q.getProductPricesByProductIDStmt != nil {
+		if cerr := q.getProductPricesByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductPricesByProductIDStmt: %w", cerr)
+		}
+	}
+	if q.getProductPricingStmt != nil {
+		if cerr := q.getProductPricingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductPricingStmt: %w", cerr)
+		}
+	}
+	if q.getProductPricingByIDsStmt != nil {
+		if cerr := q.getProductPricingByIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductPricingByIDsStmt: %w", cerr)
+		}
+	}
+	if q.getProductRatingHistogramStmt != nil {
+		if cerr := q.getProductRatingHistogramStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductRatingHistogramStmt: %w", cerr)
+		}
+	}
 	if q.getProductReviewsStmt != nil {
 		if cerr := q.getProductReviewsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProductReviewsStmt: %w", cerr)
 		}
 	}
+	if q.getProductSaleByIDStmt != nil {
+		if cerr := q.getProductSaleByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductSaleByIDStmt: %w", cerr)
+		}
+	}
 	if q.getProductsByCategoryNameStmt != nil {
 		if cerr := q.getProductsByCategoryNameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProductsByCategoryNameStmt: %w", cerr)
@@ -286,14 +819,114 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getProductsBySellerIDStmt: %w", cerr)
 		}
 	}
+	if q.getProductsWithCategoriesBySellerIDStmt != nil {
+		if cerr := q.getProductsWithCategoriesBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getProductsWithCategoriesBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getReviewByIDStmt != nil {
+		if cerr := q.getReviewByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReviewByIDStmt: %w", cerr)
+		}
+	}
 	if q.getReviewByUserAndProductIDStmt != nil {
 		if cerr := q.getReviewByUserAndProductIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReviewByUserAndProductIDStmt: %w", cerr)
 		}
 	}
-	if q.getWishListItemByUserAndProductIDStmt != nil {
-		if cerr := q.getWishListItemByUserAndProductIDStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing getWishListItemByUserAndProductIDStmt: %w", cerr)
+	if q.getReviewImageCountByReviewIDStmt != nil {
+		if cerr := q.getReviewImageCountByReviewIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReviewImageCountByReviewIDStmt: %w", cerr)
+		}
+	}
+	if q.getReviewImagesByReviewIDsStmt != nil {
+		if cerr := q.getReviewImagesByReviewIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReviewImagesByReviewIDsStmt: %w", cerr)
+		}
+	}
+	if q.getReviewsByUserIDStmt != nil {
+		if cerr := q.getReviewsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReviewsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getSellerProductCountStmt != nil {
+		if cerr := q.getSellerProductCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerProductCountStmt: %w", cerr)
+		}
+	}
+	if q.getSellerRatingSummaryStmt != nil {
+		if cerr := q.getSellerRatingSummaryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerRatingSummaryStmt: %w", cerr)
+		}
+	}
+	if q.getSellerServiceAreasStmt != nil {
+		if cerr := q.getSellerServiceAreasStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerServiceAreasStmt: %w", cerr)
+		}
+	}
+	if q.getSellerShippingSettingsStmt != nil {
+		if cerr := q.getSellerShippingSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerShippingSettingsStmt: %w", cerr)
+		}
+	}
+	if q.getSellerShipsToPincodeStmt != nil {
+		if cerr := q.getSellerShipsToPincodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerShipsToPincodeStmt: %w", cerr)
+		}
+	}
+	if q.getSellerStorefrontStmt != nil {
+		if cerr := q.getSellerStorefrontStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerStorefrontStmt: %w", cerr)
+		}
+	}
+	if q.getStockAlertsBySellerIDStmt != nil {
+		if cerr := q.getStockAlertsBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockAlertsBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getStockSubscriptionsByUserIDStmt != nil {
+		if cerr := q.getStockSubscriptionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getStockSubscriptionsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getUnprocessedStockAlertsStmt != nil {
+		if cerr := q.getUnprocessedStockAlertsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUnprocessedStockAlertsStmt: %w", cerr)
+		}
+	}
+	if q.getWishListAlertUserIDsByProductIDStmt != nil {
+		if cerr := q.getWishListAlertUserIDsByProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListAlertUserIDsByProductIDStmt: %w", cerr)
+		}
+	}
+	if q.getWishListByIDStmt != nil {
+		if cerr := q.getWishListByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListByIDStmt: %w", cerr)
+		}
+	}
+	if q.getWishListByShareTokenStmt != nil {
+		if cerr := q.getWishListByShareTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListByShareTokenStmt: %w", cerr)
+		}
+	}
+	if q.getWishListItemByListAndProductIDStmt != nil {
+		if cerr := q.getWishListItemByListAndProductIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListItemByListAndProductIDStmt: %w", cerr)
+		}
+	}
+	if q.getWishListItemsByListIDStmt != nil {
+		if cerr := q.getWishListItemsByListIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListItemsByListIDStmt: %w", cerr)
+		}
+	}
+	if q.getWishListItemsByUserIDStmt != nil {
+		if cerr := q.getWishListItemsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListItemsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getWishListsByUserIDStmt != nil {
+		if cerr := q.getWishListsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWishListsByUserIDStmt: %w", cerr)
 		}
 	}
 	if q.incProductStockByIDStmt != nil {
@@ -301,6 +934,101 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing incProductStockByIDStmt: %w", cerr)
 		}
 	}
+	if q.isCategoryDescendantStmt != nil {
+		if cerr := q.isCategoryDescendantStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isCategoryDescendantStmt: %w", cerr)
+		}
+	}
+	if q.markPriceAlertedStmt != nil {
+		if cerr := q.markPriceAlertedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markPriceAlertedStmt: %w", cerr)
+		}
+	}
+	if q.markReviewFlaggedStmt != nil {
+		if cerr := q.markReviewFlaggedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markReviewFlaggedStmt: %w", cerr)
+		}
+	}
+	if q.markStockAlertProcessedStmt != nil {
+		if cerr := q.markStockAlertProcessedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markStockAlertProcessedStmt: %w", cerr)
+		}
+	}
+	if q.markStockAlertsReadBySellerIDStmt != nil {
+		if cerr := q.markStockAlertsReadBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markStockAlertsReadBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.markStockSubscriptionNotifiedStmt != nil {
+		if cerr := q.markStockSubscriptionNotifiedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markStockSubscriptionNotifiedStmt: %w", cerr)
+		}
+	}
+	if q.resolveReviewFlagsStmt != nil {
+		if cerr := q.resolveReviewFlagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing resolveReviewFlagsStmt: %w", cerr)
+		}
+	}
+	if q.searchProductCategoryFacetsStmt != nil {
+		if cerr := q.searchProductCategoryFacetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchProductCategoryFacetsStmt: %w", cerr)
+		}
+	}
+	if q.searchProductPriceFacetsStmt != nil {
+		if cerr := q.searchProductPriceFacetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchProductPriceFacetsStmt: %w", cerr)
+		}
+	}
+	if q.searchProductsStmt != nil {
+		if cerr := q.searchProductsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchProductsStmt: %w", cerr)
+		}
+	}
+	if q.setReviewModerationStatusStmt != nil {
+		if cerr := q.setReviewModerationStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setReviewModerationStatusStmt: %w", cerr)
+		}
+	}
+	if q.setWishListShareTokenStmt != nil {
+		if cerr := q.setWishListShareTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setWishListShareTokenStmt: %w", cerr)
+		}
+	}
+	if q.shiftProductImagePositionsAfterStmt != nil {
+		if cerr := q.shiftProductImagePositionsAfterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing shiftProductImagePositionsAfterStmt: %w", cerr)
+		}
+	}
+	if q.updateProductImagePositionStmt != nil {
+		if cerr := q.updateProductImagePositionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateProductImagePositionStmt: %w", cerr)
+		}
+	}
+	if q.updateProductImportJobStatusStmt != nil {
+		if cerr := q.updateProductImportJobStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateProductImportJobStatusStmt: %w", cerr)
+		}
+	}
+	if q.upsertProductAttributeValueStmt != nil {
+		if cerr := q.upsertProductAttributeValueStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertProductAttributeValueStmt: %w", cerr)
+		}
+	}
+	if q.upsertSellerShippingRuleStmt != nil {
+		if cerr := q.upsertSellerShippingRuleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertSellerShippingRuleStmt: %w", cerr)
+		}
+	}
+	if q.upsertSellerShippingSettingsStmt != nil {
+		if cerr := q.upsertSellerShippingSettingsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertSellerShippingSettingsStmt: %w", cerr)
+		}
+	}
+	if q.upsertSellerStorefrontStmt != nil {
+		if cerr := q.upsertSellerStorefrontStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertSellerStorefrontStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -338,81 +1066,263 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                             DBTX
-	tx                                             *sql.Tx
-	addCateogryStmt                                *sql.Stmt
-	addProductStmt                                 *sql.Stmt
-	addProductReviewWithCommmentStmt               *sql.Stmt
-	addProductReviewWithoutCommentStmt             *sql.Stmt
-	addProductToCategoryByCategoryNameStmt         *sql.Stmt
-	addProductToCategoryByIDStmt                   *sql.Stmt
-	addWishListItemStmt                            *sql.Stmt
-	decProductStockByIDStmt                        *sql.Stmt
-	deleteAllCategoriesForProductByIDStmt          *sql.Stmt
-	deleteAllWishListItemsByUserIDStmt             *sql.Stmt
-	deleteCategoryByNameStmt                       *sql.Stmt
-	deleteProductByIDStmt                          *sql.Stmt
-	deleteProductsBySellerIDStmt                   *sql.Stmt
-	deleteWishListItemByUserAndProductIDStmt       *sql.Stmt
-	editCategoryNameByNameStmt                     *sql.Stmt
-	editProductByIDStmt                            *sql.Stmt
-	getAllCategoriesStmt                           *sql.Stmt
-	getAllCategoriesForAdminStmt                   *sql.Stmt
-	getAllProductsStmt                             *sql.Stmt
-	getAllProductsForAdminStmt                     *sql.Stmt
-	getAllWishListItemsByUserIDStmt                *sql.Stmt
-	getAllWishListItemsWithProductNameByUserIDStmt *sql.Stmt
-	getCategoryByIDStmt                            *sql.Stmt
-	getCategoryByNameStmt                          *sql.Stmt
-	getCategoryNamesOfProductByIDStmt              *sql.Stmt
-	getProductAndCategoryNameByIDStmt              *sql.Stmt
-	getProductAverageRatingAndTotalRatingStmt      *sql.Stmt
-	getProductByIDStmt                             *sql.Stmt
-	getProductReviewsStmt                          *sql.Stmt
-	getProductsByCategoryNameStmt                  *sql.Stmt
-	getProductsBySellerIDStmt                      *sql.Stmt
-	getReviewByUserAndProductIDStmt                *sql.Stmt
-	getWishListItemByUserAndProductIDStmt          *sql.Stmt
-	incProductStockByIDStmt                        *sql.Stmt
+	db                                                 DBTX
+	tx                                                 *sql.Tx
+	addCategoryAttributeStmt                           *sql.Stmt
+	addCateogryStmt                                    *sql.Stmt
+	addProductStmt                                     *sql.Stmt
+	addProductImageStmt                                *sql.Stmt
+	addProductImportJobStmt                            *sql.Stmt
+	addProductReviewWithCommmentStmt                   *sql.Stmt
+	addProductReviewWithoutCommentStmt                 *sql.Stmt
+	addProductSaleStmt                                 *sql.Stmt
+	addProductToCategoryByCategoryNameStmt             *sql.Stmt
+	addProductToCategoryByIDStmt                       *sql.Stmt
+	addReviewFlagStmt                                  *sql.Stmt
+	addReviewImageStmt                                 *sql.Stmt
+	addSellerServiceAreaStmt                           *sql.Stmt
+	addStockSubscriptionStmt                           *sql.Stmt
+	addWishListStmt                                    *sql.Stmt
+	addWishListItemStmt                                *sql.Stmt
+	cancelProductSaleByIDStmt                          *sql.Stmt
+	completeProductImportJobStmt                       *sql.Stmt
+	decProductStockByIDStmt                            *sql.Stmt
+	deleteAllCategoriesForProductByIDStmt              *sql.Stmt
+	deleteAllWishListItemsByListIDStmt                 *sql.Stmt
+	deleteAllWishListItemsByUserIDStmt                 *sql.Stmt
+	deleteCategoryAttributeByIDStmt                    *sql.Stmt
+	deleteCategoryByNameStmt                           *sql.Stmt
+	deleteProductAttributeValuesByProductIDStmt        *sql.Stmt
+	deleteProductByIDStmt                              *sql.Stmt
+	deleteProductImageByIDStmt                         *sql.Stmt
+	deleteProductReviewByUserAndProductIDStmt          *sql.Stmt
+	deleteProductsBySellerIDStmt                       *sql.Stmt
+	deleteReviewFlagsByUserIDStmt                      *sql.Stmt
+	deleteReviewImagesByUserIDStmt                     *sql.Stmt
+	deleteSellerServiceAreasStmt                       *sql.Stmt
+	deleteStockSubscriptionStmt                        *sql.Stmt
+	deleteStockSubscriptionsByUserIDStmt               *sql.Stmt
+	deleteWishListStmt                                 *sql.Stmt
+	deleteWishListItemByListAndProductIDStmt           *sql.Stmt
+	deleteWishListsByUserIDStmt                        *sql.Stmt
+	editCategoryNameByNameStmt                         *sql.Stmt
+	editCategoryParentBySlugStmt                       *sql.Stmt
+	editProductByIDStmt                                *sql.Stmt
+	editProductLowStockThresholdByIDStmt               *sql.Stmt
+	editProductMRPByIDStmt                             *sql.Stmt
+	editProductReviewByUserAndProductIDStmt            *sql.Stmt
+	editProductWeightByIDStmt                          *sql.Stmt
+	editWishListStmt                                   *sql.Stmt
+	eraseReviewsByUserIDStmt                           *sql.Stmt
+	failUnfinishedProductImportJobsStmt                *sql.Stmt
+	getAllCategoriesStmt                               *sql.Stmt
+	getAllCategoriesForAdminStmt                       *sql.Stmt
+	getAllProductsStmt                                 *sql.Stmt
+	getAllProductsForAdminStmt                         *sql.Stmt
+	getAttributesForProductByIDStmt                    *sql.Stmt
+	getCategoryAttributeByIDStmt                       *sql.Stmt
+	getCategoryAttributesWithAncestorsByCategoryIDStmt *sql.Stmt
+	getCategoryByIDStmt                                *sql.Stmt
+	getCategoryByNameStmt                              *sql.Stmt
+	getCategoryBySlugStmt                              *sql.Stmt
+	getCategoryNamesOfProductByIDStmt                  *sql.Stmt
+	getDuePriceAlertsStmt                              *sql.Stmt
+	getFlaggedReviewsStmt                              *sql.Stmt
+	getLowStockProductsBySellerIDStmt                  *sql.Stmt
+	getOrCreateDefaultWishListStmt                     *sql.Stmt
+	getOverlappingSaleCountStmt                        *sql.Stmt
+	getPendingStockSubscriptionsByProductIDStmt        *sql.Stmt
+	getProductAndCategoryNameByIDStmt                  *sql.Stmt
+	getProductAttributeValuesByProductIDStmt           *sql.Stmt
+	getProductAverageRatingAndTotalRatingStmt          *sql.Stmt
+	getProductByIDStmt                                 *sql.Stmt
+	getProductImageByIDStmt                            *sql.Stmt
+	getProductImageCountByProductIDStmt                *sql.Stmt
+	getProductImagesByProductIDStmt                    *sql.Stmt
+	getProductImagesByProductIDsStmt                   *sql.Stmt
+	getProductImportJobByIDAndSellerIDStmt             *sql.Stmt
+	getProductImportJobsBySellerIDStmt                 *sql.Stmt
+	getProductPriceAtStmt                              *sql.Stmt
+	getProductPricesByProductIDStmt                    *sql.Stmt
+	getProductPricingStmt                              *sql.Stmt
+	getProductPricingByIDsStmt                         *sql.Stmt
+	getProductRatingHistogramStmt                      *sql.Stmt
+	getProductReviewsStmt                              *sql.Stmt
+	getProductSaleByIDStmt                             *sql.Stmt
+	getProductsByCategoryNameStmt                      *sql.Stmt
+	getProductsBySellerIDStmt                          *sql.Stmt
+	getProductsWithCategoriesBySellerIDStmt            *sql.Stmt
+	getReviewByIDStmt                                  *sql.Stmt
+	getReviewByUserAndProductIDStmt                    *sql.Stmt
+	getReviewImageCountByReviewIDStmt                  *sql.Stmt
+	getReviewImagesByReviewIDsStmt                     *sql.Stmt
+	getReviewsByUserIDStmt                             *sql.Stmt
+	getSellerProductCountStmt                          *sql.Stmt
+	getSellerRatingSummaryStmt                         *sql.Stmt
+	getSellerServiceAreasStmt                          *sql.Stmt
+	getSellerShippingSettingsStmt                      *sql.Stmt
+	getSellerShipsToPincodeStmt                        *sql.Stmt
+	getSellerStorefrontStmt                            *sql.Stmt
+	getStockAlertsBySellerIDStmt                       *sql.Stmt
+	getStockSubscriptionsByUserIDStmt                  *sql.Stmt
+	getUnprocessedStockAlertsStmt                      *sql.Stmt
+	getWishListAlertUserIDsByProductIDStmt             *sql.Stmt
+	getWishListByIDStmt                                *sql.Stmt
+	getWishListByShareTokenStmt                        *sql.Stmt
+	getWishListItemByListAndProductIDStmt              *sql.Stmt
+	getWishListItemsByListIDStmt                       *sql.Stmt
+	getWishListItemsByUserIDStmt                       *sql.Stmt
+	getWishListsByUserIDStmt                           *sql.Stmt
+	incProductStockByIDStmt                            *sql.Stmt
+	isCategoryDescendantStmt                           *sql.Stmt
+	markPriceAlertedStmt                               *sql.Stmt
+	markReviewFlaggedStmt                              *sql.Stmt
+	markStockAlertProcessedStmt                        *sql.Stmt
+	markStockAlertsReadBySellerIDStmt                  *sql.Stmt
+	markStockSubscriptionNotifiedStmt                  *sql.Stmt
+	resolveReviewFlagsStmt                             *sql.Stmt
+	searchProductCategoryFacetsStmt                    *sql.Stmt
+	searchProductPriceFacetsStmt                       *sql.Stmt
+	searchProductsStmt                                 *sql.Stmt
+	setReviewModerationStatusStmt                      *sql.Stmt
+	setWishListShareTokenStmt                          *sql.Stmt
+	shiftProductImagePositionsAfterStmt                *sql.Stmt
+	updateProductImagePositionStmt                     *sql.Stmt
+	updateProductImportJobStatusStmt                   *sql.Stmt
+	upsertProductAttributeValueStmt                    *sql.Stmt
+	upsertSellerShippingRuleStmt                       *sql.Stmt
+	upsertSellerShippingSettingsStmt                   *sql.Stmt
+	upsertSellerStorefrontStmt                         *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                             tx,
-		tx:                                             tx,
-		addCateogryStmt:                                q.addCateogryStmt,
-		addProductStmt:                                 q.addProductStmt,
-		addProductReviewWithCommmentStmt:               q.addProductReviewWithCommmentStmt,
-		addProductReviewWithoutCommentStmt:             q.addProductReviewWithoutCommentStmt,
-		addProductToCategoryByCategoryNameStmt:         q.addProductToCategoryByCategoryNameStmt,
-		addProductToCategoryByIDStmt:                   q.addProductToCategoryByIDStmt,
-		addWishListItemStmt:                            q.addWishListItemStmt,
-		decProductStockByIDStmt:                        q.decProductStockByIDStmt,
-		deleteAllCategoriesForProductByIDStmt:          q.deleteAllCategoriesForProductByIDStmt,
-		deleteAllWishListItemsByUserIDStmt:             q.deleteAllWishListItemsByUserIDStmt,
-		deleteCategoryByNameStmt:                       q.deleteCategoryByNameStmt,
-		deleteProductByIDStmt:                          q.deleteProductByIDStmt,
-		deleteProductsBySellerIDStmt:                   q.deleteProductsBySellerIDStmt,
-		deleteWishListItemByUserAndProductIDStmt:       q.deleteWishListItemByUserAndProductIDStmt,
-		editCategoryNameByNameStmt:                     q.editCategoryNameByNameStmt,
-		editProductByIDStmt:                            q.editProductByIDStmt,
-		getAllCategoriesStmt:                           q.getAllCategoriesStmt,
-		getAllCategoriesForAdminStmt:                   q.getAllCategoriesForAdminStmt,
-		getAllProductsStmt:                             q.getAllProductsStmt,
-		getAllProductsForAdminStmt:                     q.getAllProductsForAdminStmt,
-		getAllWishListItemsByUserIDStmt:                q.getAllWishListItemsByUserIDStmt,
-		getAllWishListItemsWithProductNameByUserIDStmt: q.getAllWishListItemsWithProductNameByUserIDStmt,
-		getCategoryByIDStmt:                            q.getCategoryByIDStmt,
-		getCategoryByNameStmt:                          q.getCategoryByNameStmt,
-		getCategoryNamesOfProductByIDStmt:              q.getCategoryNamesOfProductByIDStmt,
-		getProductAndCategoryNameByIDStmt:              q.getProductAndCategoryNameByIDStmt,
-		getProductAverageRatingAndTotalRatingStmt:      q.getProductAverageRatingAndTotalRatingStmt,
-		getProductByIDStmt:                             q.getProductByIDStmt,
-		getProductReviewsStmt:                          q.getProductReviewsStmt,
-		getProductsByCategoryNameStmt:                  q.getProductsByCategoryNameStmt,
-		getProductsBySellerIDStmt:                      q.getProductsBySellerIDStmt,
-		getReviewByUserAndProductIDStmt:                q.getReviewByUserAndProductIDStmt,
-		getWishListItemByUserAndProductIDStmt:          q.getWishListItemByUserAndProductIDStmt,
-		incProductStockByIDStmt:                        q.incProductStockByIDStmt,
+		db:                                                 tx,
+		tx:                                                 tx,
+		addCategoryAttributeStmt:                           q.addCategoryAttributeStmt,
+		addCateogryStmt:                                    q.addCateogryStmt,
+		addProductStmt:                                     q.addProductStmt,
+		addProductImageStmt:                                q.addProductImageStmt,
+		addProductImportJobStmt:                            q.addProductImportJobStmt,
+		addProductReviewWithCommmentStmt:                   q.addProductReviewWithCommmentStmt,
+		addProductReviewWithoutCommentStmt:                 q.addProductReviewWithoutCommentStmt,
+		addProductSaleStmt:                                 q.addProductSaleStmt,
+		addProductToCategoryByCategoryNameStmt:             q.addProductToCategoryByCategoryNameStmt,
+		addProductToCategoryByIDStmt:                       q.addProductToCategoryByIDStmt,
+		addReviewFlagStmt:                                  q.addReviewFlagStmt,
+		addReviewImageStmt:                                 q.addReviewImageStmt,
+		addSellerServiceAreaStmt:                           q.addSellerServiceAreaStmt,
+		addStockSubscriptionStmt:                           q.addStockSubscriptionStmt,
+		addWishListStmt:                                    q.addWishListStmt,
+		addWishListItemStmt:                                q.addWishListItemStmt,
+		cancelProductSaleByIDStmt:                          q.cancelProductSaleByIDStmt,
+		completeProductImportJobStmt:                       q.completeProductImportJobStmt,
+		decProductStockByIDStmt:                            q.decProductStockByIDStmt,
+		deleteAllCategoriesForProductByIDStmt:              q.deleteAllCategoriesForProductByIDStmt,
+		deleteAllWishListItemsByListIDStmt:                 q.deleteAllWishListItemsByListIDStmt,
+		deleteAllWishListItemsByUserIDStmt:                 q.deleteAllWishListItemsByUserIDStmt,
+		deleteCategoryAttributeByIDStmt:                    q.deleteCategoryAttributeByIDStmt,
+		deleteCategoryByNameStmt:                           q.deleteCategoryByNameStmt,
+		deleteProductAttributeValuesByProductIDStmt:        q.deleteProductAttributeValuesByProductIDStmt,
+		deleteProductByIDStmt:                              q.deleteProductByIDStmt,
+		deleteProductImageByIDStmt:                         q.deleteProductImageByIDStmt,
+		deleteProductReviewByUserAndProductIDStmt:          q.deleteProductReviewByUserAndProductIDStmt,
+		deleteProductsBySellerIDStmt:                       q.deleteProductsBySellerIDStmt,
+		deleteReviewFlagsByUserIDStmt:                      q.deleteReviewFlagsByUserIDStmt,
+		deleteReviewImagesByUserIDStmt:                     q.deleteReviewImagesByUserIDStmt,
+		deleteSellerServiceAreasStmt:                       q.deleteSellerServiceAreasStmt,
+		deleteStockSubscriptionStmt:                        q.deleteStockSubscriptionStmt,
+		deleteStockSubscriptionsByUserIDStmt:               q.deleteStockSubscriptionsByUserIDStmt,
+		deleteWishListStmt:                                 q.deleteWishListStmt,
+		deleteWishListItemByListAndProductIDStmt:           q.deleteWishListItemByListAndProductIDStmt,
+		deleteWishListsByUserIDStmt:                        q.deleteWishListsByUserIDStmt,
+		editCategoryNameByNameStmt:                         q.editCategoryNameByNameStmt,
+		editCategoryParentBySlugStmt:                       q.editCategoryParentBySlugStmt,
+		editProductByIDStmt:                                q.editProductByIDStmt,
+		editProductLowStockThresholdByIDStmt:               q.editProductLowStockThresholdByIDStmt,
+		editProductMRPByIDStmt:                             q.editProductMRPByIDStmt,
+		editProductReviewByUserAndProductIDStmt:            q.editProductReviewByUserAndProductIDStmt,
+		editProductWeightByIDStmt:                          q.editProductWeightByIDStmt,
+		editWishListStmt:                                   q.editWishListStmt,
+		eraseReviewsByUserIDStmt:                           q.eraseReviewsByUserIDStmt,
+		failUnfinishedProductImportJobsStmt:                q.failUnfinishedProductImportJobsStmt,
+		getAllCategoriesStmt:                               q.getAllCategoriesStmt,
+		getAllCategoriesForAdminStmt:                       q.getAllCategoriesForAdminStmt,
+		getAllProductsStmt:                                 q.getAllProductsStmt,
+		getAllProductsForAdminStmt:                         q.getAllProductsForAdminStmt,
+		getAttributesForProductByIDStmt:                    q.getAttributesForProductByIDStmt,
+		getCategoryAttributeByIDStmt:                       q.getCategoryAttributeByIDStmt,
+		getCategoryAttributesWithAncestorsByCategoryIDStmt: q.getCategoryAttributesWithAncestorsByCategoryIDStmt,
+		getCategoryByIDStmt:                                q.getCategoryByIDStmt,
+		getCategoryByNameStmt:                              q.getCategoryByNameStmt,
+		getCategoryBySlugStmt:                              q.getCategoryBySlugStmt,
+		getCategoryNamesOfProductByIDStmt:                  q.getCategoryNamesOfProductByIDStmt,
+		getDuePriceAlertsStmt:                              q.getDuePriceAlertsStmt,
+		getFlaggedReviewsStmt:                              q.getFlaggedReviewsStmt,
+		getLowStockProductsBySellerIDStmt:                  q.getLowStockProductsBySellerIDStmt,
+		getOrCreateDefaultWishListStmt:                     q.getOrCreateDefaultWishListStmt,
+		getOverlappingSaleCountStmt:                        q.getOverlappingSaleCountStmt,
+		getPendingStockSubscriptionsByProductIDStmt:        q.getPendingStockSubscriptionsByProductIDStmt,
+		getProductAndCategoryNameByIDStmt:                  q.getProductAndCategoryNameByIDStmt,
+		getProductAttributeValuesByProductIDStmt:           q.getProductAttributeValuesByProductIDStmt,
+		getProductAverageRatingAndTotalRatingStmt:          q.getProductAverageRatingAndTotalRatingStmt,
+		getProductByIDStmt:                                 q.getProductByIDStmt,
+		getProductImageByIDStmt:                            q.getProductImageByIDStmt,
+		getProductImageCountByProductIDStmt:                q.getProductImageCountByProductIDStmt,
+		getProductImagesByProductIDStmt:                    q.getProductImagesByProductIDStmt,
+		getProductImagesByProductIDsStmt:                   q.getProductImagesByProductIDsStmt,
+		getProductImportJobByIDAndSellerIDStmt:             q.getProductImportJobByIDAndSellerIDStmt,
+		getProductImportJobsBySellerIDStmt:                 q.getProductImportJobsBySellerIDStmt,
+		getProductPriceAtStmt:                              q.getProductPriceAtStmt,
+		getProductPricesByProductIDStmt:                    q.getProductPricesByProductIDStmt,
+		getProductPricingStmt:                              q.getProductPricingStmt,
+		getProductPricingByIDsStmt:                         q.getProductPricingByIDsStmt,
+		getProductRatingHistogramStmt:                      q.getProductRatingHistogramStmt,
+		getProductReviewsStmt:                              q.getProductReviewsStmt,
+		getProductSaleByIDStmt:                             q.getProductSaleByIDStmt,
+		getProductsByCategoryNameStmt:                      q.getProductsByCategoryNameStmt,
+		getProductsBySellerIDStmt:                          q.getProductsBySellerIDStmt,
+		getProductsWithCategoriesBySellerIDStmt:            q.getProductsWithCategoriesBySellerIDStmt,
+		getReviewByIDStmt:                                  q.getReviewByIDStmt,
+		getReviewByUserAndProductIDStmt:                    q.getReviewByUserAndProductIDStmt,
+		getReviewImageCountByReviewIDStmt:                  q.getReviewImageCountByReviewIDStmt,
+		getReviewImagesByReviewIDsStmt:                     q.getReviewImagesByReviewIDsStmt,
+		getReviewsByUserIDStmt:                             q.getReviewsByUserIDStmt,
+		getSellerProductCountStmt:                          q.getSellerProductCountStmt,
+		getSellerRatingSummaryStmt:                         q.getSellerRatingSummaryStmt,
+		getSellerServiceAreasStmt:                          q.getSellerServiceAreasStmt,
+		getSellerShippingSettingsStmt:                      q.getSellerShippingSettingsStmt,
+		getSellerShipsToPincodeStmt:                        q.getSellerShipsToPincodeStmt,
+		getSellerStorefrontStmt:                            q.getSellerStorefrontStmt,
+		getStockAlertsBySellerIDStmt:                       q.getStockAlertsBySellerIDStmt,
+		getStockSubscriptionsByUserIDStmt:                  q.getStockSubscriptionsByUserIDStmt,
+		getUnprocessedStockAlertsStmt:                      q.getUnprocessedStockAlertsStmt,
+		getWishListAlertUserIDsByProductIDStmt:             q.getWishListAlertUserIDsByProductIDStmt,
+		getWishListByIDStmt:                                q.getWishListByIDStmt,
+		getWishListByShareTokenStmt:                        q.getWishListByShareTokenStmt,
+		getWishListItemByListAndProductIDStmt:              q.getWishListItemByListAndProductIDStmt,
+		getWishListItemsByListIDStmt:                       q.getWishListItemsByListIDStmt,
+		getWishListItemsByUserIDStmt:                       q.getWishListItemsByUserIDStmt,
+		getWishListsByUserIDStmt:                           q.getWishListsByUserIDStmt,
+		incProductStockByIDStmt:                            q.incProductStockByIDStmt,
+		isCategoryDescendantStmt:                           q.isCategoryDescendantStmt,
+		markPriceAlertedStmt:                               q.markPriceAlertedStmt,
+		markReviewFlaggedStmt:                              q.markReviewFlaggedStmt,
+		markStockAlertProcessedStmt:                        q.markStockAlertProcessedStmt,
+		markStockAlertsReadBySellerIDStmt:                  q.markStockAlertsReadBySellerIDStmt,
+		markStockSubscriptionNotifiedStmt:                  q.markStockSubscriptionNotifiedStmt,
+		resolveReviewFlagsStmt:                             q.resolveReviewFlagsStmt,
+		searchProductCategoryFacetsStmt:                    q.searchProductCategoryFacetsStmt,
+		searchProductPriceFacetsStmt:                       q.searchProductPriceFacetsStmt,
+		searchProductsStmt:                                 q.searchProductsStmt,
+		setReviewModerationStatusStmt:                      q.setReviewModerationStatusStmt,
+		setWishListShareTokenStmt:                          q.setWishListShareTokenStmt,
+		shiftProductImagePositionsAfterStmt:                q.shiftProductImagePositionsAfterStmt,
+		updateProductImagePositionStmt:                     q.updateProductImagePositionStmt,
+		updateProductImportJobStatusStmt:                   q.updateProductImportJobStatusStmt,
+		upsertProductAttributeValueStmt:                    q.upsertProductAttributeValueStmt,
+		upsertSellerShippingRuleStmt:                       q.upsertSellerShippingRuleStmt,
+		upsertSellerShippingSettingsStmt:                   q.upsertSellerShippingSettingsStmt,
+		upsertSellerStorefrontStmt:                         q.upsertSellerStorefrontStmt,
 	}
 }
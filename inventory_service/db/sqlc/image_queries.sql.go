@@ -0,0 +1,234 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: image_queries.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addProductImage = `-- name: AddProductImage :one
+insert into product_images
+(product_id, image_url, image_key, thumbnail_url, thumbnail_key, position)
+values
+($1, $2, $3, $4, $5, (select count(*) from product_images where product_id = $1))
+returning id, product_id, image_url, image_key, thumbnail_url, thumbnail_key, position, created_at, updated_at
+`
+
+type AddProductImageParams struct {
+	ProductID    uuid.UUID `json:"product_id"`
+	ImageUrl     string    `json:"image_url"`
+	ImageKey     string    `json:"image_key"`
+	ThumbnailUrl string    `json:"thumbnail_url"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+}
+
+func (q *Queries) AddProductImage(ctx context.Context, arg AddProductImageParams) (ProductImage, error) {
+	row := q.queryRow(ctx, q.addProductImageStmt, addProductImage,
+		arg.ProductID,
+		arg.ImageUrl,
+		arg.ImageKey,
+		arg.ThumbnailUrl,
+		arg.ThumbnailKey,
+	)
+	var i ProductImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.ThumbnailUrl,
+		&i.ThumbnailKey,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteProductImageByID = `-- name: DeleteProductImageByID :one
+delete from product_images
+where id = $1
+returning id, product_id, image_url, image_key, thumbnail_url, thumbnail_key, position, created_at, updated_at
+`
+
+func (q *Queries) DeleteProductImageByID(ctx context.Context, id uuid.UUID) (ProductImage, error) {
+	row := q.queryRow(ctx, q.deleteProductImageByIDStmt, deleteProductImageByID, id)
+	var i ProductImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.ThumbnailUrl,
+		&i.ThumbnailKey,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductImageByID = `-- name: GetProductImageByID :one
+select id, product_id, image_url, image_key, thumbnail_url, thumbnail_key, position, created_at, updated_at from product_images
+where id = $1
+`
+
+func (q *Queries) GetProductImageByID(ctx context.Context, id uuid.UUID) (ProductImage, error) {
+	row := q.queryRow(ctx, q.getProductImageByIDStmt, getProductImageByID, id)
+	var i ProductImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.ThumbnailUrl,
+		&i.ThumbnailKey,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getProductImageCountByProductID = `-- name: GetProductImageCountByProductID :one
+select count(*) from product_images
+where product_id = $1
+`
+
+func (q *Queries) GetProductImageCountByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.queryRow(ctx, q.getProductImageCountByProductIDStmt, getProductImageCountByProductID, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getProductImagesByProductID = `-- name: GetProductImagesByProductID :many
+select id, product_id, image_url, image_key, thumbnail_url, thumbnail_key, position, created_at, updated_at from product_images
+where product_id = $1
+order by position, created_at
+`
+
+func (q *Queries) GetProductImagesByProductID(ctx context.Context, productID uuid.UUID) ([]ProductImage, error) {
+	rows, err := q.query(ctx, q.getProductImagesByProductIDStmt, getProductImagesByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductImage{}
+	for rows.Next() {
+		var i ProductImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ImageUrl,
+			&i.ImageKey,
+			&i.ThumbnailUrl,
+			&i.ThumbnailKey,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductImagesByProductIDs = `-- name: GetProductImagesByProductIDs :many
+select id, product_id, image_url, image_key, thumbnail_url, thumbnail_key, position, created_at, updated_at from product_images
+where product_id = any($1::uuid[])
+order by product_id, position, created_at
+`
+
+func (q *Queries) GetProductImagesByProductIDs(ctx context.Context, productIds []uuid.UUID) ([]ProductImage, error) {
+	rows, err := q.query(ctx, q.getProductImagesByProductIDsStmt, getProductImagesByProductIDs, pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductImage{}
+	for rows.Next() {
+		var i ProductImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ImageUrl,
+			&i.ImageKey,
+			&i.ThumbnailUrl,
+			&i.ThumbnailKey,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const shiftProductImagePositionsAfter = `-- name: ShiftProductImagePositionsAfter :exec
+update product_images
+set position = position - 1, updated_at = current_timestamp
+where product_id = $1 and position > $2
+`
+
+type ShiftProductImagePositionsAfterParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	Position  int32     `json:"position"`
+}
+
+// close the gap left behind by a deleted image
+func (q *Queries) ShiftProductImagePositionsAfter(ctx context.Context, arg ShiftProductImagePositionsAfterParams) error {
+	_, err := q.exec(ctx, q.shiftProductImagePositionsAfterStmt, shiftProductImagePositionsAfter, arg.ProductID, arg.Position)
+	return err
+}
+
+const updateProductImagePosition = `-- name: UpdateProductImagePosition :one
+update product_images
+set position = $1, updated_at = current_timestamp
+where id = $2 and product_id = $3
+returning id, product_id, image_url, image_key, thumbnail_url, thumbnail_key, position, created_at, updated_at
+`
+
+type UpdateProductImagePositionParams struct {
+	Position  int32     `json:"position"`
+	ID        uuid.UUID `json:"id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) UpdateProductImagePosition(ctx context.Context, arg UpdateProductImagePositionParams) (ProductImage, error) {
+	row := q.queryRow(ctx, q.updateProductImagePositionStmt, updateProductImagePosition, arg.Position, arg.ID, arg.ProductID)
+	var i ProductImage
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.ThumbnailUrl,
+		&i.ThumbnailKey,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,279 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: import_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const addProductImportJob = `-- name: AddProductImportJob :one
+insert into product_import_jobs
+(seller_id, file_name, total_rows)
+values
+($1, $2, $3)
+returning id, seller_id, file_name, status, total_rows, created_rows, updated_rows, failed_rows, report, created_at, updated_at, completed_at
+`
+
+type AddProductImportJobParams struct {
+	SellerID  uuid.UUID `json:"seller_id"`
+	FileName  string    `json:"file_name"`
+	TotalRows int32     `json:"total_rows"`
+}
+
+func (q *Queries) AddProductImportJob(ctx context.Context, arg AddProductImportJobParams) (ProductImportJob, error) {
+	row := q.queryRow(ctx, q.addProductImportJobStmt, addProductImportJob, arg.SellerID, arg.FileName, arg.TotalRows)
+	var i ProductImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.FileName,
+		&i.Status,
+		&i.TotalRows,
+		&i.CreatedRows,
+		&i.UpdatedRows,
+		&i.FailedRows,
+		&i.Report,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const completeProductImportJob = `-- name: CompleteProductImportJob :one
+update product_import_jobs
+set status = $1, created_rows = $2, updated_rows = $3, failed_rows = $4,
+report = $5, updated_at = current_timestamp, completed_at = current_timestamp
+where id = $6
+returning id, seller_id, file_name, status, total_rows, created_rows, updated_rows, failed_rows, report, created_at, updated_at, completed_at
+`
+
+type CompleteProductImportJobParams struct {
+	Status      string          `json:"status"`
+	CreatedRows int32           `json:"created_rows"`
+	UpdatedRows int32           `json:"updated_rows"`
+	FailedRows  int32           `json:"failed_rows"`
+	Report      json.RawMessage `json:"report"`
+	ID          uuid.UUID       `json:"id"`
+}
+
+func (q *Queries) CompleteProductImportJob(ctx context.Context, arg CompleteProductImportJobParams) (ProductImportJob, error) {
+	row := q.queryRow(ctx, q.completeProductImportJobStmt, completeProductImportJob,
+		arg.Status,
+		arg.CreatedRows,
+		arg.UpdatedRows,
+		arg.FailedRows,
+		arg.Report,
+		arg.ID,
+	)
+	var i ProductImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.FileName,
+		&i.Status,
+		&i.TotalRows,
+		&i.CreatedRows,
+		&i.UpdatedRows,
+		&i.FailedRows,
+		&i.Report,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const failUnfinishedProductImportJobs = `-- name: FailUnfinishedProductImportJobs :execrows
+update product_import_jobs
+set status = 'failed', report = $1, updated_at = current_timestamp, completed_at = current_timestamp
+where status in ('pending', 'processing')
+`
+
+// the rows of an import only live in the service running it, a job it left
+// unfinished when it stopped can't be picked up again
+func (q *Queries) FailUnfinishedProductImportJobs(ctx context.Context, report json.RawMessage) (int64, error) {
+	result, err := q.exec(ctx, q.failUnfinishedProductImportJobsStmt, failUnfinishedProductImportJobs, report)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getProductImportJobByIDAndSellerID = `-- name: GetProductImportJobByIDAndSellerID :one
+select id, seller_id, file_name, status, total_rows, created_rows, updated_rows, failed_rows, report, created_at, updated_at, completed_at from product_import_jobs
+where id = $1 and seller_id = $2
+`
+
+type GetProductImportJobByIDAndSellerIDParams struct {
+	ID       uuid.UUID `json:"id"`
+	SellerID uuid.UUID `json:"seller_id"`
+}
+
+func (q *Queries) GetProductImportJobByIDAndSellerID(ctx context.Context, arg GetProductImportJobByIDAndSellerIDParams) (ProductImportJob, error) {
+	row := q.queryRow(ctx, q.getProductImportJobByIDAndSellerIDStmt, getProductImportJobByIDAndSellerID, arg.ID, arg.SellerID)
+	var i ProductImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.FileName,
+		&i.Status,
+		&i.TotalRows,
+		&i.CreatedRows,
+		&i.UpdatedRows,
+		&i.FailedRows,
+		&i.Report,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
+
+const getProductImportJobsBySellerID = `-- name: GetProductImportJobsBySellerID :many
+select id, seller_id, file_name, status, total_rows, created_rows, updated_rows, failed_rows, created_at, updated_at, completed_at
+from product_import_jobs
+where seller_id = $1
+order by created_at desc
+`
+
+type GetProductImportJobsBySellerIDRow struct {
+	ID          uuid.UUID    `json:"id"`
+	SellerID    uuid.UUID    `json:"seller_id"`
+	FileName    string       `json:"file_name"`
+	Status      string       `json:"status"`
+	TotalRows   int32        `json:"total_rows"`
+	CreatedRows int32        `json:"created_rows"`
+	UpdatedRows int32        `json:"updated_rows"`
+	FailedRows  int32        `json:"failed_rows"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	CompletedAt sql.NullTime `json:"completed_at"`
+}
+
+func (q *Queries) GetProductImportJobsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]GetProductImportJobsBySellerIDRow, error) {
+	rows, err := q.query(ctx, q.getProductImportJobsBySellerIDStmt, getProductImportJobsBySellerID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductImportJobsBySellerIDRow{}
+	for rows.Next() {
+		var i GetProductImportJobsBySellerIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.FileName,
+			&i.Status,
+			&i.TotalRows,
+			&i.CreatedRows,
+			&i.UpdatedRows,
+			&i.FailedRows,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductsWithCategoriesBySellerID = `-- name: GetProductsWithCategoriesBySellerID :many
+select p.id, p.name, p.description, p.price, p.stock,
+coalesce(string_agg(c.name, ';' order by c.name) filter (where c.id is not null), '')::text as categories
+from products p
+left join category_items ci
+on ci.product_id = p.id
+left join categories c
+on ci.category_id = c.id and c.is_deleted = false
+where p.seller_id = $1 and p.is_deleted = false
+group by p.id
+order by p.created_at
+`
+
+type GetProductsWithCategoriesBySellerIDRow struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Price       float64   `json:"price"`
+	Stock       int32     `json:"stock"`
+	Categories  string    `json:"categories"`
+}
+
+func (q *Queries) GetProductsWithCategoriesBySellerID(ctx context.Context, sellerID uuid.UUID) ([]GetProductsWithCategoriesBySellerIDRow, error) {
+	rows, err := q.query(ctx, q.getProductsWithCategoriesBySellerIDStmt, getProductsWithCategoriesBySellerID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductsWithCategoriesBySellerIDRow{}
+	for rows.Next() {
+		var i GetProductsWithCategoriesBySellerIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Stock,
+			&i.Categories,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateProductImportJobStatus = `-- name: UpdateProductImportJobStatus :one
+update product_import_jobs
+set status = $1, updated_at = current_timestamp
+where id = $2
+returning id, seller_id, file_name, status, total_rows, created_rows, updated_rows, failed_rows, report, created_at, updated_at, completed_at
+`
+
+type UpdateProductImportJobStatusParams struct {
+	Status string    `json:"status"`
+	ID     uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateProductImportJobStatus(ctx context.Context, arg UpdateProductImportJobStatusParams) (ProductImportJob, error) {
+	row := q.queryRow(ctx, q.updateProductImportJobStatusStmt, updateProductImportJobStatus, arg.Status, arg.ID)
+	var i ProductImportJob
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.FileName,
+		&i.Status,
+		&i.TotalRows,
+		&i.CreatedRows,
+		&i.UpdatedRows,
+		&i.FailedRows,
+		&i.Report,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CompletedAt,
+	)
+	return i, err
+}
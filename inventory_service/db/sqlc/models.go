@@ -6,17 +6,34 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
 )
 
 type Category struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	IsDeleted bool      `json:"is_deleted"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uuid.UUID     `json:"id"`
+	Name      string        `json:"name"`
+	Slug      string        `json:"slug"`
+	ParentID  uuid.NullUUID `json:"parent_id"`
+	IsDeleted bool          `json:"is_deleted"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type CategoryAttribute struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"category_id"`
+	Name       string    `json:"name"`
+	Label      string    `json:"label"`
+	DataType   string    `json:"data_type"`
+	Options    []string  `json:"options"`
+	IsRequired bool      `json:"is_required"`
+	IsDeleted  bool      `json:"is_deleted"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 type CategoryItem struct {
@@ -27,41 +44,180 @@ type CategoryItem struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type InventoryAuditLog struct {
+	ID             uuid.UUID             `json:"id"`
+	ActorID        uuid.NullUUID         `json:"actor_id"`
+	ActorRole      string                `json:"actor_role"`
+	ImpersonatorID uuid.NullUUID         `json:"impersonator_id"`
+	Action         string                `json:"action"`
+	EntityType     string                `json:"entity_type"`
+	EntityID       string                `json:"entity_id"`
+	Before         pqtype.NullRawMessage `json:"before"`
+	After          pqtype.NullRawMessage `json:"after"`
+	IpAddress      string                `json:"ip_address"`
+	UserAgent      string                `json:"user_agent"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
 type Product struct {
+	ID                uuid.UUID       `json:"id"`
+	Name              string          `json:"name"`
+	Description       string          `json:"description"`
+	Price             float64         `json:"price"`
+	Mrp               sql.NullFloat64 `json:"mrp"`
+	Stock             int32           `json:"stock"`
+	SoldCount         int32           `json:"sold_count"`
+	LowStockThreshold int32           `json:"low_stock_threshold"`
+	WeightGrams       int32           `json:"weight_grams"`
+	SellerID          uuid.UUID       `json:"seller_id"`
+	IsDeleted         bool            `json:"is_deleted"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+type ProductAttributeValue struct {
 	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	Stock       int32     `json:"stock"`
-	SellerID    uuid.UUID `json:"seller_id"`
-	IsDeleted   bool      `json:"is_deleted"`
+	ProductID   uuid.UUID `json:"product_id"`
+	AttributeID uuid.UUID `json:"attribute_id"`
+	Value       string    `json:"value"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 type ProductImage struct {
-	ID        uuid.UUID `json:"id"`
-	ProductID uuid.UUID `json:"product_id"`
-	ImageUrl  string    `json:"image_url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           uuid.UUID `json:"id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	ImageUrl     string    `json:"image_url"`
+	ImageKey     string    `json:"image_key"`
+	ThumbnailUrl string    `json:"thumbnail_url"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+	Position     int32     `json:"position"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type ProductImportJob struct {
+	ID          uuid.UUID       `json:"id"`
+	SellerID    uuid.UUID       `json:"seller_id"`
+	FileName    string          `json:"file_name"`
+	Status      string          `json:"status"`
+	TotalRows   int32           `json:"total_rows"`
+	CreatedRows int32           `json:"created_rows"`
+	UpdatedRows int32           `json:"updated_rows"`
+	FailedRows  int32           `json:"failed_rows"`
+	Report      json.RawMessage `json:"report"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	CompletedAt sql.NullTime    `json:"completed_at"`
+}
+
+type ProductPrice struct {
+	ID          uuid.UUID    `json:"id"`
+	ProductID   uuid.UUID    `json:"product_id"`
+	Kind        string       `json:"kind"`
+	Price       float64      `json:"price"`
+	StartsAt    time.Time    `json:"starts_at"`
+	EndsAt      sql.NullTime `json:"ends_at"`
+	IsCancelled bool         `json:"is_cancelled"`
+	AlertedAt   sql.NullTime `json:"alerted_at"`
+	CreatedAt   time.Time    `json:"created_at"`
 }
 
 type Review struct {
-	ID        uuid.UUID      `json:"id"`
-	UserID    uuid.UUID      `json:"user_id"`
-	ProductID uuid.UUID      `json:"product_id"`
-	Rating    int32          `json:"rating"`
-	Comment   sql.NullString `json:"comment"`
-	IsDeleted bool           `json:"is_deleted"`
-	IsEdited  bool           `json:"is_edited"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	ID               uuid.UUID      `json:"id"`
+	UserID           uuid.UUID      `json:"user_id"`
+	ProductID        uuid.UUID      `json:"product_id"`
+	Rating           int32          `json:"rating"`
+	Comment          sql.NullString `json:"comment"`
+	IsDeleted        bool           `json:"is_deleted"`
+	IsEdited         bool           `json:"is_edited"`
+	IsVerified       bool           `json:"is_verified"`
+	ModerationStatus string         `json:"moderation_status"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+type ReviewFlag struct {
+	ID         uuid.UUID `json:"id"`
+	ReviewID   uuid.UUID `json:"review_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Reason     string    `json:"reason"`
+	IsResolved bool      `json:"is_resolved"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ReviewImage struct {
+	ID           uuid.UUID `json:"id"`
+	ReviewID     uuid.UUID `json:"review_id"`
+	ImageUrl     string    `json:"image_url"`
+	ImageKey     string    `json:"image_key"`
+	ThumbnailUrl string    `json:"thumbnail_url"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type SellerServiceArea struct {
+	ID          uuid.UUID `json:"id"`
+	SellerID    uuid.UUID `json:"seller_id"`
+	PincodeFrom int32     `json:"pincode_from"`
+	PincodeTo   int32     `json:"pincode_to"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type SellerShippingSetting struct {
+	SellerID          uuid.UUID       `json:"seller_id"`
+	HandlingDays      int32           `json:"handling_days"`
+	ShippingRule      string          `json:"shipping_rule"`
+	FlatFee           float64         `json:"flat_fee"`
+	FreeShippingAbove sql.NullFloat64 `json:"free_shipping_above"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+type SellerStorefront struct {
+	SellerID         uuid.UUID `json:"seller_id"`
+	ReturnWindowDays int32     `json:"return_window_days"`
+	ReturnPolicy     string    `json:"return_policy"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type StockAlert struct {
+	ID          uuid.UUID    `json:"id"`
+	ProductID   uuid.UUID    `json:"product_id"`
+	SellerID    uuid.UUID    `json:"seller_id"`
+	Kind        string       `json:"kind"`
+	Stock       int32        `json:"stock"`
+	IsRead      bool         `json:"is_read"`
+	ProcessedAt sql.NullTime `json:"processed_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+type StockSubscription struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	ProductID  uuid.UUID    `json:"product_id"`
+	Email      string       `json:"email"`
+	NotifiedAt sql.NullTime `json:"notified_at"`
+	CreatedAt  time.Time    `json:"created_at"`
 }
 
 type Wishlist struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	ListID     uuid.UUID `json:"list_id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	PriceAtAdd float64   `json:"price_at_add"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WishlistList struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	Name          string         `json:"name"`
+	IsDefault     bool           `json:"is_default"`
+	AlertsEnabled bool           `json:"alerts_enabled"`
+	ShareToken    sql.NullString `json:"share_token"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 }
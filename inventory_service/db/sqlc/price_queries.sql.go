@@ -0,0 +1,355 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: price_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addProductSale = `-- name: AddProductSale :one
+insert into product_prices
+(product_id, kind, price, starts_at, ends_at)
+values
+($1, 'sale', $2, $3, $4)
+returning id, product_id, kind, price, starts_at, ends_at, is_cancelled, alerted_at, created_at
+`
+
+type AddProductSaleParams struct {
+	ProductID uuid.UUID    `json:"product_id"`
+	Price     float64      `json:"price"`
+	StartsAt  time.Time    `json:"starts_at"`
+	EndsAt    sql.NullTime `json:"ends_at"`
+}
+
+func (q *Queries) AddProductSale(ctx context.Context, arg AddProductSaleParams) (ProductPrice, error) {
+	row := q.queryRow(ctx, q.addProductSaleStmt, addProductSale,
+		arg.ProductID,
+		arg.Price,
+		arg.StartsAt,
+		arg.EndsAt,
+	)
+	var i ProductPrice
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Kind,
+		&i.Price,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.IsCancelled,
+		&i.AlertedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const cancelProductSaleByID = `-- name: CancelProductSaleByID :one
+update product_prices
+set is_cancelled = (starts_at > current_timestamp),
+ends_at = (case when starts_at > current_timestamp then ends_at else current_timestamp end)
+where id = $1 and kind = 'sale' and is_cancelled = false and ends_at > current_timestamp
+returning id, product_id, kind, price, starts_at, ends_at, is_cancelled, alerted_at, created_at
+`
+
+// a sale that has not started is cancelled, a running one is ended right away
+func (q *Queries) CancelProductSaleByID(ctx context.Context, id uuid.UUID) (ProductPrice, error) {
+	row := q.queryRow(ctx, q.cancelProductSaleByIDStmt, cancelProductSaleByID, id)
+	var i ProductPrice
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Kind,
+		&i.Price,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.IsCancelled,
+		&i.AlertedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const editProductMRPByID = `-- name: EditProductMRPByID :one
+update products
+set mrp = $2, updated_at = current_timestamp
+where id = $1 and is_deleted = false
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
+`
+
+type EditProductMRPByIDParams struct {
+	ID  uuid.UUID       `json:"id"`
+	Mrp sql.NullFloat64 `json:"mrp"`
+}
+
+func (q *Queries) EditProductMRPByID(ctx context.Context, arg EditProductMRPByIDParams) (Product, error) {
+	row := q.queryRow(ctx, q.editProductMRPByIDStmt, editProductMRPByID, arg.ID, arg.Mrp)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Mrp,
+		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
+		&i.SellerID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getDuePriceAlerts = `-- name: GetDuePriceAlerts :many
+select pp.id, pp.product_id, p.name as product_name,
+coalesce(product_price_at(pp.product_id, pp.starts_at - interval '1 microsecond'), p.price)::float8 as old_price,
+coalesce(product_price_at(pp.product_id, pp.starts_at), p.price)::float8 as new_price
+from product_prices pp
+inner join products p
+on pp.product_id = p.id
+where pp.alerted_at is null and pp.is_cancelled = false and pp.starts_at <= current_timestamp and p.is_deleted = false
+order by pp.starts_at
+limit 100
+`
+
+type GetDuePriceAlertsRow struct {
+	ID          uuid.UUID `json:"id"`
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	OldPrice    float64   `json:"old_price"`
+	NewPrice    float64   `json:"new_price"`
+}
+
+// rows whose price took effect and haven't been alerted for, with the effective
+// price just before and at the moment they took effect
+func (q *Queries) GetDuePriceAlerts(ctx context.Context) ([]GetDuePriceAlertsRow, error) {
+	rows, err := q.query(ctx, q.getDuePriceAlertsStmt, getDuePriceAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetDuePriceAlertsRow{}
+	for rows.Next() {
+		var i GetDuePriceAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.OldPrice,
+			&i.NewPrice,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverlappingSaleCount = `-- name: GetOverlappingSaleCount :one
+select count(*) from product_prices
+where product_id = $1 and kind = 'sale' and is_cancelled = false
+and starts_at < $2::timestamptz and ends_at > $3::timestamptz
+`
+
+type GetOverlappingSaleCountParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	EndsAt    time.Time `json:"ends_at"`
+	StartsAt  time.Time `json:"starts_at"`
+}
+
+func (q *Queries) GetOverlappingSaleCount(ctx context.Context, arg GetOverlappingSaleCountParams) (int64, error) {
+	row := q.queryRow(ctx, q.getOverlappingSaleCountStmt, getOverlappingSaleCount, arg.ProductID, arg.EndsAt, arg.StartsAt)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getProductPriceAt = `-- name: GetProductPriceAt :one
+select coalesce(product_price_at(p.id, $1::timestamptz), p.price)::float8 as price
+from products p
+where p.id = $2
+`
+
+type GetProductPriceAtParams struct {
+	At        time.Time `json:"at"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) GetProductPriceAt(ctx context.Context, arg GetProductPriceAtParams) (float64, error) {
+	row := q.queryRow(ctx, q.getProductPriceAtStmt, getProductPriceAt, arg.At, arg.ProductID)
+	var price float64
+	err := row.Scan(&price)
+	return price, err
+}
+
+const getProductPricesByProductID = `-- name: GetProductPricesByProductID :many
+select id, product_id, kind, price, starts_at, ends_at, is_cancelled, alerted_at, created_at from product_prices
+where product_id = $1
+order by starts_at desc
+limit 100
+`
+
+func (q *Queries) GetProductPricesByProductID(ctx context.Context, productID uuid.UUID) ([]ProductPrice, error) {
+	rows, err := q.query(ctx, q.getProductPricesByProductIDStmt, getProductPricesByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProductPrice{}
+	for rows.Next() {
+		var i ProductPrice
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.Kind,
+			&i.Price,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.IsCancelled,
+			&i.AlertedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductPricing = `-- name: GetProductPricing :one
+
+select p.price as regular_price, p.mrp,
+coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as effective_price,
+(select pp.ends_at from product_prices pp
+ where pp.product_id = p.id and pp.kind = 'sale' and pp.is_cancelled = false
+ and pp.starts_at <= current_timestamp and pp.ends_at > current_timestamp
+ order by pp.ends_at limit 1) as sale_ends_at
+from products p
+where p.id = $1
+`
+
+type GetProductPricingRow struct {
+	RegularPrice   float64         `json:"regular_price"`
+	Mrp            sql.NullFloat64 `json:"mrp"`
+	EffectivePrice float64         `json:"effective_price"`
+	SaleEndsAt     sql.NullTime    `json:"sale_ends_at"`
+}
+
+// effective price of a product is coalesce(product_price_at(p.id, <moment>), p.price),
+// see the product_price_at function in the schema
+// end of the running sale, null when there is none
+func (q *Queries) GetProductPricing(ctx context.Context, id uuid.UUID) (GetProductPricingRow, error) {
+	row := q.queryRow(ctx, q.getProductPricingStmt, getProductPricing, id)
+	var i GetProductPricingRow
+	err := row.Scan(
+		&i.RegularPrice,
+		&i.Mrp,
+		&i.EffectivePrice,
+		&i.SaleEndsAt,
+	)
+	return i, err
+}
+
+const getProductPricingByIDs = `-- name: GetProductPricingByIDs :many
+select p.id, p.price as regular_price, p.mrp,
+coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as effective_price,
+(select pp.ends_at from product_prices pp
+ where pp.product_id = p.id and pp.kind = 'sale' and pp.is_cancelled = false
+ and pp.starts_at <= current_timestamp and pp.ends_at > current_timestamp
+ order by pp.ends_at limit 1) as sale_ends_at
+from products p
+where p.id = any($1::uuid[])
+`
+
+type GetProductPricingByIDsRow struct {
+	ID             uuid.UUID       `json:"id"`
+	RegularPrice   float64         `json:"regular_price"`
+	Mrp            sql.NullFloat64 `json:"mrp"`
+	EffectivePrice float64         `json:"effective_price"`
+	SaleEndsAt     sql.NullTime    `json:"sale_ends_at"`
+}
+
+// end of the running sale, null when there is none
+func (q *Queries) GetProductPricingByIDs(ctx context.Context, productIds []uuid.UUID) ([]GetProductPricingByIDsRow, error) {
+	rows, err := q.query(ctx, q.getProductPricingByIDsStmt, getProductPricingByIDs, pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductPricingByIDsRow{}
+	for rows.Next() {
+		var i GetProductPricingByIDsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RegularPrice,
+			&i.Mrp,
+			&i.EffectivePrice,
+			&i.SaleEndsAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getProductSaleByID = `-- name: GetProductSaleByID :one
+select id, product_id, kind, price, starts_at, ends_at, is_cancelled, alerted_at, created_at from product_prices
+where id = $1 and kind = 'sale'
+`
+
+func (q *Queries) GetProductSaleByID(ctx context.Context, id uuid.UUID) (ProductPrice, error) {
+	row := q.queryRow(ctx, q.getProductSaleByIDStmt, getProductSaleByID, id)
+	var i ProductPrice
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.Kind,
+		&i.Price,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.IsCancelled,
+		&i.AlertedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markPriceAlerted = `-- name: MarkPriceAlerted :exec
+update product_prices
+set alerted_at = current_timestamp
+where id = $1
+`
+
+func (q *Queries) MarkPriceAlerted(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.markPriceAlertedStmt, markPriceAlerted, id)
+	return err
+}
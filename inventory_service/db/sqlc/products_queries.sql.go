@@ -17,7 +17,7 @@ const addProduct = `-- name: AddProduct :one
 insert into products
 (name, description, price, stock, seller_id)
 values ($1, $2, $3, $4, $5)
-returning id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
 `
 
 type AddProductParams struct {
@@ -42,7 +42,11 @@ func (q *Queries) AddProduct(ctx context.Context, arg AddProductParams) (Product
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -53,17 +57,18 @@ func (q *Queries) AddProduct(ctx context.Context, arg AddProductParams) (Product
 
 const addProductReviewWithCommment = `-- name: AddProductReviewWithCommment :one
 insert into reviews
-(user_id, product_id, rating, comment)
+(user_id, product_id, rating, comment, is_verified)
 values
-($1, $2, $3, $4)
-returning id, user_id, product_id, rating, comment, is_deleted, is_edited, created_at, updated_at
+($1, $2, $3, $4, $5)
+returning id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at
 `
 
 type AddProductReviewWithCommmentParams struct {
-	UserID    uuid.UUID      `json:"user_id"`
-	ProductID uuid.UUID      `json:"product_id"`
-	Rating    int32          `json:"rating"`
-	Comment   sql.NullString `json:"comment"`
+	UserID     uuid.UUID      `json:"user_id"`
+	ProductID  uuid.UUID      `json:"product_id"`
+	Rating     int32          `json:"rating"`
+	Comment    sql.NullString `json:"comment"`
+	IsVerified bool           `json:"is_verified"`
 }
 
 func (q *Queries) AddProductReviewWithCommment(ctx context.Context, arg AddProductReviewWithCommmentParams) (Review, error) {
@@ -72,6 +77,7 @@ func (q *Queries) AddProductReviewWithCommment(ctx context.Context, arg AddProdu
 		arg.ProductID,
 		arg.Rating,
 		arg.Comment,
+		arg.IsVerified,
 	)
 	var i Review
 	err := row.Scan(
@@ -82,6 +88,8 @@ func (q *Queries) AddProductReviewWithCommment(ctx context.Context, arg AddProdu
 		&i.Comment,
 		&i.IsDeleted,
 		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -90,20 +98,26 @@ func (q *Queries) AddProductReviewWithCommment(ctx context.Context, arg AddProdu
 
 const addProductReviewWithoutComment = `-- name: AddProductReviewWithoutComment :one
 insert into reviews
-(user_id, product_id, rating)
+(user_id, product_id, rating, is_verified)
 values
-($1, $2, $3)
-returning id, user_id, product_id, rating, comment, is_deleted, is_edited, created_at, updated_at
+($1, $2, $3, $4)
+returning id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at
 `
 
 type AddProductReviewWithoutCommentParams struct {
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Rating    int32     `json:"rating"`
+	UserID     uuid.UUID `json:"user_id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	Rating     int32     `json:"rating"`
+	IsVerified bool      `json:"is_verified"`
 }
 
 func (q *Queries) AddProductReviewWithoutComment(ctx context.Context, arg AddProductReviewWithoutCommentParams) (Review, error) {
-	row := q.queryRow(ctx, q.addProductReviewWithoutCommentStmt, addProductReviewWithoutComment, arg.UserID, arg.ProductID, arg.Rating)
+	row := q.queryRow(ctx, q.addProductReviewWithoutCommentStmt, addProductReviewWithoutComment,
+		arg.UserID,
+		arg.ProductID,
+		arg.Rating,
+		arg.IsVerified,
+	)
 	var i Review
 	err := row.Scan(
 		&i.ID,
@@ -113,6 +127,8 @@ func (q *Queries) AddProductReviewWithoutComment(ctx context.Context, arg AddPro
 		&i.Comment,
 		&i.IsDeleted,
 		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -173,9 +189,9 @@ func (q *Queries) AddProductToCategoryByID(ctx context.Context, arg AddProductTo
 
 const decProductStockByID = `-- name: DecProductStockByID :one
 update products
-set stock = stock - $1, updated_at = current_timestamp
+set stock = stock - $1, sold_count = sold_count + $1, updated_at = current_timestamp
 where id = $2 and stock >= $1
-returning id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
 `
 
 type DecProductStockByIDParams struct {
@@ -191,7 +207,11 @@ func (q *Queries) DecProductStockByID(ctx context.Context, arg DecProductStockBy
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -204,7 +224,7 @@ const deleteProductByID = `-- name: DeleteProductByID :one
 update products
 set is_deleted = true, updated_at = current_timestamp
 where id = $1 and is_deleted = false
-returning id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
 `
 
 func (q *Queries) DeleteProductByID(ctx context.Context, id uuid.UUID) (Product, error) {
@@ -215,7 +235,11 @@ func (q *Queries) DeleteProductByID(ctx context.Context, id uuid.UUID) (Product,
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -224,11 +248,42 @@ func (q *Queries) DeleteProductByID(ctx context.Context, id uuid.UUID) (Product,
 	return i, err
 }
 
+const deleteProductReviewByUserAndProductID = `-- name: DeleteProductReviewByUserAndProductID :one
+update reviews
+set is_deleted = true, updated_at = current_timestamp
+where user_id = $1 and product_id = $2 and is_deleted = false
+returning id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at
+`
+
+type DeleteProductReviewByUserAndProductIDParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) DeleteProductReviewByUserAndProductID(ctx context.Context, arg DeleteProductReviewByUserAndProductIDParams) (Review, error) {
+	row := q.queryRow(ctx, q.deleteProductReviewByUserAndProductIDStmt, deleteProductReviewByUserAndProductID, arg.UserID, arg.ProductID)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Rating,
+		&i.Comment,
+		&i.IsDeleted,
+		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const deleteProductsBySellerID = `-- name: DeleteProductsBySellerID :many
 update products
 set is_deleted = true, updated_at = current_timestamp
 where seller_id = $1
-returning id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
 `
 
 func (q *Queries) DeleteProductsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]Product, error) {
@@ -245,7 +300,11 @@ func (q *Queries) DeleteProductsBySellerID(ctx context.Context, sellerID uuid.UU
 			&i.Name,
 			&i.Description,
 			&i.Price,
+			&i.Mrp,
 			&i.Stock,
+			&i.SoldCount,
+			&i.LowStockThreshold,
+			&i.WeightGrams,
 			&i.SellerID,
 			&i.IsDeleted,
 			&i.CreatedAt,
@@ -268,7 +327,7 @@ const editProductByID = `-- name: EditProductByID :one
 update products
 set name = $2, description = $3, price = $4, stock = $5, updated_at = current_timestamp
 where id = $1 and is_deleted = false
-returning id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
 `
 
 type EditProductByIDParams struct {
@@ -293,7 +352,11 @@ func (q *Queries) EditProductByID(ctx context.Context, arg EditProductByIDParams
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -302,8 +365,46 @@ func (q *Queries) EditProductByID(ctx context.Context, arg EditProductByIDParams
 	return i, err
 }
 
+const editProductReviewByUserAndProductID = `-- name: EditProductReviewByUserAndProductID :one
+update reviews
+set rating = $1, comment = $2, is_edited = true, updated_at = current_timestamp
+where user_id = $3 and product_id = $4 and is_deleted = false
+returning id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at
+`
+
+type EditProductReviewByUserAndProductIDParams struct {
+	Rating    int32          `json:"rating"`
+	Comment   sql.NullString `json:"comment"`
+	UserID    uuid.UUID      `json:"user_id"`
+	ProductID uuid.UUID      `json:"product_id"`
+}
+
+func (q *Queries) EditProductReviewByUserAndProductID(ctx context.Context, arg EditProductReviewByUserAndProductIDParams) (Review, error) {
+	row := q.queryRow(ctx, q.editProductReviewByUserAndProductIDStmt, editProductReviewByUserAndProductID,
+		arg.Rating,
+		arg.Comment,
+		arg.UserID,
+		arg.ProductID,
+	)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Rating,
+		&i.Comment,
+		&i.IsDeleted,
+		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getAllProducts = `-- name: GetAllProducts :many
-select id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at from products
+select id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at from products
 where is_deleted = false
 `
 
@@ -321,7 +422,11 @@ func (q *Queries) GetAllProducts(ctx context.Context) ([]Product, error) {
 			&i.Name,
 			&i.Description,
 			&i.Price,
+			&i.Mrp,
 			&i.Stock,
+			&i.SoldCount,
+			&i.LowStockThreshold,
+			&i.WeightGrams,
 			&i.SellerID,
 			&i.IsDeleted,
 			&i.CreatedAt,
@@ -341,7 +446,7 @@ func (q *Queries) GetAllProducts(ctx context.Context) ([]Product, error) {
 }
 
 const getAllProductsForAdmin = `-- name: GetAllProductsForAdmin :many
-select id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at from products
+select id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at from products
 `
 
 func (q *Queries) GetAllProductsForAdmin(ctx context.Context) ([]Product, error) {
@@ -358,7 +463,11 @@ func (q *Queries) GetAllProductsForAdmin(ctx context.Context) ([]Product, error)
 			&i.Name,
 			&i.Description,
 			&i.Price,
+			&i.Mrp,
 			&i.Stock,
+			&i.SoldCount,
+			&i.LowStockThreshold,
+			&i.WeightGrams,
 			&i.SellerID,
 			&i.IsDeleted,
 			&i.CreatedAt,
@@ -378,7 +487,7 @@ func (q *Queries) GetAllProductsForAdmin(ctx context.Context) ([]Product, error)
 }
 
 const getProductAndCategoryNameByID = `-- name: GetProductAndCategoryNameByID :one
-select p.id, p.name, p.description, p.price, p.stock, p.seller_id, p.is_deleted, p.created_at, p.updated_at, c.name as category_name
+select p.id, p.name, p.description, p.price, p.mrp, p.stock, p.sold_count, p.low_stock_threshold, p.weight_grams, p.seller_id, p.is_deleted, p.created_at, p.updated_at, c.name as category_name
 from category_items ci
 inner join products p
 on ci.product_id = p.id
@@ -388,16 +497,20 @@ where p.id = $1
 `
 
 type GetProductAndCategoryNameByIDRow struct {
-	ID           uuid.UUID `json:"id"`
-	Name         string    `json:"name"`
-	Description  string    `json:"description"`
-	Price        float64   `json:"price"`
-	Stock        int32     `json:"stock"`
-	SellerID     uuid.UUID `json:"seller_id"`
-	IsDeleted    bool      `json:"is_deleted"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-	CategoryName string    `json:"category_name"`
+	ID                uuid.UUID       `json:"id"`
+	Name              string          `json:"name"`
+	Description       string          `json:"description"`
+	Price             float64         `json:"price"`
+	Mrp               sql.NullFloat64 `json:"mrp"`
+	Stock             int32           `json:"stock"`
+	SoldCount         int32           `json:"sold_count"`
+	LowStockThreshold int32           `json:"low_stock_threshold"`
+	WeightGrams       int32           `json:"weight_grams"`
+	SellerID          uuid.UUID       `json:"seller_id"`
+	IsDeleted         bool            `json:"is_deleted"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	CategoryName      string          `json:"category_name"`
 }
 
 func (q *Queries) GetProductAndCategoryNameByID(ctx context.Context, id uuid.UUID) (GetProductAndCategoryNameByIDRow, error) {
@@ -408,7 +521,11 @@ func (q *Queries) GetProductAndCategoryNameByID(ctx context.Context, id uuid.UUI
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -421,7 +538,7 @@ func (q *Queries) GetProductAndCategoryNameByID(ctx context.Context, id uuid.UUI
 const getProductAverageRatingAndTotalRating = `-- name: GetProductAverageRatingAndTotalRating :one
 select avg(rating) as average_rating, count(*) as total_rating
 from reviews 
-where product_id = $1
+where product_id = $1 and is_deleted = false and moderation_status <> 'hidden'
 `
 
 type GetProductAverageRatingAndTotalRatingRow struct {
@@ -437,7 +554,7 @@ func (q *Queries) GetProductAverageRatingAndTotalRating(ctx context.Context, pro
 }
 
 const getProductByID = `-- name: GetProductByID :one
-select id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at from products
+select id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at from products
 where id = $1 and is_deleted = false
 `
 
@@ -449,7 +566,11 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (Product, er
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -458,11 +579,49 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (Product, er
 	return i, err
 }
 
+const getProductRatingHistogram = `-- name: GetProductRatingHistogram :many
+select rating, count(*) as count
+from reviews
+where product_id = $1 and is_deleted = false and moderation_status <> 'hidden'
+group by rating
+order by rating desc
+`
+
+type GetProductRatingHistogramRow struct {
+	Rating int32 `json:"rating"`
+	Count  int64 `json:"count"`
+}
+
+func (q *Queries) GetProductRatingHistogram(ctx context.Context, productID uuid.UUID) ([]GetProductRatingHistogramRow, error) {
+	rows, err := q.query(ctx, q.getProductRatingHistogramStmt, getProductRatingHistogram, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductRatingHistogramRow{}
+	for rows.Next() {
+		var i GetProductRatingHistogramRow
+		if err := rows.Scan(&i.Rating, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getProductReviews = `-- name: GetProductReviews :many
-select id, user_id, product_id, rating, comment, is_deleted, is_edited, created_at, updated_at from reviews
-where product_id = $1
+select id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at from reviews
+where product_id = $1 and is_deleted = false and moderation_status <> 'hidden'
+order by is_verified desc, created_at desc
 `
 
+// hidden reviews stay with their author but are not shown on the product
 func (q *Queries) GetProductReviews(ctx context.Context, productID uuid.UUID) ([]Review, error) {
 	rows, err := q.query(ctx, q.getProductReviewsStmt, getProductReviews, productID)
 	if err != nil {
@@ -480,6 +639,8 @@ func (q *Queries) GetProductReviews(ctx context.Context, productID uuid.UUID) ([
 			&i.Comment,
 			&i.IsDeleted,
 			&i.IsEdited,
+			&i.IsVerified,
+			&i.ModerationStatus,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -497,7 +658,7 @@ func (q *Queries) GetProductReviews(ctx context.Context, productID uuid.UUID) ([
 }
 
 const getProductsBySellerID = `-- name: GetProductsBySellerID :many
-select id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at from products
+select id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at from products
 where seller_id = $1 and is_deleted = false
 `
 
@@ -515,7 +676,11 @@ func (q *Queries) GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID)
 			&i.Name,
 			&i.Description,
 			&i.Price,
+			&i.Mrp,
 			&i.Stock,
+			&i.SoldCount,
+			&i.LowStockThreshold,
+			&i.WeightGrams,
 			&i.SellerID,
 			&i.IsDeleted,
 			&i.CreatedAt,
@@ -534,9 +699,33 @@ func (q *Queries) GetProductsBySellerID(ctx context.Context, sellerID uuid.UUID)
 	return items, nil
 }
 
+const getReviewByID = `-- name: GetReviewByID :one
+select id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at from reviews
+where id = $1 and is_deleted = false
+`
+
+func (q *Queries) GetReviewByID(ctx context.Context, id uuid.UUID) (Review, error) {
+	row := q.queryRow(ctx, q.getReviewByIDStmt, getReviewByID, id)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Rating,
+		&i.Comment,
+		&i.IsDeleted,
+		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getReviewByUserAndProductID = `-- name: GetReviewByUserAndProductID :one
-select id, user_id, product_id, rating, comment, is_deleted, is_edited, created_at, updated_at from reviews
-where user_id = $1 and product_id = $2
+select id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at from reviews
+where user_id = $1 and product_id = $2 and is_deleted = false
 `
 
 type GetReviewByUserAndProductIDParams struct {
@@ -555,6 +744,8 @@ func (q *Queries) GetReviewByUserAndProductID(ctx context.Context, arg GetReview
 		&i.Comment,
 		&i.IsDeleted,
 		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -563,9 +754,9 @@ func (q *Queries) GetReviewByUserAndProductID(ctx context.Context, arg GetReview
 
 const incProductStockByID = `-- name: IncProductStockByID :one
 update products
-set stock = stock + $1, updated_at = current_timestamp
+set stock = stock + $1, sold_count = greatest(sold_count - $1, 0), updated_at = current_timestamp
 where id = $2
-returning id, name, description, price, stock, seller_id, is_deleted, created_at, updated_at
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
 `
 
 type IncProductStockByIDParams struct {
@@ -581,7 +772,11 @@ func (q *Queries) IncProductStockByID(ctx context.Context, arg IncProductStockBy
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
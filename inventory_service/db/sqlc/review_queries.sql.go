@@ -0,0 +1,250 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: review_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const addReviewFlag = `-- name: AddReviewFlag :one
+insert into review_flags
+(review_id, user_id, reason)
+values
+($1, $2, $3)
+on conflict (review_id, user_id) do nothing
+returning id, review_id, user_id, reason, is_resolved, created_at
+`
+
+type AddReviewFlagParams struct {
+	ReviewID uuid.UUID `json:"review_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Reason   string    `json:"reason"`
+}
+
+func (q *Queries) AddReviewFlag(ctx context.Context, arg AddReviewFlagParams) (ReviewFlag, error) {
+	row := q.queryRow(ctx, q.addReviewFlagStmt, addReviewFlag, arg.ReviewID, arg.UserID, arg.Reason)
+	var i ReviewFlag
+	err := row.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.UserID,
+		&i.Reason,
+		&i.IsResolved,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const addReviewImage = `-- name: AddReviewImage :one
+insert into review_images
+(review_id, image_url, image_key, thumbnail_url, thumbnail_key)
+values
+($1, $2, $3, $4, $5)
+returning id, review_id, image_url, image_key, thumbnail_url, thumbnail_key, created_at
+`
+
+type AddReviewImageParams struct {
+	ReviewID     uuid.UUID `json:"review_id"`
+	ImageUrl     string    `json:"image_url"`
+	ImageKey     string    `json:"image_key"`
+	ThumbnailUrl string    `json:"thumbnail_url"`
+	ThumbnailKey string    `json:"thumbnail_key"`
+}
+
+func (q *Queries) AddReviewImage(ctx context.Context, arg AddReviewImageParams) (ReviewImage, error) {
+	row := q.queryRow(ctx, q.addReviewImageStmt, addReviewImage,
+		arg.ReviewID,
+		arg.ImageUrl,
+		arg.ImageKey,
+		arg.ThumbnailUrl,
+		arg.ThumbnailKey,
+	)
+	var i ReviewImage
+	err := row.Scan(
+		&i.ID,
+		&i.ReviewID,
+		&i.ImageUrl,
+		&i.ImageKey,
+		&i.ThumbnailUrl,
+		&i.ThumbnailKey,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getFlaggedReviews = `-- name: GetFlaggedReviews :many
+select r.id, r.user_id, r.product_id, p.name as product_name, r.rating, r.comment, r.is_verified, r.created_at,
+count(f.id) as flag_count, array_agg(f.reason order by f.created_at)::text[] as reasons
+from reviews r
+inner join products p
+on r.product_id = p.id
+inner join review_flags f
+on f.review_id = r.id and f.is_resolved = false
+where r.moderation_status = 'flagged' and r.is_deleted = false
+group by r.id, p.name
+order by flag_count desc, r.created_at
+limit $2 offset $1
+`
+
+type GetFlaggedReviewsParams struct {
+	OffsetCount int32 `json:"offset_count"`
+	LimitCount  int32 `json:"limit_count"`
+}
+
+type GetFlaggedReviewsRow struct {
+	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	ProductID   uuid.UUID      `json:"product_id"`
+	ProductName string         `json:"product_name"`
+	Rating      int32          `json:"rating"`
+	Comment     sql.NullString `json:"comment"`
+	IsVerified  bool           `json:"is_verified"`
+	CreatedAt   time.Time      `json:"created_at"`
+	FlagCount   int64          `json:"flag_count"`
+	Reasons     []string       `json:"reasons"`
+}
+
+func (q *Queries) GetFlaggedReviews(ctx context.Context, arg GetFlaggedReviewsParams) ([]GetFlaggedReviewsRow, error) {
+	rows, err := q.query(ctx, q.getFlaggedReviewsStmt, getFlaggedReviews, arg.OffsetCount, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetFlaggedReviewsRow{}
+	for rows.Next() {
+		var i GetFlaggedReviewsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProductID,
+			&i.ProductName,
+			&i.Rating,
+			&i.Comment,
+			&i.IsVerified,
+			&i.CreatedAt,
+			&i.FlagCount,
+			pq.Array(&i.Reasons),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewImageCountByReviewID = `-- name: GetReviewImageCountByReviewID :one
+select count(*) from review_images
+where review_id = $1
+`
+
+func (q *Queries) GetReviewImageCountByReviewID(ctx context.Context, reviewID uuid.UUID) (int64, error) {
+	row := q.queryRow(ctx, q.getReviewImageCountByReviewIDStmt, getReviewImageCountByReviewID, reviewID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getReviewImagesByReviewIDs = `-- name: GetReviewImagesByReviewIDs :many
+select id, review_id, image_url, image_key, thumbnail_url, thumbnail_key, created_at from review_images
+where review_id = any($1::uuid[])
+order by review_id, created_at
+`
+
+func (q *Queries) GetReviewImagesByReviewIDs(ctx context.Context, reviewIds []uuid.UUID) ([]ReviewImage, error) {
+	rows, err := q.query(ctx, q.getReviewImagesByReviewIDsStmt, getReviewImagesByReviewIDs, pq.Array(reviewIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReviewImage{}
+	for rows.Next() {
+		var i ReviewImage
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReviewID,
+			&i.ImageUrl,
+			&i.ImageKey,
+			&i.ThumbnailUrl,
+			&i.ThumbnailKey,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReviewFlagged = `-- name: MarkReviewFlagged :exec
+update reviews
+set moderation_status = 'flagged', updated_at = current_timestamp
+where id = $1 and moderation_status = 'visible'
+`
+
+// a review the admin already approved or hid keeps its status until a new flag comes in
+func (q *Queries) MarkReviewFlagged(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.markReviewFlaggedStmt, markReviewFlagged, id)
+	return err
+}
+
+const resolveReviewFlags = `-- name: ResolveReviewFlags :exec
+update review_flags
+set is_resolved = true
+where review_id = $1 and is_resolved = false
+`
+
+func (q *Queries) ResolveReviewFlags(ctx context.Context, reviewID uuid.UUID) error {
+	_, err := q.exec(ctx, q.resolveReviewFlagsStmt, resolveReviewFlags, reviewID)
+	return err
+}
+
+const setReviewModerationStatus = `-- name: SetReviewModerationStatus :one
+update reviews
+set moderation_status = $1, updated_at = current_timestamp
+where id = $2 and is_deleted = false
+returning id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at
+`
+
+type SetReviewModerationStatusParams struct {
+	ModerationStatus string    `json:"moderation_status"`
+	ID               uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetReviewModerationStatus(ctx context.Context, arg SetReviewModerationStatusParams) (Review, error) {
+	row := q.queryRow(ctx, q.setReviewModerationStatusStmt, setReviewModerationStatus, arg.ModerationStatus, arg.ID)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Rating,
+		&i.Comment,
+		&i.IsDeleted,
+		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,301 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: search_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const searchProductCategoryFacets = `-- name: SearchProductCategoryFacets :many
+select c.name, count(distinct p.id) as product_count
+from products p
+inner join category_items ci
+on ci.product_id = p.id
+inner join categories c
+on ci.category_id = c.id
+where p.is_deleted = false and c.is_deleted = false
+and ($1::uuid is null or p.seller_id = $1::uuid)
+and ($2::text = ''
+    or to_tsvector('english', p.name || ' ' || p.description) @@ websearch_to_tsquery('english', $2::text)
+    or p.name % $2::text)
+and coalesce(product_price_at(p.id, current_timestamp), p.price) >= $3::float8 and coalesce(product_price_at(p.id, current_timestamp), p.price) <= $4::float8
+and not exists (
+    select 1 from unnest($5::text[]) as f(pair)
+    where not exists (
+        select 1 from product_attribute_values pav
+        inner join category_attributes ca
+        on pav.attribute_id = ca.id
+        where pav.product_id = p.id and lower(ca.name || '=' || pav.value) = lower(f.pair)
+    )
+)
+group by c.name
+order by product_count desc, c.name
+`
+
+type SearchProductCategoryFacetsParams struct {
+	SellerID   uuid.NullUUID `json:"seller_id"`
+	Query      string        `json:"query"`
+	PriceMin   float64       `json:"price_min"`
+	PriceMax   float64       `json:"price_max"`
+	Attributes []string      `json:"attributes"`
+}
+
+type SearchProductCategoryFacetsRow struct {
+	Name         string `json:"name"`
+	ProductCount int64  `json:"product_count"`
+}
+
+// category counts ignore the category filter itself so the other options stay visible
+func (q *Queries) SearchProductCategoryFacets(ctx context.Context, arg SearchProductCategoryFacetsParams) ([]SearchProductCategoryFacetsRow, error) {
+	rows, err := q.query(ctx, q.searchProductCategoryFacetsStmt, searchProductCategoryFacets,
+		arg.SellerID,
+		arg.Query,
+		arg.PriceMin,
+		arg.PriceMax,
+		pq.Array(arg.Attributes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductCategoryFacetsRow{}
+	for rows.Next() {
+		var i SearchProductCategoryFacetsRow
+		if err := rows.Scan(&i.Name, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProductPriceFacets = `-- name: SearchProductPriceFacets :many
+with recursive category_tree as (
+    select cat.id from categories cat
+    where (cat.name = any($4::text[]) or cat.slug = any($4::text[])) and cat.is_deleted = false
+    union
+    select c.id from categories c
+    inner join category_tree t
+    on c.parent_id = t.id
+    where c.is_deleted = false
+)
+select width_bucket(coalesce(product_price_at(p.id, current_timestamp), p.price)::float8, $1::float8[]) as bucket, count(*) as product_count
+from products p
+where p.is_deleted = false
+and ($2::uuid is null or p.seller_id = $2::uuid)
+and ($3::text = ''
+    or to_tsvector('english', p.name || ' ' || p.description) @@ websearch_to_tsquery('english', $3::text)
+    or p.name % $3::text)
+and (cardinality($4::text[]) = 0 or exists (
+    select 1 from category_items ci
+    where ci.product_id = p.id and ci.category_id in (select ct.id from category_tree ct)
+))
+and not exists (
+    select 1 from unnest($5::text[]) as f(pair)
+    where not exists (
+        select 1 from product_attribute_values pav
+        inner join category_attributes ca
+        on pav.attribute_id = ca.id
+        where pav.product_id = p.id and lower(ca.name || '=' || pav.value) = lower(f.pair)
+    )
+)
+group by bucket
+order by bucket
+`
+
+type SearchProductPriceFacetsParams struct {
+	Bounds     []float64     `json:"bounds"`
+	SellerID   uuid.NullUUID `json:"seller_id"`
+	Query      string        `json:"query"`
+	Categories []string      `json:"categories"`
+	Attributes []string      `json:"attributes"`
+}
+
+type SearchProductPriceFacetsRow struct {
+	Bucket       int32 `json:"bucket"`
+	ProductCount int64 `json:"product_count"`
+}
+
+// bucket 0 is below the first bound, bucket n is at or above the last one
+func (q *Queries) SearchProductPriceFacets(ctx context.Context, arg SearchProductPriceFacetsParams) ([]SearchProductPriceFacetsRow, error) {
+	rows, err := q.query(ctx, q.searchProductPriceFacetsStmt, searchProductPriceFacets,
+		pq.Array(arg.Bounds),
+		arg.SellerID,
+		arg.Query,
+		pq.Array(arg.Categories),
+		pq.Array(arg.Attributes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductPriceFacetsRow{}
+	for rows.Next() {
+		var i SearchProductPriceFacetsRow
+		if err := rows.Scan(&i.Bucket, &i.ProductCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchProducts = `-- name: SearchProducts :many
+
+with recursive category_tree as (
+    select cat.id from categories cat
+    where (cat.name = any($4::text[]) or cat.slug = any($4::text[])) and cat.is_deleted = false
+    union
+    select c.id from categories c
+    inner join category_tree t
+    on c.parent_id = t.id
+    where c.is_deleted = false
+), ratings as (
+    select product_id, avg(rating)::float8 as average_rating, count(*) as rating_count
+    from reviews
+    where is_deleted = false and moderation_status <> 'hidden'
+    group by product_id
+), matched as (
+    -- price is the effective price, sale prices included
+    select p.id, p.name, p.description, coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as price, p.stock, p.sold_count, p.seller_id, p.created_at, p.updated_at,
+    coalesce(r.average_rating, 0)::float8 as average_rating,
+    coalesce(r.rating_count, 0)::bigint as rating_count,
+    (case when $5::text = '' then 0
+          else ts_rank(to_tsvector('english', p.name || ' ' || p.description), websearch_to_tsquery('english', $5::text)) + similarity(p.name, $5::text)
+     end)::float8 as relevance
+    from products p
+    left join ratings r
+    on r.product_id = p.id
+    where p.is_deleted = false
+    and ($6::uuid is null or p.seller_id = $6::uuid)
+    and ($5::text = ''
+        or to_tsvector('english', p.name || ' ' || p.description) @@ websearch_to_tsquery('english', $5::text)
+        or p.name % $5::text)
+    and coalesce(product_price_at(p.id, current_timestamp), p.price) >= $7::float8 and coalesce(product_price_at(p.id, current_timestamp), p.price) <= $8::float8
+    and (cardinality($4::text[]) = 0 or exists (
+        select 1 from category_items ci
+        where ci.product_id = p.id and ci.category_id in (select ct.id from category_tree ct)
+    ))
+    and not exists (
+        select 1 from unnest($9::text[]) as f(pair)
+        where not exists (
+            select 1 from product_attribute_values pav
+            inner join category_attributes ca
+            on pav.attribute_id = ca.id
+            where pav.product_id = p.id and lower(ca.name || '=' || pav.value) = lower(f.pair)
+        )
+    )
+)
+select id, name, description, price, stock, sold_count, seller_id, created_at, updated_at, average_rating, rating_count, relevance, count(*) over() as total_count
+from matched
+order by
+    -- out of stock products always go after the available ones
+    (stock = 0),
+    case when $1::text = 'price_asc' then price end asc,
+    case when $1::text = 'price_desc' then price end desc,
+    case when $1::text = 'rating' then average_rating end desc,
+    case when $1::text = 'best_selling' then sold_count end desc,
+    case when $1::text = 'relevance' then relevance end desc,
+    created_at desc,
+    id
+limit $3 offset $2
+`
+
+type SearchProductsParams struct {
+	Sort       string        `json:"sort"`
+	PageOffset int32         `json:"page_offset"`
+	PageLimit  int32         `json:"page_limit"`
+	Categories []string      `json:"categories"`
+	Query      string        `json:"query"`
+	SellerID   uuid.NullUUID `json:"seller_id"`
+	PriceMin   float64       `json:"price_min"`
+	PriceMax   float64       `json:"price_max"`
+	Attributes []string      `json:"attributes"`
+}
+
+type SearchProductsRow struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Price         float64   `json:"price"`
+	Stock         int32     `json:"stock"`
+	SoldCount     int32     `json:"sold_count"`
+	SellerID      uuid.UUID `json:"seller_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	AverageRating float64   `json:"average_rating"`
+	RatingCount   int64     `json:"rating_count"`
+	Relevance     float64   `json:"relevance"`
+	TotalCount    int64     `json:"total_count"`
+}
+
+// the filters below are repeated in every search query so the facets
+// are counted over the same set of products the listing returns.
+// empty query / categories / attributes means the filter is off.
+// attributes are "name=value" pairs and a product has to match all of them.
+// seller_id is only set for the seller storefront
+func (q *Queries) SearchProducts(ctx context.Context, arg SearchProductsParams) ([]SearchProductsRow, error) {
+	rows, err := q.query(ctx, q.searchProductsStmt, searchProducts,
+		arg.Sort,
+		arg.PageOffset,
+		arg.PageLimit,
+		pq.Array(arg.Categories),
+		arg.Query,
+		arg.SellerID,
+		arg.PriceMin,
+		arg.PriceMax,
+		pq.Array(arg.Attributes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SearchProductsRow{}
+	for rows.Next() {
+		var i SearchProductsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Stock,
+			&i.SoldCount,
+			&i.SellerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.AverageRating,
+			&i.RatingCount,
+			&i.Relevance,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
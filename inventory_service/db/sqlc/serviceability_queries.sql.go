@@ -0,0 +1,195 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: serviceability_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const addSellerServiceArea = `-- name: AddSellerServiceArea :one
+insert into seller_service_areas
+(seller_id, pincode_from, pincode_to)
+values
+($1, $2, $3)
+returning id, seller_id, pincode_from, pincode_to, created_at
+`
+
+type AddSellerServiceAreaParams struct {
+	SellerID    uuid.UUID `json:"seller_id"`
+	PincodeFrom int32     `json:"pincode_from"`
+	PincodeTo   int32     `json:"pincode_to"`
+}
+
+func (q *Queries) AddSellerServiceArea(ctx context.Context, arg AddSellerServiceAreaParams) (SellerServiceArea, error) {
+	row := q.queryRow(ctx, q.addSellerServiceAreaStmt, addSellerServiceArea, arg.SellerID, arg.PincodeFrom, arg.PincodeTo)
+	var i SellerServiceArea
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.PincodeFrom,
+		&i.PincodeTo,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteSellerServiceAreas = `-- name: DeleteSellerServiceAreas :exec
+delete from seller_service_areas
+where seller_id = $1
+`
+
+func (q *Queries) DeleteSellerServiceAreas(ctx context.Context, sellerID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteSellerServiceAreasStmt, deleteSellerServiceAreas, sellerID)
+	return err
+}
+
+const getSellerServiceAreas = `-- name: GetSellerServiceAreas :many
+select id, seller_id, pincode_from, pincode_to, created_at from seller_service_areas
+where seller_id = $1
+order by pincode_from
+`
+
+func (q *Queries) GetSellerServiceAreas(ctx context.Context, sellerID uuid.UUID) ([]SellerServiceArea, error) {
+	rows, err := q.query(ctx, q.getSellerServiceAreasStmt, getSellerServiceAreas, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SellerServiceArea{}
+	for rows.Next() {
+		var i SellerServiceArea
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.PincodeFrom,
+			&i.PincodeTo,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSellerShippingSettings = `-- name: GetSellerShippingSettings :one
+select seller_id, handling_days, shipping_rule, flat_fee, free_shipping_above, created_at, updated_at from seller_shipping_settings
+where seller_id = $1
+`
+
+func (q *Queries) GetSellerShippingSettings(ctx context.Context, sellerID uuid.UUID) (SellerShippingSetting, error) {
+	row := q.queryRow(ctx, q.getSellerShippingSettingsStmt, getSellerShippingSettings, sellerID)
+	var i SellerShippingSetting
+	err := row.Scan(
+		&i.SellerID,
+		&i.HandlingDays,
+		&i.ShippingRule,
+		&i.FlatFee,
+		&i.FreeShippingAbove,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSellerShipsToPincode = `-- name: GetSellerShipsToPincode :one
+select (not exists (
+    select 1 from seller_service_areas a where a.seller_id = $1
+) or exists (
+    select 1 from seller_service_areas a
+    where a.seller_id = $1 and $2::int between a.pincode_from and a.pincode_to
+))::bool as ships
+`
+
+type GetSellerShipsToPincodeParams struct {
+	SellerID uuid.UUID `json:"seller_id"`
+	Pincode  int32     `json:"pincode"`
+}
+
+// true when the pincode is in one of the seller's ranges or the seller has none
+func (q *Queries) GetSellerShipsToPincode(ctx context.Context, arg GetSellerShipsToPincodeParams) (bool, error) {
+	row := q.queryRow(ctx, q.getSellerShipsToPincodeStmt, getSellerShipsToPincode, arg.SellerID, arg.Pincode)
+	var ships bool
+	err := row.Scan(&ships)
+	return ships, err
+}
+
+const upsertSellerShippingRule = `-- name: UpsertSellerShippingRule :one
+insert into seller_shipping_settings
+(seller_id, shipping_rule, flat_fee, free_shipping_above)
+values
+($1, $2, $3, $4)
+on conflict (seller_id) do update
+set shipping_rule = excluded.shipping_rule, flat_fee = excluded.flat_fee,
+free_shipping_above = excluded.free_shipping_above, updated_at = current_timestamp
+returning seller_id, handling_days, shipping_rule, flat_fee, free_shipping_above, created_at, updated_at
+`
+
+type UpsertSellerShippingRuleParams struct {
+	SellerID          uuid.UUID       `json:"seller_id"`
+	ShippingRule      string          `json:"shipping_rule"`
+	FlatFee           float64         `json:"flat_fee"`
+	FreeShippingAbove sql.NullFloat64 `json:"free_shipping_above"`
+}
+
+func (q *Queries) UpsertSellerShippingRule(ctx context.Context, arg UpsertSellerShippingRuleParams) (SellerShippingSetting, error) {
+	row := q.queryRow(ctx, q.upsertSellerShippingRuleStmt, upsertSellerShippingRule,
+		arg.SellerID,
+		arg.ShippingRule,
+		arg.FlatFee,
+		arg.FreeShippingAbove,
+	)
+	var i SellerShippingSetting
+	err := row.Scan(
+		&i.SellerID,
+		&i.HandlingDays,
+		&i.ShippingRule,
+		&i.FlatFee,
+		&i.FreeShippingAbove,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSellerShippingSettings = `-- name: UpsertSellerShippingSettings :one
+insert into seller_shipping_settings
+(seller_id, handling_days)
+values
+($1, $2)
+on conflict (seller_id) do update
+set handling_days = excluded.handling_days, updated_at = current_timestamp
+returning seller_id, handling_days, shipping_rule, flat_fee, free_shipping_above, created_at, updated_at
+`
+
+type UpsertSellerShippingSettingsParams struct {
+	SellerID     uuid.UUID `json:"seller_id"`
+	HandlingDays int32     `json:"handling_days"`
+}
+
+func (q *Queries) UpsertSellerShippingSettings(ctx context.Context, arg UpsertSellerShippingSettingsParams) (SellerShippingSetting, error) {
+	row := q.queryRow(ctx, q.upsertSellerShippingSettingsStmt, upsertSellerShippingSettings, arg.SellerID, arg.HandlingDays)
+	var i SellerShippingSetting
+	err := row.Scan(
+		&i.SellerID,
+		&i.HandlingDays,
+		&i.ShippingRule,
+		&i.FlatFee,
+		&i.FreeShippingAbove,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,361 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: stock_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const addStockSubscription = `-- name: AddStockSubscription :one
+insert into stock_subscriptions
+(user_id, product_id, email)
+values
+($1, $2, $3)
+on conflict (user_id, product_id)
+do update set email = excluded.email, notified_at = null, created_at = current_timestamp
+returning id, user_id, product_id, email, notified_at, created_at
+`
+
+type AddStockSubscriptionParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Email     string    `json:"email"`
+}
+
+// subscribing again after being notified starts a fresh subscription
+func (q *Queries) AddStockSubscription(ctx context.Context, arg AddStockSubscriptionParams) (StockSubscription, error) {
+	row := q.queryRow(ctx, q.addStockSubscriptionStmt, addStockSubscription, arg.UserID, arg.ProductID, arg.Email)
+	var i StockSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Email,
+		&i.NotifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteStockSubscription = `-- name: DeleteStockSubscription :one
+delete from stock_subscriptions
+where user_id = $1 and product_id = $2
+returning id, user_id, product_id, email, notified_at, created_at
+`
+
+type DeleteStockSubscriptionParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) DeleteStockSubscription(ctx context.Context, arg DeleteStockSubscriptionParams) (StockSubscription, error) {
+	row := q.queryRow(ctx, q.deleteStockSubscriptionStmt, deleteStockSubscription, arg.UserID, arg.ProductID)
+	var i StockSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProductID,
+		&i.Email,
+		&i.NotifiedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const editProductLowStockThresholdByID = `-- name: EditProductLowStockThresholdByID :one
+update products
+set low_stock_threshold = $1, updated_at = current_timestamp
+where id = $2 and is_deleted = false
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
+`
+
+type EditProductLowStockThresholdByIDParams struct {
+	LowStockThreshold int32     `json:"low_stock_threshold"`
+	ID                uuid.UUID `json:"id"`
+}
+
+func (q *Queries) EditProductLowStockThresholdByID(ctx context.Context, arg EditProductLowStockThresholdByIDParams) (Product, error) {
+	row := q.queryRow(ctx, q.editProductLowStockThresholdByIDStmt, editProductLowStockThresholdByID, arg.LowStockThreshold, arg.ID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Mrp,
+		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
+		&i.SellerID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const editProductWeightByID = `-- name: EditProductWeightByID :one
+update products
+set weight_grams = $1, updated_at = current_timestamp
+where id = $2 and is_deleted = false
+returning id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at
+`
+
+type EditProductWeightByIDParams struct {
+	WeightGrams int32     `json:"weight_grams"`
+	ID          uuid.UUID `json:"id"`
+}
+
+func (q *Queries) EditProductWeightByID(ctx context.Context, arg EditProductWeightByIDParams) (Product, error) {
+	row := q.queryRow(ctx, q.editProductWeightByIDStmt, editProductWeightByID, arg.WeightGrams, arg.ID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.Mrp,
+		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
+		&i.SellerID,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLowStockProductsBySellerID = `-- name: GetLowStockProductsBySellerID :many
+select id, name, description, price, mrp, stock, sold_count, low_stock_threshold, weight_grams, seller_id, is_deleted, created_at, updated_at from products
+where seller_id = $1 and is_deleted = false and stock <= low_stock_threshold
+order by stock, name
+`
+
+// out of stock products come first
+func (q *Queries) GetLowStockProductsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]Product, error) {
+	rows, err := q.query(ctx, q.getLowStockProductsBySellerIDStmt, getLowStockProductsBySellerID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.Mrp,
+			&i.Stock,
+			&i.SoldCount,
+			&i.LowStockThreshold,
+			&i.WeightGrams,
+			&i.SellerID,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPendingStockSubscriptionsByProductID = `-- name: GetPendingStockSubscriptionsByProductID :many
+select id, user_id, product_id, email, notified_at, created_at from stock_subscriptions
+where product_id = $1 and notified_at is null
+`
+
+func (q *Queries) GetPendingStockSubscriptionsByProductID(ctx context.Context, productID uuid.UUID) ([]StockSubscription, error) {
+	rows, err := q.query(ctx, q.getPendingStockSubscriptionsByProductIDStmt, getPendingStockSubscriptionsByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []StockSubscription{}
+	for rows.Next() {
+		var i StockSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProductID,
+			&i.Email,
+			&i.NotifiedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStockAlertsBySellerID = `-- name: GetStockAlertsBySellerID :many
+select sa.id, sa.product_id, sa.seller_id, sa.kind, sa.stock, sa.is_read, sa.processed_at, sa.created_at, p.name as product_name from stock_alerts sa
+inner join products p
+on sa.product_id = p.id
+where sa.seller_id = $1 and (sa.is_read = false or $2::boolean)
+order by sa.created_at desc
+limit 100
+`
+
+type GetStockAlertsBySellerIDParams struct {
+	SellerID    uuid.UUID `json:"seller_id"`
+	IncludeRead bool      `json:"include_read"`
+}
+
+type GetStockAlertsBySellerIDRow struct {
+	ID          uuid.UUID    `json:"id"`
+	ProductID   uuid.UUID    `json:"product_id"`
+	SellerID    uuid.UUID    `json:"seller_id"`
+	Kind        string       `json:"kind"`
+	Stock       int32        `json:"stock"`
+	IsRead      bool         `json:"is_read"`
+	ProcessedAt sql.NullTime `json:"processed_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	ProductName string       `json:"product_name"`
+}
+
+func (q *Queries) GetStockAlertsBySellerID(ctx context.Context, arg GetStockAlertsBySellerIDParams) ([]GetStockAlertsBySellerIDRow, error) {
+	rows, err := q.query(ctx, q.getStockAlertsBySellerIDStmt, getStockAlertsBySellerID, arg.SellerID, arg.IncludeRead)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStockAlertsBySellerIDRow{}
+	for rows.Next() {
+		var i GetStockAlertsBySellerIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.SellerID,
+			&i.Kind,
+			&i.Stock,
+			&i.IsRead,
+			&i.ProcessedAt,
+			&i.CreatedAt,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnprocessedStockAlerts = `-- name: GetUnprocessedStockAlerts :many
+select sa.id, sa.product_id, sa.seller_id, sa.kind, sa.stock, sa.is_read, sa.processed_at, sa.created_at, p.name as product_name from stock_alerts sa
+inner join products p
+on sa.product_id = p.id
+where sa.processed_at is null
+order by sa.created_at
+limit 100
+`
+
+type GetUnprocessedStockAlertsRow struct {
+	ID          uuid.UUID    `json:"id"`
+	ProductID   uuid.UUID    `json:"product_id"`
+	SellerID    uuid.UUID    `json:"seller_id"`
+	Kind        string       `json:"kind"`
+	Stock       int32        `json:"stock"`
+	IsRead      bool         `json:"is_read"`
+	ProcessedAt sql.NullTime `json:"processed_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	ProductName string       `json:"product_name"`
+}
+
+func (q *Queries) GetUnprocessedStockAlerts(ctx context.Context) ([]GetUnprocessedStockAlertsRow, error) {
+	rows, err := q.query(ctx, q.getUnprocessedStockAlertsStmt, getUnprocessedStockAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUnprocessedStockAlertsRow{}
+	for rows.Next() {
+		var i GetUnprocessedStockAlertsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.SellerID,
+			&i.Kind,
+			&i.Stock,
+			&i.IsRead,
+			&i.ProcessedAt,
+			&i.CreatedAt,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markStockAlertProcessed = `-- name: MarkStockAlertProcessed :exec
+update stock_alerts
+set processed_at = current_timestamp
+where id = $1
+`
+
+func (q *Queries) MarkStockAlertProcessed(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.markStockAlertProcessedStmt, markStockAlertProcessed, id)
+	return err
+}
+
+const markStockAlertsReadBySellerID = `-- name: MarkStockAlertsReadBySellerID :exec
+update stock_alerts
+set is_read = true
+where seller_id = $1 and is_read = false
+`
+
+func (q *Queries) MarkStockAlertsReadBySellerID(ctx context.Context, sellerID uuid.UUID) error {
+	_, err := q.exec(ctx, q.markStockAlertsReadBySellerIDStmt, markStockAlertsReadBySellerID, sellerID)
+	return err
+}
+
+const markStockSubscriptionNotified = `-- name: MarkStockSubscriptionNotified :exec
+update stock_subscriptions
+set notified_at = current_timestamp
+where id = $1
+`
+
+func (q *Queries) MarkStockSubscriptionNotified(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.markStockSubscriptionNotifiedStmt, markStockSubscriptionNotified, id)
+	return err
+}
@@ -0,0 +1,92 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: storefront_queries.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const getSellerProductCount = `-- name: GetSellerProductCount :one
+select count(*) from products
+where seller_id = $1 and is_deleted = false
+`
+
+func (q *Queries) GetSellerProductCount(ctx context.Context, sellerID uuid.UUID) (int64, error) {
+	row := q.queryRow(ctx, q.getSellerProductCountStmt, getSellerProductCount, sellerID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getSellerRatingSummary = `-- name: GetSellerRatingSummary :one
+select coalesce(avg(r.rating), 0)::float8 as average_rating, count(r.id) as rating_count
+from reviews r
+inner join products p
+on r.product_id = p.id
+where p.seller_id = $1 and p.is_deleted = false and r.is_deleted = false and r.moderation_status <> 'hidden'
+`
+
+type GetSellerRatingSummaryRow struct {
+	AverageRating float64 `json:"average_rating"`
+	RatingCount   int64   `json:"rating_count"`
+}
+
+// rating of the seller is over the reviews of all their products
+func (q *Queries) GetSellerRatingSummary(ctx context.Context, sellerID uuid.UUID) (GetSellerRatingSummaryRow, error) {
+	row := q.queryRow(ctx, q.getSellerRatingSummaryStmt, getSellerRatingSummary, sellerID)
+	var i GetSellerRatingSummaryRow
+	err := row.Scan(&i.AverageRating, &i.RatingCount)
+	return i, err
+}
+
+const getSellerStorefront = `-- name: GetSellerStorefront :one
+select seller_id, return_window_days, return_policy, created_at, updated_at from seller_storefronts
+where seller_id = $1
+`
+
+func (q *Queries) GetSellerStorefront(ctx context.Context, sellerID uuid.UUID) (SellerStorefront, error) {
+	row := q.queryRow(ctx, q.getSellerStorefrontStmt, getSellerStorefront, sellerID)
+	var i SellerStorefront
+	err := row.Scan(
+		&i.SellerID,
+		&i.ReturnWindowDays,
+		&i.ReturnPolicy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSellerStorefront = `-- name: UpsertSellerStorefront :one
+insert into seller_storefronts
+(seller_id, return_window_days, return_policy)
+values
+($1, $2, $3)
+on conflict (seller_id) do update
+set return_window_days = excluded.return_window_days, return_policy = excluded.return_policy, updated_at = current_timestamp
+returning seller_id, return_window_days, return_policy, created_at, updated_at
+`
+
+type UpsertSellerStorefrontParams struct {
+	SellerID         uuid.UUID `json:"seller_id"`
+	ReturnWindowDays int32     `json:"return_window_days"`
+	ReturnPolicy     string    `json:"return_policy"`
+}
+
+func (q *Queries) UpsertSellerStorefront(ctx context.Context, arg UpsertSellerStorefrontParams) (SellerStorefront, error) {
+	row := q.queryRow(ctx, q.upsertSellerStorefrontStmt, upsertSellerStorefront, arg.SellerID, arg.ReturnWindowDays, arg.ReturnPolicy)
+	var i SellerStorefront
+	err := row.Scan(
+		&i.SellerID,
+		&i.ReturnWindowDays,
+		&i.ReturnPolicy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
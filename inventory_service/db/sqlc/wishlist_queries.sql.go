@@ -7,36 +7,85 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const addWishList = `-- name: AddWishList :one
+insert into wishlist_lists
+(user_id, name)
+values
+($1, $2)
+returning id, user_id, name, is_default, alerts_enabled, share_token, created_at, updated_at
+`
+
+type AddWishListParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+func (q *Queries) AddWishList(ctx context.Context, arg AddWishListParams) (WishlistList, error) {
+	row := q.queryRow(ctx, q.addWishListStmt, addWishList, arg.UserID, arg.Name)
+	var i WishlistList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.IsDefault,
+		&i.AlertsEnabled,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const addWishListItem = `-- name: AddWishListItem :one
 insert into wishlists
-(user_id, product_id)
+(user_id, list_id, product_id, price_at_add)
 values
-($1, $2)
-returning id, user_id, product_id, created_at
+($1, $2, $3, $4)
+returning id, user_id, list_id, product_id, price_at_add, created_at
 `
 
 type AddWishListItemParams struct {
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	ListID     uuid.UUID `json:"list_id"`
+	ProductID  uuid.UUID `json:"product_id"`
+	PriceAtAdd float64   `json:"price_at_add"`
 }
 
 func (q *Queries) AddWishListItem(ctx context.Context, arg AddWishListItemParams) (Wishlist, error) {
-	row := q.queryRow(ctx, q.addWishListItemStmt, addWishListItem, arg.UserID, arg.ProductID)
+	row := q.queryRow(ctx, q.addWishListItemStmt, addWishListItem,
+		arg.UserID,
+		arg.ListID,
+		arg.ProductID,
+		arg.PriceAtAdd,
+	)
 	var i Wishlist
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
+		&i.ListID,
 		&i.ProductID,
+		&i.PriceAtAdd,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const deleteAllWishListItemsByListID = `-- name: DeleteAllWishListItemsByListID :exec
+delete from wishlists
+where list_id = $1
+`
+
+func (q *Queries) DeleteAllWishListItemsByListID(ctx context.Context, listID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteAllWishListItemsByListIDStmt, deleteAllWishListItemsByListID, listID)
+	return err
+}
+
 const deleteAllWishListItemsByUserID = `-- name: DeleteAllWishListItemsByUserID :exec
 delete from wishlists
 where user_id = $1
@@ -47,43 +96,249 @@ func (q *Queries) DeleteAllWishListItemsByUserID(ctx context.Context, userID uui
 	return err
 }
 
-const deleteWishListItemByUserAndProductID = `-- name: DeleteWishListItemByUserAndProductID :execrows
+const deleteWishList = `-- name: DeleteWishList :execrows
+delete from wishlist_lists
+where id = $1 and user_id = $2 and is_default = false
+`
+
+type DeleteWishListParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteWishList(ctx context.Context, arg DeleteWishListParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteWishListStmt, deleteWishList, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWishListItemByListAndProductID = `-- name: DeleteWishListItemByListAndProductID :execrows
 delete from wishlists
-where user_id = $1 and product_id = $2
+where list_id = $1 and product_id = $2
 `
 
-type DeleteWishListItemByUserAndProductIDParams struct {
-	UserID    uuid.UUID `json:"user_id"`
+type DeleteWishListItemByListAndProductIDParams struct {
+	ListID    uuid.UUID `json:"list_id"`
 	ProductID uuid.UUID `json:"product_id"`
 }
 
-func (q *Queries) DeleteWishListItemByUserAndProductID(ctx context.Context, arg DeleteWishListItemByUserAndProductIDParams) (int64, error) {
-	result, err := q.exec(ctx, q.deleteWishListItemByUserAndProductIDStmt, deleteWishListItemByUserAndProductID, arg.UserID, arg.ProductID)
+func (q *Queries) DeleteWishListItemByListAndProductID(ctx context.Context, arg DeleteWishListItemByListAndProductIDParams) (int64, error) {
+	result, err := q.exec(ctx, q.deleteWishListItemByListAndProductIDStmt, deleteWishListItemByListAndProductID, arg.ListID, arg.ProductID)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
-const getAllWishListItemsByUserID = `-- name: GetAllWishListItemsByUserID :many
-select id, user_id, product_id, created_at from wishlists
-where user_id = $1
+const editWishList = `-- name: EditWishList :one
+update wishlist_lists
+set name = $1, alerts_enabled = $2, updated_at = current_timestamp
+where id = $3 and user_id = $4
+returning id, user_id, name, is_default, alerts_enabled, share_token, created_at, updated_at
+`
+
+type EditWishListParams struct {
+	Name          string    `json:"name"`
+	AlertsEnabled bool      `json:"alerts_enabled"`
+	ID            uuid.UUID `json:"id"`
+	UserID        uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) EditWishList(ctx context.Context, arg EditWishListParams) (WishlistList, error) {
+	row := q.queryRow(ctx, q.editWishListStmt, editWishList,
+		arg.Name,
+		arg.AlertsEnabled,
+		arg.ID,
+		arg.UserID,
+	)
+	var i WishlistList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.IsDefault,
+		&i.AlertsEnabled,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOrCreateDefaultWishList = `-- name: GetOrCreateDefaultWishList :one
+insert into wishlist_lists
+(user_id, name, is_default)
+values
+($1, 'default', true)
+on conflict (user_id, name) do update
+set name = excluded.name
+returning id, user_id, name, is_default, alerts_enabled, share_token, created_at, updated_at
+`
+
+// the no-op update makes returning give back the existing row
+func (q *Queries) GetOrCreateDefaultWishList(ctx context.Context, userID uuid.UUID) (WishlistList, error) {
+	row := q.queryRow(ctx, q.getOrCreateDefaultWishListStmt, getOrCreateDefaultWishList, userID)
+	var i WishlistList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.IsDefault,
+		&i.AlertsEnabled,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWishListAlertUserIDsByProductID = `-- name: GetWishListAlertUserIDsByProductID :many
+select distinct w.user_id
+from wishlists w
+inner join wishlist_lists l
+on w.list_id = l.id
+where w.product_id = $1 and l.alerts_enabled = true
+`
+
+// a user with the product in several lists is only alerted once
+func (q *Queries) GetWishListAlertUserIDsByProductID(ctx context.Context, productID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.query(ctx, q.getWishListAlertUserIDsByProductIDStmt, getWishListAlertUserIDsByProductID, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWishListByID = `-- name: GetWishListByID :one
+select id, user_id, name, is_default, alerts_enabled, share_token, created_at, updated_at from wishlist_lists
+where id = $1 and user_id = $2
+`
+
+type GetWishListByIDParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetWishListByID(ctx context.Context, arg GetWishListByIDParams) (WishlistList, error) {
+	row := q.queryRow(ctx, q.getWishListByIDStmt, getWishListByID, arg.ID, arg.UserID)
+	var i WishlistList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.IsDefault,
+		&i.AlertsEnabled,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWishListByShareToken = `-- name: GetWishListByShareToken :one
+select id, user_id, name, is_default, alerts_enabled, share_token, created_at, updated_at from wishlist_lists
+where share_token = $1
 `
 
-func (q *Queries) GetAllWishListItemsByUserID(ctx context.Context, userID uuid.UUID) ([]Wishlist, error) {
-	rows, err := q.query(ctx, q.getAllWishListItemsByUserIDStmt, getAllWishListItemsByUserID, userID)
+func (q *Queries) GetWishListByShareToken(ctx context.Context, shareToken sql.NullString) (WishlistList, error) {
+	row := q.queryRow(ctx, q.getWishListByShareTokenStmt, getWishListByShareToken, shareToken)
+	var i WishlistList
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.IsDefault,
+		&i.AlertsEnabled,
+		&i.ShareToken,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWishListItemByListAndProductID = `-- name: GetWishListItemByListAndProductID :one
+select id, user_id, list_id, product_id, price_at_add, created_at from wishlists
+where list_id = $1 and product_id = $2
+`
+
+type GetWishListItemByListAndProductIDParams struct {
+	ListID    uuid.UUID `json:"list_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) GetWishListItemByListAndProductID(ctx context.Context, arg GetWishListItemByListAndProductIDParams) (Wishlist, error) {
+	row := q.queryRow(ctx, q.getWishListItemByListAndProductIDStmt, getWishListItemByListAndProductID, arg.ListID, arg.ProductID)
+	var i Wishlist
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ListID,
+		&i.ProductID,
+		&i.PriceAtAdd,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWishListItemsByListID = `-- name: GetWishListItemsByListID :many
+select w.id, w.user_id, w.list_id, w.product_id, w.price_at_add, w.created_at, p.name as product_name, coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as current_price, p.stock, p.is_deleted
+from wishlists w
+inner join products p
+on w.product_id = p.id
+where w.list_id = $1
+order by w.created_at desc
+`
+
+type GetWishListItemsByListIDRow struct {
+	ID           uuid.UUID `json:"id"`
+	UserID       uuid.UUID `json:"user_id"`
+	ListID       uuid.UUID `json:"list_id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	PriceAtAdd   float64   `json:"price_at_add"`
+	CreatedAt    time.Time `json:"created_at"`
+	ProductName  string    `json:"product_name"`
+	CurrentPrice float64   `json:"current_price"`
+	Stock        int32     `json:"stock"`
+	IsDeleted    bool      `json:"is_deleted"`
+}
+
+func (q *Queries) GetWishListItemsByListID(ctx context.Context, listID uuid.UUID) ([]GetWishListItemsByListIDRow, error) {
+	rows, err := q.query(ctx, q.getWishListItemsByListIDStmt, getWishListItemsByListID, listID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Wishlist{}
+	items := []GetWishListItemsByListIDRow{}
 	for rows.Next() {
-		var i Wishlist
+		var i GetWishListItemsByListIDRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
+			&i.ListID,
 			&i.ProductID,
+			&i.PriceAtAdd,
 			&i.CreatedAt,
+			&i.ProductName,
+			&i.CurrentPrice,
+			&i.Stock,
+			&i.IsDeleted,
 		); err != nil {
 			return nil, err
 		}
@@ -98,36 +353,47 @@ func (q *Queries) GetAllWishListItemsByUserID(ctx context.Context, userID uuid.U
 	return items, nil
 }
 
-const getAllWishListItemsWithProductNameByUserID = `-- name: GetAllWishListItemsWithProductNameByUserID :many
-select w.id, w.user_id, w.product_id, w.created_at, p.name as product_name from wishlists w
-inner join products p
-on w.product_id = p.id
-where w.user_id = $1
+const getWishListsByUserID = `-- name: GetWishListsByUserID :many
+select l.id, l.user_id, l.name, l.is_default, l.alerts_enabled, l.share_token, l.created_at, l.updated_at, count(w.id) as item_count
+from wishlist_lists l
+left join wishlists w
+on w.list_id = l.id
+where l.user_id = $1
+group by l.id
+order by l.is_default desc, l.created_at
 `
 
-type GetAllWishListItemsWithProductNameByUserIDRow struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	ProductID   uuid.UUID `json:"product_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	ProductName string    `json:"product_name"`
+type GetWishListsByUserIDRow struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	Name          string         `json:"name"`
+	IsDefault     bool           `json:"is_default"`
+	AlertsEnabled bool           `json:"alerts_enabled"`
+	ShareToken    sql.NullString `json:"share_token"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	ItemCount     int64          `json:"item_count"`
 }
 
-func (q *Queries) GetAllWishListItemsWithProductNameByUserID(ctx context.Context, userID uuid.UUID) ([]GetAllWishListItemsWithProductNameByUserIDRow, error) {
-	rows, err := q.query(ctx, q.getAllWishListItemsWithProductNameByUserIDStmt, getAllWishListItemsWithProductNameByUserID, userID)
+func (q *Queries) GetWishListsByUserID(ctx context.Context, userID uuid.UUID) ([]GetWishListsByUserIDRow, error) {
+	rows, err := q.query(ctx, q.getWishListsByUserIDStmt, getWishListsByUserID, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAllWishListItemsWithProductNameByUserIDRow{}
+	items := []GetWishListsByUserIDRow{}
 	for rows.Next() {
-		var i GetAllWishListItemsWithProductNameByUserIDRow
+		var i GetWishListsByUserIDRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
-			&i.ProductID,
+			&i.Name,
+			&i.IsDefault,
+			&i.AlertsEnabled,
+			&i.ShareToken,
 			&i.CreatedAt,
-			&i.ProductName,
+			&i.UpdatedAt,
+			&i.ItemCount,
 		); err != nil {
 			return nil, err
 		}
@@ -142,24 +408,32 @@ func (q *Queries) GetAllWishListItemsWithProductNameByUserID(ctx context.Context
 	return items, nil
 }
 
-const getWishListItemByUserAndProductID = `-- name: GetWishListItemByUserAndProductID :one
-select id, user_id, product_id, created_at from wishlists
-where user_id = $1 and product_id = $2
+const setWishListShareToken = `-- name: SetWishListShareToken :one
+update wishlist_lists
+set share_token = $1, updated_at = current_timestamp
+where id = $2 and user_id = $3
+returning id, user_id, name, is_default, alerts_enabled, share_token, created_at, updated_at
 `
 
-type GetWishListItemByUserAndProductIDParams struct {
-	UserID    uuid.UUID `json:"user_id"`
-	ProductID uuid.UUID `json:"product_id"`
+type SetWishListShareTokenParams struct {
+	ShareToken sql.NullString `json:"share_token"`
+	ID         uuid.UUID      `json:"id"`
+	UserID     uuid.UUID      `json:"user_id"`
 }
 
-func (q *Queries) GetWishListItemByUserAndProductID(ctx context.Context, arg GetWishListItemByUserAndProductIDParams) (Wishlist, error) {
-	row := q.queryRow(ctx, q.getWishListItemByUserAndProductIDStmt, getWishListItemByUserAndProductID, arg.UserID, arg.ProductID)
-	var i Wishlist
+// a null token stops sharing, a new token invalidates the old link
+func (q *Queries) SetWishListShareToken(ctx context.Context, arg SetWishListShareTokenParams) (WishlistList, error) {
+	row := q.queryRow(ctx, q.setWishListShareTokenStmt, setWishListShareToken, arg.ShareToken, arg.ID, arg.UserID)
+	var i WishlistList
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.ProductID,
+		&i.Name,
+		&i.IsDefault,
+		&i.AlertsEnabled,
+		&i.ShareToken,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
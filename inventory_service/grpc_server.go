@@ -0,0 +1,306 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/inventorypb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InventoryServer answers the other services' questions about products and the
+// data users keep in the inventory service
+type InventoryServer struct {
+	inventorypb.UnimplementedInventoryServiceServer
+	DB *db.Queries
+}
+
+// NewGRPCServer returns a grpc server with the inventory service registered,
+// the service main serves it next to the http mux
+func NewGRPCServer() *grpc.Server {
+	srv := grpc.NewServer()
+	inventorypb.RegisterInventoryServiceServer(srv, &InventoryServer{DB: DB})
+	return srv
+}
+
+func (is *InventoryServer) GetProductByID(ctx context.Context, req *inventorypb.GetProductByIDRequest) (*inventorypb.GetProductByIDResponse, error) {
+	productID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product id")
+	}
+	product, err := is.DB.GetProductByID(ctx, productID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "no product with the id")
+	} else if err != nil {
+		log.Error("error fetching product in grpc GetProductByID:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching product")
+	}
+	return &inventorypb.GetProductByIDResponse{
+		Id:          product.ID.String(),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Stock:       int64(product.Stock),
+		SellerId:    product.SellerID.String(),
+		IsDeleted:   product.IsDeleted,
+		CreatedAt:   timestamppb.New(product.CreatedAt),
+		UpdatedAt:   timestamppb.New(product.UpdatedAt),
+	}, nil
+}
+
+type exportReview struct {
+	ID               uuid.UUID `json:"id"`
+	ProductID        uuid.UUID `json:"product_id"`
+	ProductName      string    `json:"product_name"`
+	Rating           int32     `json:"rating"`
+	Comment          *string   `json:"comment"`
+	IsDeleted        bool      `json:"is_deleted"`
+	IsEdited         bool      `json:"is_edited"`
+	IsVerified       bool      `json:"is_verified"`
+	ModerationStatus string    `json:"moderation_status"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type exportWishListItem struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	PriceAtAdd  float64   `json:"price_at_add"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+type exportWishList struct {
+	ID        uuid.UUID            `json:"id"`
+	Name      string               `json:"name"`
+	IsDefault bool                 `json:"is_default"`
+	Items     []exportWishListItem `json:"items"`
+}
+
+type exportStockSubscription struct {
+	ProductID   uuid.UUID  `json:"product_id"`
+	ProductName string     `json:"product_name"`
+	Email       string     `json:"email"`
+	NotifiedAt  *time.Time `json:"notified_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ExportUserData returns reviews.json, wishlists.json and stock_subscriptions.json of the user
+func (is *InventoryServer) ExportUserData(ctx context.Context, req *inventorypb.ExportUserDataRequest) (*inventorypb.ExportUserDataResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	reviews, err := is.DB.GetReviewsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching reviews in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching reviews")
+	}
+	exportReviews := []exportReview{}
+	for _, r := range reviews {
+		e := exportReview{
+			ID:               r.ID,
+			ProductID:        r.ProductID,
+			ProductName:      r.ProductName,
+			Rating:           r.Rating,
+			IsDeleted:        r.IsDeleted,
+			IsEdited:         r.IsEdited,
+			IsVerified:       r.IsVerified,
+			ModerationStatus: r.ModerationStatus,
+			CreatedAt:        r.CreatedAt,
+			UpdatedAt:        r.UpdatedAt,
+		}
+		if r.Comment.Valid {
+			e.Comment = &r.Comment.String
+		}
+		exportReviews = append(exportReviews, e)
+	}
+
+	items, err := is.DB.GetWishListItemsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching wishlists in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching wishlists")
+	}
+	// items come ordered by list so each list is one run of items
+	exportLists := []exportWishList{}
+	for _, item := range items {
+		if len(exportLists) == 0 || exportLists[len(exportLists)-1].ID != item.ListID {
+			exportLists = append(exportLists, exportWishList{
+				ID:        item.ListID,
+				Name:      item.ListName,
+				IsDefault: item.IsDefault,
+				Items:     []exportWishListItem{},
+			})
+		}
+		list := &exportLists[len(exportLists)-1]
+		list.Items = append(list.Items, exportWishListItem{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			PriceAtAdd:  item.PriceAtAdd,
+			AddedAt:     item.CreatedAt,
+		})
+	}
+
+	subs, err := is.DB.GetStockSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching stock subscriptions in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching stock subscriptions")
+	}
+	exportSubs := []exportStockSubscription{}
+	for _, s := range subs {
+		e := exportStockSubscription{
+			ProductID:   s.ProductID,
+			ProductName: s.ProductName,
+			Email:       s.Email,
+			CreatedAt:   s.CreatedAt,
+		}
+		if s.NotifiedAt.Valid {
+			e.NotifiedAt = &s.NotifiedAt.Time
+		}
+		exportSubs = append(exportSubs, e)
+	}
+
+	var resp inventorypb.ExportUserDataResponse
+	for _, f := range []struct {
+		name string
+		data any
+	}{
+		{"reviews.json", exportReviews},
+		{"wishlists.json", exportLists},
+		{"stock_subscriptions.json", exportSubs},
+	} {
+		b, err := json.MarshalIndent(f.data, "", "  ")
+		if err != nil {
+			log.Error("error encoding "+f.name+" in grpc ExportUserData:", err.Error())
+			return nil, status.Error(codes.Internal, "internal error encoding export")
+		}
+		resp.Files = append(resp.Files, &inventorypb.ExportFile{Name: f.name, Data: b})
+	}
+	return &resp, nil
+}
+
+// EraseUserData drops the wishlists, stock subscriptions and review flags of the user
+// and the text and images of their reviews. ratings are kept, running it again is a no-op
+func (is *InventoryServer) EraseUserData(ctx context.Context, req *inventorypb.EraseUserDataRequest) (*inventorypb.EraseUserDataResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("error starting transaction in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	defer tx.Rollback()
+	qtx := is.DB.WithTx(tx)
+	images, err := qtx.DeleteReviewImagesByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error deleting review images in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = qtx.EraseReviewsByUserID(ctx, userID); err != nil {
+		log.Error("error erasing reviews in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = qtx.DeleteReviewFlagsByUserID(ctx, userID); err != nil {
+		log.Error("error deleting review flags in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = qtx.DeleteWishListsByUserID(ctx, userID); err != nil {
+		log.Error("error deleting wishlists in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = qtx.DeleteStockSubscriptionsByUserID(ctx, userID); err != nil {
+		log.Error("error deleting stock subscriptions in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = tx.Commit(); err != nil {
+		log.Error("error committing in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+
+	// the rows are gone so a file left behind here is only an orphan in storage
+	for _, img := range images {
+		if err = store.Delete(ctx, img.ImageKey); err != nil {
+			log.Warn("error deleting review image from storage in grpc EraseUserData:", err.Error())
+		}
+		if err = store.Delete(ctx, img.ThumbnailKey); err != nil {
+			log.Warn("error deleting review thumbnail from storage in grpc EraseUserData:", err.Error())
+		}
+	}
+	return &inventorypb.EraseUserDataResponse{}, nil
+}
+
+// CheckDelivery answers per product whether its seller ships to the pincode and by when,
+// with the shipping rules of the sellers so checkout can charge shipping.
+// unknown or deleted products come back undeliverable rather than failing the call
+func (is *InventoryServer) CheckDelivery(ctx context.Context, req *inventorypb.CheckDeliveryRequest) (*inventorypb.CheckDeliveryResponse, error) {
+	if !validators.ValidatePincode(int(req.GetPincode())) {
+		return nil, status.Error(codes.InvalidArgument, "invalid pincode")
+	}
+	// a cart often has several products of one seller
+	checks := make(map[uuid.UUID]deliveryCheck)
+	rules := make(map[uuid.UUID]bool)
+	var resp inventorypb.CheckDeliveryResponse
+	for _, idStr := range req.GetProductIds() {
+		productID, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid product id "+idStr)
+		}
+		estimate := &inventorypb.DeliveryEstimate{ProductId: idStr}
+		resp.Estimates = append(resp.Estimates, estimate)
+		product, err := is.DB.GetProductByID(ctx, productID)
+		if err == sql.ErrNoRows || (err == nil && product.IsDeleted) {
+			estimate.Reason = "the product is no longer available"
+			continue
+		} else if err != nil {
+			log.Error("error fetching product in grpc CheckDelivery:", err.Error())
+			return nil, status.Error(codes.Internal, "internal error fetching product")
+		}
+		estimate.SellerId = product.SellerID.String()
+		estimate.WeightGrams = product.WeightGrams
+		check, ok := checks[product.SellerID]
+		if !ok {
+			check, err = checkDelivery(ctx, is.DB, product.SellerID, req.GetPincode())
+			if err != nil {
+				log.Error("error checking delivery in grpc CheckDelivery:", err.Error())
+				return nil, status.Error(codes.Internal, "internal error checking delivery")
+			}
+			checks[product.SellerID] = check
+		}
+		estimate.Deliverable = check.Deliverable
+		estimate.Reason = check.Reason
+		if check.Deliverable {
+			estimate.Zone = string(check.Zone)
+			estimate.HandlingDays = int32(check.HandlingDays)
+			estimate.TransitDays = int32(check.TransitDays)
+			estimate.EstimatedDate = timestamppb.New(check.EstimatedDate)
+		}
+		if check.Deliverable && !rules[product.SellerID] {
+			rule, err := sellerShippingRule(is.DB, product.SellerID)
+			if err != nil {
+				log.Error("error fetching shipping rule in grpc CheckDelivery:", err.Error())
+				return nil, status.Error(codes.Internal, "internal error fetching shipping rule")
+			}
+			rules[product.SellerID] = true
+			resp.ShippingRules = append(resp.ShippingRules, &inventorypb.ShippingRule{
+				SellerId:  product.SellerID.String(),
+				Rule:      rule.Kind,
+				FlatFee:   rule.FlatFee,
+				FreeAbove: rule.FreeAbove,
+			})
+		}
+	}
+	return &resp, nil
+}
@@ -9,37 +9,73 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	db "inventory_service/db/sqlc"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/grpcclient"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/helpers"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/impersonation"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
 	middleware "github.com/amankhys/multi_vendor_ecommerce_go/pkg/middlewares"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/paymentpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/userpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/storage"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
 	"github.com/amankhys/multi_vendor_ecommerce_go/repository"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var dbConn = repository.NewDBConfig("user")
 var DB = db.New(dbConn)
+var auditLog = audit.New(dbConn, "inventory_audit_logs")
 var u = User{DB: DB}
 var helper = helpers.Helper{
 	DB: DB,
 }
+var paymentClient = grpcclient.NewPaymentClient()
+var userClient = grpcclient.NewUserClient()
 
 func RegisterRoutes(mux *http.ServeMux) {
+	// requests made while an admin impersonates someone are checked and recorded
+	middleware.SetImpersonationRecorder(impersonation.NewRecorder(dbConn, "inventory_service"))
+	// mails are queued and sent by the outbox worker of the user service
+	mail.SetOutbox(mail.NewOutbox(dbConn))
+
 	// user side
 	mux.HandleFunc("GET /user/products", u.ProductsHandler)
 	mux.HandleFunc("GET /user/product", u.ProductHandler)
+	mux.HandleFunc("GET /user/product/delivery", u.ProductDeliveryHandler)
 	mux.HandleFunc("POST /user/product/review", middleware.AuthenticateUserMiddleware(u.AddProductReviewHandler, utils.UserRole))
+	mux.HandleFunc("PUT /user/product/review/edit", middleware.AuthenticateUserMiddleware(u.EditProductReviewHandler, utils.UserRole))
+	mux.HandleFunc("DELETE /user/product/review/delete", middleware.AuthenticateUserMiddleware(u.DeleteProductReviewHandler, utils.UserRole))
+	mux.HandleFunc("POST /user/product/review/images", middleware.AuthenticateUserMiddleware(u.AddReviewImagesHandler, utils.UserRole))
+	mux.HandleFunc("POST /user/product/review/flag", middleware.AuthenticateUserMiddleware(u.FlagReviewHandler, utils.UserRole))
 	mux.HandleFunc("GET /user/category", u.CategoryHandler)
+	mux.HandleFunc("GET /user/seller", u.SellerStorefrontHandler)
+	mux.HandleFunc("GET /user/seller/products", u.SellerStorefrontProductsHandler)
+	mux.HandleFunc("GET /user/categories/tree", u.CategoryTreeHandler)
+	mux.HandleFunc("GET /user/category/attributes", u.CategoryAttributesHandler)
+	mux.HandleFunc("POST /user/product/notify", middleware.AuthenticateUserMiddleware(u.AddStockSubscriptionHandler, utils.UserRole))
+	mux.HandleFunc("DELETE /user/product/notify", middleware.AuthenticateUserMiddleware(u.RemoveStockSubscriptionHandler, utils.UserRole))
 	mux.HandleFunc("GET /user/wishlist", middleware.AuthenticateUserMiddleware(u.GetWishListHandler, utils.UserRole))
 	mux.HandleFunc("POST /user/wishlist/add", middleware.AuthenticateUserMiddleware(u.AddProductToWishListHandler, utils.UserRole))
 	mux.HandleFunc("DELETE /user/wishlist/item/delete", middleware.AuthenticateUserMiddleware(u.RemoveWishListItemHandler, utils.UserRole))
 	mux.HandleFunc("DELETE /user/wishlist/delete", middleware.AuthenticateUserMiddleware(u.RemoveAllWishListHandler, utils.UserRole))
 	mux.HandleFunc("POST /user/wishlist/add_to_cart", middleware.AuthenticateUserMiddleware(u.AddWishListToCartHandler, utils.UserRole))
+	mux.HandleFunc("GET /user/wishlists", middleware.AuthenticateUserMiddleware(u.GetWishListsHandler, utils.UserRole))
+	mux.HandleFunc("POST /user/wishlists/add", middleware.AuthenticateUserMiddleware(u.AddWishListHandler, utils.UserRole))
+	mux.HandleFunc("PUT /user/wishlists/edit", middleware.AuthenticateUserMiddleware(u.EditWishListHandler, utils.UserRole))
+	mux.HandleFunc("DELETE /user/wishlists/delete", middleware.AuthenticateUserMiddleware(u.DeleteWishListHandler, utils.UserRole))
+	mux.HandleFunc("POST /user/wishlists/share", middleware.AuthenticateUserMiddleware(u.ShareWishListHandler, utils.UserRole))
+	mux.HandleFunc("DELETE /user/wishlists/share", middleware.AuthenticateUserMiddleware(u.UnshareWishListHandler, utils.UserRole))
+	mux.HandleFunc("GET /user/wishlist/shared", u.SharedWishListHandler)
 
 	// seller side
 	s := &Seller{DB: DB}
@@ -48,130 +84,343 @@ func RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /seller/product/add", middleware.AuthenticateUserMiddleware(s.AddProductHandler, utils.SellerRole))
 	mux.HandleFunc("PUT /seller/product/edit", middleware.AuthenticateUserMiddleware(s.EditProductHandler, utils.SellerRole))
 	mux.HandleFunc("DELETE /seller/product/delete", middleware.AuthenticateUserMiddleware(s.DeleteProductHandler, utils.SellerRole))
+	mux.HandleFunc("POST /seller/product/images/add", middleware.AuthenticateUserMiddleware(s.AddProductImagesHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/product/images/reorder", middleware.AuthenticateUserMiddleware(s.ReorderProductImagesHandler, utils.SellerRole))
+	mux.HandleFunc("DELETE /seller/product/image/delete", middleware.AuthenticateUserMiddleware(s.DeleteProductImageHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/product/attributes", middleware.AuthenticateUserMiddleware(s.SetProductAttributesHandler, utils.SellerRole))
+	mux.HandleFunc("POST /seller/products/import", middleware.AuthenticateUserMiddleware(s.ImportProductsHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/products/import/status", middleware.AuthenticateUserMiddleware(s.ImportStatusHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/products/export", middleware.AuthenticateUserMiddleware(s.ExportProductsHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/stock", middleware.AuthenticateUserMiddleware(s.StockDashboardHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/stock/alerts/read", middleware.AuthenticateUserMiddleware(s.MarkStockAlertsReadHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/storefront", middleware.AuthenticateUserMiddleware(s.GetStorefrontHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/storefront/edit", middleware.AuthenticateUserMiddleware(s.EditStorefrontHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/serviceability", middleware.AuthenticateUserMiddleware(s.ServiceabilityHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/serviceability/edit", middleware.AuthenticateUserMiddleware(s.EditServiceabilityHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/shipping", middleware.AuthenticateUserMiddleware(s.ShippingRuleHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/shipping/edit", middleware.AuthenticateUserMiddleware(s.EditShippingRuleHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/product/threshold", middleware.AuthenticateUserMiddleware(s.EditLowStockThresholdHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/product/weight", middleware.AuthenticateUserMiddleware(s.EditProductWeightHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/product/prices", middleware.AuthenticateUserMiddleware(s.ProductPricesHandler, utils.SellerRole))
+	mux.HandleFunc("POST /seller/product/sale", middleware.AuthenticateUserMiddleware(s.AddProductSaleHandler, utils.SellerRole))
+	mux.HandleFunc("DELETE /seller/product/sale", middleware.AuthenticateUserMiddleware(s.CancelProductSaleHandler, utils.SellerRole))
+	mux.HandleFunc("PUT /seller/product/mrp", middleware.AuthenticateUserMiddleware(s.EditProductMRPHandler, utils.SellerRole))
 
 	mux.HandleFunc("GET /seller/categories", middleware.AuthenticateUserMiddleware(s.GetAllCategoriesHandler, utils.SellerRole))
 	mux.HandleFunc("POST /seller/category/add", middleware.AuthenticateUserMiddleware(s.AddProductToCategoryHandler, utils.SellerRole))
 
 	// admin side
 	a := &Admin{DB: DB}
-	mux.HandleFunc("GET /admin/products", middleware.AuthenticateUserMiddleware(a.AdminProductsHandler, utils.AdminRole))
-	mux.HandleFunc("DELETE /admin/product/delete", middleware.AuthenticateUserMiddleware(a.DeleteProductHandler, utils.AdminRole))
+	mux.HandleFunc("GET /admin/products", middleware.AuthorizeMiddleware(a.AdminProductsHandler, utils.PermProductsModerate))
+	mux.HandleFunc("DELETE /admin/product/delete", middleware.AuthorizeMiddleware(a.DeleteProductHandler, utils.PermProductsModerate))
+
+	mux.HandleFunc("GET /admin/reviews/flagged", middleware.AuthorizeMiddleware(a.FlaggedReviewsHandler, utils.PermReviewsModerate))
+	mux.HandleFunc("PUT /admin/review/moderate", middleware.AuthorizeMiddleware(a.ModerateReviewHandler, utils.PermReviewsModerate))
 
-	mux.HandleFunc("GET /admin/categories", middleware.AuthenticateUserMiddleware(a.AdminCategoriesHandler, utils.AdminRole))
-	mux.HandleFunc("POST /admin/category/add", middleware.AuthenticateUserMiddleware(a.AddCategoryHandler, utils.AdminRole))
-	mux.HandleFunc("PUT /admin/category/edit", middleware.AuthenticateUserMiddleware(a.EditCategoryHandler, utils.AdminRole))
-	mux.HandleFunc("DELETE /admin/category/delete", middleware.AuthenticateUserMiddleware(a.DeleteCategoryHandler, utils.AdminRole))
+	mux.HandleFunc("GET /admin/categories", middleware.AuthorizeMiddleware(a.AdminCategoriesHandler, utils.PermCategoriesWrite))
+	mux.HandleFunc("POST /admin/category/add", middleware.AuthorizeMiddleware(a.AddCategoryHandler, utils.PermCategoriesWrite))
+	mux.HandleFunc("PUT /admin/category/edit", middleware.AuthorizeMiddleware(a.EditCategoryHandler, utils.PermCategoriesWrite))
+	mux.HandleFunc("DELETE /admin/category/delete", middleware.AuthorizeMiddleware(a.DeleteCategoryHandler, utils.PermCategoriesWrite))
+	mux.HandleFunc("PUT /admin/category/parent", middleware.AuthorizeMiddleware(a.EditCategoryParentHandler, utils.PermCategoriesWrite))
+	mux.HandleFunc("POST /admin/category/attribute/add", middleware.AuthorizeMiddleware(a.AddCategoryAttributeHandler, utils.PermCategoriesWrite))
+	mux.HandleFunc("DELETE /admin/category/attribute/delete", middleware.AuthorizeMiddleware(a.DeleteCategoryAttributeHandler, utils.PermCategoriesWrite))
 
+	mux.HandleFunc("GET /admin/audit", middleware.AuthorizeMiddleware(auditLog.Handler(), utils.PermAuditRead))
+
+	// uploaded files are served by the service itself only on local storage
+	if local, ok := store.(*storage.Local); ok {
+		mux.Handle("GET "+local.BaseURL+"/", local.Handler())
+	}
 }
 
 type User struct{ DB *db.Queries }
 
+// sort options for the product listing
+const (
+	sortRelevance   = "relevance"
+	sortNewest      = "newest"
+	sortPriceAsc    = "price_asc"
+	sortPriceDesc   = "price_desc"
+	sortRating      = "rating"
+	sortBestSelling = "best_selling"
+)
+
+const defaultPageLimit = 20
+const maxPageLimit = 100
+
+// pages past this are clamped so the offset can't overflow int32
+const maxPage = 10000
+
+type respPagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"total_pages"`
+	HasNext    bool  `json:"has_next"`
+}
+
+// searchProducts runs the product search and returns the total match count
+// along with the page. The count comes from the rows, so for a page past the
+// end the first page is fetched just to read it
+func searchProducts(q *db.Queries, arg db.SearchProductsParams) ([]db.SearchProductsRow, int64, error) {
+	products, err := q.SearchProducts(context.TODO(), arg)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(products) > 0 {
+		return products, products[0].TotalCount, nil
+	}
+	if arg.PageOffset == 0 {
+		return products, 0, nil
+	}
+	arg.PageLimit, arg.PageOffset = 1, 0
+	first, err := q.SearchProducts(context.TODO(), arg)
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	if len(first) > 0 {
+		total = first[0].TotalCount
+	}
+	return products, total, nil
+}
+
+// bounds of the price facet buckets, last bucket is open ended
+var priceBucketBounds = []float64{500, 1000, 2500, 5000, 10000}
+
 func (u *User) ProductsHandler(w http.ResponseWriter, r *http.Request) {
 	// take request value params
 	var req struct {
 		PriceMaxStr string   `json:"price_max"`
 		PriceMinStr string   `json:"price_min"`
 		Categories  []string `json:"categories"`
-		Name        string   `json:"name"`
+		Query       string   `json:"q"`
+		Sort        string   `json:"sort"`
+		PageStr     string   `json:"page"`
+		LimitStr    string   `json:"limit"`
+		SellerIDStr string   `json:"seller_id"`
 	}
 	req.PriceMaxStr = r.URL.Query().Get("price_max")
 	req.PriceMinStr = r.URL.Query().Get("price_min")
 	req.Categories = r.URL.Query()["categories"]
-	req.Name = r.URL.Query().Get("name")
+	req.Query = r.URL.Query().Get("q")
+	if req.Query == "" {
+		// older clients search with name
+		req.Query = r.URL.Query().Get("name")
+	}
+	req.Sort = r.URL.Query().Get("sort")
+	req.PageStr = r.URL.Query().Get("page")
+	req.LimitStr = r.URL.Query().Get("limit")
+	req.SellerIDStr = r.URL.Query().Get("seller_id")
 
 	// take valid values out of request
-	PriceMax, err := strconv.Atoi(req.PriceMaxStr)
+	PriceMax, err := strconv.ParseFloat(req.PriceMaxStr, 64)
 	if err != nil || PriceMax < 0 {
-		PriceMax = math.MaxInt
+		PriceMax = math.MaxInt32
 	}
-	PriceMin, err := strconv.Atoi(req.PriceMinStr)
+	PriceMin, err := strconv.ParseFloat(req.PriceMinStr, 64)
 	if err != nil || PriceMin < 0 {
 		PriceMin = 0
 	}
-	var Categories []string
+	var Categories = []string{}
 	for _, v := range req.Categories {
-		category, err := u.DB.GetCategoryByName(context.TODO(), v)
-		if err == sql.ErrNoRows {
-			continue
-		} else if err != nil {
-			log.Warn("error fetching categoryByName in ProductsHandler in user:", err.Error())
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v != "" {
+			Categories = append(Categories, v)
+		}
+	}
+	Query := strings.TrimSpace(req.Query)
+	// optional, narrows the listing and the facets to one seller
+	var SellerID uuid.NullUUID
+	if req.SellerIDStr != "" {
+		id, err := uuid.Parse(req.SellerIDStr)
+		if err != nil {
+			http.Error(w, "invalid seller id", http.StatusBadRequest)
+			return
+		}
+		SellerID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	// attribute filters come as attr_<name>=<value>, eg. attr_material=wood
+	var Attributes = []string{}
+	for key, values := range r.URL.Query() {
+		name, ok := strings.CutPrefix(key, "attr_")
+		if !ok || !validators.ValidateAttributeName(name) {
 			continue
 		}
-		Categories = append(Categories, category.Name)
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				Attributes = append(Attributes, name+"="+v)
+			}
+		}
+	}
+	Sort := req.Sort
+	switch Sort {
+	case sortRelevance, sortNewest, sortPriceAsc, sortPriceDesc, sortRating, sortBestSelling:
+	default:
+		Sort = sortNewest
+		if Query != "" {
+			Sort = sortRelevance
+		}
 	}
-	// take all products
-	allProducts, err := u.DB.GetAllProducts(context.TODO())
+	Page, err := strconv.Atoi(req.PageStr)
+	if err != nil || Page < 1 {
+		Page = 1
+	} else if Page > maxPage {
+		Page = maxPage
+	}
+	Limit, err := strconv.Atoi(req.LimitStr)
+	if err != nil || Limit < 1 {
+		Limit = defaultPageLimit
+	} else if Limit > maxPageLimit {
+		Limit = maxPageLimit
+	}
+
+	// filtered, sorted and paginated products
+	products, total, err := searchProducts(u.DB, db.SearchProductsParams{
+		Query:      Query,
+		SellerID:   SellerID,
+		PriceMin:   PriceMin,
+		PriceMax:   PriceMax,
+		Categories: Categories,
+		Attributes: Attributes,
+		Sort:       Sort,
+		PageLimit:  int32(Limit),
+		PageOffset: int32((Page - 1) * Limit),
+	})
 	if err != nil {
-		log.Warn("error fetching products in ProductsHandler in user:", err.Error())
+		log.Warn("error searching products in ProductsHandler in user:", err.Error())
 		http.Error(w, "internal server error fetching products", http.StatusInternalServerError)
 		return
 	}
 
-	// name filter by either name or description
-	var nameFilterProducts []db.Product
-	if len(req.Name) > 0 {
-		for _, v := range allProducts {
-			if utils.FilterName(req.Name, v.Name+v.Description) {
-				nameFilterProducts = append(nameFilterProducts, v)
-			}
-		}
-	} else {
-		nameFilterProducts = allProducts
-	}
-
-	// take category filtered products
-	var filteredProducts []db.Product
-	if len(Categories) > 0 {
-		for _, v := range nameFilterProducts {
-			vCats, err := u.DB.GetCategoryNamesOfProductByID(context.TODO(), v.ID)
-			if err != nil {
-				log.Warn("error fetching category names of product by id in ProductsHandler for user")
-				continue
-			} else if utils.CheckCategory(vCats, Categories) {
-				filteredProducts = append(filteredProducts, v)
-			}
-		}
-	} else {
-		filteredProducts = nameFilterProducts
+	// facets, a failure here should not fail the listing
+	var Err []string
+	categoryFacets, err := u.DB.SearchProductCategoryFacets(context.TODO(), db.SearchProductCategoryFacetsParams{
+		Query:      Query,
+		SellerID:   SellerID,
+		PriceMin:   PriceMin,
+		PriceMax:   PriceMax,
+		Attributes: Attributes,
+	})
+	if err != nil {
+		log.Warn("error fetching category facets in ProductsHandler in user:", err.Error())
+		Err = append(Err, "error fetching category facets")
+	}
+	priceFacets, err := u.DB.SearchProductPriceFacets(context.TODO(), db.SearchProductPriceFacetsParams{
+		Query:      Query,
+		SellerID:   SellerID,
+		Categories: Categories,
+		Attributes: Attributes,
+		Bounds:     priceBucketBounds,
+	})
+	if err != nil {
+		log.Warn("error fetching price facets in ProductsHandler in user:", err.Error())
+		Err = append(Err, "error fetching price facets")
 	}
 
-	// filter products by price
-	var finalProducts []db.Product
-	for _, v := range filteredProducts {
-		if v.Price <= float64(PriceMax) && v.Price >= float64(PriceMin) {
-			finalProducts = append(finalProducts, v)
-		}
+	// images of all the products in a single query
+	var productIDs []uuid.UUID
+	for _, v := range products {
+		productIDs = append(productIDs, v.ID)
 	}
+	imagesMap := productImagesByIDs(u.DB, productIDs)
+	pricingMap := productPricingByIDs(u.DB, productIDs)
 
 	// make response product struct
 	type respProduct struct {
-		ID          uuid.UUID `json:"id"`
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Price       float64   `json:"price"`
-		Stock       int       `json:"stock"`
-		SellerID    uuid.UUID `json:"seller_id"`
-	}
-	var respProducts []respProduct
-	for _, v := range finalProducts {
+		ID            uuid.UUID   `json:"id"`
+		Name          string      `json:"name"`
+		Description   string      `json:"description"`
+		Price         float64     `json:"price"`
+		WasPrice      *float64    `json:"was_price"`
+		SaleEndsAt    *time.Time  `json:"sale_ends_at"`
+		Stock         int         `json:"stock"`
+		Available     bool        `json:"available"`
+		SellerID      uuid.UUID   `json:"seller_id"`
+		AverageRating float64     `json:"average_rating"`
+		RatingCount   int         `json:"rating_count"`
+		Images        []respImage `json:"images"`
+	}
+	var respProducts = []respProduct{}
+	for _, v := range products {
 		var temp respProduct
 		temp.ID = v.ID
 		temp.Name = v.Name
 		temp.Description = v.Description
 		temp.Price = v.Price
+		if pricing, ok := pricingMap[v.ID]; ok {
+			temp.WasPrice = pricing.WasPrice
+			temp.SaleEndsAt = pricing.SaleEndsAt
+		}
 		temp.Stock = int(v.Stock)
+		temp.Available = v.Stock > 0
 		temp.SellerID = v.SellerID
+		temp.AverageRating = math.Round(v.AverageRating*10) / 10
+		temp.RatingCount = int(v.RatingCount)
+		temp.Images = imagesMap[v.ID]
+		if temp.Images == nil {
+			temp.Images = []respImage{}
+		}
 
 		respProducts = append(respProducts, temp)
 	}
 
+	type respCategoryFacet struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	var respCategoryFacets = []respCategoryFacet{}
+	for _, v := range categoryFacets {
+		respCategoryFacets = append(respCategoryFacets, respCategoryFacet{Name: v.Name, Count: int(v.ProductCount)})
+	}
+
+	// width_bucket gives 0 for below the first bound and len(bounds) for the open ended last bucket
+	type respPriceFacet struct {
+		Min   float64  `json:"min"`
+		Max   *float64 `json:"max"`
+		Count int      `json:"count"`
+	}
+	var respPriceFacets = []respPriceFacet{}
+	for _, v := range priceFacets {
+		var temp respPriceFacet
+		b := int(v.Bucket)
+		if b > 0 {
+			temp.Min = priceBucketBounds[b-1]
+		}
+		if b < len(priceBucketBounds) {
+			temp.Max = &priceBucketBounds[b]
+		}
+		temp.Count = int(v.ProductCount)
+		respPriceFacets = append(respPriceFacets, temp)
+	}
+
+	var pagination = respPagination{
+		Page:       Page,
+		Limit:      Limit,
+		Total:      total,
+		TotalPages: int((total + int64(Limit) - 1) / int64(Limit)),
+		HasNext:    int64(Page*Limit) < total,
+	}
+
 	// send response
 	var resp struct {
-		Data    []respProduct `json:"data"`
-		Message string        `json:"message"`
+		Data       []respProduct  `json:"data"`
+		Pagination respPagination `json:"pagination"`
+		Facets     struct {
+			Categories []respCategoryFacet `json:"categories"`
+			Prices     []respPriceFacet    `json:"prices"`
+		} `json:"facets"`
+		Sort    string   `json:"sort"`
+		Message string   `json:"message"`
+		Err     []string `json:"errors"`
 	}
 	w.Header().Set("Content-Type", "application/json")
 	resp.Data = respProducts
+	resp.Pagination = pagination
+	resp.Facets.Categories = respCategoryFacets
+	resp.Facets.Prices = respPriceFacets
+	resp.Sort = Sort
+	resp.Err = Err
 	resp.Message = "successfully fetched filtered products"
 	json.NewEncoder(w).Encode(resp)
 }
@@ -196,12 +445,18 @@ func (u *User) ProductHandler(w http.ResponseWriter, r *http.Request) {
 	var averageRating float64
 	var totalRating int
 	type respReview struct {
-		ReviewID uuid.UUID      `json:"review_id"`
-		Rating   int            `json:"rating"`
-		Comment  sql.NullString `json:"comment"`
+		ReviewID   uuid.UUID         `json:"review_id"`
+		Rating     int               `json:"rating"`
+		Comment    sql.NullString    `json:"comment"`
+		IsVerified bool              `json:"is_verified"`
+		IsEdited   bool              `json:"is_edited"`
+		Images     []respReviewImage `json:"images"`
+		CreatedAt  time.Time         `json:"created_at"`
 	}
 	var respReviews []respReview
-	if err != nil {
+	// counts of 1 to 5 star ratings, keyed by the rating
+	var histogram = map[int]int64{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}
+	if err != nil || len(reviews) == 0 {
 		Messages = append(Messages, "no reveiws added for this product as of yet")
 	} else {
 		result, err := u.DB.GetProductAverageRatingAndTotalRating(context.TODO(), product.ID)
@@ -211,33 +466,89 @@ func (u *User) ProductHandler(w http.ResponseWriter, r *http.Request) {
 		averageRating = result.AverageRating
 		totalRating = int(result.TotalRating)
 
+		counts, err := u.DB.GetProductRatingHistogram(context.TODO(), product.ID)
+		if err != nil {
+			Err = append(Err, "error fetching rating histogram for the product")
+		}
+		for _, c := range counts {
+			histogram[int(c.Rating)] = c.Count
+		}
+
+		var reviewIDs []uuid.UUID
+		for _, r := range reviews {
+			reviewIDs = append(reviewIDs, r.ID)
+		}
+		reviewImages := reviewImagesByIDs(u.DB, reviewIDs)
 		for _, r := range reviews {
 			var temp respReview
 			temp.ReviewID = r.ID
 			temp.Rating = int(r.Rating)
 			temp.Comment = r.Comment
+			temp.IsVerified = r.IsVerified
+			temp.IsEdited = r.IsEdited
+			temp.Images = reviewImages[r.ID]
+			temp.CreatedAt = r.CreatedAt
 			respReviews = append(respReviews, temp)
 		}
 	}
 
+	// seller name is looked up in the user service, the product is still shown without it
+	type respSeller struct {
+		ID   uuid.UUID `json:"id"`
+		Name string    `json:"name"`
+	}
+	var seller = respSeller{ID: product.SellerID}
+	sellerRes, err := userClient.GetSellerByID(context.TODO(), &userpb.GetSellerByIDRequest{SellerID: product.SellerID.String()})
+	if err != nil {
+		log.Warn("error fetching seller in ProductHandler:", err.Error())
+		Err = append(Err, "error fetching seller of the product")
+	} else {
+		seller.Name = sellerRes.Name
+	}
+
+	pricing := respPricing{Price: product.Price}
+	pricingRow, err := u.DB.GetProductPricing(context.TODO(), product.ID)
+	if err != nil {
+		log.Warn("error fetching product pricing in ProductHandler:", err.Error())
+		Err = append(Err, "error fetching current price of the product")
+	} else {
+		pricing = toRespPricing(pricingRow.RegularPrice, pricingRow.Mrp, pricingRow.EffectivePrice, pricingRow.SaleEndsAt)
+	}
+
 	var resp struct {
-		ProductID     uuid.UUID       `json:"product_id"`
-		Name          string          `json:"name"`
-		Price         float64         `json:"price"`
-		AverageRating sql.NullFloat64 `json:"average_rating"`
-		RatingCount   int             `json:"rating_count"`
-		Reviews       []respReview    `json:"reviews"`
-		Err           []string        `json:"errors"`
-		Messages      []string        `json:"messages"`
+		ProductID     uuid.UUID            `json:"product_id"`
+		Name          string               `json:"name"`
+		Price         float64              `json:"price"`
+		WasPrice      *float64             `json:"was_price"`
+		SaleEndsAt    *time.Time           `json:"sale_ends_at"`
+		Stock         int                  `json:"stock"`
+		Available     bool                 `json:"available"`
+		Seller        respSeller           `json:"seller"`
+		Images        []respImage          `json:"images"`
+		Attributes    []respAttributeValue `json:"attributes"`
+		AverageRating sql.NullFloat64      `json:"average_rating"`
+		RatingCount   int                  `json:"rating_count"`
+		Histogram     map[int]int64        `json:"rating_histogram"`
+		Reviews       []respReview         `json:"reviews"`
+		Err           []string             `json:"errors"`
+		Messages      []string             `json:"messages"`
 	}
 	resp.ProductID = product.ID
 	resp.Name = product.Name
-	resp.Price = product.Price
+	resp.Price = pricing.Price
+	resp.WasPrice = pricing.WasPrice
+	resp.SaleEndsAt = pricing.SaleEndsAt
+	resp.Stock = int(product.Stock)
+	resp.Available = product.Stock > 0
+	resp.Seller = seller
+	resp.Images = productImages(u.DB, product.ID)
+	resp.Attributes = productAttributeValues(u.DB, product.ID)
 	if averageRating != 0 {
 		resp.AverageRating.Float64 = averageRating
 		resp.AverageRating.Valid = true
 	}
 	resp.RatingCount = totalRating
+	resp.Histogram = histogram
 	resp.Reviews = respReviews
 	resp.Err = Err
 	resp.Messages = Messages
@@ -269,11 +580,12 @@ func (u *User) AddProductReviewHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "user already added review. Kindly Edit review if further changes are to be done.", http.StatusBadRequest)
 		return
 	}
-	_, err = u.DB.GetOrderItemByUserAndProductID(context.TODO(), db.GetOrderItemByUserAndProductIDParams{
-		UserID:    user.ID,
-		ProductID: productID,
+	// only users with a delivered order item of the product can review it
+	_, err = paymentClient.GetOrderItemByUserAndProductID(context.TODO(), &paymentpb.GetOrderItemByUserAndProductIDRequest{
+		UserID:    user.ID.String(),
+		ProductID: productID.String(),
 	})
-	if err == sql.ErrNoRows {
+	if status.Code(err) == codes.NotFound {
 		http.Error(w, "cannot add rating to unpurchased item", http.StatusBadRequest)
 		return
 	} else if err != nil {
@@ -289,9 +601,10 @@ func (u *User) AddProductReviewHandler(w http.ResponseWriter, r *http.Request) {
 	rating, _ := strconv.Atoi(ratingStr)
 	if len(comment) == 0 {
 		review, err = u.DB.AddProductReviewWithoutComment(context.TODO(), db.AddProductReviewWithoutCommentParams{
-			UserID:    user.ID,
-			ProductID: productID,
-			Rating:    int32(rating),
+			UserID:     user.ID,
+			ProductID:  productID,
+			Rating:     int32(rating),
+			IsVerified: true,
 		})
 		if err != nil {
 			log.Error("error adding review in AddProductReviewHandler:", err.Error())
@@ -307,6 +620,7 @@ func (u *User) AddProductReviewHandler(w http.ResponseWriter, r *http.Request) {
 				String: comment,
 				Valid:  true,
 			},
+			IsVerified: true,
 		})
 		if err != nil {
 			log.Error("error adding review in AddProductReviewHandler:", err.Error())
@@ -316,16 +630,18 @@ func (u *User) AddProductReviewHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var resp struct {
-		ProductID uuid.UUID      `json:"product_id"`
-		Rating    int            `json:"rating"`
-		Comment   sql.NullString `json:"comment"`
-		Message   string         `json:"message"`
-		IsEdited  bool           `json:"is_edited"`
+		ProductID  uuid.UUID      `json:"product_id"`
+		Rating     int            `json:"rating"`
+		Comment    sql.NullString `json:"comment"`
+		Message    string         `json:"message"`
+		IsEdited   bool           `json:"is_edited"`
+		IsVerified bool           `json:"is_verified"`
 	}
 	resp.ProductID = productID
 	resp.Rating = int(review.Rating)
 	resp.Comment = review.Comment
 	resp.IsEdited = review.IsEdited
+	resp.IsVerified = review.IsVerified
 	resp.Message = "successfully added review to the product"
 	w.Header().Add("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
@@ -333,7 +649,11 @@ func (u *User) AddProductReviewHandler(w http.ResponseWriter, r *http.Request) {
 
 func (u *User) CategoryHandler(w http.ResponseWriter, r *http.Request) {
 	queryParams := r.URL.Query()
-	categoryName := queryParams.Get("category_name")
+	// products of sub categories are included, the category can be given by name or slug
+	categoryName := queryParams.Get("category")
+	if categoryName == "" {
+		categoryName = queryParams.Get("category_name")
+	}
 
 	products, err := u.DB.GetProductsByCategoryName(r.Context(), categoryName)
 	if err == sql.ErrNoRows {
@@ -345,13 +665,24 @@ func (u *User) CategoryHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal error fetching products by category name", http.StatusBadRequest)
 		return
 	}
+	var productIDs []uuid.UUID
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+	}
+	imagesMap := productImagesByIDs(u.DB, productIDs)
+	pricingMap := productPricingByIDs(u.DB, productIDs)
+
 	type respProduct struct {
-		ID          uuid.UUID `json:"id"`
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Price       float64   `json:"price"`
-		Stock       int32     `json:"stock"`
-		SellerID    uuid.UUID `json:"seller_id"`
+		ID          uuid.UUID   `json:"id"`
+		Name        string      `json:"name"`
+		Description string      `json:"description"`
+		Price       float64     `json:"price"`
+		WasPrice    *float64    `json:"was_price"`
+		SaleEndsAt  *time.Time  `json:"sale_ends_at"`
+		Stock       int32       `json:"stock"`
+		Available   bool        `json:"available"`
+		SellerID    uuid.UUID   `json:"seller_id"`
+		Images      []respImage `json:"images"`
 	}
 
 	var respProductsData []respProduct
@@ -362,8 +693,19 @@ func (u *User) CategoryHandler(w http.ResponseWriter, r *http.Request) {
 		temp.Name = p.Name
 		temp.Description = p.Description
 		temp.Price = p.Price
+		// category listing reads products directly so the price is swapped for the effective one
+		if pricing, ok := pricingMap[p.ID]; ok {
+			temp.Price = pricing.Price
+			temp.WasPrice = pricing.WasPrice
+			temp.SaleEndsAt = pricing.SaleEndsAt
+		}
 		temp.Stock = p.Stock
+		temp.Available = p.Stock > 0
 		temp.SellerID = p.SellerID
+		temp.Images = imagesMap[p.ID]
+		if temp.Images == nil {
+			temp.Images = []respImage{}
+		}
 		respProductsData = append(respProductsData, temp)
 	}
 
@@ -382,32 +724,24 @@ func (u *User) GetWishListHandler(w http.ResponseWriter, r *http.Request) {
 	if user.ID == uuid.Nil {
 		return
 	}
-	wishListItems, err := u.DB.GetAllWishListItemsWithProductNameByUserID(context.TODO(), user.ID)
+	list, ok := u.wishList(w, user.ID, r.URL.Query().Get("list_id"))
+	if !ok {
+		return
+	}
+	wishListItems, err := u.DB.GetWishListItemsByListID(context.TODO(), list.ID)
 	if err != nil {
 		log.Error("error fetching wishlist items in GetWishListHandler:", err.Error())
 		http.Error(w, "internal error fetching wishList items", http.StatusInternalServerError)
 		return
 	}
 
-	type respWItem struct {
-		ID          uuid.UUID `json:"id"`
-		ProductID   uuid.UUID `json:"product_id"`
-		ProductName string    `json:"product_name"`
-	}
-	var respWItems []respWItem
-	for _, w := range wishListItems {
-		var temp respWItem
-		temp.ID = w.ID
-		temp.ProductID = w.ProductID
-		temp.ProductName = w.ProductName
-		respWItems = append(respWItems, temp)
-	}
-
 	var resp struct {
-		Data    []respWItem `json:"data"`
-		Message string      `json:"message"`
+		List    respWishList       `json:"list"`
+		Data    []respWishListItem `json:"data"`
+		Message string             `json:"message"`
 	}
-	resp.Data = respWItems
+	resp.List = toRespWishList(list, int64(len(wishListItems)))
+	resp.Data = toRespWishListItems(wishListItems)
 	resp.Message = "successfully fetched wishlist items"
 	w.Header().Add("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
@@ -433,10 +767,14 @@ func (u *User) AddProductToWishListHandler(w http.ResponseWriter, r *http.Reques
 		http.Error(w, "internal error adding product to wishlist", http.StatusInternalServerError)
 		return
 	}
+	list, ok := u.wishList(w, user.ID, r.URL.Query().Get("list_id"))
+	if !ok {
+		return
+	}
 
 	// check if the product is already in the wishlist
-	wishlistItem, err := u.DB.GetWishListItemByUserAndProductID(context.TODO(), db.GetWishListItemByUserAndProductIDParams{
-		UserID:    user.ID,
+	wishlistItem, err := u.DB.GetWishListItemByListAndProductID(context.TODO(), db.GetWishListItemByListAndProductIDParams{
+		ListID:    list.ID,
 		ProductID: product.ID,
 	})
 	if err == sql.ErrNoRows {
@@ -446,14 +784,24 @@ func (u *User) AddProductToWishListHandler(w http.ResponseWriter, r *http.Reques
 		http.Error(w, "internal server error adding wishlistItem", http.StatusInternalServerError)
 		return
 	} else {
-		msg := fmt.Sprintf("product: %s already exists in wishlist", product.Name)
+		msg := fmt.Sprintf("product: %s already exists in wishlist %s", product.Name, list.Name)
 		http.Error(w, msg, http.StatusBadRequest)
 		return
 	}
 
+	// price at add is kept to show how much the price dropped since, it is the
+	// price the product sells for now so a running sale isn't counted as a drop later
+	pricing, err := u.DB.GetProductPricing(context.TODO(), product.ID)
+	if err != nil {
+		log.Error("error fetching product pricing in AddProductToWishListHandler:", err.Error())
+		http.Error(w, "internal error adding wishlist item", http.StatusInternalServerError)
+		return
+	}
 	wishlistItem, err = u.DB.AddWishListItem(context.TODO(), db.AddWishListItemParams{
-		UserID:    user.ID,
-		ProductID: productID,
+		UserID:     user.ID,
+		ListID:     list.ID,
+		ProductID:  productID,
+		PriceAtAdd: pricing.EffectivePrice,
 	})
 	if err != nil {
 		log.Error("error adding wishlistItem in ADdProdutToWishlistHandler:", err.Error())
@@ -463,11 +811,13 @@ func (u *User) AddProductToWishListHandler(w http.ResponseWriter, r *http.Reques
 
 	var resp struct {
 		ID          uuid.UUID `json:"id"`
-		ProductID   uuid.UUID `josn:"product_id"`
+		ListID      uuid.UUID `json:"list_id"`
+		ProductID   uuid.UUID `json:"product_id"`
 		ProductName string    `json:"product_name"`
 		Message     string    `json:"message"`
 	}
 	resp.ID = wishlistItem.ID
+	resp.ListID = wishlistItem.ListID
 	resp.ProductID = wishlistItem.ProductID
 	resp.ProductName = product.Name
 	resp.Message = "successfully added wishlistItem"
@@ -486,38 +836,20 @@ func (u *User) RemoveWishListItemHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "product_id not valid", http.StatusBadRequest)
 		return
 	}
-	product, err := u.DB.GetProductByID(context.TODO(), productID)
-	if err == sql.ErrNoRows {
-		http.Error(w, "invalid product_id", http.StatusBadRequest)
-		return
-	} else if err != nil {
-		log.Error("error fetching product in ADdProdutToWishListHandler:", err.Error())
-		http.Error(w, "internal error adding product to wishlist", http.StatusInternalServerError)
-		return
-	}
-	_, err = u.DB.GetWishListItemByUserAndProductID(context.TODO(), db.GetWishListItemByUserAndProductIDParams{
-		UserID:    user.ID,
-		ProductID: product.ID,
-	})
-	if err == sql.ErrNoRows {
-		http.Error(w, "the product is not added in wishlist to delete the item", http.StatusBadRequest)
-		return
-	} else if err != nil {
-		log.Error("error fetching wishlistItem in RemoveWishListItemHandler:", err.Error())
-		http.Error(w, "Internal error removing wishlist item", http.StatusInternalServerError)
+	list, ok := u.wishList(w, user.ID, r.URL.Query().Get("list_id"))
+	if !ok {
 		return
 	}
-	k, err := u.DB.DeleteWishListItemByUserAndProductID(context.TODO(), db.DeleteWishListItemByUserAndProductIDParams{
-		UserID:    user.ID,
-		ProductID: product.ID,
+	k, err := u.DB.DeleteWishListItemByListAndProductID(context.TODO(), db.DeleteWishListItemByListAndProductIDParams{
+		ListID:    list.ID,
+		ProductID: productID,
 	})
 	if err != nil {
 		log.Error("error deleting wishlistItem in RemoveWishListItemHandler:", err.Error())
 		http.Error(w, "internal errror removing wishlistItem", http.StatusInternalServerError)
 		return
 	} else if k == 0 {
-		log.Error("error no rows affected after query execution in RemoveWishListItemHandler")
-		http.Error(w, "internal errror removing wishlistItem", http.StatusInternalServerError)
+		http.Error(w, "the product is not added in wishlist to delete the item", http.StatusBadRequest)
 		return
 	}
 
@@ -531,15 +863,19 @@ func (u *User) RemoveAllWishListHandler(w http.ResponseWriter, r *http.Request)
 	if user.ID == uuid.Nil {
 		return
 	}
+	list, ok := u.wishList(w, user.ID, r.URL.Query().Get("list_id"))
+	if !ok {
+		return
+	}
 
-	err := u.DB.DeleteAllWishListItemsByUserID(context.TODO(), user.ID)
+	err := u.DB.DeleteAllWishListItemsByListID(context.TODO(), list.ID)
 	if err != nil {
 		log.Error("error deleting all wishlist items for user in RemoveAllWishListHandler:", err.Error())
 		http.Error(w, "internal error clearing all wishlist items", http.StatusInternalServerError)
 		return
 	}
 	w.Header().Add("Content-Type", "application/json")
-	msg := "successfully deleted all wishlist items for the user"
+	msg := "successfully deleted all items of wishlist " + list.Name
 	w.Write([]byte(msg))
 }
 
@@ -607,13 +943,20 @@ func (s *Seller) OwnProductsHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "unable to fetch seller products", http.StatusInternalServerError)
 		return
 	}
+	var productIDs []uuid.UUID
+	for _, p := range products {
+		productIDs = append(productIDs, p.ID)
+	}
+	imagesMap := productImagesByIDs(s.DB, productIDs)
+
 	type respProduct struct {
-		ID          uuid.UUID `json:"id"`
-		Name        string    `json:"name"`
-		Description string    `json:"description"`
-		Price       float64   `json:"price"`
-		Stock       int32     `json:"stock"`
-		SellerID    uuid.UUID `json:"seller_id"`
+		ID          uuid.UUID   `json:"id"`
+		Name        string      `json:"name"`
+		Description string      `json:"description"`
+		Price       float64     `json:"price"`
+		Stock       int32       `json:"stock"`
+		SellerID    uuid.UUID   `json:"seller_id"`
+		Images      []respImage `json:"images"`
 	}
 
 	var respProductsData []respProduct
@@ -626,6 +969,10 @@ func (s *Seller) OwnProductsHandler(w http.ResponseWriter, r *http.Request) {
 		temp.Price = p.Price
 		temp.Stock = p.Stock
 		temp.SellerID = p.SellerID
+		temp.Images = imagesMap[p.ID]
+		if temp.Images == nil {
+			temp.Images = []respImage{}
+		}
 
 		respProductsData = append(respProductsData, temp)
 	}
@@ -681,13 +1028,17 @@ func (s *Seller) ProductDetailsHandler(w http.ResponseWriter, r *http.Request) {
 		SellerID:    product.SellerID,
 	}
 	var resp struct {
-		Data       respProduct `json:"data"`
-		Message    string      `json:"message"`
-		Categories []string    `json:"categories"`
-		Err        []string    `json:"errors"`
+		Data       respProduct          `json:"data"`
+		Message    string               `json:"message"`
+		Categories []string             `json:"categories"`
+		Images     []respImage          `json:"images"`
+		Attributes []respAttributeValue `json:"attributes"`
+		Err        []string             `json:"errors"`
 	}
 	resp.Data = respProductData
 	resp.Categories = categories
+	resp.Images = productImages(s.DB, product.ID)
+	resp.Attributes = productAttributeValues(s.DB, product.ID)
 	resp.Err = Err
 	resp.Message = "successfully fetched product"
 	w.Header().Set("Content-Type", "application/json")
@@ -699,19 +1050,46 @@ func (s *Seller) ProductDetailsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// checkSellerApproved writes the error response itself and returns false when
+// the seller's onboarding application hasn't been approved yet
+func checkSellerApproved(w http.ResponseWriter, sellerID uuid.UUID) bool {
+	seller, err := userClient.GetSellerByID(context.TODO(), &userpb.GetSellerByIDRequest{SellerID: sellerID.String()})
+	if err != nil {
+		log.Warn("error fetching seller in checkSellerApproved:", err.Error())
+		http.Error(w, "internal error fetching seller", http.StatusInternalServerError)
+		return false
+	} else if !seller.UserVerified {
+		http.Error(w, "cannot list products until your seller application is approved. visit /seller/onboarding", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// checkSellerAddress makes sure the seller has an address to ship from, the
+// addresses live in the user service
+func checkSellerAddress(w http.ResponseWriter, sellerID uuid.UUID) bool {
+	address, err := userClient.GetAddressBySellerID(context.TODO(), &userpb.GetAddressBySellerIDRequest{SellerID: sellerID.String()})
+	if err != nil {
+		log.Warn("error fetching address for seller in checkSellerAddress:", err.Error())
+		http.Error(w, "internal error fetching seller address to verify the seller has an address before adding product", http.StatusInternalServerError)
+		return false
+	} else if !address.Exists {
+		http.Error(w, "cannot add product withot a address for seller. visit /seller/address/add and make an address", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
 func (s *Seller) AddProductHandler(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserHelper(w, r)
 	if user.ID == uuid.Nil {
 		return
 	}
-	// check if the seller added a shipping address or not
-	_, err := s.DB.GetAddressBySellerID(context.TODO(), user.ID)
-	if err == sql.ErrNoRows {
-		http.Error(w, "cannot add product withot a address for seller. visit /seller/address/add and make an address", http.StatusBadRequest)
+	if !checkSellerApproved(w, user.ID) {
 		return
-	} else if err != nil {
-		log.Warn("error fetching address for seller in AddProductHandler:", err.Error())
-		http.Error(w, "internal error fetching seller address to verify the seller has an address before adding product", http.StatusInternalServerError)
+	}
+	// check if the seller added a shipping address or not
+	if !checkSellerAddress(w, user.ID) {
 		return
 	}
 	var arg struct {
@@ -721,7 +1099,7 @@ func (s *Seller) AddProductHandler(w http.ResponseWriter, r *http.Request) {
 		Stock       int      `json:"stock"`
 		Categories  []string `json:"categories"`
 	}
-	err = json.NewDecoder(r.Body).Decode(&arg)
+	err := json.NewDecoder(r.Body).Decode(&arg)
 	if err != nil {
 		http.Error(w, "invalid data format", http.StatusBadRequest)
 		return
@@ -971,8 +1349,10 @@ func (s *Seller) GetAllCategoriesHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	type respCategory struct {
-		ID   uuid.UUID `json:"id"`
-		Name string    `json:"name"`
+		ID       uuid.UUID     `json:"id"`
+		Name     string        `json:"name"`
+		Slug     string        `json:"slug"`
+		ParentID uuid.NullUUID `json:"parent_id"`
 	}
 
 	var respCategories []respCategory
@@ -981,6 +1361,8 @@ func (s *Seller) GetAllCategoriesHandler(w http.ResponseWriter, r *http.Request)
 		var temp respCategory
 		temp.ID = c.ID
 		temp.Name = c.Name
+		temp.Slug = c.Slug
+		temp.ParentID = c.ParentID
 		respCategories = append(respCategories, temp)
 	}
 	var resp struct {
@@ -1114,8 +1496,11 @@ func (a *Admin) AdminProductsHandler(w http.ResponseWriter, r *http.Request) {
 
 func (a *Admin) AdminCategoriesHandler(w http.ResponseWriter, r *http.Request) {
 	type respCategory struct {
-		ID   uuid.UUID `json:"id"`
-		Name string    `json:"name"`
+		ID        uuid.UUID     `json:"id"`
+		Name      string        `json:"name"`
+		Slug      string        `json:"slug"`
+		ParentID  uuid.NullUUID `json:"parent_id"`
+		IsDeleted bool          `json:"is_deleted"`
 	}
 
 	var respCateogies []respCategory
@@ -1134,6 +1519,9 @@ func (a *Admin) AdminCategoriesHandler(w http.ResponseWriter, r *http.Request) {
 		var temp respCategory
 		temp.ID = c.ID
 		temp.Name = c.Name
+		temp.Slug = c.Slug
+		temp.ParentID = c.ParentID
+		temp.IsDeleted = c.IsDeleted
 		respCateogies = append(respCateogies, temp)
 	}
 
@@ -1161,6 +1549,12 @@ func (a *Admin) DeleteProductHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "error deleting product", http.StatusInternalServerError)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionProductDelete,
+		EntityType: audit.EntityProduct,
+		EntityID:   product.ID.String(),
+		Before:     product,
+	})
 	log.Infof("deleted product: %s", product.ID.String())
 	w.Header().Set("Content-Type", "application/json")
 	message := fmt.Sprintf("product: %s deleted", product.Name)
@@ -1169,20 +1563,50 @@ func (a *Admin) DeleteProductHandler(w http.ResponseWriter, r *http.Request) {
 
 func (a *Admin) AddCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		Name string `json:"name"`
+		Name       string `json:"name"`
+		Slug       string `json:"slug"`
+		ParentSlug string `json:"parent_slug"`
 	}
 	err := json.NewDecoder(r.Body).Decode(&req)
 	if err != nil {
 		http.Error(w, "invalid data format", http.StatusBadRequest)
 		return
 	}
-	req.Name = strings.ToLower(req.Name)
-	category, err := a.DB.AddCateogry(context.TODO(), req.Name)
+	var arg db.AddCateogryParams
+	arg.Name = strings.ToLower(req.Name)
+	// slug defaults to the name, "remote cars" -> "remote-cars"
+	arg.Slug = strings.ToLower(req.Slug)
+	if arg.Slug == "" {
+		arg.Slug = utils.Slugify(arg.Name)
+	}
+	if !validators.ValidateSlug(arg.Slug) {
+		http.Error(w, "invalid slug", http.StatusBadRequest)
+		return
+	}
+	if req.ParentSlug != "" {
+		parent, err := a.DB.GetCategoryBySlug(context.TODO(), req.ParentSlug)
+		if err == sql.ErrNoRows {
+			http.Error(w, "invalid parent slug", http.StatusBadRequest)
+			return
+		} else if err != nil {
+			log.Warn("error fetching parent category in AddCategoryHandler:", err.Error())
+			http.Error(w, "internal error fetching parent category", http.StatusInternalServerError)
+			return
+		}
+		arg.ParentID = uuid.NullUUID{UUID: parent.ID, Valid: true}
+	}
+	category, err := a.DB.AddCateogry(context.TODO(), arg)
 	if err != nil {
 		log.Warn(err)
 		http.Error(w, fmt.Errorf("failed to add cateogry: %w", err).Error(), http.StatusBadRequest)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCategoryAdd,
+		EntityType: audit.EntityCategory,
+		EntityID:   category.ID.String(),
+		After:      category,
+	})
 	log.Infof("added category: %s", category.Name)
 	w.Header().Set("Content-Type", "text/plain")
 	message := fmt.Sprintf("category: %s added", category.Name)
@@ -1198,12 +1622,27 @@ func (a *Admin) EditCategoryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	req.Name = strings.ToLower(req.Name)
 	req.NewName = strings.ToLower(req.NewName)
+	req.NewSlug = strings.ToLower(req.NewSlug)
+	if req.NewSlug == "" {
+		req.NewSlug = utils.Slugify(req.NewName)
+	}
+	if !validators.ValidateSlug(req.NewSlug) {
+		http.Error(w, "invalid slug", http.StatusBadRequest)
+		return
+	}
 	category, err := a.DB.EditCategoryNameByName(context.TODO(), req)
 	if err != nil {
 		log.Warn(err)
 		http.Error(w, fmt.Errorf("failed to rename cateogry: %w", err).Error(), http.StatusBadRequest)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCategoryEdit,
+		EntityType: audit.EntityCategory,
+		EntityID:   category.ID.String(),
+		Before:     map[string]any{"name": req.Name},
+		After:      map[string]any{"name": category.Name, "slug": category.Slug},
+	})
 	log.Infof("renamed category: %s", category.Name)
 	w.Header().Set("Content-Type", "text/plain")
 	message := fmt.Sprintf("category: %s renamed to %s", req.Name, category.Name)
@@ -1228,6 +1667,12 @@ func (a *Admin) DeleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Errorf("failed to delete category: %w", err).Error(), http.StatusBadRequest)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCategoryDelete,
+		EntityType: audit.EntityCategory,
+		EntityID:   category.ID.String(),
+		Before:     category,
+	})
 	log.Infof("deleted category: %s", category.Name)
 	w.Header().Set("Content-Type", "text/plain")
 	message := fmt.Sprintf("category: %s deleted", category.Name)
@@ -0,0 +1,399 @@
+package inventoryservice
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/images"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/storage"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxImagesPerProduct = 8
+
+var store = newStore()
+
+func newStore() storage.Storage {
+	st, err := storage.New()
+	if err != nil {
+		log.Fatal("error setting up file storage: ", err)
+	}
+	return st
+}
+
+type respImage struct {
+	ID           uuid.UUID `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	Position     int       `json:"position"`
+}
+
+func toRespImage(img db.ProductImage) respImage {
+	return respImage{
+		ID:           img.ID,
+		URL:          img.ImageUrl,
+		ThumbnailURL: img.ThumbnailUrl,
+		Position:     int(img.Position),
+	}
+}
+
+// productImages fetches the images of a single product in display order
+func productImages(q *db.Queries, productID uuid.UUID) []respImage {
+	imgs, err := q.GetProductImagesByProductID(context.TODO(), productID)
+	if err != nil {
+		log.Warn("error fetching images of product ", productID, ":", err.Error())
+	}
+	var respImages = []respImage{}
+	for _, img := range imgs {
+		respImages = append(respImages, toRespImage(img))
+	}
+	return respImages
+}
+
+// productImagesByIDs fetches images of many products in one query, keyed by productID
+func productImagesByIDs(q *db.Queries, productIDs []uuid.UUID) map[uuid.UUID][]respImage {
+	imagesMap := make(map[uuid.UUID][]respImage)
+	if len(productIDs) == 0 {
+		return imagesMap
+	}
+	imgs, err := q.GetProductImagesByProductIDs(context.TODO(), productIDs)
+	if err != nil {
+		log.Warn("error fetching images of products:", err.Error())
+		return imagesMap
+	}
+	for _, img := range imgs {
+		imagesMap[img.ProductID] = append(imagesMap[img.ProductID], toRespImage(img))
+	}
+	return imagesMap
+}
+
+type storedImage struct {
+	ImageURL     string
+	ImageKey     string
+	ThumbnailURL string
+	ThumbnailKey string
+}
+
+// delete removes both files, used when saving the row fails after the upload
+func (si storedImage) delete() {
+	store.Delete(context.TODO(), si.ImageKey)
+	store.Delete(context.TODO(), si.ThumbnailKey)
+}
+
+// readImageUpload reads an uploaded file, the returned string is a message
+// for the client and is empty when the file is fine
+func readImageUpload(fh *multipart.FileHeader) ([]byte, string) {
+	if fh.Size > images.MaxImageSize {
+		return nil, "image larger than 5MB"
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return nil, "unable to read file"
+	}
+	data, err := io.ReadAll(io.LimitReader(f, images.MaxImageSize+1))
+	f.Close()
+	if err != nil || len(data) > images.MaxImageSize {
+		return nil, "unable to read file"
+	}
+	return data, ""
+}
+
+// putImage stores the image and its thumbnail under dir, the returned string
+// is a message for the client and is empty on success
+func putImage(dir string, data []byte) (storedImage, string) {
+	var si storedImage
+	contentType, ext, err := images.DetectType(data)
+	if err != nil {
+		return si, err.Error()
+	}
+	thumb, thumbType, err := images.Thumbnail(data, images.ThumbnailSize)
+	if errors.Is(err, images.ErrTooLarge) {
+		return si, err.Error()
+	}
+	if err != nil {
+		return si, "not a valid image"
+	}
+
+	name := uuid.New().String()
+	si.ImageKey = fmt.Sprintf("%s/%s%s", dir, name, ext)
+	thumbExt := ".jpg"
+	if thumbType == "image/png" {
+		thumbExt = ".png"
+	}
+	si.ThumbnailKey = fmt.Sprintf("%s/thumbs/%s%s", dir, name, thumbExt)
+
+	si.ImageURL, err = store.Put(context.TODO(), si.ImageKey, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		log.Warn("error storing image ", si.ImageKey, ":", err.Error())
+		return si, "internal error storing image"
+	}
+	si.ThumbnailURL, err = store.Put(context.TODO(), si.ThumbnailKey, bytes.NewReader(thumb), int64(len(thumb)), thumbType)
+	if err != nil {
+		log.Warn("error storing thumbnail ", si.ThumbnailKey, ":", err.Error())
+		store.Delete(context.TODO(), si.ImageKey)
+		return si, "internal error storing image"
+	}
+	return si, ""
+}
+
+// checkSellerProduct writes the error response itself and returns false
+// when the product does not exist or isn't owned by the seller
+func (s *Seller) checkSellerProduct(w http.ResponseWriter, sellerID, productID uuid.UUID) bool {
+	product, err := s.DB.GetProductByID(context.TODO(), productID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return false
+	} else if err != nil {
+		log.Warn("error fetching product by id:", err.Error())
+		http.Error(w, "internal error fetching product", http.StatusInternalServerError)
+		return false
+	} else if product.SellerID != sellerID {
+		http.Error(w, "trying to edit products not owned by you", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func (s *Seller) AddProductImagesHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	productID, err := uuid.Parse(r.URL.Query().Get("product_id"))
+	if err != nil {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, productID) {
+		return
+	}
+
+	count, err := s.DB.GetProductImageCountByProductID(context.TODO(), productID)
+	if err != nil {
+		log.Warn("error fetching image count in AddProductImagesHandler:", err.Error())
+		http.Error(w, "internal error fetching product images", http.StatusInternalServerError)
+		return
+	}
+
+	// cap the whole body so a huge upload is cut off before it is read into memory
+	r.Body = http.MaxBytesReader(w, r.Body, maxImagesPerProduct*images.MaxImageSize+(1<<20))
+	if err = r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "invalid multipart form or upload too large", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	files := r.MultipartForm.File["images"]
+	if len(files) == 0 {
+		http.Error(w, "no images uploaded, use the form field 'images'", http.StatusBadRequest)
+		return
+	}
+	if int(count)+len(files) > maxImagesPerProduct {
+		http.Error(w, fmt.Sprintf("a product can have at most %d images, it already has %d", maxImagesPerProduct, count), http.StatusBadRequest)
+		return
+	}
+
+	var Err []string
+	var respImages []respImage
+	for _, fh := range files {
+		data, errMsg := readImageUpload(fh)
+		if errMsg != "" {
+			Err = append(Err, fh.Filename+": "+errMsg)
+			continue
+		}
+		stored, errMsg := putImage(fmt.Sprintf("products/%s", productID), data)
+		if errMsg != "" {
+			Err = append(Err, fh.Filename+": "+errMsg)
+			continue
+		}
+
+		img, err := s.DB.AddProductImage(context.TODO(), db.AddProductImageParams{
+			ProductID:    productID,
+			ImageUrl:     stored.ImageURL,
+			ImageKey:     stored.ImageKey,
+			ThumbnailUrl: stored.ThumbnailURL,
+			ThumbnailKey: stored.ThumbnailKey,
+		})
+		if err != nil {
+			log.Warn("error adding product image in AddProductImagesHandler:", err.Error())
+			stored.delete()
+			Err = append(Err, fh.Filename+": internal error saving image")
+			continue
+		}
+		respImages = append(respImages, toRespImage(img))
+	}
+
+	var resp struct {
+		Data    []respImage `json:"data"`
+		Message string      `json:"message"`
+		Err     []string    `json:"errors"`
+	}
+	resp.Data = respImages
+	resp.Err = Err
+	resp.Message = fmt.Sprintf("uploaded %d of %d images", len(respImages), len(files))
+	w.Header().Set("Content-Type", "application/json")
+	if len(respImages) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) ReorderProductImagesHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID uuid.UUID   `json:"product_id"`
+		ImageIDs  []uuid.UUID `json:"image_ids"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, req.ProductID) {
+		return
+	}
+
+	// the new order has to contain every image of the product exactly once
+	current, err := s.DB.GetProductImagesByProductID(context.TODO(), req.ProductID)
+	if err != nil {
+		log.Warn("error fetching product images in ReorderProductImagesHandler:", err.Error())
+		http.Error(w, "internal error fetching product images", http.StatusInternalServerError)
+		return
+	}
+	seen := make(map[uuid.UUID]bool)
+	for _, img := range current {
+		seen[img.ID] = false
+	}
+	for _, id := range req.ImageIDs {
+		done, ok := seen[id]
+		if !ok || done {
+			http.Error(w, "image_ids should list every image of the product exactly once", http.StatusBadRequest)
+			return
+		}
+		seen[id] = true
+	}
+	if len(req.ImageIDs) != len(current) {
+		http.Error(w, "image_ids should list every image of the product exactly once", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in ReorderProductImagesHandler:", err.Error())
+		http.Error(w, "internal error reordering images", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := s.DB.WithTx(tx)
+	for i, id := range req.ImageIDs {
+		_, err = qtx.UpdateProductImagePosition(context.TODO(), db.UpdateProductImagePositionParams{
+			ID:        id,
+			ProductID: req.ProductID,
+			Position:  int32(i),
+		})
+		if err != nil {
+			log.Warn("error updating image position in ReorderProductImagesHandler:", err.Error())
+			http.Error(w, "internal error reordering images", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing image order in ReorderProductImagesHandler:", err.Error())
+		http.Error(w, "internal error reordering images", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Data    []respImage `json:"data"`
+		Message string      `json:"message"`
+	}
+	resp.Data = productImages(s.DB, req.ProductID)
+	resp.Message = "successfully reordered product images"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) DeleteProductImageHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ImageID uuid.UUID `json:"image_id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	img, err := s.DB.GetProductImageByID(context.TODO(), req.ImageID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid image_id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching product image in DeleteProductImageHandler:", err.Error())
+		http.Error(w, "internal error fetching product image", http.StatusInternalServerError)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, img.ProductID) {
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in DeleteProductImageHandler:", err.Error())
+		http.Error(w, "internal error deleting image", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := s.DB.WithTx(tx)
+	_, err = qtx.DeleteProductImageByID(context.TODO(), img.ID)
+	if err != nil {
+		log.Warn("error deleting product image in DeleteProductImageHandler:", err.Error())
+		http.Error(w, "internal error deleting image", http.StatusInternalServerError)
+		return
+	}
+	err = qtx.ShiftProductImagePositionsAfter(context.TODO(), db.ShiftProductImagePositionsAfterParams{
+		ProductID: img.ProductID,
+		Position:  img.Position,
+	})
+	if err != nil {
+		log.Warn("error shifting image positions in DeleteProductImageHandler:", err.Error())
+		http.Error(w, "internal error deleting image", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing image delete in DeleteProductImageHandler:", err.Error())
+		http.Error(w, "internal error deleting image", http.StatusInternalServerError)
+		return
+	}
+
+	// the row is gone already, a leftover file is only wasted space so just log it
+	if err = store.Delete(context.TODO(), img.ImageKey); err != nil {
+		log.Warn("error deleting image file ", img.ImageKey, ":", err.Error())
+	}
+	if err = store.Delete(context.TODO(), img.ThumbnailKey); err != nil {
+		log.Warn("error deleting thumbnail file ", img.ThumbnailKey, ":", err.Error())
+	}
+
+	var resp struct {
+		Data    []respImage `json:"data"`
+		Message string      `json:"message"`
+	}
+	resp.Data = productImages(s.DB, img.ProductID)
+	resp.Message = "successfully deleted product image"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,297 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// respPricing is the "was ₹X, now ₹Y" part of product responses
+type respPricing struct {
+	Price      float64    `json:"price"`
+	WasPrice   *float64   `json:"was_price"`
+	SaleEndsAt *time.Time `json:"sale_ends_at"`
+}
+
+// toRespPricing uses the higher of mrp and the regular price as the was price,
+// it is only set when the product actually sells for less than that
+func toRespPricing(regular float64, mrp sql.NullFloat64, effective float64, saleEndsAt sql.NullTime) respPricing {
+	pricing := respPricing{Price: effective}
+	was := regular
+	if mrp.Valid && mrp.Float64 > was {
+		was = mrp.Float64
+	}
+	if was > effective {
+		pricing.WasPrice = &was
+	}
+	if saleEndsAt.Valid {
+		pricing.SaleEndsAt = &saleEndsAt.Time
+	}
+	return pricing
+}
+
+// productPricingByIDs fetches the current pricing of many products in one query, keyed by productID
+func productPricingByIDs(q *db.Queries, productIDs []uuid.UUID) map[uuid.UUID]respPricing {
+	pricingMap := make(map[uuid.UUID]respPricing)
+	if len(productIDs) == 0 {
+		return pricingMap
+	}
+	rows, err := q.GetProductPricingByIDs(context.TODO(), productIDs)
+	if err != nil {
+		log.Warn("error fetching pricing of products:", err.Error())
+		return pricingMap
+	}
+	for _, p := range rows {
+		pricingMap[p.ID] = toRespPricing(p.RegularPrice, p.Mrp, p.EffectivePrice, p.SaleEndsAt)
+	}
+	return pricingMap
+}
+
+type respProductPrice struct {
+	ID          uuid.UUID  `json:"id"`
+	Kind        string     `json:"kind"`
+	Price       float64    `json:"price"`
+	StartsAt    time.Time  `json:"starts_at"`
+	EndsAt      *time.Time `json:"ends_at"`
+	IsCancelled bool       `json:"is_cancelled"`
+}
+
+func toRespProductPrice(p db.ProductPrice) respProductPrice {
+	resp := respProductPrice{
+		ID:          p.ID,
+		Kind:        p.Kind,
+		Price:       p.Price,
+		StartsAt:    p.StartsAt,
+		IsCancelled: p.IsCancelled,
+	}
+	if p.EndsAt.Valid {
+		resp.EndsAt = &p.EndsAt.Time
+	}
+	return resp
+}
+
+// ProductPricesHandler shows the seller the current pricing of a product along
+// with its price history and scheduled sales
+func (s *Seller) ProductPricesHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	productID, err := uuid.Parse(r.URL.Query().Get("product_id"))
+	if err != nil {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, productID) {
+		return
+	}
+
+	pricing, err := s.DB.GetProductPricing(context.TODO(), productID)
+	if err != nil {
+		log.Warn("error fetching product pricing in ProductPricesHandler:", err.Error())
+		http.Error(w, "internal error fetching product pricing", http.StatusInternalServerError)
+		return
+	}
+	prices, err := s.DB.GetProductPricesByProductID(context.TODO(), productID)
+	if err != nil {
+		log.Warn("error fetching product prices in ProductPricesHandler:", err.Error())
+		http.Error(w, "internal error fetching product prices", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		RegularPrice float64            `json:"regular_price"`
+		MRP          *float64           `json:"mrp"`
+		Current      respPricing        `json:"current"`
+		History      []respProductPrice `json:"history"`
+	}
+	resp.RegularPrice = pricing.RegularPrice
+	if pricing.Mrp.Valid {
+		resp.MRP = &pricing.Mrp.Float64
+	}
+	resp.Current = toRespPricing(pricing.RegularPrice, pricing.Mrp, pricing.EffectivePrice, pricing.SaleEndsAt)
+	resp.History = []respProductPrice{}
+	for _, p := range prices {
+		resp.History = append(resp.History, toRespProductPrice(p))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) AddProductSaleHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID uuid.UUID  `json:"product_id"`
+		SalePrice float64    `json:"sale_price"`
+		StartsAt  *time.Time `json:"starts_at"`
+		EndsAt    time.Time  `json:"ends_at"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, req.ProductID) {
+		return
+	}
+
+	// a sale without a start time starts right away
+	startsAt := time.Now()
+	if req.StartsAt != nil && req.StartsAt.After(startsAt) {
+		startsAt = *req.StartsAt
+	}
+	if !req.EndsAt.After(startsAt) {
+		http.Error(w, "ends_at should be after starts_at and in the future", http.StatusBadRequest)
+		return
+	}
+
+	pricing, err := s.DB.GetProductPricing(context.TODO(), req.ProductID)
+	if err != nil {
+		log.Warn("error fetching product pricing in AddProductSaleHandler:", err.Error())
+		http.Error(w, "internal error fetching product pricing", http.StatusInternalServerError)
+		return
+	}
+	if req.SalePrice <= 0 || req.SalePrice >= pricing.RegularPrice {
+		http.Error(w, "sale_price should be more than 0 and less than the regular price", http.StatusBadRequest)
+		return
+	}
+
+	count, err := s.DB.GetOverlappingSaleCount(context.TODO(), db.GetOverlappingSaleCountParams{
+		ProductID: req.ProductID,
+		StartsAt:  startsAt,
+		EndsAt:    req.EndsAt,
+	})
+	if err != nil {
+		log.Warn("error checking overlapping sales in AddProductSaleHandler:", err.Error())
+		http.Error(w, "internal error adding sale", http.StatusInternalServerError)
+		return
+	}
+	if count > 0 {
+		http.Error(w, "product already has a sale during this time", http.StatusConflict)
+		return
+	}
+
+	sale, err := s.DB.AddProductSale(context.TODO(), db.AddProductSaleParams{
+		ProductID: req.ProductID,
+		Price:     req.SalePrice,
+		StartsAt:  startsAt,
+		EndsAt:    sql.NullTime{Time: req.EndsAt, Valid: true},
+	})
+	if err != nil {
+		log.Warn("error adding sale in AddProductSaleHandler:", err.Error())
+		http.Error(w, "internal error adding sale", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Message string           `json:"message"`
+		Sale    respProductPrice `json:"sale"`
+	}
+	resp.Message = "sale scheduled"
+	resp.Sale = toRespProductPrice(sale)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CancelProductSaleHandler cancels a scheduled sale, or ends a running one right away
+func (s *Seller) CancelProductSaleHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	saleID, err := uuid.Parse(r.URL.Query().Get("sale_id"))
+	if err != nil {
+		http.Error(w, "invalid sale_id", http.StatusBadRequest)
+		return
+	}
+	sale, err := s.DB.GetProductSaleByID(context.TODO(), saleID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "sale not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching sale in CancelProductSaleHandler:", err.Error())
+		http.Error(w, "internal error fetching sale", http.StatusInternalServerError)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, sale.ProductID) {
+		return
+	}
+
+	sale, err = s.DB.CancelProductSaleByID(context.TODO(), saleID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "sale is already over or cancelled", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error cancelling sale in CancelProductSaleHandler:", err.Error())
+		http.Error(w, "internal error cancelling sale", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Message string           `json:"message"`
+		Sale    respProductPrice `json:"sale"`
+	}
+	resp.Message = "sale cancelled"
+	resp.Sale = toRespProductPrice(sale)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// EditProductMRPHandler sets the compare-at price shown as the was price,
+// sending 0 or null clears it
+func (s *Seller) EditProductMRPHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID uuid.UUID `json:"product_id"`
+		MRP       *float64  `json:"mrp"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.MRP != nil && *req.MRP < 0 {
+		http.Error(w, "mrp cannot be negative", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, req.ProductID) {
+		return
+	}
+
+	var mrp sql.NullFloat64
+	if req.MRP != nil && *req.MRP > 0 {
+		mrp = sql.NullFloat64{Float64: *req.MRP, Valid: true}
+	}
+	_, err = s.DB.EditProductMRPByID(context.TODO(), db.EditProductMRPByIDParams{
+		ID:  req.ProductID,
+		Mrp: mrp,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error editing mrp in EditProductMRPHandler:", err.Error())
+		http.Error(w, "internal error editing mrp", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Message string `json:"message"`
+	}
+	resp.Message = "mrp updated"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
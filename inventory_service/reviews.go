@@ -0,0 +1,442 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/images"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxImagesPerReview = 3
+const maxFlagReasonLength = 500
+
+type respReviewImage struct {
+	ID           uuid.UUID `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+}
+
+// reviewImagesByIDs fetches images of many reviews in one query, keyed by reviewID
+func reviewImagesByIDs(q *db.Queries, reviewIDs []uuid.UUID) map[uuid.UUID][]respReviewImage {
+	imagesMap := make(map[uuid.UUID][]respReviewImage)
+	if len(reviewIDs) == 0 {
+		return imagesMap
+	}
+	imgs, err := q.GetReviewImagesByReviewIDs(context.TODO(), reviewIDs)
+	if err != nil {
+		log.Warn("error fetching images of reviews:", err.Error())
+		return imagesMap
+	}
+	for _, img := range imgs {
+		imagesMap[img.ReviewID] = append(imagesMap[img.ReviewID], respReviewImage{
+			ID:           img.ID,
+			URL:          img.ImageUrl,
+			ThumbnailURL: img.ThumbnailUrl,
+		})
+	}
+	return imagesMap
+}
+
+func (u *User) EditProductReviewHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID uuid.UUID `json:"product_id"`
+		Rating    int       `json:"rating"`
+		Comment   string    `json:"comment"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		http.Error(w, "Invalid review rating. Rate from 1-5", http.StatusBadRequest)
+		return
+	}
+	// an empty comment clears it
+	review, err := u.DB.EditProductReviewByUserAndProductID(context.TODO(), db.EditProductReviewByUserAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: req.ProductID,
+		Rating:    int32(req.Rating),
+		Comment: sql.NullString{
+			String: req.Comment,
+			Valid:  req.Comment != "",
+		},
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "no review added by the user for this product", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error editing review in EditProductReviewHandler:", err.Error())
+		http.Error(w, "internal error editing review", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		ReviewID   uuid.UUID      `json:"review_id"`
+		ProductID  uuid.UUID      `json:"product_id"`
+		Rating     int            `json:"rating"`
+		Comment    sql.NullString `json:"comment"`
+		IsEdited   bool           `json:"is_edited"`
+		IsVerified bool           `json:"is_verified"`
+		Message    string         `json:"message"`
+	}
+	resp.ReviewID = review.ID
+	resp.ProductID = review.ProductID
+	resp.Rating = int(review.Rating)
+	resp.Comment = review.Comment
+	resp.IsEdited = review.IsEdited
+	resp.IsVerified = review.IsVerified
+	resp.Message = "successfully edited review"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) DeleteProductReviewHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	productID, err := uuid.Parse(r.URL.Query().Get("product_id"))
+	if err != nil {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return
+	}
+	// reviews are only marked deleted, the user can add a new one afterwards
+	_, err = u.DB.DeleteProductReviewByUserAndProductID(context.TODO(), db.DeleteProductReviewByUserAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: productID,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "no review added by the user for this product", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error deleting review in DeleteProductReviewHandler:", err.Error())
+		http.Error(w, "internal error deleting review", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("successfully deleted review"))
+}
+
+func (u *User) AddReviewImagesHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	productID, err := uuid.Parse(r.URL.Query().Get("product_id"))
+	if err != nil {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return
+	}
+	review, err := u.DB.GetReviewByUserAndProductID(context.TODO(), db.GetReviewByUserAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: productID,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "add a review for the product before adding photos", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching review in AddReviewImagesHandler:", err.Error())
+		http.Error(w, "internal error fetching review", http.StatusInternalServerError)
+		return
+	}
+	count, err := u.DB.GetReviewImageCountByReviewID(context.TODO(), review.ID)
+	if err != nil {
+		log.Warn("error fetching image count in AddReviewImagesHandler:", err.Error())
+		http.Error(w, "internal error fetching review images", http.StatusInternalServerError)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImagesPerReview*images.MaxImageSize+(1<<20))
+	if err = r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "invalid multipart form or upload too large", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	files := r.MultipartForm.File["images"]
+	if len(files) == 0 {
+		http.Error(w, "no images uploaded, use the form field 'images'", http.StatusBadRequest)
+		return
+	}
+	if int(count)+len(files) > maxImagesPerReview {
+		http.Error(w, fmt.Sprintf("a review can have at most %d images, it already has %d", maxImagesPerReview, count), http.StatusBadRequest)
+		return
+	}
+
+	var Err []string
+	var respImages []respReviewImage
+	for _, fh := range files {
+		data, errMsg := readImageUpload(fh)
+		if errMsg != "" {
+			Err = append(Err, fh.Filename+": "+errMsg)
+			continue
+		}
+		stored, errMsg := putImage(fmt.Sprintf("reviews/%s", review.ID), data)
+		if errMsg != "" {
+			Err = append(Err, fh.Filename+": "+errMsg)
+			continue
+		}
+		img, err := u.DB.AddReviewImage(context.TODO(), db.AddReviewImageParams{
+			ReviewID:     review.ID,
+			ImageUrl:     stored.ImageURL,
+			ImageKey:     stored.ImageKey,
+			ThumbnailUrl: stored.ThumbnailURL,
+			ThumbnailKey: stored.ThumbnailKey,
+		})
+		if err != nil {
+			log.Warn("error adding review image in AddReviewImagesHandler:", err.Error())
+			stored.delete()
+			Err = append(Err, fh.Filename+": internal error saving image")
+			continue
+		}
+		respImages = append(respImages, respReviewImage{
+			ID:           img.ID,
+			URL:          img.ImageUrl,
+			ThumbnailURL: img.ThumbnailUrl,
+		})
+	}
+
+	var resp struct {
+		Data    []respReviewImage `json:"data"`
+		Message string            `json:"message"`
+		Err     []string          `json:"errors"`
+	}
+	resp.Data = respImages
+	resp.Err = Err
+	resp.Message = fmt.Sprintf("uploaded %d of %d images", len(respImages), len(files))
+	w.Header().Set("Content-Type", "application/json")
+	if len(respImages) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) FlagReviewHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ReviewID uuid.UUID `json:"review_id"`
+		Reason   string    `json:"reason"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" || len(req.Reason) > maxFlagReasonLength {
+		http.Error(w, fmt.Sprintf("reason is required and can be at most %d characters", maxFlagReasonLength), http.StatusBadRequest)
+		return
+	}
+	review, err := u.DB.GetReviewByID(context.TODO(), req.ReviewID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid review_id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching review in FlagReviewHandler:", err.Error())
+		http.Error(w, "internal error fetching review", http.StatusInternalServerError)
+		return
+	} else if review.UserID == user.ID {
+		http.Error(w, "cannot flag your own review", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in FlagReviewHandler:", err.Error())
+		http.Error(w, "internal error flagging review", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := u.DB.WithTx(tx)
+	_, err = qtx.AddReviewFlag(context.TODO(), db.AddReviewFlagParams{
+		ReviewID: review.ID,
+		UserID:   user.ID,
+		Reason:   req.Reason,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "you have already flagged this review", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error adding review flag in FlagReviewHandler:", err.Error())
+		http.Error(w, "internal error flagging review", http.StatusInternalServerError)
+		return
+	}
+	err = qtx.MarkReviewFlagged(context.TODO(), review.ID)
+	if err != nil {
+		log.Warn("error marking review flagged in FlagReviewHandler:", err.Error())
+		http.Error(w, "internal error flagging review", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing transaction in FlagReviewHandler:", err.Error())
+		http.Error(w, "internal error flagging review", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("review flagged, an admin will look into it"))
+}
+
+// admin side
+
+func (a *Admin) FlaggedReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	Page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || Page < 1 {
+		Page = 1
+	} else if Page > maxPage {
+		Page = maxPage
+	}
+	Limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || Limit < 1 {
+		Limit = defaultPageLimit
+	} else if Limit > maxPageLimit {
+		Limit = maxPageLimit
+	}
+	reviews, err := a.DB.GetFlaggedReviews(context.TODO(), db.GetFlaggedReviewsParams{
+		LimitCount:  int32(Limit),
+		OffsetCount: int32((Page - 1) * Limit),
+	})
+	if err != nil {
+		log.Warn("error fetching flagged reviews in FlaggedReviewsHandler:", err.Error())
+		http.Error(w, "internal error fetching flagged reviews", http.StatusInternalServerError)
+		return
+	}
+
+	type respFlaggedReview struct {
+		ReviewID    uuid.UUID         `json:"review_id"`
+		UserID      uuid.UUID         `json:"user_id"`
+		ProductID   uuid.UUID         `json:"product_id"`
+		ProductName string            `json:"product_name"`
+		Rating      int               `json:"rating"`
+		Comment     sql.NullString    `json:"comment"`
+		IsVerified  bool              `json:"is_verified"`
+		Images      []respReviewImage `json:"images"`
+		FlagCount   int64             `json:"flag_count"`
+		Reasons     []string          `json:"reasons"`
+		CreatedAt   time.Time         `json:"created_at"`
+	}
+	var reviewIDs []uuid.UUID
+	for _, rv := range reviews {
+		reviewIDs = append(reviewIDs, rv.ID)
+	}
+	reviewImages := reviewImagesByIDs(a.DB, reviewIDs)
+	var respReviews = []respFlaggedReview{}
+	for _, rv := range reviews {
+		respReviews = append(respReviews, respFlaggedReview{
+			ReviewID:    rv.ID,
+			UserID:      rv.UserID,
+			ProductID:   rv.ProductID,
+			ProductName: rv.ProductName,
+			Rating:      int(rv.Rating),
+			Comment:     rv.Comment,
+			IsVerified:  rv.IsVerified,
+			Images:      reviewImages[rv.ID],
+			FlagCount:   rv.FlagCount,
+			Reasons:     rv.Reasons,
+			CreatedAt:   rv.CreatedAt,
+		})
+	}
+
+	var resp struct {
+		Data    []respFlaggedReview `json:"data"`
+		Page    int                 `json:"page"`
+		Limit   int                 `json:"limit"`
+		Message string              `json:"message"`
+	}
+	resp.Data = respReviews
+	resp.Page = Page
+	resp.Limit = Limit
+	resp.Message = "successfully fetched flagged reviews"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *Admin) ModerateReviewHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ReviewID uuid.UUID `json:"review_id"`
+		Action   string    `json:"action"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	// approve puts the review back on the product, hide takes it off
+	var moderationStatus string
+	switch req.Action {
+	case "approve":
+		moderationStatus = utils.ReviewStatusVisible
+	case "hide":
+		moderationStatus = utils.ReviewStatusHidden
+	default:
+		http.Error(w, "action should be either approve or hide", http.StatusBadRequest)
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in ModerateReviewHandler:", err.Error())
+		http.Error(w, "internal error moderating review", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := a.DB.WithTx(tx)
+	review, err := qtx.SetReviewModerationStatus(context.TODO(), db.SetReviewModerationStatusParams{
+		ID:               req.ReviewID,
+		ModerationStatus: moderationStatus,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid review_id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error updating review status in ModerateReviewHandler:", err.Error())
+		http.Error(w, "internal error moderating review", http.StatusInternalServerError)
+		return
+	}
+	err = qtx.ResolveReviewFlags(context.TODO(), review.ID)
+	if err != nil {
+		log.Warn("error resolving review flags in ModerateReviewHandler:", err.Error())
+		http.Error(w, "internal error moderating review", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing transaction in ModerateReviewHandler:", err.Error())
+		http.Error(w, "internal error moderating review", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionReviewModerate,
+		EntityType: audit.EntityReview,
+		EntityID:   review.ID.String(),
+		After:      map[string]any{"moderation_status": review.ModerationStatus},
+	})
+
+	var resp struct {
+		ReviewID         uuid.UUID `json:"review_id"`
+		ModerationStatus string    `json:"moderation_status"`
+		Message          string    `json:"message"`
+	}
+	resp.ReviewID = review.ID
+	resp.ModerationStatus = review.ModerationStatus
+	resp.Message = "successfully moderated review"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
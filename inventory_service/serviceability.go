@@ -0,0 +1,415 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/delivery"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/userpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// used when the seller never saved their shipping settings, same as the column default
+const defaultHandlingDays = 1
+const maxHandlingDays = 30
+const maxServiceAreas = 100
+
+// same as the products.weight_grams check
+const maxProductWeightGrams = 100000
+
+type respServiceArea struct {
+	From int32 `json:"from"`
+	To   int32 `json:"to"`
+}
+
+// deliveryCheck answers whether a seller ships to a pincode and by when
+type deliveryCheck struct {
+	Deliverable   bool
+	Reason        string
+	Zone          delivery.Zone
+	HandlingDays  int
+	TransitDays   int
+	EstimatedDate time.Time
+}
+
+func sellerHandlingDays(q *db.Queries, sellerID uuid.UUID) (int, error) {
+	settings, err := q.GetSellerShippingSettings(context.TODO(), sellerID)
+	if err == sql.ErrNoRows {
+		return defaultHandlingDays, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return int(settings.HandlingDays), nil
+}
+
+// sellerShippingRule is free shipping for sellers who never set a rule
+func sellerShippingRule(q *db.Queries, sellerID uuid.UUID) (delivery.Rule, error) {
+	settings, err := q.GetSellerShippingSettings(context.TODO(), sellerID)
+	if err == sql.ErrNoRows {
+		return delivery.Rule{Kind: delivery.RuleFree}, nil
+	} else if err != nil {
+		return delivery.Rule{}, err
+	}
+	return delivery.Rule{
+		Kind:      settings.ShippingRule,
+		FlatFee:   settings.FlatFee,
+		FreeAbove: settings.FreeShippingAbove.Float64,
+	}, nil
+}
+
+// checkDelivery looks up the seller's service areas and handling time here and their
+// pickup address in the user service, the zone between the pincodes gives the transit time
+func checkDelivery(ctx context.Context, q *db.Queries, sellerID uuid.UUID, pincode int32) (deliveryCheck, error) {
+	ships, err := q.GetSellerShipsToPincode(ctx, db.GetSellerShipsToPincodeParams{SellerID: sellerID, Pincode: pincode})
+	if err != nil {
+		return deliveryCheck{}, err
+	}
+	if !ships {
+		return deliveryCheck{Reason: "the seller doesn't ship to pincode " + strconv.Itoa(int(pincode))}, nil
+	}
+	address, err := userClient.GetAddressBySellerID(ctx, &userpb.GetAddressBySellerIDRequest{SellerID: sellerID.String()})
+	if err != nil {
+		return deliveryCheck{}, err
+	}
+	if !address.Exists {
+		return deliveryCheck{Reason: "the seller has no address to ship from"}, nil
+	}
+	handlingDays, err := sellerHandlingDays(q, sellerID)
+	if err != nil {
+		return deliveryCheck{}, err
+	}
+	zone := delivery.ZoneOf(int(address.Pincode), int(pincode))
+	return deliveryCheck{
+		Deliverable:   true,
+		Zone:          zone,
+		HandlingDays:  handlingDays,
+		TransitDays:   delivery.TransitDays[zone],
+		EstimatedDate: delivery.EstimatedDate(time.Now(), handlingDays, zone),
+	}, nil
+}
+
+// answers "deliverable to 560001 by when?" on the product page
+func (u *User) ProductDeliveryHandler(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "wrong productID format", http.StatusBadRequest)
+		return
+	}
+	pincode, err := strconv.Atoi(r.URL.Query().Get("pincode"))
+	if err != nil || !validators.ValidatePincode(pincode) {
+		http.Error(w, "invalid pincode", http.StatusBadRequest)
+		return
+	}
+	product, err := u.DB.GetProductByID(context.TODO(), productID)
+	if err == sql.ErrNoRows || (err == nil && product.IsDeleted) {
+		http.Error(w, "no such product exists", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching product in ProductDeliveryHandler:", err.Error())
+		http.Error(w, "internal error fetching product", http.StatusInternalServerError)
+		return
+	}
+	check, err := checkDelivery(context.TODO(), u.DB, product.SellerID, int32(pincode))
+	if err != nil {
+		log.Warn("error checking delivery in ProductDeliveryHandler:", err.Error())
+		http.Error(w, "internal error checking delivery", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		ProductID     uuid.UUID     `json:"product_id"`
+		Pincode       int           `json:"pincode"`
+		Deliverable   bool          `json:"deliverable"`
+		Reason        string        `json:"reason,omitempty"`
+		Zone          delivery.Zone `json:"zone,omitempty"`
+		HandlingDays  int           `json:"handling_days,omitempty"`
+		TransitDays   int           `json:"transit_days,omitempty"`
+		EstimatedDate string        `json:"estimated_date,omitempty"`
+	}
+	resp.ProductID = product.ID
+	resp.Pincode = pincode
+	resp.Deliverable = check.Deliverable
+	resp.Reason = check.Reason
+	if check.Deliverable {
+		resp.Zone = check.Zone
+		resp.HandlingDays = check.HandlingDays
+		resp.TransitDays = check.TransitDays
+		resp.EstimatedDate = check.EstimatedDate.Format("2006-01-02")
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) ServiceabilityHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	handlingDays, err := sellerHandlingDays(s.DB, user.ID)
+	if err != nil {
+		log.Warn("error fetching shipping settings in ServiceabilityHandler:", err.Error())
+		http.Error(w, "internal error fetching shipping settings", http.StatusInternalServerError)
+		return
+	}
+	areas, err := s.DB.GetSellerServiceAreas(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error fetching service areas in ServiceabilityHandler:", err.Error())
+		http.Error(w, "internal error fetching service areas", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		HandlingDays  int               `json:"handling_days"`
+		PincodeRanges []respServiceArea `json:"pincode_ranges"`
+		Message       string            `json:"message"`
+	}
+	resp.HandlingDays = handlingDays
+	resp.PincodeRanges = []respServiceArea{}
+	for _, a := range areas {
+		resp.PincodeRanges = append(resp.PincodeRanges, respServiceArea{From: a.PincodeFrom, To: a.PincodeTo})
+	}
+	if len(areas) == 0 {
+		resp.Message = "no pincode ranges set, shipping to every pincode"
+	} else {
+		resp.Message = "successfully fetched serviceability"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// replaces the seller's handling time and pincode ranges, an empty list ships everywhere
+func (s *Seller) EditServiceabilityHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		HandlingDays  int               `json:"handling_days"`
+		PincodeRanges []respServiceArea `json:"pincode_ranges"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.HandlingDays < 0 || req.HandlingDays > maxHandlingDays {
+		http.Error(w, fmt.Sprintf("handling_days should be between 0 and %d", maxHandlingDays), http.StatusBadRequest)
+		return
+	}
+	if len(req.PincodeRanges) > maxServiceAreas {
+		http.Error(w, fmt.Sprintf("at most %d pincode ranges can be set", maxServiceAreas), http.StatusBadRequest)
+		return
+	}
+	for _, a := range req.PincodeRanges {
+		if !validators.ValidatePincode(int(a.From)) || !validators.ValidatePincode(int(a.To)) || a.From > a.To {
+			http.Error(w, fmt.Sprintf("invalid pincode range %d-%d", a.From, a.To), http.StatusBadRequest)
+			return
+		}
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in EditServiceabilityHandler:", err.Error())
+		http.Error(w, "internal error saving serviceability", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := s.DB.WithTx(tx)
+	settings, err := qtx.UpsertSellerShippingSettings(context.TODO(), db.UpsertSellerShippingSettingsParams{
+		SellerID:     user.ID,
+		HandlingDays: int32(req.HandlingDays),
+	})
+	if err != nil {
+		log.Warn("error saving shipping settings in EditServiceabilityHandler:", err.Error())
+		http.Error(w, "internal error saving serviceability", http.StatusInternalServerError)
+		return
+	}
+	if err = qtx.DeleteSellerServiceAreas(context.TODO(), user.ID); err != nil {
+		log.Warn("error deleting service areas in EditServiceabilityHandler:", err.Error())
+		http.Error(w, "internal error saving serviceability", http.StatusInternalServerError)
+		return
+	}
+	ranges := []respServiceArea{}
+	for _, a := range req.PincodeRanges {
+		area, err := qtx.AddSellerServiceArea(context.TODO(), db.AddSellerServiceAreaParams{
+			SellerID:    user.ID,
+			PincodeFrom: a.From,
+			PincodeTo:   a.To,
+		})
+		if err != nil {
+			log.Warn("error adding service area in EditServiceabilityHandler:", err.Error())
+			http.Error(w, "internal error saving serviceability", http.StatusInternalServerError)
+			return
+		}
+		ranges = append(ranges, respServiceArea{From: area.PincodeFrom, To: area.PincodeTo})
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing in EditServiceabilityHandler:", err.Error())
+		http.Error(w, "internal error saving serviceability", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		HandlingDays  int32             `json:"handling_days"`
+		PincodeRanges []respServiceArea `json:"pincode_ranges"`
+		Message       string            `json:"message"`
+	}
+	resp.HandlingDays = settings.HandlingDays
+	resp.PincodeRanges = ranges
+	resp.Message = "successfully updated serviceability"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+type respShippingRule struct {
+	Rule      string   `json:"rule"`
+	FlatFee   float64  `json:"flat_fee"`
+	FreeAbove *float64 `json:"free_shipping_above"`
+	// rates of the weight rule, per started slab
+	SlabGrams   int                       `json:"slab_grams"`
+	RatePerSlab map[delivery.Zone]float64 `json:"rate_per_slab"`
+}
+
+func newRespShippingRule(rule delivery.Rule) respShippingRule {
+	resp := respShippingRule{
+		Rule:        rule.Kind,
+		FlatFee:     rule.FlatFee,
+		SlabGrams:   delivery.SlabGrams,
+		RatePerSlab: delivery.RatePerSlab,
+	}
+	if rule.FreeAbove > 0 {
+		resp.FreeAbove = &rule.FreeAbove
+	}
+	return resp
+}
+
+func (s *Seller) ShippingRuleHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	rule, err := sellerShippingRule(s.DB, user.ID)
+	if err != nil {
+		log.Warn("error fetching shipping rule in ShippingRuleHandler:", err.Error())
+		http.Error(w, "internal error fetching shipping rule", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		ShippingRule respShippingRule `json:"shipping_rule"`
+		Message      string           `json:"message"`
+	}
+	resp.ShippingRule = newRespShippingRule(rule)
+	resp.Message = "successfully fetched shipping rule"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// sets how the seller charges shipping: free, a flat fee per order or by weight and zone,
+// optionally free from a subtotal. orders already placed keep their charges
+func (s *Seller) EditShippingRuleHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		Rule      string   `json:"rule"`
+		FlatFee   float64  `json:"flat_fee"`
+		FreeAbove *float64 `json:"free_shipping_above"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.Rule != delivery.RuleFree && req.Rule != delivery.RuleFlat && req.Rule != delivery.RuleWeight {
+		http.Error(w, "rule should be free, flat or weight", http.StatusBadRequest)
+		return
+	}
+	if req.Rule == delivery.RuleFlat && req.FlatFee <= 0 {
+		http.Error(w, "flat_fee should be more than 0 for the flat rule", http.StatusBadRequest)
+		return
+	} else if req.Rule != delivery.RuleFlat {
+		req.FlatFee = 0
+	}
+	if req.FreeAbove != nil && *req.FreeAbove <= 0 {
+		http.Error(w, "free_shipping_above should be more than 0, leave it out for no threshold", http.StatusBadRequest)
+		return
+	}
+	arg := db.UpsertSellerShippingRuleParams{
+		SellerID:     user.ID,
+		ShippingRule: req.Rule,
+		FlatFee:      req.FlatFee,
+	}
+	if req.FreeAbove != nil && req.Rule != delivery.RuleFree {
+		arg.FreeShippingAbove = sql.NullFloat64{Float64: *req.FreeAbove, Valid: true}
+	}
+	settings, err := s.DB.UpsertSellerShippingRule(context.TODO(), arg)
+	if err != nil {
+		log.Warn("error saving shipping rule in EditShippingRuleHandler:", err.Error())
+		http.Error(w, "internal error saving shipping rule", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		ShippingRule respShippingRule `json:"shipping_rule"`
+		Message      string           `json:"message"`
+	}
+	resp.ShippingRule = newRespShippingRule(delivery.Rule{
+		Kind:      settings.ShippingRule,
+		FlatFee:   settings.FlatFee,
+		FreeAbove: settings.FreeShippingAbove.Float64,
+	})
+	resp.Message = "successfully updated shipping rule"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) EditProductWeightHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID   uuid.UUID `json:"product_id"`
+		WeightGrams int       `json:"weight_grams"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.WeightGrams <= 0 || req.WeightGrams > maxProductWeightGrams {
+		http.Error(w, fmt.Sprintf("weight_grams should be between 1 and %d", maxProductWeightGrams), http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, req.ProductID) {
+		return
+	}
+	product, err := s.DB.EditProductWeightByID(context.TODO(), db.EditProductWeightByIDParams{
+		ID:          req.ProductID,
+		WeightGrams: int32(req.WeightGrams),
+	})
+	if err != nil {
+		log.Warn("error updating product weight in EditProductWeightHandler:", err.Error())
+		http.Error(w, "internal error updating product weight", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		ProductID   uuid.UUID `json:"product_id"`
+		WeightGrams int32     `json:"weight_grams"`
+		Message     string    `json:"message"`
+	}
+	resp.ProductID = product.ID
+	resp.WeightGrams = product.WeightGrams
+	resp.Message = "successfully updated product weight"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,265 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const stockAlertKindLowStock = "low_stock"
+const stockAlertKindOutOfStock = "out_of_stock"
+const stockAlertKindBackInStock = "back_in_stock"
+
+// StockAlertsCron goes through the alerts written by the products_stock_alert trigger
+// and the product_prices rows that took effect and mails the users subscribed to
+// products that are back in stock or have them in a wishlist.
+// run it in its own goroutine from the service main, it returns once ctx is done
+func StockAlertsCron(ctx context.Context) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		processStockAlerts(DB)
+		processPriceChanges(DB)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func processStockAlerts(DB *db.Queries) {
+	alerts, err := DB.GetUnprocessedStockAlerts(context.TODO())
+	if err != nil {
+		log.Error("error fetching stock alerts in processStockAlerts:", err.Error())
+		return
+	}
+	for _, a := range alerts {
+		// low and out of stock alerts are only shown on the seller dashboard
+		if a.Kind == stockAlertKindBackInStock {
+			subscriptions, err := DB.GetPendingStockSubscriptionsByProductID(context.TODO(), a.ProductID)
+			if err != nil {
+				log.Error("error fetching stock subscriptions in processStockAlerts:", err.Error())
+				continue
+			}
+			// users with a subscription and the product in a wishlist get a single mail
+			var mailed = make(map[string]bool)
+			for _, sub := range subscriptions {
+				err = mail.SendBackInStockMail(a.ProductName, a.ProductID.String(), sub.Email)
+				if err != nil {
+					// left pending, the next back in stock alert will try again
+					log.Error("error sending back in stock mail to ", sub.Email, ":", err.Error())
+					continue
+				}
+				mailed[sub.Email] = true
+				err = DB.MarkStockSubscriptionNotified(context.TODO(), sub.ID)
+				if err != nil {
+					log.Error("error marking stock subscription notified:", err.Error())
+				}
+			}
+			sent := notifyWishListUsers(DB, a.ProductID, func(email string) error {
+				if mailed[email] {
+					return nil
+				}
+				return mail.SendBackInStockMail(a.ProductName, a.ProductID.String(), email)
+			})
+			log.Infof("notified %d subscribed and %d wishlist users that product: %s is back in stock", len(mailed), sent, a.ProductID)
+		}
+		err = DB.MarkStockAlertProcessed(context.TODO(), a.ID)
+		if err != nil {
+			log.Error("error marking stock alert processed:", err.Error())
+		}
+	}
+}
+
+func (s *Seller) StockDashboardHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	products, err := s.DB.GetLowStockProductsBySellerID(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error fetching low stock products in StockDashboardHandler:", err.Error())
+		http.Error(w, "internal error fetching low stock products", http.StatusInternalServerError)
+		return
+	}
+	alerts, err := s.DB.GetStockAlertsBySellerID(context.TODO(), db.GetStockAlertsBySellerIDParams{
+		SellerID:    user.ID,
+		IncludeRead: false,
+	})
+	if err != nil {
+		log.Warn("error fetching stock alerts in StockDashboardHandler:", err.Error())
+		http.Error(w, "internal error fetching stock alerts", http.StatusInternalServerError)
+		return
+	}
+
+	type respProduct struct {
+		ID                uuid.UUID `json:"id"`
+		Name              string    `json:"name"`
+		Stock             int32     `json:"stock"`
+		LowStockThreshold int32     `json:"low_stock_threshold"`
+	}
+	var outOfStock = []respProduct{}
+	var lowStock = []respProduct{}
+	for _, p := range products {
+		temp := respProduct{
+			ID:                p.ID,
+			Name:              p.Name,
+			Stock:             p.Stock,
+			LowStockThreshold: p.LowStockThreshold,
+		}
+		if p.Stock == 0 {
+			outOfStock = append(outOfStock, temp)
+		} else {
+			lowStock = append(lowStock, temp)
+		}
+	}
+
+	var resp struct {
+		OutOfStock []respProduct                    `json:"out_of_stock"`
+		LowStock   []respProduct                    `json:"low_stock"`
+		Alerts     []db.GetStockAlertsBySellerIDRow `json:"alerts"`
+		Message    string                           `json:"message"`
+	}
+	resp.OutOfStock = outOfStock
+	resp.LowStock = lowStock
+	resp.Alerts = alerts
+	resp.Message = "successfully fetched stock dashboard"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) MarkStockAlertsReadHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	err := s.DB.MarkStockAlertsReadBySellerID(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error marking stock alerts read in MarkStockAlertsReadHandler:", err.Error())
+		http.Error(w, "internal error updating stock alerts", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("marked all stock alerts as read"))
+}
+
+func (s *Seller) EditLowStockThresholdHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID         uuid.UUID `json:"product_id"`
+		LowStockThreshold int       `json:"low_stock_threshold"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.LowStockThreshold < 0 {
+		http.Error(w, "low_stock_threshold can't be negative", http.StatusBadRequest)
+		return
+	}
+	if !s.checkSellerProduct(w, user.ID, req.ProductID) {
+		return
+	}
+	product, err := s.DB.EditProductLowStockThresholdByID(context.TODO(), db.EditProductLowStockThresholdByIDParams{
+		ID:                req.ProductID,
+		LowStockThreshold: int32(req.LowStockThreshold),
+	})
+	if err != nil {
+		log.Warn("error updating low stock threshold in EditLowStockThresholdHandler:", err.Error())
+		http.Error(w, "internal error updating low stock threshold", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		ProductID         uuid.UUID `json:"product_id"`
+		Stock             int32     `json:"stock"`
+		LowStockThreshold int32     `json:"low_stock_threshold"`
+		Message           string    `json:"message"`
+	}
+	resp.ProductID = product.ID
+	resp.Stock = product.Stock
+	resp.LowStockThreshold = product.LowStockThreshold
+	resp.Message = "successfully updated low stock threshold"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) AddStockSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID uuid.UUID `json:"product_id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	product, err := u.DB.GetProductByID(context.TODO(), req.ProductID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid product_id", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching product in AddStockSubscriptionHandler:", err.Error())
+		http.Error(w, "internal error fetching product", http.StatusInternalServerError)
+		return
+	} else if product.Stock > 0 {
+		http.Error(w, "product is in stock, no need to wait for it", http.StatusBadRequest)
+		return
+	}
+	_, err = u.DB.AddStockSubscription(context.TODO(), db.AddStockSubscriptionParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Email:     user.Email,
+	})
+	if err != nil {
+		log.Warn("error adding stock subscription in AddStockSubscriptionHandler:", err.Error())
+		http.Error(w, "internal error adding stock subscription", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("you will get a mail at " + user.Email + " when " + product.Name + " is back in stock"))
+}
+
+func (u *User) RemoveStockSubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ProductID uuid.UUID `json:"product_id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	_, err = u.DB.DeleteStockSubscription(context.TODO(), db.DeleteStockSubscriptionParams{
+		UserID:    user.ID,
+		ProductID: req.ProductID,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "no back in stock subscription for the product", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error deleting stock subscription in RemoveStockSubscriptionHandler:", err.Error())
+		http.Error(w, "internal error removing stock subscription", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("removed back in stock subscription"))
+}
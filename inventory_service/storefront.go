@@ -0,0 +1,330 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/paymentpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/userpb"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// used when the seller never saved their storefront settings, same as the column defaults
+const defaultReturnWindowDays = 7
+const maxReturnWindowDays = 90
+const maxReturnPolicyLength = 2000
+
+type respReturnPolicy struct {
+	ReturnWindowDays int    `json:"return_window_days"`
+	Policy           string `json:"policy"`
+}
+
+// sellerReturnPolicy falls back to the defaults when the seller has no storefront row
+func sellerReturnPolicy(q *db.Queries, sellerID uuid.UUID) (respReturnPolicy, error) {
+	storefront, err := q.GetSellerStorefront(context.TODO(), sellerID)
+	if err == sql.ErrNoRows {
+		return respReturnPolicy{ReturnWindowDays: defaultReturnWindowDays}, nil
+	} else if err != nil {
+		return respReturnPolicy{}, err
+	}
+	return respReturnPolicy{
+		ReturnWindowDays: int(storefront.ReturnWindowDays),
+		Policy:           storefront.ReturnPolicy,
+	}, nil
+}
+
+// sellerProfile fetches the seller from the user service, it writes the error
+// response itself and returns nil when the seller can't be shown
+func sellerProfile(w http.ResponseWriter, sellerID uuid.UUID) *userpb.GetSellerByIDResponse {
+	seller, err := userClient.GetSellerByID(context.TODO(), &userpb.GetSellerByIDRequest{SellerID: sellerID.String()})
+	if status.Code(err) == codes.NotFound {
+		http.Error(w, "no such seller exists", http.StatusNotFound)
+		return nil
+	} else if err != nil {
+		log.Warn("error fetching seller from user service:", err.Error())
+		http.Error(w, "internal error fetching seller", http.StatusInternalServerError)
+		return nil
+	} else if seller.IsBlocked {
+		http.Error(w, "no such seller exists", http.StatusNotFound)
+		return nil
+	}
+	return seller
+}
+
+func (u *User) SellerStorefrontHandler(w http.ResponseWriter, r *http.Request) {
+	sellerID, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "invalid seller id", http.StatusBadRequest)
+		return
+	}
+	seller := sellerProfile(w, sellerID)
+	if seller == nil {
+		return
+	}
+
+	var Err []string
+	returnPolicy, err := sellerReturnPolicy(u.DB, sellerID)
+	if err != nil {
+		log.Warn("error fetching storefront in SellerStorefrontHandler:", err.Error())
+		Err = append(Err, "error fetching return policy of the seller")
+	}
+	rating, err := u.DB.GetSellerRatingSummary(context.TODO(), sellerID)
+	if err != nil {
+		log.Warn("error fetching seller rating in SellerStorefrontHandler:", err.Error())
+		Err = append(Err, "error fetching rating of the seller")
+	}
+	productCount, err := u.DB.GetSellerProductCount(context.TODO(), sellerID)
+	if err != nil {
+		log.Warn("error fetching product count in SellerStorefrontHandler:", err.Error())
+		Err = append(Err, "error fetching product count of the seller")
+	}
+
+	type respFulfilment struct {
+		TotalItems         int64   `json:"total_items"`
+		OnTimeShippingRate float64 `json:"on_time_shipping_rate"`
+		CancellationRate   float64 `json:"cancellation_rate"`
+		ReturnRate         float64 `json:"return_rate"`
+	}
+	// the storefront is still shown when the payment service is down
+	var fulfilment *respFulfilment
+	stats, err := paymentClient.GetSellerFulfilmentStats(context.TODO(), &paymentpb.GetSellerFulfilmentStatsRequest{SellerID: sellerID.String()})
+	if err != nil {
+		log.Warn("error fetching fulfilment stats in SellerStorefrontHandler:", err.Error())
+		Err = append(Err, "error fetching fulfilment stats of the seller")
+	} else {
+		fulfilment = &respFulfilment{
+			TotalItems:         stats.TotalItems,
+			OnTimeShippingRate: stats.OnTimeShippingRate,
+			CancellationRate:   stats.CancellationRate,
+			ReturnRate:         stats.ReturnRate,
+		}
+	}
+
+	var resp struct {
+		SellerID      uuid.UUID        `json:"seller_id"`
+		Name          string           `json:"name"`
+		About         string           `json:"about"`
+		IsVerified    bool             `json:"is_verified"`
+		MemberSince   time.Time        `json:"member_since"`
+		ProductCount  int64            `json:"product_count"`
+		AverageRating float64          `json:"average_rating"`
+		RatingCount   int64            `json:"rating_count"`
+		Fulfilment    *respFulfilment  `json:"fulfilment"`
+		ReturnPolicy  respReturnPolicy `json:"return_policy"`
+		Message       string           `json:"message"`
+		Err           []string         `json:"errors"`
+	}
+	resp.SellerID = sellerID
+	resp.Name = seller.Name
+	resp.About = seller.About
+	resp.IsVerified = seller.UserVerified
+	resp.MemberSince = seller.CreatedAt.AsTime()
+	resp.ProductCount = productCount
+	resp.AverageRating = math.Round(rating.AverageRating*10) / 10
+	resp.RatingCount = rating.RatingCount
+	resp.Fulfilment = fulfilment
+	resp.ReturnPolicy = returnPolicy
+	resp.Err = Err
+	resp.Message = "successfully fetched seller storefront"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SellerStorefrontProductsHandler is the catalogue of a single seller, it takes
+// the same q, sort, page and limit params as the product listing
+func (u *User) SellerStorefrontProductsHandler(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	sellerID, err := uuid.Parse(queryParams.Get("id"))
+	if err != nil {
+		http.Error(w, "invalid seller id", http.StatusBadRequest)
+		return
+	}
+	if sellerProfile(w, sellerID) == nil {
+		return
+	}
+
+	Query := strings.TrimSpace(queryParams.Get("q"))
+	Sort := queryParams.Get("sort")
+	switch Sort {
+	case sortRelevance, sortNewest, sortPriceAsc, sortPriceDesc, sortRating, sortBestSelling:
+	default:
+		Sort = sortNewest
+		if Query != "" {
+			Sort = sortRelevance
+		}
+	}
+	Page, err := strconv.Atoi(queryParams.Get("page"))
+	if err != nil || Page < 1 {
+		Page = 1
+	} else if Page > maxPage {
+		Page = maxPage
+	}
+	Limit, err := strconv.Atoi(queryParams.Get("limit"))
+	if err != nil || Limit < 1 {
+		Limit = defaultPageLimit
+	} else if Limit > maxPageLimit {
+		Limit = maxPageLimit
+	}
+
+	products, total, err := searchProducts(u.DB, db.SearchProductsParams{
+		Query:      Query,
+		SellerID:   uuid.NullUUID{UUID: sellerID, Valid: true},
+		PriceMin:   0,
+		PriceMax:   math.MaxInt32,
+		Categories: []string{},
+		Attributes: []string{},
+		Sort:       Sort,
+		PageLimit:  int32(Limit),
+		PageOffset: int32((Page - 1) * Limit),
+	})
+	if err != nil {
+		log.Warn("error fetching seller products in SellerStorefrontProductsHandler:", err.Error())
+		http.Error(w, "internal server error fetching products", http.StatusInternalServerError)
+		return
+	}
+
+	var productIDs []uuid.UUID
+	for _, v := range products {
+		productIDs = append(productIDs, v.ID)
+	}
+	imagesMap := productImagesByIDs(u.DB, productIDs)
+	pricingMap := productPricingByIDs(u.DB, productIDs)
+
+	type respProduct struct {
+		ID            uuid.UUID   `json:"id"`
+		Name          string      `json:"name"`
+		Description   string      `json:"description"`
+		Price         float64     `json:"price"`
+		WasPrice      *float64    `json:"was_price"`
+		SaleEndsAt    *time.Time  `json:"sale_ends_at"`
+		Available     bool        `json:"available"`
+		AverageRating float64     `json:"average_rating"`
+		RatingCount   int         `json:"rating_count"`
+		Images        []respImage `json:"images"`
+	}
+	var respProducts = []respProduct{}
+	for _, v := range products {
+		temp := respProduct{
+			ID:            v.ID,
+			Name:          v.Name,
+			Description:   v.Description,
+			Price:         v.Price,
+			WasPrice:      pricingMap[v.ID].WasPrice,
+			SaleEndsAt:    pricingMap[v.ID].SaleEndsAt,
+			Available:     v.Stock > 0,
+			AverageRating: math.Round(v.AverageRating*10) / 10,
+			RatingCount:   int(v.RatingCount),
+			Images:        imagesMap[v.ID],
+		}
+		if temp.Images == nil {
+			temp.Images = []respImage{}
+		}
+		respProducts = append(respProducts, temp)
+	}
+
+	var resp struct {
+		SellerID   uuid.UUID      `json:"seller_id"`
+		Data       []respProduct  `json:"data"`
+		Pagination respPagination `json:"pagination"`
+		Sort       string         `json:"sort"`
+		Message    string         `json:"message"`
+	}
+	resp.SellerID = sellerID
+	resp.Data = respProducts
+	resp.Pagination = respPagination{
+		Page:       Page,
+		Limit:      Limit,
+		Total:      total,
+		TotalPages: int((total + int64(Limit) - 1) / int64(Limit)),
+		HasNext:    int64(Page*Limit) < total,
+	}
+	resp.Sort = Sort
+	resp.Message = "successfully fetched seller products"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// seller side
+
+func (s *Seller) GetStorefrontHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	returnPolicy, err := sellerReturnPolicy(s.DB, user.ID)
+	if err != nil {
+		log.Warn("error fetching storefront in GetStorefrontHandler:", err.Error())
+		http.Error(w, "internal error fetching storefront", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		SellerID     uuid.UUID        `json:"seller_id"`
+		ReturnPolicy respReturnPolicy `json:"return_policy"`
+		Message      string           `json:"message"`
+	}
+	resp.SellerID = user.ID
+	resp.ReturnPolicy = returnPolicy
+	resp.Message = "successfully fetched storefront settings"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Seller) EditStorefrontHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ReturnWindowDays int    `json:"return_window_days"`
+		ReturnPolicy     string `json:"return_policy"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.ReturnWindowDays < 0 || req.ReturnWindowDays > maxReturnWindowDays {
+		http.Error(w, fmt.Sprintf("return_window_days should be between 0 and %d", maxReturnWindowDays), http.StatusBadRequest)
+		return
+	}
+	req.ReturnPolicy = strings.TrimSpace(req.ReturnPolicy)
+	if len(req.ReturnPolicy) > maxReturnPolicyLength {
+		http.Error(w, fmt.Sprintf("return_policy can be at most %d characters", maxReturnPolicyLength), http.StatusBadRequest)
+		return
+	}
+	storefront, err := s.DB.UpsertSellerStorefront(context.TODO(), db.UpsertSellerStorefrontParams{
+		SellerID:         user.ID,
+		ReturnWindowDays: int32(req.ReturnWindowDays),
+		ReturnPolicy:     req.ReturnPolicy,
+	})
+	if err != nil {
+		log.Warn("error saving storefront in EditStorefrontHandler:", err.Error())
+		http.Error(w, "internal error saving storefront", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		SellerID     uuid.UUID        `json:"seller_id"`
+		ReturnPolicy respReturnPolicy `json:"return_policy"`
+		Message      string           `json:"message"`
+	}
+	resp.SellerID = storefront.SellerID
+	resp.ReturnPolicy = respReturnPolicy{
+		ReturnWindowDays: int(storefront.ReturnWindowDays),
+		Policy:           storefront.ReturnPolicy,
+	}
+	resp.Message = "successfully updated storefront settings"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
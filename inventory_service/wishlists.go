@@ -0,0 +1,426 @@
+package inventoryservice
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	db "inventory_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/userpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxWishListNameLength = 50
+const defaultWishListName = "default"
+
+// 24 random bytes give a 32 character url safe token without padding
+const shareTokenBytes = 24
+
+type respWishList struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	IsDefault     bool      `json:"is_default"`
+	AlertsEnabled bool      `json:"alerts_enabled"`
+	IsShared      bool      `json:"is_shared"`
+	ShareToken    string    `json:"share_token,omitempty"`
+	ItemCount     int64     `json:"item_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func toRespWishList(l db.WishlistList, itemCount int64) respWishList {
+	return respWishList{
+		ID:            l.ID,
+		Name:          l.Name,
+		IsDefault:     l.IsDefault,
+		AlertsEnabled: l.AlertsEnabled,
+		IsShared:      l.ShareToken.Valid,
+		ShareToken:    l.ShareToken.String,
+		ItemCount:     itemCount,
+		CreatedAt:     l.CreatedAt,
+	}
+}
+
+type respWishListItem struct {
+	ID           uuid.UUID `json:"id"`
+	ProductID    uuid.UUID `json:"product_id"`
+	ProductName  string    `json:"product_name"`
+	Price        float64   `json:"price"`
+	PriceAtAdd   float64   `json:"price_at_add"`
+	PriceDropped bool      `json:"price_dropped"`
+	Available    bool      `json:"available"`
+	AddedAt      time.Time `json:"added_at"`
+}
+
+func toRespWishListItems(items []db.GetWishListItemsByListIDRow) []respWishListItem {
+	var respItems = []respWishListItem{}
+	for _, i := range items {
+		respItems = append(respItems, respWishListItem{
+			ID:           i.ID,
+			ProductID:    i.ProductID,
+			ProductName:  i.ProductName,
+			Price:        i.CurrentPrice,
+			PriceAtAdd:   i.PriceAtAdd,
+			PriceDropped: i.CurrentPrice < i.PriceAtAdd,
+			Available:    i.Stock > 0 && !i.IsDeleted,
+			AddedAt:      i.CreatedAt,
+		})
+	}
+	return respItems
+}
+
+// wishList returns the list with the given id or the user's default list when listIDStr is empty.
+// it writes the error response itself and returns false when the list can't be used
+func (u *User) wishList(w http.ResponseWriter, userID uuid.UUID, listIDStr string) (db.WishlistList, bool) {
+	if listIDStr == "" {
+		list, err := u.DB.GetOrCreateDefaultWishList(context.TODO(), userID)
+		if err != nil {
+			log.Error("error fetching default wishlist:", err.Error())
+			http.Error(w, "internal error fetching wishlist", http.StatusInternalServerError)
+			return db.WishlistList{}, false
+		}
+		return list, true
+	}
+	listID, err := uuid.Parse(listIDStr)
+	if err != nil {
+		http.Error(w, "invalid list_id", http.StatusBadRequest)
+		return db.WishlistList{}, false
+	}
+	list, err := u.DB.GetWishListByID(context.TODO(), db.GetWishListByIDParams{
+		ID:     listID,
+		UserID: userID,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid list_id", http.StatusBadRequest)
+		return db.WishlistList{}, false
+	} else if err != nil {
+		log.Error("error fetching wishlist:", err.Error())
+		http.Error(w, "internal error fetching wishlist", http.StatusInternalServerError)
+		return db.WishlistList{}, false
+	}
+	return list, true
+}
+
+// validWishListName writes the error response itself when the name can't be used
+func validWishListName(w http.ResponseWriter, name string) bool {
+	if name == "" || len(name) > maxWishListNameLength {
+		http.Error(w, "name should be 1 to 50 characters", http.StatusBadRequest)
+		return false
+	} else if strings.EqualFold(name, defaultWishListName) {
+		http.Error(w, "the name default is reserved", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (u *User) GetWishListsHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	// makes sure the default list shows up even before anything is added
+	if _, ok := u.wishList(w, user.ID, ""); !ok {
+		return
+	}
+	lists, err := u.DB.GetWishListsByUserID(context.TODO(), user.ID)
+	if err != nil {
+		log.Error("error fetching wishlists in GetWishListsHandler:", err.Error())
+		http.Error(w, "internal error fetching wishlists", http.StatusInternalServerError)
+		return
+	}
+	var respLists = []respWishList{}
+	for _, l := range lists {
+		respLists = append(respLists, toRespWishList(db.WishlistList{
+			ID:            l.ID,
+			Name:          l.Name,
+			IsDefault:     l.IsDefault,
+			AlertsEnabled: l.AlertsEnabled,
+			ShareToken:    l.ShareToken,
+			CreatedAt:     l.CreatedAt,
+		}, l.ItemCount))
+	}
+	var resp struct {
+		Data    []respWishList `json:"data"`
+		Message string         `json:"message"`
+	}
+	resp.Data = respLists
+	resp.Message = "successfully fetched wishlists"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) AddWishListHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if !validWishListName(w, req.Name) {
+		return
+	}
+	list, err := u.DB.AddWishList(context.TODO(), db.AddWishListParams{
+		UserID: user.ID,
+		Name:   req.Name,
+	})
+	if err != nil && strings.Contains(err.Error(), "wishlist_lists_user_id_name_unique") {
+		http.Error(w, "a wishlist with the name already exists", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Error("error adding wishlist in AddWishListHandler:", err.Error())
+		http.Error(w, "internal error adding wishlist", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data    respWishList `json:"data"`
+		Message string       `json:"message"`
+	}
+	resp.Data = toRespWishList(list, 0)
+	resp.Message = "successfully added wishlist"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) EditWishListHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	// fields left out keep their current value
+	var req struct {
+		ListID        string  `json:"list_id"`
+		Name          *string `json:"name"`
+		AlertsEnabled *bool   `json:"alerts_enabled"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	list, ok := u.wishList(w, user.ID, req.ListID)
+	if !ok {
+		return
+	}
+	arg := db.EditWishListParams{
+		ID:            list.ID,
+		UserID:        user.ID,
+		Name:          list.Name,
+		AlertsEnabled: list.AlertsEnabled,
+	}
+	if req.Name != nil {
+		if list.IsDefault {
+			http.Error(w, "the default wishlist can't be renamed", http.StatusBadRequest)
+			return
+		}
+		arg.Name = strings.TrimSpace(*req.Name)
+		if !validWishListName(w, arg.Name) {
+			return
+		}
+	}
+	if req.AlertsEnabled != nil {
+		arg.AlertsEnabled = *req.AlertsEnabled
+	}
+	list, err = u.DB.EditWishList(context.TODO(), arg)
+	if err != nil && strings.Contains(err.Error(), "wishlist_lists_user_id_name_unique") {
+		http.Error(w, "a wishlist with the name already exists", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Error("error editing wishlist in EditWishListHandler:", err.Error())
+		http.Error(w, "internal error editing wishlist", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data    respWishList `json:"data"`
+		Message string       `json:"message"`
+	}
+	resp.Data = toRespWishList(list, 0)
+	resp.Message = "successfully edited wishlist"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) DeleteWishListHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	listID, err := uuid.Parse(r.URL.Query().Get("list_id"))
+	if err != nil {
+		http.Error(w, "invalid list_id", http.StatusBadRequest)
+		return
+	}
+	// items of the list go with it
+	k, err := u.DB.DeleteWishList(context.TODO(), db.DeleteWishListParams{
+		ID:     listID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		log.Error("error deleting wishlist in DeleteWishListHandler:", err.Error())
+		http.Error(w, "internal error deleting wishlist", http.StatusInternalServerError)
+		return
+	} else if k == 0 {
+		http.Error(w, "invalid list_id, the default wishlist can't be deleted", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("successfully deleted wishlist"))
+}
+
+// ShareWishListHandler gives the list a new share token, sharing an already shared
+// list again invalidates the old link
+func (u *User) ShareWishListHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	list, ok := u.wishList(w, user.ID, r.URL.Query().Get("list_id"))
+	if !ok {
+		return
+	}
+	token, err := utils.GenerateRandomString(shareTokenBytes)
+	if err != nil {
+		log.Error("error generating share token in ShareWishListHandler:", err.Error())
+		http.Error(w, "internal error sharing wishlist", http.StatusInternalServerError)
+		return
+	}
+	list, err = u.DB.SetWishListShareToken(context.TODO(), db.SetWishListShareTokenParams{
+		ID:         list.ID,
+		UserID:     user.ID,
+		ShareToken: sql.NullString{String: token, Valid: true},
+	})
+	if err != nil {
+		log.Error("error setting share token in ShareWishListHandler:", err.Error())
+		http.Error(w, "internal error sharing wishlist", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		ListID     uuid.UUID `json:"list_id"`
+		ShareToken string    `json:"share_token"`
+		SharePath  string    `json:"share_path"`
+		Message    string    `json:"message"`
+	}
+	resp.ListID = list.ID
+	resp.ShareToken = list.ShareToken.String
+	resp.SharePath = "/user/wishlist/shared?token=" + list.ShareToken.String
+	resp.Message = "successfully shared wishlist, anyone with the link can view it"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (u *User) UnshareWishListHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	list, ok := u.wishList(w, user.ID, r.URL.Query().Get("list_id"))
+	if !ok {
+		return
+	}
+	_, err := u.DB.SetWishListShareToken(context.TODO(), db.SetWishListShareTokenParams{
+		ID:     list.ID,
+		UserID: user.ID,
+	})
+	if err != nil {
+		log.Error("error removing share token in UnshareWishListHandler:", err.Error())
+		http.Error(w, "internal error unsharing wishlist", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("wishlist is no longer shared, the old link stops working"))
+}
+
+// SharedWishListHandler is the read only view of a shared list, no login needed
+func (u *User) SharedWishListHandler(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		http.Error(w, "invalid token", http.StatusBadRequest)
+		return
+	}
+	list, err := u.DB.GetWishListByShareToken(context.TODO(), sql.NullString{String: token, Valid: true})
+	if err == sql.ErrNoRows {
+		http.Error(w, "no shared wishlist for the link", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Error("error fetching shared wishlist in SharedWishListHandler:", err.Error())
+		http.Error(w, "internal error fetching wishlist", http.StatusInternalServerError)
+		return
+	}
+	items, err := u.DB.GetWishListItemsByListID(context.TODO(), list.ID)
+	if err != nil {
+		log.Error("error fetching wishlist items in SharedWishListHandler:", err.Error())
+		http.Error(w, "internal error fetching wishlist items", http.StatusInternalServerError)
+		return
+	}
+	// only the name of the list is shown, not the owner or the token
+	var resp struct {
+		Name    string             `json:"name"`
+		Data    []respWishListItem `json:"data"`
+		Message string             `json:"message"`
+	}
+	resp.Name = list.Name
+	resp.Data = toRespWishListItems(items)
+	resp.Message = "successfully fetched shared wishlist"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// wishList alerts, run from StockAlertsCron
+
+// notifyWishListUsers sends a mail to every user with alerts on for a list holding the product
+func notifyWishListUsers(DB *db.Queries, productID uuid.UUID, send func(email string) error) int {
+	userIDs, err := DB.GetWishListAlertUserIDsByProductID(context.TODO(), productID)
+	if err != nil {
+		log.Error("error fetching wishlist users of product ", productID, ":", err.Error())
+		return 0
+	}
+	var sent int
+	for _, id := range userIDs {
+		user, err := userClient.GetUserByID(context.TODO(), &userpb.GetUserByIDRequest{UserID: id.String()})
+		if err != nil {
+			log.Error("error fetching user ", id, " for wishlist alert:", err.Error())
+			continue
+		} else if user.IsBlocked {
+			continue
+		}
+		if err = send(user.Email); err != nil {
+			log.Error("error sending wishlist alert to ", user.Email, ":", err.Error())
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+func processPriceChanges(DB *db.Queries) {
+	changes, err := DB.GetDuePriceAlerts(context.TODO())
+	if err != nil {
+		log.Error("error fetching due price alerts in processPriceChanges:", err.Error())
+		return
+	}
+	for _, c := range changes {
+		// price increases and sales ending are only kept as history
+		if c.NewPrice < c.OldPrice {
+			sent := notifyWishListUsers(DB, c.ProductID, func(email string) error {
+				return mail.SendPriceDropMail(c.ProductName, c.ProductID.String(), c.OldPrice, c.NewPrice, email)
+			})
+			log.Infof("notified %d users of price drop on product: %s", sent, c.ProductID)
+		}
+		err = DB.MarkPriceAlerted(context.TODO(), c.ID)
+		if err != nil {
+			log.Error("error marking price alerted:", err.Error())
+		}
+	}
+}
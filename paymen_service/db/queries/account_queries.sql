@@ -0,0 +1,57 @@
+-- queries for the account data export and deletion of the user service
+
+-- name: GetOrderItemsWithProductByUserID :many
+select oi.*, p.name as product_name from order_items oi
+inner join orders o
+on oi.order_id = o.id
+inner join products p
+on oi.product_id = p.id
+where o.user_id = $1
+order by oi.created_at;
+
+-- name: GetPaymentsByUserID :many
+select p.* from payments p
+inner join orders o
+on p.order_id = o.id
+where o.user_id = $1
+order by p.created_at;
+
+-- name: GetShippingAddressesByUserID :many
+select s.* from shipping_address s
+inner join orders o
+on s.order_id = o.id
+where o.user_id = $1;
+
+-- name: GetReturnRefundsByUserID :many
+select * from return_refunds
+where user_id = $1
+order by created_at;
+
+-- there is no wallet ledger, wallet payments are the debits and the wallet
+-- credits recorded in the audit log (cancellations and returns) are the credits
+-- name: GetWalletHistoryByUserID :many
+select 'debit'::text as kind, p.total_amount::float8 as amount, 'order payment'::text as reason,
+p.order_id::text as order_id, ''::text as order_item_id, p.created_at
+from payments p
+inner join orders o
+on p.order_id = o.id
+where o.user_id = @user_id::uuid and p.method = 'wallet' and p.status = 'successful'
+union all
+select 'credit', (l.after->>'credit')::float8, coalesce(l.after->>'reason', ''),
+coalesce(l.after->>'order_id', ''), coalesce(l.after->>'order_item_id', ''), l.created_at
+from payment_audit_logs l
+where l.action = 'wallet.credit' and l.entity_type = 'wallet' and l.entity_id = (@user_id::uuid)::text
+order by created_at;
+
+-- items still on their way, an account isn't erased while it has any
+-- name: CountUndeliveredOrderItemsByUserID :one
+select count(*) from order_items oi
+inner join orders o
+on oi.order_id = o.id
+where o.user_id = $1 and oi.status in ('pending', 'processing', 'shipped');
+
+-- the town, district, state and pincode are kept for the tax records of the order
+-- name: EraseShippingAddressesByUserID :exec
+update shipping_address
+set house_name = 'erased', street_name = 'erased', updated_at = current_timestamp
+where order_id in (select id from orders where user_id = $1);
@@ -3,7 +3,11 @@ select * from carts
 where id = $1;
 
 -- name: GetCartItemsByUserID :many
-select c.id as cart_id, p.id as product_id, p.name as product_name, c.quantity, p.price, (p.price * c.quantity)::numeric(10,2) as total_amount
+-- prices are the effective price right now so a running sale reaches the cart, checkout
+-- and the order items the invoices are made from. product_price_at is in the inventory schema
+select c.id as cart_id, p.id as product_id, p.name as product_name, c.quantity,
+coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as price,
+(coalesce(product_price_at(p.id, current_timestamp), p.price) * c.quantity)::numeric(10,2)::float8 as total_amount
 from carts c
 inner join products p
 on c.product_id = p.id
@@ -48,7 +52,7 @@ delete from carts
 where user_id = $1;
 
 -- name: GetSumOfCartItemsByUserID :one
-select cast(sum(p.price * cast(c.quantity as float)) as double precision) as total_amount
+select cast(sum(coalesce(product_price_at(p.id, current_timestamp), p.price) * cast(c.quantity as float)) as double precision) as total_amount
 from carts c 
 inner join products p on p.id = c.product_id
 where c.user_id = @user_id;
\ No newline at end of file
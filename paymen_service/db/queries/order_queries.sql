@@ -42,7 +42,7 @@ returning *;
 
 -- name: EditOrderAmountByID :one
 update orders
-set total_amount = @total_amount, discount_amount = @discount_amount, coupon_id = @coupon_id, updated_at = current_timestamp
+set total_amount = @total_amount, discount_amount = @discount_amount, shipping_amount = @shipping_amount, coupon_id = @coupon_id, updated_at = current_timestamp
 where id = @id
 returning *;
 
@@ -52,9 +52,9 @@ where id = $1;
 
 -- name: AddOrderITem :one
 insert into order_items
-(order_id, product_id, price, quantity)
+(order_id, product_id, price, quantity, shipping_amount)
 values
-($1, $2, $3, $4)
+($1, $2, $3, $4, $5)
 returning *;
 
 -- name: GetOrderItemsByUserID :many
@@ -74,6 +74,14 @@ inner join orders o
 on oi.order_id = o.id
 where oi.id = $1;
 
+-- name: GetOrderItemBuyerAndProduct :one
+select o.user_id, oi.order_id, p.name as product_name from order_items oi
+inner join orders o
+on oi.order_id = o.id
+inner join products p
+on oi.product_id = p.id
+where oi.id = $1;
+
 -- name: GetOrderItemsByOrderID :many
 select oi.*, p.name as product_name
 from order_items oi
@@ -131,10 +139,32 @@ inner join orders o
 on oi.order_id = o.id
 where oi.product_id = @product_id and 
 o.user_id = @user_id and
-(oi.status = 'delivered' or oi.status = 'returned')
+oi.status = 'delivered'
 limit 1;
 
 -- name: GetReviewByUserAndProductID :one
 select *
 from reviews r
-where r.user_id = $1 and r.product_id = $2;
\ No newline at end of file
+where r.user_id = $1 and r.product_id = $2;
+-- name: GetSellerFulfilmentStats :one
+-- an item is shipped on time when it left within @ship_within_days of being ordered
+select count(*) as total_items,
+count(*) filter (where oi.shipped_at is not null) as shipped_items,
+count(*) filter (where oi.shipped_at is not null and oi.shipped_at <= oi.created_at + make_interval(days => @ship_within_days::int)) as shipped_on_time,
+count(*) filter (where oi.status = 'delivered') as delivered_items,
+count(*) filter (where oi.status = 'cancelled') as cancelled_items,
+count(*) filter (where oi.status = 'returned') as returned_items
+from order_items oi
+inner join products p
+on oi.product_id = p.id
+where p.seller_id = @seller_id;
+
+-- the flag is set by the user service once the phone confirms an sms code
+-- name: GetUserPhoneVerifiedByID :one
+select phone_verified from users
+where id = $1;
+
+-- addresses are kept by the user service
+-- name: GetDefaultShippingAddressIDByUserID :one
+select id from addresses
+where user_id = $1 and is_default_shipping;
@@ -10,8 +10,9 @@ select * from payments
 where order_id = @order_id;
 
 -- name: DecPaymentAmountByOrderItemID :one
+-- the item's shipping share goes with it
 WITH cte AS (
-  SELECT oi.order_id, oi.total_amount
+  SELECT oi.order_id, oi.total_amount + oi.shipping_amount AS total_amount
   FROM order_items oi
   WHERE oi.id = $1
 )
@@ -16,7 +16,9 @@ CREATE TABLE orders (
     total_amount NUMERIC(10,2) NOT NULL DEFAULT -1, -- -1 when order is first created, later will be updated
     coupon_id UUID REFERENCES coupons(id),
     discount_amount NUMERIC(10,2) DEFAULT 0,
-    net_amount NUMERIC(10,2) GENERATED ALWAYS AS (total_amount - discount_amount) STORED,
+    -- shipping charged by the sellers' rules at checkout, the sum of the items' shares
+    shipping_amount NUMERIC(10,2) NOT NULL DEFAULT 0 CHECK (shipping_amount >= 0),
+    net_amount NUMERIC(10,2) GENERATED ALWAYS AS (total_amount - discount_amount + shipping_amount) STORED,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
 );
@@ -55,11 +57,34 @@ CREATE TABLE IF NOT EXISTS order_items (
     -- check == 0 since the orderItems cannot have 0 for total_amount 
     -- thus total_amount here never becomes zero  unless all the items are cancelled.
     total_amount NUMERIC(10, 2) NOT NULL CHECK (total_amount >=0),
+    -- the item's share of its seller's shipping charge, refunded with the item on cancelling
+    shipping_amount NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (shipping_amount >= 0),
     status TEXT NOT NULL CHECK (status in ('pending', 'processing', 'shipped', 'delivered', 'cancelled', 'returned')) DEFAULT 'pending',
+    -- set by the order_items_status_times trigger, used for the seller fulfilment stats
+    shipped_at TIMESTAMPTZ,
+    delivered_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK(updated_at>=created_at)
 );
 
+CREATE OR REPLACE FUNCTION order_items_status_times() RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status = 'shipped' AND NEW.shipped_at IS NULL THEN
+        NEW.shipped_at := CURRENT_TIMESTAMP;
+    ELSIF NEW.status = 'delivered' AND NEW.delivered_at IS NULL THEN
+        NEW.delivered_at := CURRENT_TIMESTAMP;
+        -- items marked delivered without passing through shipped count as shipped at delivery
+        NEW.shipped_at := COALESCE(NEW.shipped_at, CURRENT_TIMESTAMP);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS order_items_status_times_trigger ON order_items;
+CREATE TRIGGER order_items_status_times_trigger
+BEFORE UPDATE OF status ON order_items
+FOR EACH ROW EXECUTE FUNCTION order_items_status_times();
+
 
 CREATE TABLE IF NOT EXISTS vendor_payments (
     id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v4(),
@@ -101,3 +126,36 @@ CREATE TABLE IF NOT EXISTS return_refunds (
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at>=created_at)
 );
+
+-- append only log of administrative and financial actions (pkg/audit)
+CREATE TABLE IF NOT EXISTS payment_audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- null for actions of crons
+    actor_id UUID,
+    actor_role TEXT NOT NULL,
+    impersonator_id UUID,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_payment_audit_logs_created_at ON payment_audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_audit_logs_entity ON payment_audit_logs(entity_type, entity_id);
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log % is append only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS payment_audit_logs_append_only ON payment_audit_logs;
+CREATE TRIGGER payment_audit_logs_append_only BEFORE UPDATE OR DELETE ON payment_audit_logs
+FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+DROP TRIGGER IF EXISTS payment_audit_logs_no_truncate ON payment_audit_logs;
+CREATE TRIGGER payment_audit_logs_no_truncate BEFORE TRUNCATE ON payment_audit_logs
+FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
@@ -0,0 +1,283 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: account_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const countUndeliveredOrderItemsByUserID = `-- name: CountUndeliveredOrderItemsByUserID :one
+select count(*) from order_items oi
+inner join orders o
+on oi.order_id = o.id
+where o.user_id = $1 and oi.status in ('pending', 'processing', 'shipped')
+`
+
+// items still on their way, an account isn't erased while it has any
+func (q *Queries) CountUndeliveredOrderItemsByUserID(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.queryRow(ctx, q.countUndeliveredOrderItemsByUserIDStmt, countUndeliveredOrderItemsByUserID, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const eraseShippingAddressesByUserID = `-- name: EraseShippingAddressesByUserID :exec
+update shipping_address
+set house_name = 'erased', street_name = 'erased', updated_at = current_timestamp
+where order_id in (select id from orders where user_id = $1)
+`
+
+// the town, district, state and pincode are kept for the tax records of the order
+func (q *Queries) EraseShippingAddressesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.eraseShippingAddressesByUserIDStmt, eraseShippingAddressesByUserID, userID)
+	return err
+}
+
+const getOrderItemsWithProductByUserID = `-- name: GetOrderItemsWithProductByUserID :many
+
+select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.shipping_amount, oi.status, oi.shipped_at, oi.delivered_at, oi.created_at, oi.updated_at, p.name as product_name from order_items oi
+inner join orders o
+on oi.order_id = o.id
+inner join products p
+on oi.product_id = p.id
+where o.user_id = $1
+order by oi.created_at
+`
+
+type GetOrderItemsWithProductByUserIDRow struct {
+	ID             uuid.UUID    `json:"id"`
+	OrderID        uuid.UUID    `json:"order_id"`
+	ProductID      uuid.UUID    `json:"product_id"`
+	Price          float64      `json:"price"`
+	Quantity       int32        `json:"quantity"`
+	TotalAmount    float64      `json:"total_amount"`
+	ShippingAmount float64      `json:"shipping_amount"`
+	Status         string       `json:"status"`
+	ShippedAt      sql.NullTime `json:"shipped_at"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	ProductName    string       `json:"product_name"`
+}
+
+// queries for the account data export and deletion of the user service
+func (q *Queries) GetOrderItemsWithProductByUserID(ctx context.Context, userID uuid.UUID) ([]GetOrderItemsWithProductByUserIDRow, error) {
+	rows, err := q.query(ctx, q.getOrderItemsWithProductByUserIDStmt, getOrderItemsWithProductByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOrderItemsWithProductByUserIDRow{}
+	for rows.Next() {
+		var i GetOrderItemsWithProductByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.ProductID,
+			&i.Price,
+			&i.Quantity,
+			&i.TotalAmount,
+			&i.ShippingAmount,
+			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ProductName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPaymentsByUserID = `-- name: GetPaymentsByUserID :many
+select p.id, p.order_id, p.method, p.status, p.total_amount, p.transaction_id, p.created_at, p.updated_at from payments p
+inner join orders o
+on p.order_id = o.id
+where o.user_id = $1
+order by p.created_at
+`
+
+func (q *Queries) GetPaymentsByUserID(ctx context.Context, userID uuid.UUID) ([]Payment, error) {
+	rows, err := q.query(ctx, q.getPaymentsByUserIDStmt, getPaymentsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Payment{}
+	for rows.Next() {
+		var i Payment
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.Method,
+			&i.Status,
+			&i.TotalAmount,
+			&i.TransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReturnRefundsByUserID = `-- name: GetReturnRefundsByUserID :many
+select id, user_id, order_item_id, payment_id, item_amount, discount_removal_amount, refund_amount, status, created_at, updated_at from return_refunds
+where user_id = $1
+order by created_at
+`
+
+func (q *Queries) GetReturnRefundsByUserID(ctx context.Context, userID uuid.UUID) ([]ReturnRefund, error) {
+	rows, err := q.query(ctx, q.getReturnRefundsByUserIDStmt, getReturnRefundsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReturnRefund{}
+	for rows.Next() {
+		var i ReturnRefund
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.OrderItemID,
+			&i.PaymentID,
+			&i.ItemAmount,
+			&i.DiscountRemovalAmount,
+			&i.RefundAmount,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getShippingAddressesByUserID = `-- name: GetShippingAddressesByUserID :many
+select s.id, s.order_id, s.house_name, s.street_name, s.town, s.district, s.state, s.pincode, s.created_at, s.updated_at from shipping_address s
+inner join orders o
+on s.order_id = o.id
+where o.user_id = $1
+`
+
+func (q *Queries) GetShippingAddressesByUserID(ctx context.Context, userID uuid.UUID) ([]ShippingAddress, error) {
+	rows, err := q.query(ctx, q.getShippingAddressesByUserIDStmt, getShippingAddressesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ShippingAddress{}
+	for rows.Next() {
+		var i ShippingAddress
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.HouseName,
+			&i.StreetName,
+			&i.Town,
+			&i.District,
+			&i.State,
+			&i.Pincode,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWalletHistoryByUserID = `-- name: GetWalletHistoryByUserID :many
+select 'debit'::text as kind, p.total_amount::float8 as amount, 'order payment'::text as reason,
+p.order_id::text as order_id, ''::text as order_item_id, p.created_at
+from payments p
+inner join orders o
+on p.order_id = o.id
+where o.user_id = $1::uuid and p.method = 'wallet' and p.status = 'successful'
+union all
+select 'credit', (l.after->>'credit')::float8, coalesce(l.after->>'reason', ''),
+coalesce(l.after->>'order_id', ''), coalesce(l.after->>'order_item_id', ''), l.created_at
+from payment_audit_logs l
+where l.action = 'wallet.credit' and l.entity_type = 'wallet' and l.entity_id = ($1::uuid)::text
+order by created_at
+`
+
+type GetWalletHistoryByUserIDRow struct {
+	Kind        string    `json:"kind"`
+	Amount      float64   `json:"amount"`
+	Reason      string    `json:"reason"`
+	OrderID     string    `json:"order_id"`
+	OrderItemID string    `json:"order_item_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// there is no wallet ledger, wallet payments are the debits and the wallet
+// credits recorded in the audit log (cancellations and returns) are the credits
+func (q *Queries) GetWalletHistoryByUserID(ctx context.Context, userID uuid.UUID) ([]GetWalletHistoryByUserIDRow, error) {
+	rows, err := q.query(ctx, q.getWalletHistoryByUserIDStmt, getWalletHistoryByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetWalletHistoryByUserIDRow{}
+	for rows.Next() {
+		var i GetWalletHistoryByUserIDRow
+		if err := rows.Scan(
+			&i.Kind,
+			&i.Amount,
+			&i.Reason,
+			&i.OrderID,
+			&i.OrderItemID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
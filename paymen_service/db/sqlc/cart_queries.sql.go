@@ -134,7 +134,9 @@ func (q *Queries) GetCartItemByUserIDAndProductID(ctx context.Context, arg GetCa
 }
 
 const getCartItemsByUserID = `-- name: GetCartItemsByUserID :many
-select c.id as cart_id, p.id as product_id, p.name as product_name, c.quantity, p.price, (p.price * c.quantity)::numeric(10,2) as total_amount
+select c.id as cart_id, p.id as product_id, p.name as product_name, c.quantity,
+coalesce(product_price_at(p.id, current_timestamp), p.price)::float8 as price,
+(coalesce(product_price_at(p.id, current_timestamp), p.price) * c.quantity)::numeric(10,2)::float8 as total_amount
 from carts c
 inner join products p
 on c.product_id = p.id
@@ -146,10 +148,12 @@ type GetCartItemsByUserIDRow struct {
 	ProductID   uuid.UUID `json:"product_id"`
 	ProductName string    `json:"product_name"`
 	Quantity    int32     `json:"quantity"`
-	Price       string    `json:"price"`
-	TotalAmount string    `json:"total_amount"`
+	Price       float64   `json:"price"`
+	TotalAmount float64   `json:"total_amount"`
 }
 
+// prices are the effective price right now so a running sale reaches the cart, checkout
+// and the order items the invoices are made from. product_price_at is in the inventory schema
 func (q *Queries) GetCartItemsByUserID(ctx context.Context, userID uuid.UUID) ([]GetCartItemsByUserIDRow, error) {
 	rows, err := q.query(ctx, q.getCartItemsByUserIDStmt, getCartItemsByUserID, userID)
 	if err != nil {
@@ -181,7 +185,7 @@ func (q *Queries) GetCartItemsByUserID(ctx context.Context, userID uuid.UUID) ([
 }
 
 const getProductFromCartByID = `-- name: GetProductFromCartByID :one
-select p.id, p.name, p.description, p.price, p.stock, p.seller_id, p.is_deleted, p.created_at, p.updated_at from carts c
+select p.id, p.name, p.description, p.price, p.mrp, p.stock, p.sold_count, p.low_stock_threshold, p.weight_grams, p.seller_id, p.is_deleted, p.created_at, p.updated_at from carts c
 inner join products p
 on c.product_id = p.id
 where c.id = $1
@@ -195,7 +199,11 @@ func (q *Queries) GetProductFromCartByID(ctx context.Context, id uuid.UUID) (Pro
 		&i.Name,
 		&i.Description,
 		&i.Price,
+		&i.Mrp,
 		&i.Stock,
+		&i.SoldCount,
+		&i.LowStockThreshold,
+		&i.WeightGrams,
 		&i.SellerID,
 		&i.IsDeleted,
 		&i.CreatedAt,
@@ -225,7 +233,7 @@ func (q *Queries) GetProductNameAndQuantityFromCartsByID(ctx context.Context, id
 }
 
 const getSumOfCartItemsByUserID = `-- name: GetSumOfCartItemsByUserID :one
-select cast(sum(p.price * cast(c.quantity as float)) as double precision) as total_amount
+select cast(sum(coalesce(product_price_at(p.id, current_timestamp), p.price) * cast(c.quantity as float)) as double precision) as total_amount
 from carts c 
 inner join products p on p.id = c.product_id
 where c.user_id = $1
@@ -60,6 +60,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.changeOrderItemStatusByIDStmt, err = db.PrepareContext(ctx, changeOrderItemStatusByID); err != nil {
 		return nil, fmt.Errorf("error preparing query ChangeOrderItemStatusByID: %w", err)
 	}
+	if q.countUndeliveredOrderItemsByUserIDStmt, err = db.PrepareContext(ctx, countUndeliveredOrderItemsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUndeliveredOrderItemsByUserID: %w", err)
+	}
 	if q.decPaymentAmountByOrderItemIDStmt, err = db.PrepareContext(ctx, decPaymentAmountByOrderItemID); err != nil {
 		return nil, fmt.Errorf("error preparing query DecPaymentAmountByOrderItemID: %w", err)
 	}
@@ -105,6 +108,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.editVendorPaymentStatusByOrderItemIDStmt, err = db.PrepareContext(ctx, editVendorPaymentStatusByOrderItemID); err != nil {
 		return nil, fmt.Errorf("error preparing query EditVendorPaymentStatusByOrderItemID: %w", err)
 	}
+	if q.eraseShippingAddressesByUserIDStmt, err = db.PrepareContext(ctx, eraseShippingAddressesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query EraseShippingAddressesByUserID: %w", err)
+	}
 	if q.getAllCouponsStmt, err = db.PrepareContext(ctx, getAllCoupons); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllCoupons: %w", err)
 	}
@@ -132,9 +138,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getCouponByNameStmt, err = db.PrepareContext(ctx, getCouponByName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCouponByName: %w", err)
 	}
+	if q.getDefaultShippingAddressIDByUserIDStmt, err = db.PrepareContext(ctx, getDefaultShippingAddressIDByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDefaultShippingAddressIDByUserID: %w", err)
+	}
 	if q.getOrderByIDStmt, err = db.PrepareContext(ctx, getOrderByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrderByID: %w", err)
 	}
+	if q.getOrderItemBuyerAndProductStmt, err = db.PrepareContext(ctx, getOrderItemBuyerAndProduct); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrderItemBuyerAndProduct: %w", err)
+	}
 	if q.getOrderItemByIDStmt, err = db.PrepareContext(ctx, getOrderItemByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrderItemByID: %w", err)
 	}
@@ -153,27 +165,42 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getOrderItemsByUserIDStmt, err = db.PrepareContext(ctx, getOrderItemsByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrderItemsByUserID: %w", err)
 	}
+	if q.getOrderItemsWithProductByUserIDStmt, err = db.PrepareContext(ctx, getOrderItemsWithProductByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrderItemsWithProductByUserID: %w", err)
+	}
 	if q.getOrdersByUserIDStmt, err = db.PrepareContext(ctx, getOrdersByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetOrdersByUserID: %w", err)
 	}
 	if q.getPaymentByOrderIDStmt, err = db.PrepareContext(ctx, getPaymentByOrderID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetPaymentByOrderID: %w", err)
 	}
+	if q.getPaymentsByUserIDStmt, err = db.PrepareContext(ctx, getPaymentsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPaymentsByUserID: %w", err)
+	}
 	if q.getProductFromCartByIDStmt, err = db.PrepareContext(ctx, getProductFromCartByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductFromCartByID: %w", err)
 	}
 	if q.getProductNameAndQuantityFromCartsByIDStmt, err = db.PrepareContext(ctx, getProductNameAndQuantityFromCartsByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetProductNameAndQuantityFromCartsByID: %w", err)
 	}
+	if q.getReturnRefundsByUserIDStmt, err = db.PrepareContext(ctx, getReturnRefundsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetReturnRefundsByUserID: %w", err)
+	}
 	if q.getReviewByUserAndProductIDStmt, err = db.PrepareContext(ctx, getReviewByUserAndProductID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetReviewByUserAndProductID: %w", err)
 	}
+	if q.getSellerFulfilmentStatsStmt, err = db.PrepareContext(ctx, getSellerFulfilmentStats); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerFulfilmentStats: %w", err)
+	}
 	if q.getSellerIDFromOrderItemIDStmt, err = db.PrepareContext(ctx, getSellerIDFromOrderItemID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSellerIDFromOrderItemID: %w", err)
 	}
 	if q.getShippingAddressByOrderIDStmt, err = db.PrepareContext(ctx, getShippingAddressByOrderID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetShippingAddressByOrderID: %w", err)
 	}
+	if q.getShippingAddressesByUserIDStmt, err = db.PrepareContext(ctx, getShippingAddressesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetShippingAddressesByUserID: %w", err)
+	}
 	if q.getSumOfCartItemsByUserIDStmt, err = db.PrepareContext(ctx, getSumOfCartItemsByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSumOfCartItemsByUserID: %w", err)
 	}
@@ -183,6 +210,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserIDFromOrderItemIDStmt, err = db.PrepareContext(ctx, getUserIDFromOrderItemID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserIDFromOrderItemID: %w", err)
 	}
+	if q.getUserPhoneVerifiedByIDStmt, err = db.PrepareContext(ctx, getUserPhoneVerifiedByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserPhoneVerifiedByID: %w", err)
+	}
 	if q.getValidCouponByNameStmt, err = db.PrepareContext(ctx, getValidCouponByName); err != nil {
 		return nil, fmt.Errorf("error preparing query GetValidCouponByName: %w", err)
 	}
@@ -198,6 +228,9 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getVendorPaymentsBySellerIDAndDateRangeStmt, err = db.PrepareContext(ctx, getVendorPaymentsBySellerIDAndDateRange); err != nil {
 		return nil, fmt.Errorf("error preparing query GetVendorPaymentsBySellerIDAndDateRange: %w", err)
 	}
+	if q.getWalletHistoryByUserIDStmt, err = db.PrepareContext(ctx, getWalletHistoryByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetWalletHistoryByUserID: %w", err)
+	}
 	if q.updateOrderTotalAmountStmt, err = db.PrepareContext(ctx, updateOrderTotalAmount); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateOrderTotalAmount: %w", err)
 	}
@@ -266,6 +299,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing changeOrderItemStatusByIDStmt: %w", cerr)
 		}
 	}
+	if q.countUndeliveredOrderItemsByUserIDStmt != nil {
+		if cerr := q.countUndeliveredOrderItemsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUndeliveredOrderItemsByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.decPaymentAmountByOrderItemIDStmt != nil {
 		if cerr := q.decPaymentAmountByOrderItemIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing decPaymentAmountByOrderItemIDStmt: %w", cerr)
@@ -341,6 +379,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing editVendorPaymentStatusByOrderItemIDStmt: %w", cerr)
 		}
 	}
+	if q.eraseShippingAddressesByUserIDStmt != nil {
+		if cerr := q.eraseShippingAddressesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing eraseShippingAddressesByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getAllCouponsStmt != nil {
 		if cerr := q.getAllCouponsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAllCouponsStmt: %w", cerr)
@@ -386,11 +429,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getCouponByNameStmt: %w", cerr)
 		}
 	}
+	if q.getDefaultShippingAddressIDByUserIDStmt != nil {
+		if cerr := q.getDefaultShippingAddressIDByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDefaultShippingAddressIDByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getOrderByIDStmt != nil {
 		if cerr := q.getOrderByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOrderByIDStmt: %w", cerr)
 		}
 	}
+	if q.getOrderItemBuyerAndProductStmt != nil {
+		if cerr := q.getOrderItemBuyerAndProductStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrderItemBuyerAndProductStmt: %w", cerr)
+		}
+	}
 	if q.getOrderItemByIDStmt != nil {
 		if cerr := q.getOrderItemByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOrderItemByIDStmt: %w", cerr)
@@ -421,6 +474,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getOrderItemsByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.getOrderItemsWithProductByUserIDStmt != nil {
+		if cerr := q.getOrderItemsWithProductByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrderItemsWithProductByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getOrdersByUserIDStmt != nil {
 		if cerr := q.getOrdersByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getOrdersByUserIDStmt: %w", cerr)
@@ -431,6 +489,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getPaymentByOrderIDStmt: %w", cerr)
 		}
 	}
+	if q.getPaymentsByUserIDStmt != nil {
+		if cerr := q.getPaymentsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPaymentsByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getProductFromCartByIDStmt != nil {
 		if cerr := q.getProductFromCartByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getProductFromCartByIDStmt: %w", cerr)
@@ -441,11 +504,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getProductNameAndQuantityFromCartsByIDStmt: %w", cerr)
 		}
 	}
+	if q.getReturnRefundsByUserIDStmt != nil {
+		if cerr := q.getReturnRefundsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getReturnRefundsByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getReviewByUserAndProductIDStmt != nil {
 		if cerr := q.getReviewByUserAndProductIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getReviewByUserAndProductIDStmt: %w", cerr)
 		}
 	}
+	if q.getSellerFulfilmentStatsStmt != nil {
+		if cerr := q.getSellerFulfilmentStatsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerFulfilmentStatsStmt: %w", cerr)
+		}
+	}
 	if q.getSellerIDFromOrderItemIDStmt != nil {
 		if cerr := q.getSellerIDFromOrderItemIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSellerIDFromOrderItemIDStmt: %w", cerr)
@@ -456,6 +529,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getShippingAddressByOrderIDStmt: %w", cerr)
 		}
 	}
+	if q.getShippingAddressesByUserIDStmt != nil {
+		if cerr := q.getShippingAddressesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getShippingAddressesByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getSumOfCartItemsByUserIDStmt != nil {
 		if cerr := q.getSumOfCartItemsByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSumOfCartItemsByUserIDStmt: %w", cerr)
@@ -471,6 +549,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserIDFromOrderItemIDStmt: %w", cerr)
 		}
 	}
+	if q.getUserPhoneVerifiedByIDStmt != nil {
+		if cerr := q.getUserPhoneVerifiedByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserPhoneVerifiedByIDStmt: %w", cerr)
+		}
+	}
 	if q.getValidCouponByNameStmt != nil {
 		if cerr := q.getValidCouponByNameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getValidCouponByNameStmt: %w", cerr)
@@ -496,6 +579,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getVendorPaymentsBySellerIDAndDateRangeStmt: %w", cerr)
 		}
 	}
+	if q.getWalletHistoryByUserIDStmt != nil {
+		if cerr := q.getWalletHistoryByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getWalletHistoryByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.updateOrderTotalAmountStmt != nil {
 		if cerr := q.updateOrderTotalAmountStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateOrderTotalAmountStmt: %w", cerr)
@@ -552,6 +640,7 @@ type Queries struct {
 	cancelVendorPaymentByOrderItemIDStmt        *sql.Stmt
 	cancelVendorPaymentsByOrderIDStmt           *sql.Stmt
 	changeOrderItemStatusByIDStmt               *sql.Stmt
+	countUndeliveredOrderItemsByUserIDStmt      *sql.Stmt
 	decPaymentAmountByOrderItemIDStmt           *sql.Stmt
 	deleteCartItemByUserIDAndProductIDStmt      *sql.Stmt
 	deleteCartItemsByUserIDStmt                 *sql.Stmt
@@ -567,6 +656,7 @@ type Queries struct {
 	editPaymentStatusByIDStmt                   *sql.Stmt
 	editPaymentStatusByOrderIDStmt              *sql.Stmt
 	editVendorPaymentStatusByOrderItemIDStmt    *sql.Stmt
+	eraseShippingAddressesByUserIDStmt          *sql.Stmt
 	getAllCouponsStmt                           *sql.Stmt
 	getAllCouponsForAdminStmt                   *sql.Stmt
 	getAllOrderItemsForAdminStmt                *sql.Stmt
@@ -576,28 +666,37 @@ type Queries struct {
 	getCartItemsByUserIDStmt                    *sql.Stmt
 	getCouponByIDStmt                           *sql.Stmt
 	getCouponByNameStmt                         *sql.Stmt
+	getDefaultShippingAddressIDByUserIDStmt     *sql.Stmt
 	getOrderByIDStmt                            *sql.Stmt
+	getOrderItemBuyerAndProductStmt             *sql.Stmt
 	getOrderItemByIDStmt                        *sql.Stmt
 	getOrderItemByUserAndProductIDStmt          *sql.Stmt
 	getOrderItemsByOrderIDStmt                  *sql.Stmt
 	getOrderItemsBySellerIDStmt                 *sql.Stmt
 	getOrderItemsBySellerIDAndDateRangeStmt     *sql.Stmt
 	getOrderItemsByUserIDStmt                   *sql.Stmt
+	getOrderItemsWithProductByUserIDStmt        *sql.Stmt
 	getOrdersByUserIDStmt                       *sql.Stmt
 	getPaymentByOrderIDStmt                     *sql.Stmt
+	getPaymentsByUserIDStmt                     *sql.Stmt
 	getProductFromCartByIDStmt                  *sql.Stmt
 	getProductNameAndQuantityFromCartsByIDStmt  *sql.Stmt
+	getReturnRefundsByUserIDStmt                *sql.Stmt
 	getReviewByUserAndProductIDStmt             *sql.Stmt
+	getSellerFulfilmentStatsStmt                *sql.Stmt
 	getSellerIDFromOrderItemIDStmt              *sql.Stmt
 	getShippingAddressByOrderIDStmt             *sql.Stmt
+	getShippingAddressesByUserIDStmt            *sql.Stmt
 	getSumOfCartItemsByUserIDStmt               *sql.Stmt
 	getTotalAmountOfCartItemsStmt               *sql.Stmt
 	getUserIDFromOrderItemIDStmt                *sql.Stmt
+	getUserPhoneVerifiedByIDStmt                *sql.Stmt
 	getValidCouponByNameStmt                    *sql.Stmt
 	getVendorPaymentByOrderItemIDStmt           *sql.Stmt
 	getVendorPaymentsByDateRangeStmt            *sql.Stmt
 	getVendorPaymentsBySellerIDStmt             *sql.Stmt
 	getVendorPaymentsBySellerIDAndDateRangeStmt *sql.Stmt
+	getWalletHistoryByUserIDStmt                *sql.Stmt
 	updateOrderTotalAmountStmt                  *sql.Stmt
 }
 
@@ -617,6 +716,7 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		cancelVendorPaymentByOrderItemIDStmt:        q.cancelVendorPaymentByOrderItemIDStmt,
 		cancelVendorPaymentsByOrderIDStmt:           q.cancelVendorPaymentsByOrderIDStmt,
 		changeOrderItemStatusByIDStmt:               q.changeOrderItemStatusByIDStmt,
+		countUndeliveredOrderItemsByUserIDStmt:      q.countUndeliveredOrderItemsByUserIDStmt,
 		decPaymentAmountByOrderItemIDStmt:           q.decPaymentAmountByOrderItemIDStmt,
 		deleteCartItemByUserIDAndProductIDStmt:      q.deleteCartItemByUserIDAndProductIDStmt,
 		deleteCartItemsByUserIDStmt:                 q.deleteCartItemsByUserIDStmt,
@@ -632,6 +732,7 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		editPaymentStatusByIDStmt:                   q.editPaymentStatusByIDStmt,
 		editPaymentStatusByOrderIDStmt:              q.editPaymentStatusByOrderIDStmt,
 		editVendorPaymentStatusByOrderItemIDStmt:    q.editVendorPaymentStatusByOrderItemIDStmt,
+		eraseShippingAddressesByUserIDStmt:          q.eraseShippingAddressesByUserIDStmt,
 		getAllCouponsStmt:                           q.getAllCouponsStmt,
 		getAllCouponsForAdminStmt:                   q.getAllCouponsForAdminStmt,
 		getAllOrderItemsForAdminStmt:                q.getAllOrderItemsForAdminStmt,
@@ -641,28 +742,37 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		getCartItemsByUserIDStmt:                    q.getCartItemsByUserIDStmt,
 		getCouponByIDStmt:                           q.getCouponByIDStmt,
 		getCouponByNameStmt:                         q.getCouponByNameStmt,
+		getDefaultShippingAddressIDByUserIDStmt:     q.getDefaultShippingAddressIDByUserIDStmt,
 		getOrderByIDStmt:                            q.getOrderByIDStmt,
+		getOrderItemBuyerAndProductStmt:             q.getOrderItemBuyerAndProductStmt,
 		getOrderItemByIDStmt:                        q.getOrderItemByIDStmt,
 		getOrderItemByUserAndProductIDStmt:          q.getOrderItemByUserAndProductIDStmt,
 		getOrderItemsByOrderIDStmt:                  q.getOrderItemsByOrderIDStmt,
 		getOrderItemsBySellerIDStmt:                 q.getOrderItemsBySellerIDStmt,
 		getOrderItemsBySellerIDAndDateRangeStmt:     q.getOrderItemsBySellerIDAndDateRangeStmt,
 		getOrderItemsByUserIDStmt:                   q.getOrderItemsByUserIDStmt,
+		getOrderItemsWithProductByUserIDStmt:        q.getOrderItemsWithProductByUserIDStmt,
 		getOrdersByUserIDStmt:                       q.getOrdersByUserIDStmt,
 		getPaymentByOrderIDStmt:                     q.getPaymentByOrderIDStmt,
+		getPaymentsByUserIDStmt:                     q.getPaymentsByUserIDStmt,
 		getProductFromCartByIDStmt:                  q.getProductFromCartByIDStmt,
 		getProductNameAndQuantityFromCartsByIDStmt:  q.getProductNameAndQuantityFromCartsByIDStmt,
+		getReturnRefundsByUserIDStmt:                q.getReturnRefundsByUserIDStmt,
 		getReviewByUserAndProductIDStmt:             q.getReviewByUserAndProductIDStmt,
+		getSellerFulfilmentStatsStmt:                q.getSellerFulfilmentStatsStmt,
 		getSellerIDFromOrderItemIDStmt:              q.getSellerIDFromOrderItemIDStmt,
 		getShippingAddressByOrderIDStmt:             q.getShippingAddressByOrderIDStmt,
+		getShippingAddressesByUserIDStmt:            q.getShippingAddressesByUserIDStmt,
 		getSumOfCartItemsByUserIDStmt:               q.getSumOfCartItemsByUserIDStmt,
 		getTotalAmountOfCartItemsStmt:               q.getTotalAmountOfCartItemsStmt,
 		getUserIDFromOrderItemIDStmt:                q.getUserIDFromOrderItemIDStmt,
+		getUserPhoneVerifiedByIDStmt:                q.getUserPhoneVerifiedByIDStmt,
 		getValidCouponByNameStmt:                    q.getValidCouponByNameStmt,
 		getVendorPaymentByOrderItemIDStmt:           q.getVendorPaymentByOrderItemIDStmt,
 		getVendorPaymentsByDateRangeStmt:            q.getVendorPaymentsByDateRangeStmt,
 		getVendorPaymentsBySellerIDStmt:             q.getVendorPaymentsBySellerIDStmt,
 		getVendorPaymentsBySellerIDAndDateRangeStmt: q.getVendorPaymentsBySellerIDAndDateRangeStmt,
+		getWalletHistoryByUserIDStmt:                q.getWalletHistoryByUserIDStmt,
 		updateOrderTotalAmountStmt:                  q.updateOrderTotalAmountStmt,
 	}
 }
@@ -9,8 +9,30 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
 )
 
+type Address struct {
+	ID                uuid.UUID       `json:"id"`
+	UserID            uuid.UUID       `json:"user_id"`
+	Type              string          `json:"type"`
+	BuildingName      string          `json:"building_name"`
+	StreetName        string          `json:"street_name"`
+	Town              string          `json:"town"`
+	District          string          `json:"district"`
+	State             string          `json:"state"`
+	Pincode           int32           `json:"pincode"`
+	Label             string          `json:"label"`
+	ContactName       sql.NullString  `json:"contact_name"`
+	ContactPhone      sql.NullInt64   `json:"contact_phone"`
+	Latitude          sql.NullFloat64 `json:"latitude"`
+	Longitude         sql.NullFloat64 `json:"longitude"`
+	IsDefaultShipping bool            `json:"is_default_shipping"`
+	IsDefaultBilling  bool            `json:"is_default_billing"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
 type Cart struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
@@ -37,21 +59,25 @@ type Order struct {
 	TotalAmount    float64       `json:"total_amount"`
 	CouponID       uuid.NullUUID `json:"coupon_id"`
 	DiscountAmount float64       `json:"discount_amount"`
+	ShippingAmount float64       `json:"shipping_amount"`
 	NetAmount      float64       `json:"net_amount"`
 	CreatedAt      time.Time     `json:"created_at"`
 	UpdatedAt      time.Time     `json:"updated_at"`
 }
 
 type OrderItem struct {
-	ID          uuid.UUID `json:"id"`
-	OrderID     uuid.UUID `json:"order_id"`
-	ProductID   uuid.UUID `json:"product_id"`
-	Price       float64   `json:"price"`
-	Quantity    int32     `json:"quantity"`
-	TotalAmount float64   `json:"total_amount"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID             uuid.UUID    `json:"id"`
+	OrderID        uuid.UUID    `json:"order_id"`
+	ProductID      uuid.UUID    `json:"product_id"`
+	Price          float64      `json:"price"`
+	Quantity       int32        `json:"quantity"`
+	TotalAmount    float64      `json:"total_amount"`
+	ShippingAmount float64      `json:"shipping_amount"`
+	Status         string       `json:"status"`
+	ShippedAt      sql.NullTime `json:"shipped_at"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
 }
 
 type Payment struct {
@@ -65,16 +91,47 @@ type Payment struct {
 	UpdatedAt     time.Time      `json:"updated_at"`
 }
 
+type PaymentAuditLog struct {
+	ID             uuid.UUID             `json:"id"`
+	ActorID        uuid.NullUUID         `json:"actor_id"`
+	ActorRole      string                `json:"actor_role"`
+	ImpersonatorID uuid.NullUUID         `json:"impersonator_id"`
+	Action         string                `json:"action"`
+	EntityType     string                `json:"entity_type"`
+	EntityID       string                `json:"entity_id"`
+	Before         pqtype.NullRawMessage `json:"before"`
+	After          pqtype.NullRawMessage `json:"after"`
+	IpAddress      string                `json:"ip_address"`
+	UserAgent      string                `json:"user_agent"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
 type Product struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Price       string    `json:"price"`
-	Stock       int32     `json:"stock"`
-	SellerID    uuid.UUID `json:"seller_id"`
-	IsDeleted   bool      `json:"is_deleted"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID                uuid.UUID      `json:"id"`
+	Name              string         `json:"name"`
+	Description       string         `json:"description"`
+	Price             string         `json:"price"`
+	Mrp               sql.NullString `json:"mrp"`
+	Stock             int32          `json:"stock"`
+	SoldCount         int32          `json:"sold_count"`
+	LowStockThreshold int32          `json:"low_stock_threshold"`
+	WeightGrams       int32          `json:"weight_grams"`
+	SellerID          uuid.UUID      `json:"seller_id"`
+	IsDeleted         bool           `json:"is_deleted"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+type ProductPrice struct {
+	ID          uuid.UUID    `json:"id"`
+	ProductID   uuid.UUID    `json:"product_id"`
+	Kind        string       `json:"kind"`
+	Price       string       `json:"price"`
+	StartsAt    time.Time    `json:"starts_at"`
+	EndsAt      sql.NullTime `json:"ends_at"`
+	IsCancelled bool         `json:"is_cancelled"`
+	AlertedAt   sql.NullTime `json:"alerted_at"`
+	CreatedAt   time.Time    `json:"created_at"`
 }
 
 type ReturnRefund struct {
@@ -91,15 +148,17 @@ type ReturnRefund struct {
 }
 
 type Review struct {
-	ID        uuid.UUID      `json:"id"`
-	UserID    uuid.UUID      `json:"user_id"`
-	ProductID uuid.UUID      `json:"product_id"`
-	Rating    int32          `json:"rating"`
-	Comment   sql.NullString `json:"comment"`
-	IsDeleted bool           `json:"is_deleted"`
-	IsEdited  bool           `json:"is_edited"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	ID               uuid.UUID      `json:"id"`
+	UserID           uuid.UUID      `json:"user_id"`
+	ProductID        uuid.UUID      `json:"product_id"`
+	Rating           int32          `json:"rating"`
+	Comment          sql.NullString `json:"comment"`
+	IsDeleted        bool           `json:"is_deleted"`
+	IsEdited         bool           `json:"is_edited"`
+	IsVerified       bool           `json:"is_verified"`
+	ModerationStatus string         `json:"moderation_status"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
 }
 
 type ShippingAddress struct {
@@ -115,6 +174,23 @@ type ShippingAddress struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+type User struct {
+	ID            uuid.UUID      `json:"id"`
+	Name          string         `json:"name"`
+	Email         string         `json:"email"`
+	Phone         sql.NullInt64  `json:"phone"`
+	Password      string         `json:"password"`
+	Role          string         `json:"role"`
+	EmailVerified bool           `json:"email_verified"`
+	PhoneVerified bool           `json:"phone_verified"`
+	UserVerified  bool           `json:"user_verified"`
+	IsBlocked     bool           `json:"is_blocked"`
+	GstNo         sql.NullString `json:"gst_no"`
+	About         sql.NullString `json:"about"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
 type VendorPayment struct {
 	ID           uuid.UUID `json:"id"`
 	OrderItemID  uuid.UUID `json:"order_item_id"`
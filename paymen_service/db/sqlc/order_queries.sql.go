@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,7 +17,7 @@ const addOrder = `-- name: AddOrder :one
 insert into orders
 (user_id)
 values ($1)
-returning id, user_id, total_amount, coupon_id, discount_amount, net_amount, created_at, updated_at
+returning id, user_id, total_amount, coupon_id, discount_amount, shipping_amount, net_amount, created_at, updated_at
 `
 
 func (q *Queries) AddOrder(ctx context.Context, userID uuid.UUID) (Order, error) {
@@ -28,6 +29,7 @@ func (q *Queries) AddOrder(ctx context.Context, userID uuid.UUID) (Order, error)
 		&i.TotalAmount,
 		&i.CouponID,
 		&i.DiscountAmount,
+		&i.ShippingAmount,
 		&i.NetAmount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -37,17 +39,18 @@ func (q *Queries) AddOrder(ctx context.Context, userID uuid.UUID) (Order, error)
 
 const addOrderITem = `-- name: AddOrderITem :one
 insert into order_items
-(order_id, product_id, price, quantity)
+(order_id, product_id, price, quantity, shipping_amount)
 values
-($1, $2, $3, $4)
-returning id, order_id, product_id, price, quantity, total_amount, status, created_at, updated_at
+($1, $2, $3, $4, $5)
+returning id, order_id, product_id, price, quantity, total_amount, shipping_amount, status, shipped_at, delivered_at, created_at, updated_at
 `
 
 type AddOrderITemParams struct {
-	OrderID   uuid.UUID `json:"order_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Price     float64   `json:"price"`
-	Quantity  int32     `json:"quantity"`
+	OrderID        uuid.UUID `json:"order_id"`
+	ProductID      uuid.UUID `json:"product_id"`
+	Price          float64   `json:"price"`
+	Quantity       int32     `json:"quantity"`
+	ShippingAmount float64   `json:"shipping_amount"`
 }
 
 func (q *Queries) AddOrderITem(ctx context.Context, arg AddOrderITemParams) (OrderItem, error) {
@@ -56,6 +59,7 @@ func (q *Queries) AddOrderITem(ctx context.Context, arg AddOrderITemParams) (Ord
 		arg.ProductID,
 		arg.Price,
 		arg.Quantity,
+		arg.ShippingAmount,
 	)
 	var i OrderItem
 	err := row.Scan(
@@ -65,7 +69,10 @@ func (q *Queries) AddOrderITem(ctx context.Context, arg AddOrderITemParams) (Ord
 		&i.Price,
 		&i.Quantity,
 		&i.TotalAmount,
+		&i.ShippingAmount,
 		&i.Status,
+		&i.ShippedAt,
+		&i.DeliveredAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -127,7 +134,7 @@ const cancelOrderByID = `-- name: CancelOrderByID :many
 update order_items
 set status = 'cancelled', updated_at = current_timestamp
 where order_id = $1
-returning id, order_id, product_id, price, quantity, total_amount, status, created_at, updated_at
+returning id, order_id, product_id, price, quantity, total_amount, shipping_amount, status, shipped_at, delivered_at, created_at, updated_at
 `
 
 func (q *Queries) CancelOrderByID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error) {
@@ -146,7 +153,10 @@ func (q *Queries) CancelOrderByID(ctx context.Context, orderID uuid.UUID) ([]Ord
 			&i.Price,
 			&i.Quantity,
 			&i.TotalAmount,
+			&i.ShippingAmount,
 			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -167,7 +177,7 @@ const changeOrderItemStatusByID = `-- name: ChangeOrderItemStatusByID :one
 update order_items oi
 set status =  $2
 where id = $1
-returning id, order_id, product_id, price, quantity, total_amount, status, created_at, updated_at
+returning id, order_id, product_id, price, quantity, total_amount, shipping_amount, status, shipped_at, delivered_at, created_at, updated_at
 `
 
 type ChangeOrderItemStatusByIDParams struct {
@@ -185,7 +195,10 @@ func (q *Queries) ChangeOrderItemStatusByID(ctx context.Context, arg ChangeOrder
 		&i.Price,
 		&i.Quantity,
 		&i.TotalAmount,
+		&i.ShippingAmount,
 		&i.Status,
+		&i.ShippedAt,
+		&i.DeliveredAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -204,14 +217,15 @@ func (q *Queries) DeleteOrderByID(ctx context.Context, id uuid.UUID) error {
 
 const editOrderAmountByID = `-- name: EditOrderAmountByID :one
 update orders
-set total_amount = $1, discount_amount = $2, coupon_id = $3, updated_at = current_timestamp
-where id = $4
-returning id, user_id, total_amount, coupon_id, discount_amount, net_amount, created_at, updated_at
+set total_amount = $1, discount_amount = $2, shipping_amount = $3, coupon_id = $4, updated_at = current_timestamp
+where id = $5
+returning id, user_id, total_amount, coupon_id, discount_amount, shipping_amount, net_amount, created_at, updated_at
 `
 
 type EditOrderAmountByIDParams struct {
 	TotalAmount    float64       `json:"total_amount"`
 	DiscountAmount float64       `json:"discount_amount"`
+	ShippingAmount float64       `json:"shipping_amount"`
 	CouponID       uuid.NullUUID `json:"coupon_id"`
 	ID             uuid.UUID     `json:"id"`
 }
@@ -220,6 +234,7 @@ func (q *Queries) EditOrderAmountByID(ctx context.Context, arg EditOrderAmountBy
 	row := q.queryRow(ctx, q.editOrderAmountByIDStmt, editOrderAmountByID,
 		arg.TotalAmount,
 		arg.DiscountAmount,
+		arg.ShippingAmount,
 		arg.CouponID,
 		arg.ID,
 	)
@@ -230,6 +245,7 @@ func (q *Queries) EditOrderAmountByID(ctx context.Context, arg EditOrderAmountBy
 		&i.TotalAmount,
 		&i.CouponID,
 		&i.DiscountAmount,
+		&i.ShippingAmount,
 		&i.NetAmount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -241,7 +257,7 @@ const editOrderItemStatusByID = `-- name: EditOrderItemStatusByID :one
 update order_items
 set status = $2, updated_at = current_timestamp
 where id = $1
-returning id, order_id, product_id, price, quantity, total_amount, status, created_at, updated_at
+returning id, order_id, product_id, price, quantity, total_amount, shipping_amount, status, shipped_at, delivered_at, created_at, updated_at
 `
 
 type EditOrderItemStatusByIDParams struct {
@@ -259,7 +275,10 @@ func (q *Queries) EditOrderItemStatusByID(ctx context.Context, arg EditOrderItem
 		&i.Price,
 		&i.Quantity,
 		&i.TotalAmount,
+		&i.ShippingAmount,
 		&i.Status,
+		&i.ShippedAt,
+		&i.DeliveredAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -267,7 +286,7 @@ func (q *Queries) EditOrderItemStatusByID(ctx context.Context, arg EditOrderItem
 }
 
 const getAllOrderItemsForAdmin = `-- name: GetAllOrderItemsForAdmin :many
-select id, order_id, product_id, price, quantity, total_amount, status, created_at, updated_at from order_items
+select id, order_id, product_id, price, quantity, total_amount, shipping_amount, status, shipped_at, delivered_at, created_at, updated_at from order_items
 order by created_at desc
 `
 
@@ -287,7 +306,10 @@ func (q *Queries) GetAllOrderItemsForAdmin(ctx context.Context) ([]OrderItem, er
 			&i.Price,
 			&i.Quantity,
 			&i.TotalAmount,
+			&i.ShippingAmount,
 			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -305,7 +327,7 @@ func (q *Queries) GetAllOrderItemsForAdmin(ctx context.Context) ([]OrderItem, er
 }
 
 const getAllOrders = `-- name: GetAllOrders :many
-select id, user_id, total_amount, coupon_id, discount_amount, net_amount, created_at, updated_at from orders
+select id, user_id, total_amount, coupon_id, discount_amount, shipping_amount, net_amount, created_at, updated_at from orders
 `
 
 func (q *Queries) GetAllOrders(ctx context.Context) ([]Order, error) {
@@ -323,6 +345,7 @@ func (q *Queries) GetAllOrders(ctx context.Context) ([]Order, error) {
 			&i.TotalAmount,
 			&i.CouponID,
 			&i.DiscountAmount,
+			&i.ShippingAmount,
 			&i.NetAmount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -340,8 +363,21 @@ func (q *Queries) GetAllOrders(ctx context.Context) ([]Order, error) {
 	return items, nil
 }
 
+const getDefaultShippingAddressIDByUserID = `-- name: GetDefaultShippingAddressIDByUserID :one
+select id from addresses
+where user_id = $1 and is_default_shipping
+`
+
+// addresses are kept by the user service
+func (q *Queries) GetDefaultShippingAddressIDByUserID(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
+	row := q.queryRow(ctx, q.getDefaultShippingAddressIDByUserIDStmt, getDefaultShippingAddressIDByUserID, userID)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getOrderByID = `-- name: GetOrderByID :one
-select id, user_id, total_amount, coupon_id, discount_amount, net_amount, created_at, updated_at from orders
+select id, user_id, total_amount, coupon_id, discount_amount, shipping_amount, net_amount, created_at, updated_at from orders
 where id = $1
 `
 
@@ -354,6 +390,7 @@ func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error)
 		&i.TotalAmount,
 		&i.CouponID,
 		&i.DiscountAmount,
+		&i.ShippingAmount,
 		&i.NetAmount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -361,8 +398,30 @@ func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error)
 	return i, err
 }
 
+const getOrderItemBuyerAndProduct = `-- name: GetOrderItemBuyerAndProduct :one
+select o.user_id, oi.order_id, p.name as product_name from order_items oi
+inner join orders o
+on oi.order_id = o.id
+inner join products p
+on oi.product_id = p.id
+where oi.id = $1
+`
+
+type GetOrderItemBuyerAndProductRow struct {
+	UserID      uuid.UUID `json:"user_id"`
+	OrderID     uuid.UUID `json:"order_id"`
+	ProductName string    `json:"product_name"`
+}
+
+func (q *Queries) GetOrderItemBuyerAndProduct(ctx context.Context, id uuid.UUID) (GetOrderItemBuyerAndProductRow, error) {
+	row := q.queryRow(ctx, q.getOrderItemBuyerAndProductStmt, getOrderItemBuyerAndProduct, id)
+	var i GetOrderItemBuyerAndProductRow
+	err := row.Scan(&i.UserID, &i.OrderID, &i.ProductName)
+	return i, err
+}
+
 const getOrderItemByID = `-- name: GetOrderItemByID :one
-select id, order_id, product_id, price, quantity, total_amount, status, created_at, updated_at from order_items
+select id, order_id, product_id, price, quantity, total_amount, shipping_amount, status, shipped_at, delivered_at, created_at, updated_at from order_items
 where id = $1
 `
 
@@ -376,7 +435,10 @@ func (q *Queries) GetOrderItemByID(ctx context.Context, id uuid.UUID) (OrderItem
 		&i.Price,
 		&i.Quantity,
 		&i.TotalAmount,
+		&i.ShippingAmount,
 		&i.Status,
+		&i.ShippedAt,
+		&i.DeliveredAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -384,13 +446,13 @@ func (q *Queries) GetOrderItemByID(ctx context.Context, id uuid.UUID) (OrderItem
 }
 
 const getOrderItemByUserAndProductID = `-- name: GetOrderItemByUserAndProductID :one
-select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.status, oi.created_at, oi.updated_at
+select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.shipping_amount, oi.status, oi.shipped_at, oi.delivered_at, oi.created_at, oi.updated_at
 from order_items oi
 inner join orders o
 on oi.order_id = o.id
 where oi.product_id = $1 and 
 o.user_id = $2 and
-(oi.status = 'delivered' or oi.status = 'returned')
+oi.status = 'delivered'
 limit 1
 `
 
@@ -409,7 +471,10 @@ func (q *Queries) GetOrderItemByUserAndProductID(ctx context.Context, arg GetOrd
 		&i.Price,
 		&i.Quantity,
 		&i.TotalAmount,
+		&i.ShippingAmount,
 		&i.Status,
+		&i.ShippedAt,
+		&i.DeliveredAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -417,7 +482,7 @@ func (q *Queries) GetOrderItemByUserAndProductID(ctx context.Context, arg GetOrd
 }
 
 const getOrderItemsByOrderID = `-- name: GetOrderItemsByOrderID :many
-select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.status, oi.created_at, oi.updated_at, p.name as product_name
+select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.shipping_amount, oi.status, oi.shipped_at, oi.delivered_at, oi.created_at, oi.updated_at, p.name as product_name
 from order_items oi
 inner join products p
 on oi.product_id = p.id
@@ -425,16 +490,19 @@ where oi.order_id = $1
 `
 
 type GetOrderItemsByOrderIDRow struct {
-	ID          uuid.UUID `json:"id"`
-	OrderID     uuid.UUID `json:"order_id"`
-	ProductID   uuid.UUID `json:"product_id"`
-	Price       float64   `json:"price"`
-	Quantity    int32     `json:"quantity"`
-	TotalAmount float64   `json:"total_amount"`
-	Status      string    `json:"status"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	ProductName string    `json:"product_name"`
+	ID             uuid.UUID    `json:"id"`
+	OrderID        uuid.UUID    `json:"order_id"`
+	ProductID      uuid.UUID    `json:"product_id"`
+	Price          float64      `json:"price"`
+	Quantity       int32        `json:"quantity"`
+	TotalAmount    float64      `json:"total_amount"`
+	ShippingAmount float64      `json:"shipping_amount"`
+	Status         string       `json:"status"`
+	ShippedAt      sql.NullTime `json:"shipped_at"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	ProductName    string       `json:"product_name"`
 }
 
 func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]GetOrderItemsByOrderIDRow, error) {
@@ -453,7 +521,10 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 			&i.Price,
 			&i.Quantity,
 			&i.TotalAmount,
+			&i.ShippingAmount,
 			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ProductName,
@@ -472,7 +543,7 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 }
 
 const getOrderItemsBySellerID = `-- name: GetOrderItemsBySellerID :many
-select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.status, oi.created_at, oi.updated_at from order_items oi
+select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.shipping_amount, oi.status, oi.shipped_at, oi.delivered_at, oi.created_at, oi.updated_at from order_items oi
 inner join products p
 on oi.product_id = p.id
 where p.seller_id = $1
@@ -495,7 +566,10 @@ func (q *Queries) GetOrderItemsBySellerID(ctx context.Context, sellerID uuid.UUI
 			&i.Price,
 			&i.Quantity,
 			&i.TotalAmount,
+			&i.ShippingAmount,
 			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -513,7 +587,7 @@ func (q *Queries) GetOrderItemsBySellerID(ctx context.Context, sellerID uuid.UUI
 }
 
 const getOrderItemsBySellerIDAndDateRange = `-- name: GetOrderItemsBySellerIDAndDateRange :many
-select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.status, oi.created_at, oi.updated_at 
+select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.shipping_amount, oi.status, oi.shipped_at, oi.delivered_at, oi.created_at, oi.updated_at 
 from order_items oi
 inner join products p on oi.product_id = p.id
 where p.seller_id = $1 
@@ -543,7 +617,10 @@ func (q *Queries) GetOrderItemsBySellerIDAndDateRange(ctx context.Context, arg G
 			&i.Price,
 			&i.Quantity,
 			&i.TotalAmount,
+			&i.ShippingAmount,
 			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -561,7 +638,7 @@ func (q *Queries) GetOrderItemsBySellerIDAndDateRange(ctx context.Context, arg G
 }
 
 const getOrderItemsByUserID = `-- name: GetOrderItemsByUserID :many
-select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.status, oi.created_at, oi.updated_at from order_items oi
+select oi.id, oi.order_id, oi.product_id, oi.price, oi.quantity, oi.total_amount, oi.shipping_amount, oi.status, oi.shipped_at, oi.delivered_at, oi.created_at, oi.updated_at from order_items oi
 inner join orders o
 on oi.order_id = o.id
 where o.user_id = $1
@@ -584,7 +661,10 @@ func (q *Queries) GetOrderItemsByUserID(ctx context.Context, userID uuid.UUID) (
 			&i.Price,
 			&i.Quantity,
 			&i.TotalAmount,
+			&i.ShippingAmount,
 			&i.Status,
+			&i.ShippedAt,
+			&i.DeliveredAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -602,7 +682,7 @@ func (q *Queries) GetOrderItemsByUserID(ctx context.Context, userID uuid.UUID) (
 }
 
 const getOrdersByUserID = `-- name: GetOrdersByUserID :many
-select id, user_id, total_amount, coupon_id, discount_amount, net_amount, created_at, updated_at from orders
+select id, user_id, total_amount, coupon_id, discount_amount, shipping_amount, net_amount, created_at, updated_at from orders
 where user_id = $1
 order by created_at desc
 `
@@ -622,6 +702,7 @@ func (q *Queries) GetOrdersByUserID(ctx context.Context, userID uuid.UUID) ([]Or
 			&i.TotalAmount,
 			&i.CouponID,
 			&i.DiscountAmount,
+			&i.ShippingAmount,
 			&i.NetAmount,
 			&i.CreatedAt,
 			&i.UpdatedAt,
@@ -640,7 +721,7 @@ func (q *Queries) GetOrdersByUserID(ctx context.Context, userID uuid.UUID) ([]Or
 }
 
 const getReviewByUserAndProductID = `-- name: GetReviewByUserAndProductID :one
-select id, user_id, product_id, rating, comment, is_deleted, is_edited, created_at, updated_at
+select id, user_id, product_id, rating, comment, is_deleted, is_edited, is_verified, moderation_status, created_at, updated_at
 from reviews r
 where r.user_id = $1 and r.product_id = $2
 `
@@ -661,12 +742,56 @@ func (q *Queries) GetReviewByUserAndProductID(ctx context.Context, arg GetReview
 		&i.Comment,
 		&i.IsDeleted,
 		&i.IsEdited,
+		&i.IsVerified,
+		&i.ModerationStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getSellerFulfilmentStats = `-- name: GetSellerFulfilmentStats :one
+select count(*) as total_items,
+count(*) filter (where oi.shipped_at is not null) as shipped_items,
+count(*) filter (where oi.shipped_at is not null and oi.shipped_at <= oi.created_at + make_interval(days => $1::int)) as shipped_on_time,
+count(*) filter (where oi.status = 'delivered') as delivered_items,
+count(*) filter (where oi.status = 'cancelled') as cancelled_items,
+count(*) filter (where oi.status = 'returned') as returned_items
+from order_items oi
+inner join products p
+on oi.product_id = p.id
+where p.seller_id = $2
+`
+
+type GetSellerFulfilmentStatsParams struct {
+	ShipWithinDays int32     `json:"ship_within_days"`
+	SellerID       uuid.UUID `json:"seller_id"`
+}
+
+type GetSellerFulfilmentStatsRow struct {
+	TotalItems     int64 `json:"total_items"`
+	ShippedItems   int64 `json:"shipped_items"`
+	ShippedOnTime  int64 `json:"shipped_on_time"`
+	DeliveredItems int64 `json:"delivered_items"`
+	CancelledItems int64 `json:"cancelled_items"`
+	ReturnedItems  int64 `json:"returned_items"`
+}
+
+// an item is shipped on time when it left within @ship_within_days of being ordered
+func (q *Queries) GetSellerFulfilmentStats(ctx context.Context, arg GetSellerFulfilmentStatsParams) (GetSellerFulfilmentStatsRow, error) {
+	row := q.queryRow(ctx, q.getSellerFulfilmentStatsStmt, getSellerFulfilmentStats, arg.ShipWithinDays, arg.SellerID)
+	var i GetSellerFulfilmentStatsRow
+	err := row.Scan(
+		&i.TotalItems,
+		&i.ShippedItems,
+		&i.ShippedOnTime,
+		&i.DeliveredItems,
+		&i.CancelledItems,
+		&i.ReturnedItems,
+	)
+	return i, err
+}
+
 const getSellerIDFromOrderItemID = `-- name: GetSellerIDFromOrderItemID :one
 select p.seller_id from order_items oi
 inner join products p
@@ -739,11 +864,24 @@ func (q *Queries) GetUserIDFromOrderItemID(ctx context.Context, id uuid.UUID) (u
 	return user_id, err
 }
 
+const getUserPhoneVerifiedByID = `-- name: GetUserPhoneVerifiedByID :one
+select phone_verified from users
+where id = $1
+`
+
+// the flag is set by the user service once the phone confirms an sms code
+func (q *Queries) GetUserPhoneVerifiedByID(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.queryRow(ctx, q.getUserPhoneVerifiedByIDStmt, getUserPhoneVerifiedByID, id)
+	var phone_verified bool
+	err := row.Scan(&phone_verified)
+	return phone_verified, err
+}
+
 const updateOrderTotalAmount = `-- name: UpdateOrderTotalAmount :one
 update orders
 set total_amount = $1, updated_at = current_timestamp
 where id = $2
-returning id, user_id, total_amount, coupon_id, discount_amount, net_amount, created_at, updated_at
+returning id, user_id, total_amount, coupon_id, discount_amount, shipping_amount, net_amount, created_at, updated_at
 `
 
 type UpdateOrderTotalAmountParams struct {
@@ -760,6 +898,7 @@ func (q *Queries) UpdateOrderTotalAmount(ctx context.Context, arg UpdateOrderTot
 		&i.TotalAmount,
 		&i.CouponID,
 		&i.DiscountAmount,
+		&i.ShippingAmount,
 		&i.NetAmount,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -167,7 +167,7 @@ func (q *Queries) CancelVendorPaymentsByOrderID(ctx context.Context, orderID uui
 
 const decPaymentAmountByOrderItemID = `-- name: DecPaymentAmountByOrderItemID :one
 WITH cte AS (
-  SELECT oi.order_id, oi.total_amount
+  SELECT oi.order_id, oi.total_amount + oi.shipping_amount AS total_amount
   FROM order_items oi
   WHERE oi.id = $1
 )
@@ -178,6 +178,7 @@ WHERE payments.order_id = cte.order_id
 RETURNING payments.id, payments.order_id, payments.method, payments.status, payments.total_amount, payments.transaction_id, payments.created_at, payments.updated_at
 `
 
+// the item's shipping share goes with it
 func (q *Queries) DecPaymentAmountByOrderItemID(ctx context.Context, id uuid.UUID) (Payment, error) {
 	row := q.queryRow(ctx, q.decPaymentAmountByOrderItemIDStmt, decPaymentAmountByOrderItemID, id)
 	var i Payment
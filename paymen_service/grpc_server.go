@@ -0,0 +1,303 @@
+package payment_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"math"
+	"time"
+
+	db "payment_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/paymentpb"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// items shipped later than this after being ordered count against the seller
+const shipWithinDays = 2
+
+// PaymentServer answers the other services' questions about orders and payments
+type PaymentServer struct {
+	paymentpb.UnimplementedPaymentServiceServer
+	DB *db.Queries
+}
+
+// NewGRPCServer returns a grpc server with the payment service registered,
+// the service main serves it next to the http mux
+func NewGRPCServer() *grpc.Server {
+	srv := grpc.NewServer()
+	paymentpb.RegisterPaymentServiceServer(srv, &PaymentServer{DB: DB})
+	return srv
+}
+
+// GetOrderItemByUserAndProductID returns a delivered order item of the product for the user,
+// NotFound when the user never received it
+func (p *PaymentServer) GetOrderItemByUserAndProductID(ctx context.Context, req *paymentpb.GetOrderItemByUserAndProductIDRequest) (*paymentpb.GetOrderItemByUserAndProductIDResponse, error) {
+	userID, err := uuid.Parse(req.GetUserID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	productID, err := uuid.Parse(req.GetProductID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid product id")
+	}
+	item, err := p.DB.GetOrderItemByUserAndProductID(ctx, db.GetOrderItemByUserAndProductIDParams{
+		UserID:    userID,
+		ProductID: productID,
+	})
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "no delivered order item for the user and product")
+	} else if err != nil {
+		log.Error("error fetching order item in grpc GetOrderItemByUserAndProductID:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching order item")
+	}
+	return &paymentpb.GetOrderItemByUserAndProductIDResponse{
+		Id:          item.ID.String(),
+		OrderID:     item.OrderID.String(),
+		ProductID:   item.ProductID.String(),
+		Quantity:    item.Quantity,
+		Price:       item.Price,
+		TotalAmount: item.TotalAmount,
+		Status:      item.Status,
+		CreatedAt:   timestamppb.New(item.CreatedAt),
+		UpdatedAt:   timestamppb.New(item.UpdatedAt),
+	}, nil
+}
+
+// GetSellerFulfilmentStats returns how reliably the seller ships, used on the seller storefront
+func (p *PaymentServer) GetSellerFulfilmentStats(ctx context.Context, req *paymentpb.GetSellerFulfilmentStatsRequest) (*paymentpb.GetSellerFulfilmentStatsResponse, error) {
+	sellerID, err := uuid.Parse(req.GetSellerID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid seller id")
+	}
+	stats, err := p.DB.GetSellerFulfilmentStats(ctx, db.GetSellerFulfilmentStatsParams{
+		SellerID:       sellerID,
+		ShipWithinDays: shipWithinDays,
+	})
+	if err != nil {
+		log.Error("error fetching seller fulfilment stats in grpc GetSellerFulfilmentStats:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching fulfilment stats")
+	}
+	return &paymentpb.GetSellerFulfilmentStatsResponse{
+		TotalItems:         stats.TotalItems,
+		ShippedItems:       stats.ShippedItems,
+		ShippedOnTime:      stats.ShippedOnTime,
+		DeliveredItems:     stats.DeliveredItems,
+		CancelledItems:     stats.CancelledItems,
+		ReturnedItems:      stats.ReturnedItems,
+		OnTimeShippingRate: rate(stats.ShippedOnTime, stats.ShippedItems),
+		CancellationRate:   rate(stats.CancelledItems, stats.TotalItems),
+		ReturnRate:         rate(stats.ReturnedItems, stats.DeliveredItems+stats.ReturnedItems),
+	}, nil
+}
+
+// rate is n/total rounded to 2 decimals, 0 when there is nothing to count
+func rate(n, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(n)/float64(total)*100) / 100
+}
+
+type exportPayment struct {
+	ID            uuid.UUID `json:"id"`
+	Method        string    `json:"method"`
+	Status        string    `json:"status"`
+	TotalAmount   float64   `json:"total_amount"`
+	TransactionID *string   `json:"transaction_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type exportOrderItem struct {
+	ID             uuid.UUID  `json:"id"`
+	ProductID      uuid.UUID  `json:"product_id"`
+	ProductName    string     `json:"product_name"`
+	Price          float64    `json:"price"`
+	Quantity       int32      `json:"quantity"`
+	TotalAmount    float64    `json:"total_amount"`
+	ShippingAmount float64    `json:"shipping_amount"`
+	Status         string     `json:"status"`
+	ShippedAt      *time.Time `json:"shipped_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type exportOrder struct {
+	ID              uuid.UUID           `json:"id"`
+	TotalAmount     float64             `json:"total_amount"`
+	CouponID        uuid.NullUUID       `json:"coupon_id"`
+	DiscountAmount  float64             `json:"discount_amount"`
+	ShippingAmount  float64             `json:"shipping_amount"`
+	NetAmount       float64             `json:"net_amount"`
+	CreatedAt       time.Time           `json:"created_at"`
+	ShippingAddress *db.ShippingAddress `json:"shipping_address"`
+	Payments        []exportPayment     `json:"payments"`
+	Items           []exportOrderItem   `json:"items"`
+}
+
+// ExportUserData returns orders.json, returns.json and wallet_history.json of the user
+func (p *PaymentServer) ExportUserData(ctx context.Context, req *paymentpb.ExportUserDataRequest) (*paymentpb.ExportUserDataResponse, error) {
+	userID, err := uuid.Parse(req.GetUserID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+
+	orders, err := p.DB.GetOrdersByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching orders in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching orders")
+	}
+	exportOrders := []exportOrder{}
+	byID := make(map[uuid.UUID]int)
+	for _, o := range orders {
+		byID[o.ID] = len(exportOrders)
+		exportOrders = append(exportOrders, exportOrder{
+			ID:             o.ID,
+			TotalAmount:    o.TotalAmount,
+			CouponID:       o.CouponID,
+			DiscountAmount: o.DiscountAmount,
+			ShippingAmount: o.ShippingAmount,
+			NetAmount:      o.NetAmount,
+			CreatedAt:      o.CreatedAt,
+			Payments:       []exportPayment{},
+			Items:          []exportOrderItem{},
+		})
+	}
+
+	addresses, err := p.DB.GetShippingAddressesByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching shipping addresses in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching shipping addresses")
+	}
+	for _, a := range addresses {
+		if i, ok := byID[a.OrderID]; ok {
+			exportOrders[i].ShippingAddress = &a
+		}
+	}
+
+	payments, err := p.DB.GetPaymentsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching payments in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching payments")
+	}
+	for _, pay := range payments {
+		i, ok := byID[pay.OrderID]
+		if !ok {
+			continue
+		}
+		e := exportPayment{
+			ID:          pay.ID,
+			Method:      pay.Method,
+			Status:      pay.Status,
+			TotalAmount: pay.TotalAmount,
+			CreatedAt:   pay.CreatedAt,
+		}
+		if pay.TransactionID.Valid {
+			e.TransactionID = &pay.TransactionID.String
+		}
+		exportOrders[i].Payments = append(exportOrders[i].Payments, e)
+	}
+
+	items, err := p.DB.GetOrderItemsWithProductByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching order items in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching order items")
+	}
+	for _, item := range items {
+		i, ok := byID[item.OrderID]
+		if !ok {
+			continue
+		}
+		e := exportOrderItem{
+			ID:             item.ID,
+			ProductID:      item.ProductID,
+			ProductName:    item.ProductName,
+			Price:          item.Price,
+			Quantity:       item.Quantity,
+			TotalAmount:    item.TotalAmount,
+			ShippingAmount: item.ShippingAmount,
+			Status:         item.Status,
+			CreatedAt:      item.CreatedAt,
+		}
+		if item.ShippedAt.Valid {
+			e.ShippedAt = &item.ShippedAt.Time
+		}
+		if item.DeliveredAt.Valid {
+			e.DeliveredAt = &item.DeliveredAt.Time
+		}
+		exportOrders[i].Items = append(exportOrders[i].Items, e)
+	}
+
+	returns, err := p.DB.GetReturnRefundsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching returns in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching returns")
+	}
+	history, err := p.DB.GetWalletHistoryByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error fetching wallet history in grpc ExportUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching wallet history")
+	}
+
+	var resp paymentpb.ExportUserDataResponse
+	for _, f := range []struct {
+		name string
+		data any
+	}{
+		{"orders.json", exportOrders},
+		{"returns.json", returns},
+		{"wallet_history.json", history},
+	} {
+		b, err := json.MarshalIndent(f.data, "", "  ")
+		if err != nil {
+			log.Error("error encoding "+f.name+" in grpc ExportUserData:", err.Error())
+			return nil, status.Error(codes.Internal, "internal error encoding export")
+		}
+		resp.Files = append(resp.Files, &paymentpb.ExportFile{Name: f.name, Data: b})
+	}
+	return &resp, nil
+}
+
+// EraseUserData empties the cart of the user and erases the house and street of
+// their shipping addresses. orders, payments and refunds are kept for the accounts
+func (p *PaymentServer) EraseUserData(ctx context.Context, req *paymentpb.EraseUserDataRequest) (*paymentpb.EraseUserDataResponse, error) {
+	userID, err := uuid.Parse(req.GetUserID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	undelivered, err := p.DB.CountUndeliveredOrderItemsByUserID(ctx, userID)
+	if err != nil {
+		log.Error("error counting undelivered order items in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if undelivered > 0 {
+		// the shipping address is still needed to deliver them
+		return nil, status.Errorf(codes.FailedPrecondition, "user has %d order items on the way", undelivered)
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("error starting transaction in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	defer tx.Rollback()
+	qtx := p.DB.WithTx(tx)
+	if err = qtx.DeleteCartItemsByUserID(ctx, userID); err != nil {
+		log.Error("error deleting cart in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = qtx.EraseShippingAddressesByUserID(ctx, userID); err != nil {
+		log.Error("error erasing shipping addresses in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	if err = tx.Commit(); err != nil {
+		log.Error("error committing in grpc EraseUserData:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error erasing user data")
+	}
+	return &paymentpb.EraseUserDataResponse{}, nil
+}
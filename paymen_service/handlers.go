@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"math"
 	"net/http"
 	"os"
 	"sort"
@@ -18,11 +19,18 @@ import (
 
 	db "payment_service/db/sqlc"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/chartGen"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/delivery"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/grpcclient"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/helpers"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/impersonation"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
 	middleware "github.com/amankhys/multi_vendor_ecommerce_go/pkg/middlewares"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/notify"
 	paymenthelper "github.com/amankhys/multi_vendor_ecommerce_go/pkg/payment"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/inventorypb"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
 	"github.com/google/uuid"
@@ -31,12 +39,18 @@ import (
 
 var dbConn = db.NewDBConfig("user")
 var DB = db.New(dbConn)
+var auditLog = audit.New(dbConn, "payment_audit_logs")
 var u = User{DB: DB}
 var helper = helpers.Helper{
 	DB: DB,
 }
+var inventoryClient = grpcclient.NewInventoryClient()
 
 func RegisterRoutes(mux *http.ServeMux) {
+	// requests made while an admin impersonates someone are checked and recorded
+	middleware.SetImpersonationRecorder(impersonation.NewRecorder(dbConn, "payment_service"))
+	// mails are queued and sent by the outbox worker of the user service
+	mail.SetOutbox(mail.NewOutbox(dbConn))
 
 	mux.HandleFunc("GET /user/cart", middleware.AuthenticateUserMiddleware(u.GetCartHandler, utils.UserRole))
 	mux.HandleFunc("POST /user/cart/add", middleware.AuthenticateUserMiddleware(u.AddCartHandler, utils.UserRole))
@@ -60,15 +74,17 @@ func RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("GET /seller/sales_report", middleware.AuthenticateUserMiddleware(s.SalesReportHandler, utils.SellerRole))
 
 	a := &Admin{DB: DB}
-	mux.HandleFunc("GET /admin/orders", middleware.AuthenticateUserMiddleware(a.GetOrderItemsHandler, utils.AdminRole))
-	mux.HandleFunc("PUT /admin/orders/deliver", middleware.AuthenticateUserMiddleware(a.DeliverOrderItemHandler, utils.AdminRole))
+	mux.HandleFunc("GET /admin/orders", middleware.AuthorizeMiddleware(a.GetOrderItemsHandler, utils.PermOrdersRead))
+	mux.HandleFunc("PUT /admin/orders/deliver", middleware.AuthorizeMiddleware(a.DeliverOrderItemHandler, utils.PermOrdersDeliver))
 
-	mux.HandleFunc("GET /admin/coupons", middleware.AuthenticateUserMiddleware(a.AdminCouponsHandler, utils.AdminRole))
-	mux.HandleFunc("POST /admin/coupons/add", middleware.AuthenticateUserMiddleware(a.AddCouponHandler, utils.AdminRole))
-	mux.HandleFunc("PUT /admin/coupons/edit", middleware.AuthenticateUserMiddleware(a.EditCouponHandler, utils.AdminRole))
-	mux.HandleFunc("DELETE /admin/coupons/delete", middleware.AuthenticateUserMiddleware(a.DeleteCouponHandler, utils.AdminRole))
+	mux.HandleFunc("GET /admin/coupons", middleware.AuthorizeMiddleware(a.AdminCouponsHandler, utils.PermCouponsRead))
+	mux.HandleFunc("POST /admin/coupons/add", middleware.AuthorizeMiddleware(a.AddCouponHandler, utils.PermCouponsWrite))
+	mux.HandleFunc("PUT /admin/coupons/edit", middleware.AuthorizeMiddleware(a.EditCouponHandler, utils.PermCouponsWrite))
+	mux.HandleFunc("DELETE /admin/coupons/delete", middleware.AuthorizeMiddleware(a.DeleteCouponHandler, utils.PermCouponsWrite))
 
-	mux.HandleFunc("GET /admin/sales_report", middleware.AuthenticateUserMiddleware(a.SalesReportHandler, utils.AdminRole))
+	mux.HandleFunc("GET /admin/sales_report", middleware.AuthorizeMiddleware(a.SalesReportHandler, utils.PermReportsRead))
+
+	mux.HandleFunc("GET /admin/audit", middleware.AuthorizeMiddleware(auditLog.Handler(), utils.PermAuditRead))
 }
 
 type User struct{ DB *db.Queries }
@@ -496,13 +512,14 @@ func (u *User) GetOrdersHandler(w http.ResponseWriter, r *http.Request) {
 
 	// respOrderItem struct
 	type respOrderItem struct {
-		OrderItemID uuid.UUID `json:"order_item_id"`
-		Status      string    `json:"order_status"`
-		ProductID   uuid.UUID `json:"product_id"`
-		ProductName string    `json:"product_name"`
-		Price       float64   `json:"price"`
-		Quantity    int       `json:"quantity"`
-		TotalAmount float64   `json:"total_amount"`
+		OrderItemID    uuid.UUID `json:"order_item_id"`
+		Status         string    `json:"order_status"`
+		ProductID      uuid.UUID `json:"product_id"`
+		ProductName    string    `json:"product_name"`
+		Price          float64   `json:"price"`
+		Quantity       int       `json:"quantity"`
+		TotalAmount    float64   `json:"total_amount"`
+		ShippingAmount float64   `json:"shipping_amount"`
 	}
 	// respOrder struct
 	type respOrder struct {
@@ -542,6 +559,7 @@ func (u *User) GetOrdersHandler(w http.ResponseWriter, r *http.Request) {
 				orderItem.Price = oi.Price
 				orderItem.Quantity = int(oi.Quantity)
 				orderItem.TotalAmount = oi.TotalAmount
+				orderItem.ShippingAmount = oi.ShippingAmount
 				temp.OrderItems = append(temp.OrderItems, orderItem)
 			}
 			respOrders = append(respOrders, temp)
@@ -607,6 +625,15 @@ func (u *User) GetOrderItemsHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// the user's default shipping address, uuid.Nil when they have no address
+func defaultShippingAddressID(userID uuid.UUID) (uuid.UUID, error) {
+	id, err := DB.GetDefaultShippingAddressIDByUserID(context.TODO(), userID)
+	if err == sql.ErrNoRows {
+		return uuid.Nil, nil
+	}
+	return id, err
+}
+
 func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserHelper(w, r)
 	if user.ID == uuid.Nil {
@@ -619,15 +646,38 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "phone number not added for user. Unauthorized to make an order", http.StatusBadRequest)
 		return
 	}
-
-	// get the shipping address from the request
-	ShippingAddressIDStr := r.URL.Query().Get("shipping_address_id")
-	ShippingAddressID, err := uuid.Parse(ShippingAddressIDStr)
+	verified, err := DB.GetUserPhoneVerifiedByID(context.TODO(), user.ID)
 	if err != nil {
-		http.Error(w, "invalid address format", http.StatusBadRequest)
+		log.Warn("error checking phone verification in AddCartToOrderHandler:", err.Error())
+		http.Error(w, "internal error checking phone number", http.StatusInternalServerError)
+		return
+	}
+	if !verified {
+		http.Error(w, "phone number not verified. verify it at /account/phone/verify before making an order", http.StatusBadRequest)
 		return
 	}
 
+	// get the shipping address from the request, the default shipping address when left out
+	var ShippingAddressID uuid.UUID
+	if ShippingAddressIDStr := r.URL.Query().Get("shipping_address_id"); ShippingAddressIDStr != "" {
+		ShippingAddressID, err = uuid.Parse(ShippingAddressIDStr)
+		if err != nil {
+			http.Error(w, "invalid address format", http.StatusBadRequest)
+			return
+		}
+	} else {
+		ShippingAddressID, err = defaultShippingAddressID(user.ID)
+		if err != nil {
+			log.Warn("error fetching default shipping address in AddCartToOrderHandler:", err.Error())
+			http.Error(w, "internal error fetching shipping address", http.StatusInternalServerError)
+			return
+		}
+		if ShippingAddressID == uuid.Nil {
+			http.Error(w, "no shipping_address_id given and no default shipping address. Add an address first", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// to add and display insignificant errors
 	var Err []string
 	var Messages []string
@@ -672,6 +722,68 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// every seller in the cart has to ship to the address, the inventory service
+	// knows their service areas and gives the delivery estimates
+	var productIDs []string
+	for _, ci := range cartItems {
+		productIDs = append(productIDs, ci.ProductID.String())
+	}
+	deliveries, err := inventoryClient.CheckDelivery(context.TODO(), &inventorypb.CheckDeliveryRequest{
+		ProductIds: productIDs,
+		Pincode:    address.Pincode,
+	})
+	if err != nil {
+		log.Error("error checking delivery in AddCartToOrderHandler:", err.Error())
+		http.Error(w, "internal error checking delivery to the shipping address", http.StatusInternalServerError)
+		return
+	}
+	// the estimates come in the order of the cart items
+	if len(deliveries.GetEstimates()) != len(cartItems) {
+		log.Error("error checking delivery in AddCartToOrderHandler: estimates don't match the cart items")
+		http.Error(w, "internal error checking delivery to the shipping address", http.StatusInternalServerError)
+		return
+	}
+	estimatedDelivery := make(map[string]time.Time)
+	var undeliverable []string
+	for i, estimate := range deliveries.GetEstimates() {
+		if !estimate.GetDeliverable() {
+			undeliverable = append(undeliverable, cartItems[i].ProductName+": "+estimate.GetReason())
+			continue
+		}
+		estimatedDelivery[estimate.GetProductId()] = estimate.GetEstimatedDate().AsTime()
+	}
+	if len(undeliverable) > 0 {
+		http.Error(w, "some cart items can't be delivered to the shipping address. remove them or choose another address\n"+
+			strings.Join(undeliverable, "\n"), http.StatusBadRequest)
+		return
+	}
+
+	// each seller's items go as one parcel charged by the seller's shipping rule, every item
+	// carries a share of its parcel's charge so cancelling the item refunds the share
+	rules := make(map[string]delivery.Rule)
+	for _, rule := range deliveries.GetShippingRules() {
+		rules[rule.GetSellerId()] = delivery.Rule{
+			Kind:      rule.GetRule(),
+			FlatFee:   rule.GetFlatFee(),
+			FreeAbove: rule.GetFreeAbove(),
+		}
+	}
+	var items []delivery.Item
+	for i, estimate := range deliveries.GetEstimates() {
+		items = append(items, delivery.Item{
+			SellerID:    estimate.GetSellerId(),
+			Amount:      cartItems[i].TotalAmount,
+			WeightGrams: int(estimate.GetWeightGrams()) * int(cartItems[i].Quantity),
+			Zone:        delivery.Zone(estimate.GetZone()),
+		})
+	}
+	itemShipping := delivery.ItemCharges(items, rules)
+	var shippingAmount float64
+	for _, share := range itemShipping {
+		shippingAmount += share
+	}
+	shippingAmount = math.Round(shippingAmount*100) / 100
+
 	// for future calculations
 	var discountAmount float64
 	var ifCouponValid bool
@@ -691,7 +803,7 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid payment method", http.StatusBadRequest)
 		return
 	}
-	if paymentMethod == utils.StatusPaymentMethodCod && totalAmount >= 1000 {
+	if paymentMethod == utils.StatusPaymentMethodCod && totalAmount+shippingAmount >= 1000 {
 		http.Error(w, "cannot create order costing more than 1000rs on Cash On Delivery", http.StatusBadRequest)
 		return
 	} else if paymentMethod == utils.StatusPaymentMethodWallet {
@@ -709,9 +821,9 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		// make sure coupon is in active time and it is valid for the user
 		// change the values of discountAmount, ifCouponValid, totalAmount
 		// accordingly
-		if wallet.Savings < totalAmount {
+		if wallet.Savings < totalAmount+shippingAmount {
 			msg := fmt.Sprintf("not enough money in wallet to buy product \n"+
-				"Needed: %0.2f; Your wallet has %0.2f", totalAmount, wallet.Savings)
+				"Needed: %0.2f; Your wallet has %0.2f", totalAmount+shippingAmount, wallet.Savings)
 			http.Error(w, msg, http.StatusBadRequest)
 			return
 		}
@@ -758,12 +870,13 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// add cartItems to orderItems
-	for _, v := range cartItems {
+	for i, v := range cartItems {
 		var addArg db.AddOrderITemParams
 		addArg.OrderID = order.ID
 		addArg.ProductID = v.ProductID
 		addArg.Price = v.Price
 		addArg.Quantity = v.Quantity
+		addArg.ShippingAmount = itemShipping[i]
 		orderItem, err := u.DB.AddOrderITem(context.TODO(), addArg)
 		if err != nil {
 			log.Warn("error adding cartItem to order_item:", err.Error())
@@ -813,6 +926,7 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 	editOrderAmountArg.ID = order.ID
 	editOrderAmountArg.TotalAmount = totalAmount
 	editOrderAmountArg.DiscountAmount = discountAmount
+	editOrderAmountArg.ShippingAmount = shippingAmount
 	if ifCouponValid {
 		editOrderAmountArg.CouponID.Valid = true
 		editOrderAmountArg.CouponID.UUID = coupon.ID
@@ -860,7 +974,8 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 	// update wallet total savings if the payment is done through wallet
 	if paymentMethod == utils.StatusPaymentMethodWallet {
 		var retractArg db.RetractSavingsFromWalletByUserIDParams
-		retractArg.Savings = order.NetAmount
+		// the order row from AddOrder has no amounts yet, they were set by EditOrderAmountByID
+		retractArg.Savings = updatedOrder.NetAmount
 		retractArg.UserID = user.ID
 		updatedWallet, err := u.DB.RetractSavingsFromWalletByUserID(context.TODO(), retractArg)
 		if err != nil {
@@ -868,7 +983,7 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 				"error retracting savings from wallet after placing order"+
 					"via wallet in AddCartToOrderHandler:", err.Error())
 		} else {
-			msg := fmt.Sprintf("retracted %0.2f from wallet;\n", order.NetAmount) +
+			msg := fmt.Sprintf("retracted %0.2f from wallet;\n", updatedOrder.NetAmount) +
 				fmt.Sprintf("Wallet balance: %0.2f ", updatedWallet.Savings)
 			Messages = append(Messages, msg)
 		}
@@ -880,16 +995,18 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		TotalAmount    float64       `json:"total_amount"`
 		CouponID       uuid.NullUUID `json:"coupon_id"`
 		DiscountAmount float64       `json:"discount_amount"`
+		ShippingAmount float64       `json:"shipping_amount"`
 		NetAmount      float64       `json:"net_amount"`
 		OrderDate      time.Time     `json:"created_at"`
 	}
 	var respOrderData = respOrder{
 		ID:             order.ID,
 		UserID:         order.UserID,
-		TotalAmount:    order.TotalAmount,
-		CouponID:       order.CouponID,
-		DiscountAmount: order.DiscountAmount,
-		NetAmount:      order.NetAmount,
+		TotalAmount:    updatedOrder.TotalAmount,
+		CouponID:       updatedOrder.CouponID,
+		DiscountAmount: updatedOrder.DiscountAmount,
+		ShippingAmount: shippingAmount,
+		NetAmount:      updatedOrder.NetAmount,
 		OrderDate:      order.CreatedAt,
 	}
 
@@ -901,6 +1018,10 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		TotalAmount float64   `json:"total_amount"`
 		Status      string    `json:"status"`
 		ProductName string    `json:"product_name"`
+		// the item's share of its seller's shipping charge
+		ShippingAmount float64 `json:"shipping_amount"`
+		// yyyy-mm-dd
+		EstimatedDelivery string `json:"estimated_delivery"`
 	}
 
 	var respOrderItemsData []respOrderItem
@@ -913,6 +1034,10 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		temp.Quantity = oi.Quantity
 		temp.TotalAmount = oi.TotalAmount
 		temp.Status = oi.Status
+		temp.ShippingAmount = oi.ShippingAmount
+		if date, ok := estimatedDelivery[oi.ProductID.String()]; ok {
+			temp.EstimatedDelivery = date.Format("2006-01-02")
+		}
 
 		respOrderItemsData = append(respOrderItemsData, temp)
 	}
@@ -954,6 +1079,10 @@ func (u *User) AddCartToOrderHandler(w http.ResponseWriter, r *http.Request) {
 		CreatedAt:     payment.CreatedAt,
 	}
 
+	notifier.Log(context.TODO(), user.ID, notify.KindOrderPlaced, notify.Data{
+		OrderID: uuid.NullUUID{UUID: order.ID, Valid: true},
+		Amount:  updatedOrder.NetAmount,
+	})
 	Messages = append(Messages, "successfully added the cart items to orders")
 	var resp struct {
 		Order           respOrder           `json:"order"`
@@ -1053,16 +1182,26 @@ func (u *User) CancelOrderItemHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if payment.Status == utils.StatusPaymentSuccessful {
+			// the item's share of the shipping charge is refunded with it
+			refund := orderItem.TotalAmount + orderItem.ShippingAmount
 			wallet, err := u.DB.AddSavingsToWalletByUserID(context.TODO(), db.AddSavingsToWalletByUserIDParams{
-				Savings: orderItem.TotalAmount,
+				Savings: refund,
 				UserID:  user.ID,
 			})
 			if err != nil {
 				log.Error("error updating money back to wallet on cancelling orderItem:", err.Error())
 				Err = append(Err, "error adding money to wallet after cancelling order")
 			} else {
+				auditLog.Record(r, audit.Entry{
+					Action:     audit.ActionWalletCredit,
+					EntityType: audit.EntityWallet,
+					EntityID:   user.ID.String(),
+					Before:     map[string]any{"savings": wallet.Savings - refund},
+					After:      map[string]any{"savings": wallet.Savings, "credit": refund, "shipping": orderItem.ShippingAmount, "reason": "order item cancelled", "order_item_id": orderItem.ID},
+				})
+				notifyRefund(user.ID, order.ID, refund, "cancelled order item")
 				msg := fmt.Sprintf("successfully added amount: %0.2f back to wallet.\nCurrent balance: %0.2f",
-					orderItem.TotalAmount, wallet.Savings)
+					refund, wallet.Savings)
 				Messages = append(Messages, msg)
 			}
 
@@ -1109,6 +1248,7 @@ func (u *User) CancelOrderItemHandler(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			log.Error("error cancelling vendor payment in CancelOrderItem for user:", err.Error())
 		}
+		notifyOrderItem(u.DB, orderItem, notify.KindOrderCancelled, "")
 
 		type RespPayment struct {
 			PaymentID      uuid.UUID `json:"payment_id"`
@@ -1188,6 +1328,13 @@ func (u *User) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
 			log.Warn("error transferring refund amount to wallet:", err.Error())
 			messages = append(messages, "error transferring refund amount to wallet")
 		} else {
+			auditLog.Record(r, audit.Entry{
+				Action:     audit.ActionWalletCredit,
+				EntityType: audit.EntityWallet,
+				EntityID:   user.ID.String(),
+				After:      map[string]any{"credit": payment.TotalAmount, "reason": "order cancelled", "order_id": order.ID},
+			})
+			notifyRefund(user.ID, order.ID, payment.TotalAmount, "cancelled order")
 			log.Warn("successfully transferred amount to user wallet")
 			messages = append(messages, fmt.Sprintf("successfully transferred cancellation refund amount: %.2f to wallet", payment.TotalAmount))
 			// cancel payment on successful addition of money to wallet
@@ -1222,6 +1369,9 @@ func (u *User) CancelOrderHandler(w http.ResponseWriter, r *http.Request) {
 		Messages []string `json:"messages"`
 		Errors   []string `json:"errors"`
 	}
+	notifier.Log(context.TODO(), user.ID, notify.KindOrderCancelled, notify.Data{
+		OrderID: uuid.NullUUID{UUID: order.ID, Valid: true},
+	})
 	resp.Messages = append(messages, "successfully cancelled order")
 	resp.Errors = errors
 	json.NewEncoder(w).Encode(resp)
@@ -1450,6 +1600,13 @@ func (u *User) ReturnOrderHandler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		log.Warn("error adding return refund back to user wallet:", err.Error())
 	} else {
+		auditLog.Record(r, audit.Entry{
+			Action:     audit.ActionWalletCredit,
+			EntityType: audit.EntityWallet,
+			EntityID:   user.ID.String(),
+			After:      map[string]any{"credit": order.NetAmount, "reason": "order returned", "order_id": order.ID},
+		})
+		notifyRefund(user.ID, order.ID, order.NetAmount, "returned order")
 		// edit payment status to be refunded
 		var editPayArg db.EditPaymentStatusByOrderIDParams
 		editPayArg.OrderID = order.ID
@@ -1738,6 +1895,14 @@ func (u *User) InvoiceHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.Ln(6)
 	}
 
+	shipping := "Free"
+	if order.ShippingAmount > 0 {
+		shipping = fmt.Sprintf("%.2f", order.ShippingAmount)
+	}
+	pdf.Cell(130, 8, "Shipping:")
+	pdf.Cell(40, 8, shipping)
+	pdf.Ln(6)
+
 	pdf.Cell(130, 8, "Total Paid:")
 	pdf.Cell(40, 8, fmt.Sprintf("%.2f", order.NetAmount))
 	pdf.Ln(10)
@@ -1878,6 +2043,9 @@ func (s *Seller) ChangeOrderStatusHandler(w http.ResponseWriter, r *http.Request
 		http.Error(w, "internal error changing status for orderItem", http.StatusInternalServerError)
 		return
 	}
+	if updatedOrderItem.Status == utils.StatusOrderShipped {
+		notifyOrderItem(s.DB, updatedOrderItem, notify.KindOrderShipped, "")
+	}
 
 	// send response
 	type respOrderItem struct {
@@ -1976,8 +2144,13 @@ func (s *Seller) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var productOrders = make(map[uuid.UUID]int)
+	// shipping charged on the seller's items, kept apart from the sales amounts
+	var shippingCharged float64
 	for _, v := range orderItems {
 		productOrders[v.ProductID] += int(v.Quantity)
+		if v.Status != utils.StatusOrderCancelled {
+			shippingCharged += v.ShippingAmount
+		}
 	}
 
 	// Convert map to slice for sorting
@@ -2153,7 +2326,8 @@ func (s *Seller) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.CellFormat(80, 10, "Product ID", "1", 0, "", true, 0, "")
 	pdf.CellFormat(25, 10, "Status", "1", 0, "", true, 0, "")
 	pdf.CellFormat(25, 10, "Qty", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(25, 10, "Price", "1", 1, "R", true, 0, "")
+	pdf.CellFormat(25, 10, "Price", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(25, 10, "Shipping", "1", 1, "R", true, 0, "")
 
 	pdf.SetFont("Arial", "", 11)
 	slno := 1
@@ -2164,7 +2338,8 @@ func (s *Seller) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 		pdf.CellFormat(80, 8, oi.ProductID.String(), "1", 0, "", fillFlag, 0, "")
 		pdf.CellFormat(25, 8, oi.Status, "1", 0, "", !fillFlag, 0, "")
 		pdf.CellFormat(25, 8, fmt.Sprintf("%d", oi.Quantity), "1", 0, "C", fillFlag, 0, "")
-		pdf.CellFormat(25, 8, fmt.Sprintf("%.2f", oi.Price), "1", 1, "R", !fillFlag, 0, "")
+		pdf.CellFormat(25, 8, fmt.Sprintf("%.2f", oi.Price), "1", 0, "R", !fillFlag, 0, "")
+		pdf.CellFormat(25, 8, fmt.Sprintf("%.2f", oi.ShippingAmount), "1", 1, "R", fillFlag, 0, "")
 		slno++
 		fillFlag = !fillFlag
 	}
@@ -2194,6 +2369,8 @@ func (s *Seller) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.Cell(0, 8, fmt.Sprintf("Debited Platform Fee: $%.2f", platformFees))
 	pdf.Ln(6)
 	pdf.Cell(0, 8, fmt.Sprintf("Net Profit: $%.2f", netProfit))
+	pdf.Ln(6)
+	pdf.Cell(0, 8, fmt.Sprintf("Shipping Charged: $%.2f", shippingCharged))
 	pdf.Ln(12)
 
 	// Vendor Payments Table
@@ -2348,6 +2525,14 @@ func (a *Admin) DeliverOrderItemHandler(w http.ResponseWriter, r *http.Request)
 		http.Error(w, "internal error changing order status to delivered", http.StatusInternalServerError)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionOrderItemDeliver,
+		EntityType: audit.EntityOrderItem,
+		EntityID:   updatedOrderItem.ID.String(),
+		Before:     map[string]any{"status": orderItem.Status},
+		After:      map[string]any{"status": updatedOrderItem.Status},
+	})
+	notifyOrderItem(a.DB, updatedOrderItem, notify.KindOrderDelivered, "")
 	// if updatedOrderItem.Status == utils.Status
 	// var editVendorPayArg db.EditVendorPaymentStatusByOrderItemIDParams
 	// editVendorPayArg.OrderItemID = updatedOrderItem.ID
@@ -2507,6 +2692,12 @@ func (a *Admin) AddCouponHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal error adding coupon", http.StatusInternalServerError)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCouponAdd,
+		EntityType: audit.EntityCoupon,
+		EntityID:   addedCoupon.ID.String(),
+		After:      addedCoupon,
+	})
 
 	type respCoupon struct {
 		Name           string    `json:"name"`
@@ -2595,16 +2786,16 @@ func (a *Admin) EditCouponHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// // fetch if the coupon exists
-	// coupon, err := a.DB.GetCouponByName(context.TODO(), req.OldName)
-	// if err == sql.ErrNoRows {
-	// 	http.Error(w, "coupon does not exist", http.StatusBadRequest)
-	// 	return
-	// } else if err != nil {
-	// 	log.Error("error fetching coupon to edit in EditCouponHandler in Admin:", err.Error())
-	// 	http.Error(w, "internal error fetching coupon to edit", http.StatusInternalServerError)
-	// 	return
-	// }
+	// fetch the coupon as it is before the edit for the audit log
+	coupon, err := a.DB.GetCouponByName(context.TODO(), req.OldName)
+	if err == sql.ErrNoRows {
+		http.Error(w, "coupon does not exist to edit", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Error("error fetching coupon to edit in EditCouponHandler in Admin:", err.Error())
+		http.Error(w, "internal error fetching coupon to edit", http.StatusInternalServerError)
+		return
+	}
 
 	var editCouponArg db.EditCouponByNameParams
 	editCouponArg.OldName = req.OldName
@@ -2624,6 +2815,13 @@ func (a *Admin) EditCouponHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal error editing coupon", http.StatusInternalServerError)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCouponEdit,
+		EntityType: audit.EntityCoupon,
+		EntityID:   editedCoupon.ID.String(),
+		Before:     coupon,
+		After:      editedCoupon,
+	})
 
 	var data struct {
 		CouponID       uuid.UUID `json:"coupon_id"`
@@ -2677,6 +2875,13 @@ func (a *Admin) DeleteCouponHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "internal error soft deleting coupon", http.StatusInternalServerError)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionCouponDelete,
+		EntityType: audit.EntityCoupon,
+		EntityID:   coupon.ID.String(),
+		Before:     map[string]any{"is_deleted": false},
+		After:      map[string]any{"is_deleted": coupon.IsDeleted},
+	})
 
 	w.Header().Add("Content-Type", "application/json")
 	var resp struct {
@@ -2789,14 +2994,20 @@ func (a *Admin) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 
 	var (
 		totalProfit, totalLossAmount float64
+		totalShipping                float64
 		totalSales, totalOrders      int
 		statusCount                  = make(map[string]int)
 		orderItemMap                 = make(map[uuid.UUID]map[string]float64)
 	)
 
 	totalOrders = len(orderItems)
+	// shipping of the items still standing in each order, cancelled items refunded theirs
+	orderShipping := make(map[uuid.UUID]float64)
 	for _, oi := range orderItems {
 		statusCount[oi.Status]++
+		if oi.Status != utils.StatusOrderCancelled {
+			orderShipping[oi.OrderID] += oi.ShippingAmount
+		}
 	}
 
 	for _, vp := range vendorPayments {
@@ -2828,6 +3039,7 @@ func (a *Admin) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 		totalLossAmount += o.DiscountAmount
+		totalShipping += orderShipping[o.ID]
 	}
 
 	// === Begin PDF ===
@@ -2890,6 +3102,8 @@ func (a *Admin) SalesReportHandler(w http.ResponseWriter, r *http.Request) {
 	pdf.Cell(95, 8, fmt.Sprintf("Platform Profit: $%.2f", totalProfit))
 	pdf.Cell(95, 8, fmt.Sprintf("Discount Loss: $%.2f", totalLossAmount))
 	pdf.Ln(8)
+	pdf.Cell(95, 8, fmt.Sprintf("Shipping Collected: $%.2f", totalShipping))
+	pdf.Ln(8)
 	pdf.SetFont("Arial", "B", 12)
 	pdf.Cell(190, 8, fmt.Sprintf("Net Profit: $%.2f", totalProfit-totalLossAmount))
 	pdf.Ln(12)
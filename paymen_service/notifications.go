@@ -0,0 +1,46 @@
+package payment_service
+
+import (
+	"context"
+
+	db "payment_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/notify"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var notifier = newNotifier()
+
+func newNotifier() *notify.Notifier {
+	n, err := notify.New(dbConn)
+	if err != nil {
+		log.Fatal("error setting up notifications: ", err)
+	}
+	return n
+}
+
+// notifyOrderItem tells the buyer of the order item about its status change
+func notifyOrderItem(q *db.Queries, orderItem db.OrderItem, kind string, reason string) {
+	item, err := q.GetOrderItemBuyerAndProduct(context.TODO(), orderItem.ID)
+	if err != nil {
+		log.Warn("error fetching buyer of order item to notify:", err.Error())
+		return
+	}
+	notifier.Log(context.TODO(), item.UserID, kind, notify.Data{
+		OrderID:     uuid.NullUUID{UUID: item.OrderID, Valid: true},
+		OrderItemID: uuid.NullUUID{UUID: orderItem.ID, Valid: true},
+		ProductName: item.ProductName,
+		Amount:      orderItem.TotalAmount,
+		Reason:      reason,
+	})
+}
+
+// notifyRefund tells the user the amount was credited back to their wallet
+func notifyRefund(userID uuid.UUID, orderID uuid.UUID, amount float64, reason string) {
+	notifier.Log(context.TODO(), userID, notify.KindRefundIssued, notify.Data{
+		OrderID: uuid.NullUUID{UUID: orderID, Valid: true},
+		Amount:  amount,
+		Reason:  reason,
+	})
+}
@@ -0,0 +1,36 @@
+package audit
+
+// actions are entity.verb so the log can be filtered by either part
+const ActionUserBlock = "user.block"
+const ActionUserUnblock = "user.unblock"
+const ActionUserErase = "user.erase"
+const ActionSellerOnboardingReview = "seller.onboarding_review"
+const ActionSellerDocumentReview = "seller.document_review"
+const ActionRoleAdd = "role.add"
+const ActionRolePermissionsEdit = "role.permissions_edit"
+const ActionUserRoleAssign = "user.role_assign"
+const ActionUserRoleRemove = "user.role_remove"
+const ActionTwoFactorPolicyEdit = "2fa_policy.edit"
+
+const ActionProductDelete = "product.delete"
+const ActionReviewModerate = "review.moderate"
+const ActionCategoryAdd = "category.add"
+const ActionCategoryEdit = "category.edit"
+const ActionCategoryDelete = "category.delete"
+
+const ActionCouponAdd = "coupon.add"
+const ActionCouponEdit = "coupon.edit"
+const ActionCouponDelete = "coupon.delete"
+const ActionOrderItemDeliver = "order_item.deliver"
+const ActionWalletCredit = "wallet.credit"
+
+const EntityUser = "user"
+const EntitySellerDocument = "seller_document"
+const EntityRole = "role"
+const EntityTwoFactorPolicy = "2fa_policy"
+const EntityProduct = "product"
+const EntityReview = "review"
+const EntityCategory = "category"
+const EntityCoupon = "coupon"
+const EntityOrderItem = "order_item"
+const EntityWallet = "wallet"
@@ -0,0 +1,203 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	middleware "github.com/amankhys/multi_vendor_ecommerce_go/pkg/middlewares"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// actor role of entries written by crons and other code without a request
+const SystemActor = "system"
+
+// Entry is one administrative or financial action. Before and After are stored
+// as json, either can be nil for actions that create or delete the entity
+type Entry struct {
+	Action     string
+	EntityType string
+	EntityID   string
+	Before     any
+	After      any
+}
+
+// Record is an entry as stored, with who did it and from where
+type Record struct {
+	ID             uuid.UUID       `json:"id"`
+	ActorID        uuid.NullUUID   `json:"actor_id"`
+	ActorRole      string          `json:"actor_role"`
+	ImpersonatorID uuid.NullUUID   `json:"impersonator_id"`
+	Action         string          `json:"action"`
+	EntityType     string          `json:"entity_type"`
+	EntityID       string          `json:"entity_id"`
+	Before         json.RawMessage `json:"before"`
+	After          json.RawMessage `json:"after"`
+	IPAddress      string          `json:"ip_address"`
+	UserAgent      string          `json:"user_agent"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+var tableName = regexp.MustCompile(`^[a-z_]+$`)
+
+// Logger writes to the append only audit table of a service, created in its schema as
+//
+//	CREATE TABLE <table> (
+//	    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+//	    actor_id UUID,
+//	    actor_role TEXT NOT NULL,
+//	    impersonator_id UUID,
+//	    action TEXT NOT NULL,
+//	    entity_type TEXT NOT NULL,
+//	    entity_id TEXT NOT NULL,
+//	    before JSONB,
+//	    after JSONB,
+//	    ip_address TEXT NOT NULL DEFAULT '',
+//	    user_agent TEXT NOT NULL DEFAULT '',
+//	    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+//	);
+type Logger struct {
+	DB    *sql.DB
+	Table string
+}
+
+func New(db *sql.DB, table string) *Logger {
+	if !tableName.MatchString(table) {
+		panic("audit: invalid table name " + table)
+	}
+	return &Logger{DB: db, Table: table}
+}
+
+func toJSON(v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+func (l *Logger) insert(ctx context.Context, rec Record, e Entry) error {
+	before, err := toJSON(e.Before)
+	if err != nil {
+		return err
+	}
+	after, err := toJSON(e.After)
+	if err != nil {
+		return err
+	}
+	_, err = l.DB.ExecContext(ctx, fmt.Sprintf(`
+		INSERT INTO %s (actor_id, actor_role, impersonator_id, action, entity_type, entity_id, before, after, ip_address, user_agent)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`, l.Table),
+		rec.ActorID, rec.ActorRole, rec.ImpersonatorID, e.Action, e.EntityType, e.EntityID,
+		nullJSON(before), nullJSON(after), rec.IPAddress, rec.UserAgent)
+	return err
+}
+
+func nullJSON(b []byte) any {
+	if b == nil {
+		return nil
+	}
+	return string(b)
+}
+
+// Record writes the entry with the user the auth middleware put in the request
+// context as the actor. a failed write is logged, the action has already happened
+func (l *Logger) Record(r *http.Request, e Entry) {
+	rec := Record{
+		ActorRole: SystemActor,
+		IPAddress: utils.GetClientIPString(r),
+		UserAgent: utils.GetUserAgent(r),
+	}
+	if u, ok := r.Context().Value(utils.UserKey).(middleware.User); ok {
+		rec.ActorID = uuid.NullUUID{UUID: u.ID, Valid: true}
+		rec.ActorRole = u.Role
+		if u.ImpersonatorID != uuid.Nil {
+			rec.ImpersonatorID = uuid.NullUUID{UUID: u.ImpersonatorID, Valid: true}
+		}
+	}
+	if err := l.insert(r.Context(), rec, e); err != nil {
+		log.Errorf("error writing audit entry %s of %s %s: %s", e.Action, e.EntityType, e.EntityID, err.Error())
+	}
+}
+
+// RecordSystem writes an entry for an action no user made, like a cron crediting wallets
+func (l *Logger) RecordSystem(ctx context.Context, e Entry) {
+	if err := l.insert(ctx, Record{ActorRole: SystemActor}, e); err != nil {
+		log.Errorf("error writing audit entry %s of %s %s: %s", e.Action, e.EntityType, e.EntityID, err.Error())
+	}
+}
+
+// Filter narrows down Query, zero values match everything
+type Filter struct {
+	ActorID    uuid.NullUUID
+	Action     string
+	EntityType string
+	EntityID   string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+// Query returns the newest records first
+func (l *Logger) Query(ctx context.Context, f Filter) ([]Record, error) {
+	var where []string
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if f.ActorID.Valid {
+		add("actor_id = $%d", f.ActorID.UUID)
+	}
+	if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.EntityType != "" {
+		add("entity_type = $%d", f.EntityType)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if !f.From.IsZero() {
+		add("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("created_at < $%d", f.To)
+	}
+	query := fmt.Sprintf(`SELECT id, actor_id, actor_role, impersonator_id, action, entity_type, entity_id,
+		before, after, ip_address, user_agent, created_at FROM %s`, l.Table)
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC"
+	if f.Limit > 0 {
+		args = append(args, f.Limit, f.Offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+	}
+
+	rows, err := l.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	records := []Record{}
+	for rows.Next() {
+		var rec Record
+		var before, after []byte
+		err = rows.Scan(&rec.ID, &rec.ActorID, &rec.ActorRole, &rec.ImpersonatorID, &rec.Action, &rec.EntityType,
+			&rec.EntityID, &before, &after, &rec.IPAddress, &rec.UserAgent, &rec.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		rec.Before, rec.After = before, after
+		records = append(records, rec)
+	}
+	return records, rows.Err()
+}
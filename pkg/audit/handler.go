@@ -0,0 +1,156 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultPageLimit = 50
+const maxPageLimit = 500
+
+// rows a csv export holds at most
+const maxExportRows = 100000
+
+// ParseFilter reads actor_id, action, entity_type, entity_id, from and to
+// (RFC 3339 or 2006-01-02), page and limit from the query string
+func ParseFilter(r *http.Request) (Filter, int, error) {
+	q := r.URL.Query()
+	f := Filter{
+		Action:     q.Get("action"),
+		EntityType: q.Get("entity_type"),
+		EntityID:   q.Get("entity_id"),
+	}
+	if s := q.Get("actor_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return f, 0, fmt.Errorf("invalid actor_id")
+		}
+		f.ActorID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	var err error
+	if f.From, err = parseTime(q.Get("from")); err != nil {
+		return f, 0, fmt.Errorf("invalid from date")
+	}
+	if f.To, err = parseTime(q.Get("to")); err != nil {
+		return f, 0, fmt.Errorf("invalid to date")
+	}
+
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	f.Limit, _ = strconv.Atoi(q.Get("limit"))
+	if f.Limit < 1 {
+		f.Limit = defaultPageLimit
+	} else if f.Limit > maxPageLimit {
+		f.Limit = maxPageLimit
+	}
+	f.Offset = (page - 1) * f.Limit
+	return f, page, nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, s)
+}
+
+// WriteCSV writes the records with a header row, before and after stay json
+func WriteCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"id", "created_at", "actor_id", "actor_role", "impersonator_id", "action",
+		"entity_type", "entity_id", "before", "after", "ip_address", "user_agent"})
+	for _, rec := range records {
+		row := []string{
+			rec.ID.String(),
+			rec.CreatedAt.Format(time.RFC3339),
+			nullUUIDString(rec.ActorID),
+			rec.ActorRole,
+			nullUUIDString(rec.ImpersonatorID),
+			rec.Action,
+			rec.EntityType,
+			rec.EntityID,
+			string(rec.Before),
+			string(rec.After),
+			rec.IPAddress,
+			rec.UserAgent,
+		}
+		for i := range row {
+			row[i] = csvCell(row[i])
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCell keeps a spreadsheet from running a value as a formula, the user agent
+// and the entity id come from the request and can start with anything
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func nullUUIDString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
+}
+
+// Handler serves the audit log of the service as json, or as a csv download of
+// every matching record with format=csv. guard it with the audit permission
+func (l *Logger) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f, page, err := ParseFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		csvExport := r.URL.Query().Get("format") == "csv"
+		if csvExport {
+			f.Limit, f.Offset = maxExportRows, 0
+		}
+		records, err := l.Query(context.TODO(), f)
+		if err != nil {
+			log.Warn("error querying audit log:", err.Error())
+			http.Error(w, "internal error fetching audit log", http.StatusInternalServerError)
+			return
+		}
+
+		if csvExport {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.csv"`, l.Table, time.Now().Format("20060102_150405")))
+			if err = WriteCSV(w, records); err != nil {
+				log.Warn("error writing audit log csv:", err.Error())
+			}
+			return
+		}
+		var resp struct {
+			Data  []Record `json:"data"`
+			Page  int      `json:"page"`
+			Limit int      `json:"limit"`
+		}
+		resp.Data = records
+		resp.Page = page
+		resp.Limit = f.Limit
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
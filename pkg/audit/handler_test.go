@@ -0,0 +1,70 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCSVCell(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"=HYPERLINK(\"http://evil\")", "'=HYPERLINK(\"http://evil\")"},
+		{"+1+1", "'+1+1"},
+		{"-2+3", "'-2+3"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"\r=1", "'\r=1"},
+		{"Mozilla/5.0", "Mozilla/5.0"},
+		{"a=b", "a=b"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := csvCell(tt.in); got != tt.want {
+			t.Errorf("csvCell(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	rec := Record{
+		ID:         uuid.New(),
+		ActorID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		ActorRole:  "admin",
+		Action:     ActionUserErase,
+		EntityType: EntityUser,
+		EntityID:   "=cmd|' /C calc'!A0",
+		Before:     json.RawMessage(`{"name":"old"}`),
+		After:      json.RawMessage(`{"name":"new"}`),
+		IPAddress:  "10.0.0.1",
+		UserAgent:  "@evil",
+		CreatedAt:  time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC),
+	}
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, []Record{rec}); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || len(rows[0]) != 12 || len(rows[1]) != 12 {
+		t.Fatalf("csv = %q, want a header and one row of 12 columns", rows)
+	}
+	want := []string{
+		rec.ID.String(), "2026-10-19T10:00:00Z", rec.ActorID.UUID.String(), "admin", "",
+		ActionUserErase, EntityUser, "'=cmd|' /C calc'!A0", `{"name":"old"}`, `{"name":"new"}`,
+		"10.0.0.1", "'@evil",
+	}
+	for i, v := range want {
+		if rows[1][i] != v {
+			t.Errorf("column %s = %q, want %q", rows[0][i], rows[1][i], v)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package delivery
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Zone is how far a parcel travels between two pincodes, by the india post numbering:
+// the first digit is the postal region, the first two the circle and the first three
+// the sorting district
+type Zone string
+
+const (
+	ZoneLocal    Zone = "local"
+	ZoneRegional Zone = "regional"
+	ZoneZonal    Zone = "zonal"
+	ZoneNational Zone = "national"
+	// jammu & kashmir, ladakh, the north east and the islands, reached slower from anywhere
+	ZoneSpecial Zone = "special"
+)
+
+// TransitDays is the working days a parcel takes to reach a zone once shipped
+var TransitDays = map[Zone]int{
+	ZoneLocal:    1,
+	ZoneRegional: 2,
+	ZoneZonal:    3,
+	ZoneNational: 5,
+	ZoneSpecial:  7,
+}
+
+var specialPrefixes = []string{"18", "19", "78", "79", "744", "68255"}
+
+func special(pin string) bool {
+	for _, p := range specialPrefixes {
+		if strings.HasPrefix(pin, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ZoneOf gives the zone of a parcel from one six digit pincode to another
+func ZoneOf(from, to int) Zone {
+	f, t := strconv.Itoa(from), strconv.Itoa(to)
+	switch {
+	case len(f) != 6 || len(t) != 6:
+		return ZoneNational
+	case f[:3] == t[:3]:
+		return ZoneLocal
+	case special(f) || special(t):
+		return ZoneSpecial
+	case f[:2] == t[:2]:
+		return ZoneRegional
+	case f[0] == t[0]:
+		return ZoneZonal
+	}
+	return ZoneNational
+}
+
+// EstimatedDate is the day a parcel ordered at from arrives, after the seller's handling
+// days and the zone's transit days. sundays aren't working days
+func EstimatedDate(from time.Time, handlingDays int, zone Zone) time.Time {
+	date := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for days := handlingDays + TransitDays[zone]; days > 0; {
+		date = date.AddDate(0, 0, 1)
+		if date.Weekday() != time.Sunday {
+			days--
+		}
+	}
+	return date
+}
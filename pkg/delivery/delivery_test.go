@@ -0,0 +1,77 @@
+package delivery
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZoneOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		want     Zone
+	}{
+		{"same sorting district", 682001, 682030, ZoneLocal},
+		{"same pincode", 560001, 560001, ZoneLocal},
+		{"same circle", 682001, 683101, ZoneRegional},
+		{"same region", 682001, 600001, ZoneZonal},
+		{"other region", 682001, 110001, ZoneNational},
+
+		// the special areas are slow to reach from anywhere outside their district
+		{"into jammu & kashmir", 110001, 190001, ZoneSpecial},
+		{"out of jammu & kashmir", 180001, 110001, ZoneSpecial},
+		{"north east within region 7", 700001, 781001, ZoneSpecial},
+		{"andaman", 600001, 744101, ZoneSpecial},
+		{"lakshadweep", 400001, 682555, ZoneSpecial},
+		{"within a special district", 190001, 190008, ZoneLocal},
+
+		// anything that isn't a six digit pincode can't be placed
+		{"short from", 68200, 682001, ZoneNational},
+		{"short to", 682001, 12345, ZoneNational},
+		{"long", 1234567, 1234567, ZoneNational},
+		{"zero", 0, 682001, ZoneNational},
+		{"negative", -682001, 682001, ZoneNational},
+	}
+	for _, tt := range tests {
+		if got := ZoneOf(tt.from, tt.to); got != tt.want {
+			t.Errorf("%s: ZoneOf(%d, %d) = %s, want %s", tt.name, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestEstimatedDate(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	day := func(d int, hour int) time.Time {
+		// october 2026, the 12th is a monday
+		return time.Date(2026, time.October, d, hour, 30, 0, 0, ist)
+	}
+	tests := []struct {
+		name     string
+		from     time.Time
+		handling int
+		zone     Zone
+		want     time.Time
+	}{
+		{"within the week", day(14, 15), 1, ZoneLocal, day(16, 0)},
+		{"sunday skipped", day(16, 9), 1, ZoneLocal, day(19, 0)},
+		{"ordered saturday", day(17, 22), 0, ZoneLocal, day(19, 0)},
+		{"ordered sunday", day(18, 10), 0, ZoneLocal, day(19, 0)},
+		{"national over a weekend", day(19, 8), 2, ZoneNational, day(27, 0)},
+		{"special over a weekend", day(12, 8), 3, ZoneSpecial, day(23, 0)},
+		{"unknown zone is handling only", day(14, 8), 2, Zone("moon"), day(16, 0)},
+		{"nothing to wait for", day(14, 8), 0, Zone("moon"), day(14, 0)},
+	}
+	for _, tt := range tests {
+		got := EstimatedDate(tt.from, tt.handling, tt.zone)
+		want := time.Date(tt.want.Year(), tt.want.Month(), tt.want.Day(), 0, 0, 0, 0, ist)
+		if !got.Equal(want) {
+			t.Errorf("%s: EstimatedDate(%s, %d, %s) = %s, want %s", tt.name, tt.from.Format("Mon 02 Jan 15:04"), tt.handling, tt.zone, got.Format("Mon 02 Jan"), want.Format("Mon 02 Jan"))
+		}
+		if got.Weekday() == time.Sunday {
+			t.Errorf("%s: estimated a sunday", tt.name)
+		}
+		if got.Location() != ist {
+			t.Errorf("%s: estimate moved out of the order's time zone", tt.name)
+		}
+	}
+}
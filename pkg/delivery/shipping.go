@@ -0,0 +1,119 @@
+package delivery
+
+import "math"
+
+// shipping rules a seller picks for their part of an order
+const (
+	RuleFree   = "free"
+	RuleFlat   = "flat"
+	RuleWeight = "weight"
+)
+
+// parcels charged by weight pay per started slab
+const SlabGrams = 500
+
+// RatePerSlab is the charge per slab of a parcel to a zone, for sellers charging by weight
+var RatePerSlab = map[Zone]float64{
+	ZoneLocal:    30,
+	ZoneRegional: 40,
+	ZoneZonal:    55,
+	ZoneNational: 70,
+	ZoneSpecial:  90,
+}
+
+// Rule is how a seller charges shipping. FreeAbove waives the charge on a parcel
+// whose subtotal reaches it, 0 means there is no such threshold
+type Rule struct {
+	Kind      string
+	FlatFee   float64
+	FreeAbove float64
+}
+
+// Charge is the shipping charge of one seller's parcel in an order
+func (r Rule) Charge(subtotal float64, weightGrams int, zone Zone) float64 {
+	if r.FreeAbove > 0 && subtotal >= r.FreeAbove {
+		return 0
+	}
+	switch r.Kind {
+	case RuleFlat:
+		return r.FlatFee
+	case RuleWeight:
+		slabs := (weightGrams + SlabGrams - 1) / SlabGrams
+		if slabs < 1 {
+			slabs = 1
+		}
+		return float64(slabs) * RatePerSlab[zone]
+	}
+	return 0
+}
+
+// Split divides a parcel's charge over its items in proportion to their amounts, so
+// cancelling an item gives back its share. shares are in paise and add up to the charge
+func Split(charge float64, amounts []float64) []float64 {
+	shares := make([]float64, len(amounts))
+	var total float64
+	for _, a := range amounts {
+		total += a
+	}
+	if len(amounts) == 0 || charge == 0 {
+		return shares
+	}
+	paise := math.Round(charge * 100)
+	var given float64
+	for i, a := range amounts {
+		if i == len(amounts)-1 {
+			shares[i] = (paise - given) / 100
+			break
+		}
+		share := math.Floor(paise / float64(len(amounts)))
+		if total > 0 {
+			share = math.Floor(paise * a / total)
+		}
+		shares[i] = share / 100
+		given += share
+	}
+	return shares
+}
+
+// Item is one order line as the shipping charge sees it
+type Item struct {
+	SellerID string
+	Amount   float64
+	// of the whole line, the product weight times the quantity
+	WeightGrams int
+	// zone from the seller to the shipping address
+	Zone Zone
+}
+
+// ItemCharges groups the items into one parcel per seller, charges each parcel by
+// its seller's rule and returns every item's share of its parcel's charge in the
+// order of items. sellers without a rule ship free
+func ItemCharges(items []Item, rules map[string]Rule) []float64 {
+	type parcel struct {
+		items    []int
+		amounts  []float64
+		subtotal float64
+		weight   int
+		zone     Zone
+	}
+	parcels := make(map[string]*parcel)
+	for i, item := range items {
+		p, ok := parcels[item.SellerID]
+		if !ok {
+			p = &parcel{zone: item.Zone}
+			parcels[item.SellerID] = p
+		}
+		p.items = append(p.items, i)
+		p.amounts = append(p.amounts, item.Amount)
+		p.subtotal += item.Amount
+		p.weight += item.WeightGrams
+	}
+	shares := make([]float64, len(items))
+	for sellerID, p := range parcels {
+		charge := rules[sellerID].Charge(p.subtotal, p.weight, p.zone)
+		for j, share := range Split(charge, p.amounts) {
+			shares[p.items[j]] = share
+		}
+	}
+	return shares
+}
@@ -0,0 +1,164 @@
+package delivery
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRuleCharge(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     Rule
+		subtotal float64
+		weight   int
+		zone     Zone
+		want     float64
+	}{
+		{"free", Rule{Kind: RuleFree}, 100, 2000, ZoneNational, 0},
+		{"unknown rule ships free", Rule{Kind: "pigeon"}, 100, 2000, ZoneNational, 0},
+		{"flat", Rule{Kind: RuleFlat, FlatFee: 49}, 100, 2000, ZoneNational, 49},
+		{"flat ignores weight and zone", Rule{Kind: RuleFlat, FlatFee: 49}, 100, 9000, ZoneSpecial, 49},
+
+		// every started slab of 500g is charged
+		{"weight one gram", Rule{Kind: RuleWeight}, 100, 1, ZoneLocal, 30},
+		{"weight one slab", Rule{Kind: RuleWeight}, 100, 500, ZoneLocal, 30},
+		{"weight just over a slab", Rule{Kind: RuleWeight}, 100, 501, ZoneLocal, 60},
+		{"weight three slabs", Rule{Kind: RuleWeight}, 100, 1500, ZoneLocal, 90},
+		{"weight unknown counts as a slab", Rule{Kind: RuleWeight}, 100, 0, ZoneLocal, 30},
+
+		// farther zones cost more per slab
+		{"weight regional", Rule{Kind: RuleWeight}, 100, 1000, ZoneRegional, 80},
+		{"weight zonal", Rule{Kind: RuleWeight}, 100, 1000, ZoneZonal, 110},
+		{"weight national", Rule{Kind: RuleWeight}, 100, 1000, ZoneNational, 140},
+		{"weight special", Rule{Kind: RuleWeight}, 100, 1000, ZoneSpecial, 180},
+
+		// the threshold waives any rule once the parcel's subtotal reaches it
+		{"flat below threshold", Rule{Kind: RuleFlat, FlatFee: 49, FreeAbove: 500}, 499.99, 500, ZoneLocal, 49},
+		{"flat at threshold", Rule{Kind: RuleFlat, FlatFee: 49, FreeAbove: 500}, 500, 500, ZoneLocal, 0},
+		{"weight above threshold", Rule{Kind: RuleWeight, FreeAbove: 1000}, 1200, 5000, ZoneSpecial, 0},
+		{"no threshold", Rule{Kind: RuleFlat, FlatFee: 49}, 1e6, 500, ZoneLocal, 49},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Charge(tt.subtotal, tt.weight, tt.zone); got != tt.want {
+				t.Errorf("Charge = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func sum(v []float64) float64 {
+	var s float64
+	for _, x := range v {
+		s += x
+	}
+	return math.Round(s*100) / 100
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		charge  float64
+		amounts []float64
+		want    []float64
+	}{
+		{"proportional", 70, []float64{300, 600, 100}, []float64{21, 42, 7}},
+		{"remainder goes to the last item", 10, []float64{1, 1, 1}, []float64{3.33, 3.33, 3.34}},
+		{"single item", 49, []float64{250}, []float64{49}},
+		{"zero amounts share evenly", 10, []float64{0, 0}, []float64{5, 5}},
+		{"no charge", 0, []float64{100, 200}, []float64{0, 0}},
+		{"no items", 49, nil, []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Split(tt.charge, tt.amounts)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Split = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Fatalf("Split = %v, want %v", got, tt.want)
+				}
+			}
+			if len(got) > 0 && sum(got) != tt.charge {
+				t.Errorf("shares add up to %v, want %v", sum(got), tt.charge)
+			}
+		})
+	}
+}
+
+func TestItemCharges(t *testing.T) {
+	rules := map[string]Rule{
+		"flat":   {Kind: RuleFlat, FlatFee: 60},
+		"weight": {Kind: RuleWeight, FreeAbove: 2000},
+		"free":   {Kind: RuleFree},
+	}
+	tests := []struct {
+		name  string
+		items []Item
+		want  []float64
+	}{
+		{
+			// one flat fee per seller however many items, split over that seller's items
+			name: "flat seller charged once",
+			items: []Item{
+				{SellerID: "flat", Amount: 100, WeightGrams: 200, Zone: ZoneLocal},
+				{SellerID: "flat", Amount: 200, WeightGrams: 200, Zone: ZoneLocal},
+			},
+			want: []float64{20, 40},
+		},
+		{
+			// the weights of a seller's items add up to one parcel, 1200g is 3 slabs
+			name: "weight of a parcel adds up",
+			items: []Item{
+				{SellerID: "weight", Amount: 100, WeightGrams: 600, Zone: ZoneZonal},
+				{SellerID: "weight", Amount: 100, WeightGrams: 600, Zone: ZoneZonal},
+			},
+			want: []float64{82.5, 82.5},
+		},
+		{
+			// items of different sellers are interleaved, each keeps its own seller's share
+			name: "mixed sellers",
+			items: []Item{
+				{SellerID: "flat", Amount: 100, WeightGrams: 500, Zone: ZoneLocal},
+				{SellerID: "weight", Amount: 300, WeightGrams: 500, Zone: ZoneNational},
+				{SellerID: "free", Amount: 999, WeightGrams: 5000, Zone: ZoneSpecial},
+				{SellerID: "flat", Amount: 100, WeightGrams: 500, Zone: ZoneLocal},
+				{SellerID: "weight", Amount: 100, WeightGrams: 400, Zone: ZoneNational},
+			},
+			// flat 60 over 100/100, weight 900g is 2 national slabs = 140 over 300/100
+			want: []float64{30, 105, 0, 30, 35},
+		},
+		{
+			// the free threshold looks at the seller's parcel, not a single item
+			name: "threshold on the parcel subtotal",
+			items: []Item{
+				{SellerID: "weight", Amount: 1500, WeightGrams: 500, Zone: ZoneLocal},
+				{SellerID: "weight", Amount: 500, WeightGrams: 500, Zone: ZoneLocal},
+			},
+			want: []float64{0, 0},
+		},
+		{
+			name:  "seller without a rule ships free",
+			items: []Item{{SellerID: "unknown", Amount: 100, WeightGrams: 500, Zone: ZoneLocal}},
+			want:  []float64{0},
+		},
+		{
+			name: "no items",
+			want: []float64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ItemCharges(tt.items, rules)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ItemCharges = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Fatalf("ItemCharges = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -28,6 +28,61 @@ const AesIV = "AES_IV"
 const GoogleClientID = "GOOGLE_CLIENT_ID"
 const GoogleSecretKey = "GOOGLE_SECRET_KEY"
 
+// url google redirects back to after sign-in, the /auth/callback of the user service
+const GoogleRedirectURL = "GOOGLE_REDIRECT_URL"
+
 // razorpay keys
 const RPID = "RPAY_KEY_ID"
 const RPSecretKey = "RPAY_SECRET_KEY"
+
+// file storage keys
+const StorageBackend = "STORAGE_BACKEND"
+const StorageLocalDir = "STORAGE_LOCAL_DIR"
+const StorageBaseURL = "STORAGE_BASE_URL"
+
+// private file storage (kyc documents), never served publicly
+const PrivateStorageBackend = "PRIVATE_STORAGE_BACKEND"
+const PrivateStorageLocalDir = "PRIVATE_STORAGE_LOCAL_DIR"
+const PrivateS3Bucket = "PRIVATE_S3_BUCKET"
+
+// s3 compatible storage keys
+const S3Endpoint = "S3_ENDPOINT"
+const S3Region = "S3_REGION"
+const S3Bucket = "S3_BUCKET"
+const S3AccessKey = "S3_ACCESS_KEY"
+const S3SecretKey = "S3_SECRET_KEY"
+const S3UseSSL = "S3_USE_SSL"
+
+// grpc addresses of the services, host:port
+const UserServiceAddr = "USER_SERVICE_ADDR"
+const InventoryServiceAddr = "INVENTORY_SERVICE_ADDR"
+const PaymentServiceAddr = "PAYMENT_SERVICE_ADDR"
+
+// port the service serves its own grpc server on
+const GRPCPort = "GRPC_PORT"
+
+// jwt signing keys as comma separated kid:secret pairs and the kid new tokens are signed with
+const JWTKeys = "JWT_KEYS"
+const JWTActiveKID = "JWT_ACTIVE_KID"
+
+// where the login and otp attempt counters are kept, postgres or memory
+const RateLimitBackend = "RATE_LIMIT_BACKEND"
+
+// comma separated channels notifications are sent on besides the in-app inbox,
+// any of email, sms, webhook and fake. defaults to email
+const NotifyChannels = "NOTIFY_CHANNELS"
+
+// sms provider phone codes and sms notifications are sent with, gateway or fake
+const SMSProvider = "SMS_PROVIDER"
+
+// http gateway sms are posted to
+const SMSGatewayURL = "SMS_GATEWAY_URL"
+const SMSAPIKey = "SMS_API_KEY"
+
+// url every notification is posted to as json, signed with the secret
+const NotifyWebhookURL = "NOTIFY_WEBHOOK_URL"
+const NotifyWebhookSecret = "NOTIFY_WEBHOOK_SECRET"
+
+// csv of the india post pincode directory addresses are checked against,
+// with pincode, district and state columns. addresses only get the range check without it
+const PincodeData = "PINCODE_DATA"
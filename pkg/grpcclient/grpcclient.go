@@ -0,0 +1,39 @@
+package grpcclient
+
+import (
+	"os"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/inventorypb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/paymentpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/userpb"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// dial doesn't connect right away, the connection is made on the first call
+// so services can start in any order
+func dial(addrEnv string) *grpc.ClientConn {
+	addr := os.Getenv(addrEnv)
+	if addr == "" {
+		log.Fatalf("%s is not set", addrEnv)
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("error creating grpc client for %s: %s", addr, err)
+	}
+	return conn
+}
+
+func NewUserClient() userpb.UserServiceClient {
+	return userpb.NewUserServiceClient(dial(envname.UserServiceAddr))
+}
+
+func NewInventoryClient() inventorypb.InventoryServiceClient {
+	return inventorypb.NewInventoryServiceClient(dial(envname.InventoryServiceAddr))
+}
+
+func NewPaymentClient() paymentpb.PaymentServiceClient {
+	return paymentpb.NewPaymentServiceClient(dial(envname.PaymentServiceAddr))
+}
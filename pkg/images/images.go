@@ -0,0 +1,92 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const MaxImageSize = 5 << 20 // 5MB
+const ThumbnailSize = 320    // longest side of a thumbnail in px
+const MaxDimension = 8000    // longest side of an upload in px
+const MaxPixels = 40_000_000 // width * height of an upload
+
+var ErrUnsupportedType = errors.New("unsupported image type, allowed types are jpeg, png and webp")
+var ErrTooLarge = errors.New("image dimensions too large, maximum is 8000px per side and 40 megapixels in total")
+
+var allowedTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// DetectType sniffs the content type from the bytes instead of trusting the client header
+// and returns it along with the file extension to use
+func DetectType(data []byte) (string, string, error) {
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedTypes[contentType]
+	if !ok {
+		return "", "", ErrUnsupportedType
+	}
+	return contentType, ext, nil
+}
+
+// CheckDimensions reads only the image header and returns ErrTooLarge
+// when the declared size is above MaxDimension or MaxPixels
+func CheckDimensions(data []byte) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return errors.New("invalid image dimensions")
+	}
+	if cfg.Width > MaxDimension || cfg.Height > MaxDimension || int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return ErrTooLarge
+	}
+	return nil
+}
+
+// Thumbnail scales the image down so its longest side is maxSide
+// and encodes it as jpeg (png stays png to keep transparency).
+// The header is checked before decoding so a small file declaring
+// huge dimensions is rejected without allocating its pixels
+func Thumbnail(data []byte, maxSide int) ([]byte, string, error) {
+	if err := CheckDimensions(data); err != nil {
+		return nil, "", err
+	}
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			height = height * maxSide / width
+			width = maxSide
+		} else {
+			width = width * maxSide / height
+			height = maxSide
+		}
+	}
+	width, height = max(width, 1), max(height, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if format == "png" {
+		err = png.Encode(&buf, dst)
+		return buf.Bytes(), "image/png", err
+	}
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	return buf.Bytes(), "image/jpeg", err
+}
@@ -0,0 +1,146 @@
+package images
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encode(t *testing.T, format string, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), 100, 255})
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = png.Encode(&buf, img)
+	case "jpeg":
+		err = jpeg.Encode(&buf, img, nil)
+	case "gif":
+		err = gif.Encode(&buf, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// declareSize rewrites the width and height in a png's IHDR chunk, so the
+// header claims a size the file doesn't have
+func declareSize(t *testing.T, data []byte, width, height uint32) []byte {
+	t.Helper()
+	data = bytes.Clone(data)
+	// 8 byte signature, then the IHDR chunk: length, type, 13 bytes of data, crc
+	if string(data[12:16]) != "IHDR" {
+		t.Fatal("png doesn't start with IHDR")
+	}
+	binary.BigEndian.PutUint32(data[16:20], width)
+	binary.BigEndian.PutUint32(data[20:24], height)
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestDetectType(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		ext         string
+		ok          bool
+	}{
+		{"png", encode(t, "png", 4, 4), "image/png", ".png", true},
+		{"jpeg", encode(t, "jpeg", 4, 4), "image/jpeg", ".jpg", true},
+		{"gif", encode(t, "gif", 4, 4), "", "", false},
+		{"text", []byte("<html>not an image</html>"), "", "", false},
+	}
+	for _, tt := range tests {
+		contentType, ext, err := DetectType(tt.data)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: DetectType error = %v, want ok %v", tt.name, err, tt.ok)
+			continue
+		}
+		if !tt.ok && err != ErrUnsupportedType {
+			t.Errorf("%s: DetectType error = %v, want ErrUnsupportedType", tt.name, err)
+		}
+		if contentType != tt.contentType || ext != tt.ext {
+			t.Errorf("%s: DetectType = %q %q, want %q %q", tt.name, contentType, ext, tt.contentType, tt.ext)
+		}
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        []byte
+		contentType string
+		width       int
+		height      int
+	}{
+		{"wide jpeg", encode(t, "jpeg", 640, 320), "image/jpeg", 320, 160},
+		{"tall png", encode(t, "png", 100, 400), "image/png", 80, 320},
+		{"small stays", encode(t, "jpeg", 100, 50), "image/jpeg", 100, 50},
+		{"thin keeps a pixel", encode(t, "png", 2000, 2), "image/png", 320, 1},
+	}
+	for _, tt := range tests {
+		thumb, contentType, err := Thumbnail(tt.data, ThumbnailSize)
+		if err != nil {
+			t.Errorf("%s: Thumbnail error = %v", tt.name, err)
+			continue
+		}
+		if contentType != tt.contentType {
+			t.Errorf("%s: content type = %q, want %q", tt.name, contentType, tt.contentType)
+		}
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(thumb))
+		if err != nil {
+			t.Errorf("%s: thumbnail doesn't decode: %v", tt.name, err)
+			continue
+		}
+		if cfg.Width != tt.width || cfg.Height != tt.height {
+			t.Errorf("%s: thumbnail is %dx%d, want %dx%d", tt.name, cfg.Width, cfg.Height, tt.width, tt.height)
+		}
+	}
+
+	if _, _, err := Thumbnail([]byte("not an image"), ThumbnailSize); err == nil {
+		t.Error("Thumbnail of garbage succeeded")
+	}
+}
+
+func TestCheckDimensions(t *testing.T) {
+	small := encode(t, "png", 4, 4)
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"small", small, nil},
+		{"at the limits", declareSize(t, small, MaxDimension, MaxPixels/MaxDimension), nil},
+		{"wide", declareSize(t, small, MaxDimension+1, 10), ErrTooLarge},
+		{"tall", declareSize(t, small, 10, MaxDimension+1), ErrTooLarge},
+		{"too many pixels", declareSize(t, small, 7000, 7000), ErrTooLarge},
+		{"huge", declareSize(t, small, 100_000, 100_000), ErrTooLarge},
+	}
+	for _, tt := range tests {
+		if err := CheckDimensions(tt.data); err != tt.err {
+			t.Errorf("%s: CheckDimensions error = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	if err := CheckDimensions([]byte("not an image")); err == nil {
+		t.Error("CheckDimensions of garbage succeeded")
+	}
+	// the header is checked first, the pixels a huge png claims are never allocated
+	if _, _, err := Thumbnail(declareSize(t, small, 7000, 7000), ThumbnailSize); err != ErrTooLarge {
+		t.Errorf("Thumbnail of a png declaring 7000x7000 error = %v, want ErrTooLarge", err)
+	}
+}
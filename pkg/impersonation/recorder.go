@@ -0,0 +1,40 @@
+package impersonation
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const EventStart = "start"
+const EventStop = "stop"
+const EventRequest = "request"
+
+// Recorder writes the requests made during an impersonation to the
+// impersonation_events table of the shared database, the tables are in the
+// user service schema
+type Recorder struct {
+	DB      *sql.DB
+	Service string
+}
+
+func NewRecorder(db *sql.DB, service string) *Recorder {
+	return &Recorder{DB: db, Service: service}
+}
+
+// RecordRequest stores the request against the impersonation and returns false
+// when the impersonation was stopped or has expired, nothing is stored then
+func (rec *Recorder) RecordRequest(ctx context.Context, impersonationID uuid.UUID, r *http.Request, blocked bool) (bool, error) {
+	result, err := rec.DB.ExecContext(ctx, `
+		INSERT INTO impersonation_events (impersonation_id, kind, service, method, path, blocked)
+		SELECT id, $2, $3, $4, $5, $6 FROM impersonations
+		WHERE id = $1 AND ended_at IS NULL AND expires_at > now()`,
+		impersonationID, EventRequest, rec.Service, r.Method, r.URL.RequestURI(), blocked)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
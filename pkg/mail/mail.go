@@ -1,81 +1,98 @@
 package mail
 
 import (
-	"fmt"
-	"net/smtp"
-	"os"
+	"context"
 	"time"
-
-	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
 )
 
-func returnAuth() (smtp.Auth, error) {
-	identity := os.Getenv(envname.SmtpIdentity)
-	smtpHost := os.Getenv(envname.SmtpHost)
-	smtpMail := os.Getenv(envname.SmtpEmail)
-	smtpPassword := os.Getenv(envname.SmtpPassword)
-	auth := smtp.PlainAuth(identity, smtpMail, smtpPassword, smtpHost)
-	return auth, nil
+var outbox *Outbox
+var mailer Mailer
+
+// SetOutbox makes the Send functions queue their mails in the outbox instead of
+// sending them while the handler waits. call it once from the service setup
+func SetOutbox(o *Outbox) {
+	outbox = o
 }
 
-func SendOTPMail(otp int, expires_at time.Time, recepientMail string) error {
-	smtpServer := os.Getenv(envname.SmtpServer)
-	smtpMail := os.Getenv(envname.SmtpEmail)
+// SetMailer replaces the smtp mailer mails are sent with when there is no outbox,
+// tests set a Memory mailer
+func SetMailer(m Mailer) {
+	mailer = m
+}
 
-	auth, err := returnAuth()
+// deliver renders the template and queues it, or sends it right away without an outbox
+func deliver(template string, recepientMail string, data any) error {
+	msg, err := Render(template, recepientMail, data)
 	if err != nil {
 		return err
 	}
-	var recepients []string
-	message := fmt.Sprintf("From: %s\r\n", smtpMail) +
-		"To: " + recepientMail + "\r\n" +
-		"Subject: OTP Verification\r\n" +
-		"MIME-Version: 1.0\r\n" +
-		"Content-Type: text/plain; charset=\"utf-8\"\r\n" +
-		"\r\n" + // Empty line separates headers from body
-		fmt.Sprintf("Dear User,\n\n"+
-			"Your One-Time Password (OTP) for verification is: %d\n\n"+
-			"This OTP will expire on: %s\n"+
-			"Time remaining: %s\n\n"+
-			"Please do not share this OTP with anyone.\n\n"+
-			"If you did not request this, please ignore this email.\n\n"+
-			"Best regards,\nToy Stores Ecom",
-			otp,
-			expires_at.Format("Monday, 02 Jan 2006, 03:04 PM MST"), // More readable date format
-			time.Until(expires_at).Round(time.Second).String())     // Time left rounded to seconds
+	if outbox != nil {
+		return outbox.Enqueue(context.TODO(), template, msg)
+	}
+	m := mailer
+	if m == nil {
+		// read when sending so settings loaded after start up are used
+		m = NewSMTP()
+	}
+	return m.Send(context.TODO(), msg)
+}
+
+type otpData struct {
+	OTP       int
+	ExpiresAt string
+	Remaining string
+}
+
+func newOTPData(otp int, expires_at time.Time) otpData {
+	return otpData{
+		OTP:       otp,
+		ExpiresAt: expires_at.Format("Monday, 02 Jan 2006, 03:04 PM MST"), // More readable date format
+		Remaining: time.Until(expires_at).Round(time.Second).String(),     // Time left rounded to seconds
+	}
+}
 
-	recepients = append(recepients, recepientMail)
-	err = smtp.SendMail(smtpServer, auth, smtpMail, recepients, []byte(message))
-	return err
+func SendOTPMail(otp int, expires_at time.Time, recepientMail string) error {
+	return deliver(TemplateOTP, recepientMail, newOTPData(otp, expires_at))
 }
 
 func SendForgotOTPMail(otp int, expires_at time.Time, recepientMail string) error {
-	smtpServer := os.Getenv(envname.SmtpServer)
-	smtpMail := os.Getenv(envname.SmtpEmail)
+	return deliver(TemplateForgotOTP, recepientMail, newOTPData(otp, expires_at))
+}
 
-	auth, err := returnAuth()
-	if err != nil {
-		return err
-	}
-	var recepients []string
-	message := fmt.Sprintf("From: %s\r\n", smtpMail) +
-		"To: " + recepientMail + "\r\n" +
-		"Subject: Forgot Password OTP Verification\r\n" +
-		"MIME-Version: 1.0\r\n" +
-		"Content-Type: text/plain; charset=\"utf-8\"\r\n" +
-		"\r\n" + // Empty line separates headers from body
-		fmt.Sprintf("Dear User,\n\n"+
-			"Your One-Time Password (OTP) to enter forget password is: %d\n\n"+
-			"This OTP will expire on: %s\n"+
-			"Time remaining: %s\n\n"+
-			"Please do not share this OTP with anyone.\n\n"+
-			"If you did not request this, please ignore this email.\n\n"+
-			"Best regards,\nToy Stores Ecom",
-			otp,
-			expires_at.Format("Monday, 02 Jan 2006, 03:04 PM MST"), // More readable date format
-			time.Until(expires_at).Round(time.Second).String())     // Time left rounded to seconds
+// SendEmailChangeOTPMail sends the otp confirming an email change to the new address
+func SendEmailChangeOTPMail(otp int, expires_at time.Time, recepientMail string) error {
+	return deliver(TemplateEmailChange, recepientMail, newOTPData(otp, expires_at))
+}
+
+func SendBackInStockMail(productName string, productID string, recepientMail string) error {
+	return deliver(TemplateBackInStock, recepientMail, struct {
+		ProductName string
+		ProductID   string
+	}{productName, productID})
+}
+
+func SendPriceDropMail(productName string, productID string, oldPrice float64, newPrice float64, recepientMail string) error {
+	return deliver(TemplatePriceDrop, recepientMail, struct {
+		ProductName string
+		ProductID   string
+		OldPrice    float64
+		NewPrice    float64
+	}{productName, productID, oldPrice, newPrice})
+}
+
+// SendSellerOnboardingMail tells a seller their onboarding application moved to status,
+// reasons are listed for rejected applications
+func SendSellerOnboardingMail(status string, reasons []string, recepientMail string) error {
+	return deliver(TemplateSellerOnboarding, recepientMail, struct {
+		Status  string
+		Reasons []string
+	}{status, reasons})
+}
 
-	recepients = append(recepients, recepientMail)
-	err = smtp.SendMail(smtpServer, auth, smtpMail, recepients, []byte(message))
-	return err
+// SendNotificationMail mails a notification whose text is built elsewhere
+func SendNotificationMail(title string, body string, recepientMail string) error {
+	return deliver(TemplateNotification, recepientMail, struct {
+		Title string
+		Body  string
+	}{title, body})
 }
@@ -0,0 +1,152 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/google/uuid"
+)
+
+// Message is a rendered mail, sent as multipart/alternative with the text part
+// as the fallback for clients that don't show html
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// Mailer delivers rendered messages
+type Mailer interface {
+	Send(ctx context.Context, m Message) error
+}
+
+// SMTP sends mails through an smtp server with plain auth
+type SMTP struct {
+	// host:port of the server
+	Server   string
+	Host     string
+	Identity string
+	From     string
+	Password string
+}
+
+// NewSMTP reads the smtp settings from the environment
+func NewSMTP() *SMTP {
+	return &SMTP{
+		Server:   os.Getenv(envname.SmtpServer),
+		Host:     os.Getenv(envname.SmtpHost),
+		Identity: os.Getenv(envname.SmtpIdentity),
+		From:     os.Getenv(envname.SmtpEmail),
+		Password: os.Getenv(envname.SmtpPassword),
+	}
+}
+
+func (s *SMTP) Send(ctx context.Context, m Message) error {
+	if s.Server == "" {
+		return errors.New("mail: smtp server not set")
+	}
+	raw, err := m.mime(s.From)
+	if err != nil {
+		return err
+	}
+	auth := smtp.PlainAuth(s.Identity, s.From, s.Password, s.Host)
+	// net/smtp takes no context, the send is given up on by the caller once it is done
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.Server, auth, s.From, []string{m.To}, raw)
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// mime builds the message with both parts quoted-printable encoded
+func (m Message) mime(from string) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=\"utf-8\"", m.Text},
+		{"text/html; charset=\"utf-8\"", m.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err = qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err = qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", m.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "Message-ID: <%s@toystores.ecom>\r\n", uuid.NewString())
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n", mw.Boundary())
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+// Memory keeps sent messages instead of delivering them, for tests and local runs.
+// set Err to make every send fail
+type Memory struct {
+	Err error
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// Sent returns a copy of every message sent so far
+func (m *Memory) Sent() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message{}, m.sent...)
+}
+
+func (m *Memory) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sent = nil
+}
@@ -0,0 +1,201 @@
+package mail
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSending = "sending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed"
+)
+
+// Outbox queues mails in the mail_outbox table of the user service so handlers
+// don't wait on the smtp server, a Worker sends them
+type Outbox struct {
+	DB *sql.DB
+}
+
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{DB: db}
+}
+
+// Enqueue queues the rendered mail of the template to be sent right away
+func (o *Outbox) Enqueue(ctx context.Context, template string, m Message) error {
+	_, err := o.DB.ExecContext(ctx, `
+		INSERT INTO mail_outbox (template, recipient, subject, html_body, text_body)
+		VALUES ($1, $2, $3, $4, $5)`,
+		template, m.To, m.Subject, m.HTML, m.Text)
+	return err
+}
+
+// Purge deletes sent and failed mails older than age
+func (o *Outbox) Purge(ctx context.Context, age time.Duration) (int64, error) {
+	result, err := o.DB.ExecContext(ctx, `
+		DELETE FROM mail_outbox
+		WHERE status IN ('sent', 'failed') AND created_at < $1`,
+		time.Now().Add(-age))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+type outboxMail struct {
+	ID       uuid.UUID
+	Attempts int
+	Message  Message
+}
+
+// claim takes up to limit due mails and leases them to this worker. a mail left
+// sending by a worker that died is due again once its lease runs out
+func (o *Outbox) claim(ctx context.Context, limit int, lease time.Duration) ([]outboxMail, error) {
+	rows, err := o.DB.QueryContext(ctx, `
+		UPDATE mail_outbox
+		SET status = 'sending', attempts = attempts + 1, next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM mail_outbox
+			WHERE status IN ('pending', 'sending') AND next_attempt_at <= CURRENT_TIMESTAMP
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, attempts, recipient, subject, html_body, text_body`,
+		limit, time.Now().Add(lease))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var mails []outboxMail
+	for rows.Next() {
+		var m outboxMail
+		err = rows.Scan(&m.ID, &m.Attempts, &m.Message.To, &m.Message.Subject, &m.Message.HTML, &m.Message.Text)
+		if err != nil {
+			return nil, err
+		}
+		mails = append(mails, m)
+	}
+	return mails, rows.Err()
+}
+
+func (o *Outbox) markSent(ctx context.Context, id uuid.UUID) error {
+	_, err := o.DB.ExecContext(ctx, `
+		UPDATE mail_outbox
+		SET status = 'sent', sent_at = CURRENT_TIMESTAMP, last_error = NULL
+		WHERE id = $1`, id)
+	return err
+}
+
+// markFailed schedules the next attempt, or gives up on the mail when retry is zero
+func (o *Outbox) markFailed(ctx context.Context, id uuid.UUID, sendErr error, retry time.Duration) error {
+	status := OutboxStatusPending
+	if retry == 0 {
+		status = OutboxStatusFailed
+	}
+	_, err := o.DB.ExecContext(ctx, `
+		UPDATE mail_outbox
+		SET status = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1`,
+		id, status, sendErr.Error(), time.Now().Add(retry))
+	return err
+}
+
+// Worker drains the outbox. failed sends are retried after Backoff, doubled on
+// every attempt up to MaxBackoff, until MaxAttempts
+type Worker struct {
+	Outbox      *Outbox
+	Mailer      Mailer
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+	// how long a claimed mail is kept from other workers
+	Lease time.Duration
+}
+
+func NewWorker(o *Outbox, m Mailer) *Worker {
+	return &Worker{
+		Outbox:      o,
+		Mailer:      m,
+		Interval:    5 * time.Second,
+		BatchSize:   20,
+		MaxAttempts: 8,
+		Backoff:     time.Minute,
+		MaxBackoff:  6 * time.Hour,
+		Lease:       5 * time.Minute,
+	}
+}
+
+// Run drains the outbox every Interval until ctx is done, run it in its own goroutine
+func (w *Worker) Run(ctx context.Context) {
+	for {
+		n, err := w.Drain(ctx)
+		if err != nil {
+			log.Error("error draining mail outbox:", err.Error())
+		} else if n > 0 {
+			log.Infof("sent %d mails from the outbox", n)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.Interval):
+		}
+	}
+}
+
+// Drain sends due mails batch by batch until none are left and returns how many were sent
+func (w *Worker) Drain(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		mails, err := w.Outbox.claim(ctx, w.BatchSize, w.Lease)
+		if err != nil {
+			return sent, err
+		}
+		if len(mails) == 0 {
+			return sent, nil
+		}
+		for _, m := range mails {
+			sendCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			err = w.Mailer.Send(sendCtx, m.Message)
+			cancel()
+			if err == nil {
+				sent++
+				if err = w.Outbox.markSent(ctx, m.ID); err != nil {
+					log.Error("error marking outbox mail sent:", err.Error())
+				}
+				continue
+			}
+			retry := w.backoff(m.Attempts)
+			if retry == 0 {
+				log.Errorf("giving up on mail %s to %s after %d attempts: %s", m.ID, m.Message.To, m.Attempts, err.Error())
+			} else {
+				log.Warnf("error sending mail %s to %s, retrying in %s: %s", m.ID, m.Message.To, retry, err.Error())
+			}
+			if err = w.Outbox.markFailed(ctx, m.ID, err, retry); err != nil {
+				log.Error("error marking outbox mail failed:", err.Error())
+			}
+		}
+	}
+}
+
+// backoff is the wait before the next attempt after attempts, zero once they run out
+func (w *Worker) backoff(attempts int) time.Duration {
+	if attempts >= w.MaxAttempts {
+		return 0
+	}
+	d := w.Backoff
+	for i := 1; i < attempts && d < w.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > w.MaxBackoff {
+		d = w.MaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// every mail is a templates/<name>.tmpl defining "subject", "html" and "text",
+// layout.tmpl wraps the html and text in the branded layout
+//
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// names of the mail templates
+const (
+	TemplateOTP              = "otp"
+	TemplateForgotOTP        = "forgot_otp"
+	TemplateBackInStock      = "back_in_stock"
+	TemplatePriceDrop        = "price_drop"
+	TemplateSellerOnboarding = "seller_onboarding"
+	TemplateNotification     = "notification"
+	TemplateEmailChange      = "email_change"
+)
+
+type mailTemplate struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var templates = parseTemplates(TemplateOTP, TemplateForgotOTP, TemplateBackInStock,
+	TemplatePriceDrop, TemplateSellerOnboarding, TemplateNotification, TemplateEmailChange)
+
+func parseTemplates(names ...string) map[string]mailTemplate {
+	parsed := make(map[string]mailTemplate)
+	for _, name := range names {
+		files := []string{"templates/layout.tmpl", "templates/" + name + ".tmpl"}
+		parsed[name] = mailTemplate{
+			html: htmltemplate.Must(htmltemplate.ParseFS(templateFS, files...)),
+			text: texttemplate.Must(texttemplate.ParseFS(templateFS, files...)),
+		}
+	}
+	return parsed
+}
+
+// Render fills the template name with data into a message to the recipient
+func Render(name string, to string, data any) (Message, error) {
+	t, ok := templates[name]
+	if !ok {
+		return Message{}, errors.New("mail: unknown template " + name)
+	}
+	var subject, html, text bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.html.ExecuteTemplate(&html, "layout_html", data); err != nil {
+		return Message{}, err
+	}
+	if err := t.text.ExecuteTemplate(&text, "layout_text", data); err != nil {
+		return Message{}, err
+	}
+	return Message{
+		To: to,
+		// a subject is a single header line
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		HTML:    html.String(),
+		Text:    text.String(),
+	}, nil
+}
@@ -2,75 +2,112 @@ package middleware
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 	"net/http"
 
-	userpb "github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/user"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sessions"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
 )
 
 type User struct {
-	ID    uuid.UUID
-	Name  string
-	Email string
-	Role  string
-	Phone string
+	ID          uuid.UUID
+	SessionID   uuid.UUID
+	Name        string
+	Email       string
+	Role        string
+	Roles       []string
+	Permissions []string
+	// set while an admin impersonates the user, uuid.Nil otherwise
+	ImpersonationID uuid.UUID
+	ImpersonatorID  uuid.UUID
 }
 
+// verifyRequest reads and verifies the access token of the request, the user service
+// is not called so a revoked session stays usable until its access token expires
+func verifyRequest(w http.ResponseWriter, r *http.Request) *utils.AccessClaims {
+	token := sessions.GetAccessToken(r)
+	if token == "" {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return nil
+	}
+
+	claims, err := utils.VerifyAccessToken(token)
+	if err != nil {
+		log.Warn("invalid access token:", err.Error())
+		http.Error(w, "invalid or expired access token. refresh it at /auth/refresh", http.StatusUnauthorized)
+		return nil
+	}
+	return claims
+}
+
+// serveWithUser stores the user of the token in the context and calls next
+func serveWithUser(next http.HandlerFunc, w http.ResponseWriter, r *http.Request, claims *utils.AccessClaims) {
+	if !GuardImpersonation(w, r, claims) {
+		return
+	}
+	// set a gloabal user struct for the auth middleware
+	contextUser := User{
+		ID:          claims.UserID,
+		SessionID:   claims.SessionID,
+		Name:        claims.Name,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+	}
+	if claims.Impersonation != nil {
+		contextUser.ImpersonationID = claims.Impersonation.ID
+		contextUser.ImpersonatorID = claims.Impersonation.ActorID
+	}
+	ctx := context.WithValue(r.Context(), utils.UserKey, contextUser)
+	next(w, r.WithContext(ctx))
+}
+
+// AuthenticateUserMiddleware lets in users whose account role is role
 func AuthenticateUserMiddleware(next http.HandlerFunc, role string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		sessionCookie, err := r.Cookie("SessionID")
-		if err != nil {
-			log.Warn("SessionID cookie not found")
-			http.Error(w, "authentication required", http.StatusUnauthorized)
+		claims := verifyRequest(w, r)
+		if claims == nil {
 			return
 		}
-
-		if sessionCookie.Value == "" {
-			http.Error(w, "invalid session", http.StatusUnauthorized)
+		if claims.Role != role {
+			http.Error(w, "unauthorized", http.StatusForbidden)
 			return
 		}
+		serveWithUser(next, w, r, claims)
+	}
+}
 
-		uid, err := uuid.Parse(sessionCookie.Value)
-		if err != nil {
-			log.Warn("Invalid sessionID format")
-			http.Error(w, "invalid session id format", http.StatusUnauthorized)
+// AuthenticateRolesMiddleware lets in users with any of the roles, either as their
+// account role or assigned, for routes shared by e.g. sellers and admins
+func AuthenticateRolesMiddleware(next http.HandlerFunc, roles ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyRequest(w, r)
+		if claims == nil {
 			return
 		}
-
-		// replace with grpc client
-		userClient := userpb.NewUserServiceClient(&grpc.ClientConn{})
-		user, err := userClient.GetUserBySessionID(context.TODO(), &userpb.GetUserBySessionIDRequest{SessionID: uid.String()})
-		if err != nil {
-			if errors.Is(err, sql.ErrNoRows) {
-				http.Error(w, "invalid session", http.StatusUnauthorized)
+		for _, role := range roles {
+			if claims.HasRole(role) {
+				serveWithUser(next, w, r, claims)
 				return
 			}
-			log.Error("Database error fetching user by sessionID:", err)
-			http.Error(w, "internal server error", http.StatusInternalServerError)
-			return
 		}
+		http.Error(w, "unauthorized", http.StatusForbidden)
+	}
+}
 
-		// Check role if needed
-		if user.Role != role {
-			http.Error(w, "unauthorized", http.StatusForbidden)
+// AuthorizeMiddleware lets in users whose roles grant the permission
+func AuthorizeMiddleware(next http.HandlerFunc, permission string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyRequest(w, r)
+		if claims == nil {
 			return
 		}
-
-		// set a gloabal user struct for the auth middleware
-		contextUser := &User{
-			ID:    user.ID,
-			Name:  user.Name,
-			Email: user.Email,
-			Role:  user.Role,
-			Phone: user.Phone,
+		if !claims.HasPermission(permission) {
+			http.Error(w, "missing permission "+permission, http.StatusForbidden)
+			return
 		}
-		// Store user in context and call next handler
-		ctx := context.WithValue(r.Context(), utils.UserKey, contextUser)
-		next(w, r.WithContext(ctx))
+		serveWithUser(next, w, r, claims)
 	}
 }
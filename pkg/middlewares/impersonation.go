@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// ImpersonationRecorder checks and records the requests made with impersonation
+// tokens, impersonation.Recorder is the one the services use
+type ImpersonationRecorder interface {
+	RecordRequest(ctx context.Context, impersonationID uuid.UUID, r *http.Request, blocked bool) (bool, error)
+}
+
+var impersonationRecorder ImpersonationRecorder
+
+// SetImpersonationRecorder is called by the services on start up, impersonation
+// tokens are refused by a service that didn't set one
+func SetImpersonationRecorder(rec ImpersonationRecorder) {
+	impersonationRecorder = rec
+}
+
+// GuardImpersonation lets through requests of tokens that aren't impersonating.
+// impersonated requests are recorded and only reads are let through while the
+// impersonation is still going. returns false when it wrote the response
+func GuardImpersonation(w http.ResponseWriter, r *http.Request, claims *utils.AccessClaims) bool {
+	if claims.Impersonation == nil {
+		return true
+	}
+	if impersonationRecorder == nil {
+		http.Error(w, "impersonation is not supported here", http.StatusForbidden)
+		return false
+	}
+	blocked := r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions
+	active, err := impersonationRecorder.RecordRequest(r.Context(), claims.Impersonation.ID, r, blocked)
+	if err != nil {
+		log.Error("error recording impersonated request:", err.Error())
+		http.Error(w, "internal error recording impersonated request", http.StatusInternalServerError)
+		return false
+	}
+	if !active {
+		http.Error(w, "impersonation has ended", http.StatusUnauthorized)
+		return false
+	}
+	if blocked {
+		http.Error(w, "write actions are blocked while impersonating", http.StatusForbidden)
+		return false
+	}
+	return true
+}
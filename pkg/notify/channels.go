@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sms"
+)
+
+// Email sends the notification over smtp with the settings pkg/mail uses
+type Email struct{}
+
+func (Email) Name() string { return ChannelEmail }
+
+func (Email) Send(ctx context.Context, to Recipient, n Notification) error {
+	if to.Email == "" {
+		return nil
+	}
+	return mail.SendNotificationMail(n.Title, n.Body, to.Email)
+}
+
+// SMS texts the notification with an sms provider
+type SMS struct {
+	Provider sms.Provider
+}
+
+func (s *SMS) Name() string { return ChannelSMS }
+
+func (s *SMS) Send(ctx context.Context, to Recipient, n Notification) error {
+	if to.Phone == "" {
+		return nil
+	}
+	return s.Provider.Send(ctx, to.Phone, n.Title+": "+n.Body)
+}
+
+// Webhook posts the notification as json. with a secret the body is signed with
+// hmac sha256 in the X-Signature header as hex so the receiver can verify it
+type Webhook struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+func (wh *Webhook) Name() string { return ChannelWebhook }
+
+func (wh *Webhook) Send(ctx context.Context, to Recipient, n Notification) error {
+	if wh.URL == "" {
+		return errors.New("notify: webhook url not set")
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if wh.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(wh.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+	return do(wh.Client, req)
+}
+
+func do(client *http.Client, req *http.Request) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notify: %s responded %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+	"sync"
+)
+
+// Sent is one notification a Fake channel got
+type Sent struct {
+	To           Recipient
+	Notification Notification
+}
+
+// Fake keeps what it is sent in memory instead of delivering it, for tests and
+// local runs. set Err to make every send fail
+type Fake struct {
+	ChannelName string
+	Err         error
+
+	mu   sync.Mutex
+	sent []Sent
+}
+
+func NewFake(name string) *Fake {
+	return &Fake{ChannelName: name}
+}
+
+func (f *Fake) Name() string { return f.ChannelName }
+
+func (f *Fake) Send(ctx context.Context, to Recipient, n Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.sent = append(f.sent, Sent{To: to, Notification: n})
+	return nil
+}
+
+// Sent returns a copy of everything sent so far
+func (f *Fake) Sent() []Sent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Sent{}, f.sent...)
+}
+
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+}
@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sms"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// kinds of notifications, each has a template in templates.go
+const (
+	KindOrderPlaced    = "order_placed"
+	KindOrderShipped   = "order_shipped"
+	KindOrderDelivered = "order_delivered"
+	KindOrderCancelled = "order_cancelled"
+	KindRefundIssued   = "refund_issued"
+	KindPayoutReceived = "payout_received"
+)
+
+// Data fills the template of a notification and is stored with it,
+// the fields a kind doesn't use are left empty
+type Data struct {
+	OrderID     uuid.NullUUID `json:"order_id"`
+	OrderItemID uuid.NullUUID `json:"order_item_id"`
+	ProductName string        `json:"product_name,omitempty"`
+	Amount      float64       `json:"amount,omitempty"`
+	Reason      string        `json:"reason,omitempty"`
+}
+
+// Notification is one rendered notification as kept in the inbox
+type Notification struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Kind      string     `json:"kind"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	Data      Data       `json:"data"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Recipient is where the channels deliver to, empty fields are skipped by the
+// channels that need them
+type Recipient struct {
+	UserID uuid.UUID
+	Name   string
+	Email  string
+	Phone  string
+}
+
+// Channel delivers a notification outside the app
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, to Recipient, n Notification) error
+}
+
+const ChannelEmail = "email"
+const ChannelSMS = "sms"
+const ChannelWebhook = "webhook"
+const ChannelFake = "fake"
+
+// Notifier writes notifications to the inbox in the notifications table of the
+// user service and sends them on its channels
+type Notifier struct {
+	DB       *sql.DB
+	Channels []Channel
+	// how long a send on one channel may take
+	Timeout time.Duration
+}
+
+// New builds the notifier with the channels of the environment
+func New(db *sql.DB) (*Notifier, error) {
+	names := os.Getenv(envname.NotifyChannels)
+	if names == "" {
+		names = ChannelEmail
+	}
+	var channels []Channel
+	for _, name := range strings.Split(names, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "", "none":
+		case ChannelEmail:
+			channels = append(channels, Email{})
+		case ChannelSMS:
+			provider, err := sms.New()
+			if err != nil {
+				return nil, err
+			}
+			channels = append(channels, &SMS{Provider: provider})
+		case ChannelWebhook:
+			channels = append(channels, &Webhook{
+				URL:    os.Getenv(envname.NotifyWebhookURL),
+				Secret: os.Getenv(envname.NotifyWebhookSecret),
+			})
+		case ChannelFake:
+			channels = append(channels, NewFake(ChannelFake))
+		default:
+			return nil, errors.New("notify: unknown channel " + name)
+		}
+	}
+	return &Notifier{DB: db, Channels: channels, Timeout: 30 * time.Second}, nil
+}
+
+// Notify renders the notification of kind, adds it to the inbox of the user and
+// sends it on every channel in the background. only the inbox write is returned
+// as an error, failed sends are logged
+func (n *Notifier) Notify(ctx context.Context, userID uuid.UUID, kind string, data Data) error {
+	title, body, err := render(kind, data)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	note := Notification{UserID: userID, Kind: kind, Title: title, Body: body, Data: data}
+	err = n.DB.QueryRowContext(ctx, `
+		INSERT INTO notifications (user_id, kind, title, body, data)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`,
+		userID, kind, title, body, string(raw)).Scan(&note.ID, &note.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if len(n.Channels) == 0 {
+		return nil
+	}
+
+	to, err := n.recipient(ctx, userID)
+	if err != nil {
+		log.Warnf("notify: error fetching recipient %s for %s: %s", userID, kind, err.Error())
+		return nil
+	}
+	go n.send(to, note)
+	return nil
+}
+
+// Log is Notify for callers that carry on either way
+func (n *Notifier) Log(ctx context.Context, userID uuid.UUID, kind string, data Data) {
+	if err := n.Notify(ctx, userID, kind, data); err != nil {
+		log.Warnf("notify: error adding %s notification for %s: %s", kind, userID, err.Error())
+	}
+}
+
+func (n *Notifier) recipient(ctx context.Context, userID uuid.UUID) (Recipient, error) {
+	to := Recipient{UserID: userID}
+	var phone sql.NullInt64
+	// only verified phones are texted
+	err := n.DB.QueryRowContext(ctx, `
+		SELECT name, email, CASE WHEN phone_verified THEN phone END
+		FROM users WHERE id = $1`, userID).
+		Scan(&to.Name, &to.Email, &phone)
+	if phone.Valid {
+		to.Phone = sms.Number(phone.Int64)
+	}
+	return to, err
+}
+
+func (n *Notifier) send(to Recipient, note Notification) {
+	for _, ch := range n.Channels {
+		ctx, cancel := context.WithTimeout(context.Background(), n.Timeout)
+		if err := ch.Send(ctx, to, note); err != nil {
+			log.Warnf("notify: error sending %s %s to %s: %s", ch.Name(), note.Kind, to.UserID, err.Error())
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,158 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRender(t *testing.T) {
+	orderID := uuid.NullUUID{UUID: uuid.MustParse("6f1c2d3e-0000-4000-8000-000000000001"), Valid: true}
+	itemID := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	tests := []struct {
+		kind      string
+		data      Data
+		wantTitle string
+		wantBody  []string
+	}{
+		{KindOrderPlaced, Data{OrderID: orderID, Amount: 1499.5}, "Order placed",
+			[]string{orderID.UUID.String(), "Rs 1499.50"}},
+		{KindOrderShipped, Data{ProductName: "Teak chair"}, "Order shipped",
+			[]string{"Teak chair has been shipped"}},
+		{KindOrderShipped, Data{}, "Order shipped",
+			[]string{"an item of your order has been shipped"}},
+		{KindOrderDelivered, Data{ProductName: "Teak chair"}, "Order delivered",
+			[]string{"delivered Teak chair"}},
+		{KindOrderCancelled, Data{OrderID: orderID}, "Order cancelled",
+			[]string{"Your order " + orderID.UUID.String() + " has been cancelled."}},
+		{KindOrderCancelled, Data{OrderItemID: itemID, ProductName: "Lamp", Reason: "Out of stock."}, "Order cancelled",
+			[]string{"We have cancelled Lamp.", "Out of stock."}},
+		{KindRefundIssued, Data{Amount: 250, Reason: "return"}, "Refund issued",
+			[]string{"Rs 250.00 has been refunded to your wallet for your return."}},
+		{KindPayoutReceived, Data{Amount: 900, ProductName: "Lamp"}, "Payout received",
+			[]string{"Rs 900.00 for Lamp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			title, body, err := render(tt.kind, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(body, want) {
+					t.Errorf("body %q doesn't contain %q", body, want)
+				}
+			}
+		})
+	}
+
+	if _, _, err := render("no_such_kind", Data{}); err == nil {
+		t.Error("unknown kind rendered without an error")
+	}
+}
+
+func TestEveryKindHasATemplate(t *testing.T) {
+	for _, kind := range []string{KindOrderPlaced, KindOrderShipped, KindOrderDelivered, KindOrderCancelled, KindRefundIssued, KindPayoutReceived} {
+		if _, ok := templates[kind]; !ok {
+			t.Errorf("no template for %s", kind)
+		}
+	}
+}
+
+func TestSendGoesToEveryChannel(t *testing.T) {
+	first, second := NewFake("first"), NewFake("second")
+	failing := NewFake("failing")
+	failing.Err = errors.New("down")
+	n := &Notifier{Channels: []Channel{first, failing, second}, Timeout: time.Second}
+
+	to := Recipient{UserID: uuid.New(), Email: "a@example.com"}
+	note := Notification{Kind: KindOrderShipped, Title: "Order shipped", Body: "on its way"}
+	n.send(to, note)
+
+	// a failing channel doesn't stop the ones after it
+	for _, f := range []*Fake{first, second} {
+		sent := f.Sent()
+		if len(sent) != 1 {
+			t.Fatalf("%s got %d notifications, want 1", f.Name(), len(sent))
+		}
+		if sent[0].To != to || sent[0].Notification.Kind != note.Kind {
+			t.Errorf("%s got %+v", f.Name(), sent[0])
+		}
+	}
+	if len(failing.Sent()) != 0 {
+		t.Error("failing channel kept the notification")
+	}
+
+	first.Reset()
+	if len(first.Sent()) != 0 {
+		t.Error("Reset kept the notifications")
+	}
+}
+
+func TestWebhookSignsTheBody(t *testing.T) {
+	const secret = "s3cret"
+	var gotBody []byte
+	var gotSignature string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get("X-Signature")
+	}))
+	defer srv.Close()
+
+	wh := &Webhook{URL: srv.URL, Secret: secret}
+	note := Notification{ID: uuid.New(), Kind: KindRefundIssued, Title: "Refund issued", Body: "Rs 10.00"}
+	if err := wh.Send(context.Background(), Recipient{}, note); err != nil {
+		t.Fatal(err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(gotBody)
+	if want := hex.EncodeToString(mac.Sum(nil)); gotSignature != want {
+		t.Errorf("signature = %q, want %q", gotSignature, want)
+	}
+	var got Notification
+	if err := json.Unmarshal(gotBody, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != note.ID || got.Kind != note.Kind {
+		t.Errorf("webhook got %+v, want %+v", got, note)
+	}
+}
+
+func TestWebhookErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if err := (&Webhook{URL: srv.URL}).Send(context.Background(), Recipient{}, Notification{}); err == nil {
+		t.Error("a 500 from the receiver is not an error")
+	}
+	if err := (&Webhook{}).Send(context.Background(), Recipient{}, Notification{}); err == nil {
+		t.Error("a webhook without a url is not an error")
+	}
+}
+
+func TestChannelsSkipMissingContacts(t *testing.T) {
+	// no email or phone means nothing to deliver, not a failure
+	if err := (Email{}).Send(context.Background(), Recipient{}, Notification{}); err != nil {
+		t.Errorf("email without an address: %v", err)
+	}
+	if err := (&SMS{}).Send(context.Background(), Recipient{}, Notification{}); err != nil {
+		t.Errorf("sms without a phone: %v", err)
+	}
+}
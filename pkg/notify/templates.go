@@ -0,0 +1,58 @@
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"text/template"
+)
+
+type notificationTemplate struct {
+	title *template.Template
+	body  *template.Template
+}
+
+func newTemplate(kind, title, body string) notificationTemplate {
+	return notificationTemplate{
+		title: template.Must(template.New(kind + "_title").Parse(title)),
+		body:  template.Must(template.New(kind + "_body").Parse(body)),
+	}
+}
+
+const itemName = `{{if .ProductName}}{{.ProductName}}{{else}}an item of your order{{end}}`
+
+var templates = map[string]notificationTemplate{
+	KindOrderPlaced: newTemplate(KindOrderPlaced,
+		"Order placed",
+		`Your order {{.OrderID.UUID}} of Rs {{printf "%.2f" .Amount}} has been placed. We will let you know once it ships.`),
+	KindOrderShipped: newTemplate(KindOrderShipped,
+		"Order shipped",
+		`Good news! `+itemName+` has been shipped and is on its way to you.`),
+	KindOrderDelivered: newTemplate(KindOrderDelivered,
+		"Order delivered",
+		`We have delivered `+itemName+`. We hope you enjoy it, leave a review once you have tried it out.`),
+	KindOrderCancelled: newTemplate(KindOrderCancelled,
+		"Order cancelled",
+		`{{if .OrderItemID.Valid}}We have cancelled `+itemName+`.{{else}}Your order {{.OrderID.UUID}} has been cancelled.{{end}}{{if .Reason}} {{.Reason}}{{end}}`),
+	KindRefundIssued: newTemplate(KindRefundIssued,
+		"Refund issued",
+		`Rs {{printf "%.2f" .Amount}} has been refunded to your wallet{{if .Reason}} for your {{.Reason}}{{end}}.`),
+	KindPayoutReceived: newTemplate(KindPayoutReceived,
+		"Payout received",
+		`Rs {{printf "%.2f" .Amount}} for {{if .ProductName}}{{.ProductName}}{{else}}a delivered order{{end}} has been credited to your wallet.`),
+}
+
+// render fills the title and body of kind with data
+func render(kind string, data Data) (string, string, error) {
+	t, ok := templates[kind]
+	if !ok {
+		return "", "", errors.New("notify: unknown kind " + kind)
+	}
+	var title, body bytes.Buffer
+	if err := t.title.Execute(&title, data); err != nil {
+		return "", "", err
+	}
+	if err := t.body.Execute(&body, data); err != nil {
+		return "", "", err
+	}
+	return title.String(), body.String(), nil
+}
@@ -70,6 +70,146 @@ func (x *GetProductByIDRequest) GetId() string {
 	return ""
 }
 
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_inventory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExportUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_inventory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EraseUserDataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CheckDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"` // UUIDs
+	Pincode       int32                  `protobuf:"varint,2,opt,name=pincode,proto3" json:"pincode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDeliveryRequest) Reset() {
+	*x = CheckDeliveryRequest{}
+	mi := &file_inventory_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDeliveryRequest) ProtoMessage() {}
+
+func (x *CheckDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*CheckDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckDeliveryRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *CheckDeliveryRequest) GetPincode() int32 {
+	if x != nil {
+		return x.Pincode
+	}
+	return 0
+}
+
 // --------------------
 // RESPONSES
 // --------------------
@@ -90,7 +230,7 @@ type GetProductByIDResponse struct {
 
 func (x *GetProductByIDResponse) Reset() {
 	*x = GetProductByIDResponse{}
-	mi := &file_inventory_proto_msgTypes[1]
+	mi := &file_inventory_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -102,7 +242,7 @@ func (x *GetProductByIDResponse) String() string {
 func (*GetProductByIDResponse) ProtoMessage() {}
 
 func (x *GetProductByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_inventory_proto_msgTypes[1]
+	mi := &file_inventory_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -115,7 +255,7 @@ func (x *GetProductByIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProductByIDResponse.ProtoReflect.Descriptor instead.
 func (*GetProductByIDResponse) Descriptor() ([]byte, []int) {
-	return file_inventory_proto_rawDescGZIP(), []int{1}
+	return file_inventory_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetProductByIDResponse) GetId() string {
@@ -181,13 +321,386 @@ func (x *GetProductByIDResponse) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+// a json file for the data export of a user, eg. reviews.json
+type ExportFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFile) Reset() {
+	*x = ExportFile{}
+	mi := &file_inventory_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFile) ProtoMessage() {}
+
+func (x *ExportFile) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFile.ProtoReflect.Descriptor instead.
+func (*ExportFile) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportFile) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*ExportFile          `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_inventory_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExportUserDataResponse) GetFiles() []*ExportFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type EraseUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_inventory_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{7}
+}
+
+// reason is set when the product can't be delivered to the pincode,
+// the estimate and zone only when it can
+type DeliveryEstimate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Deliverable   bool                   `protobuf:"varint,2,opt,name=deliverable,proto3" json:"deliverable,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Zone          string                 `protobuf:"bytes,4,opt,name=zone,proto3" json:"zone,omitempty"`
+	HandlingDays  int32                  `protobuf:"varint,5,opt,name=handling_days,json=handlingDays,proto3" json:"handling_days,omitempty"`
+	TransitDays   int32                  `protobuf:"varint,6,opt,name=transit_days,json=transitDays,proto3" json:"transit_days,omitempty"`
+	EstimatedDate *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=estimated_date,json=estimatedDate,proto3" json:"estimated_date,omitempty"`
+	SellerId      string                 `protobuf:"bytes,8,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"` // UUID
+	WeightGrams   int32                  `protobuf:"varint,9,opt,name=weight_grams,json=weightGrams,proto3" json:"weight_grams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryEstimate) Reset() {
+	*x = DeliveryEstimate{}
+	mi := &file_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryEstimate) ProtoMessage() {}
+
+func (x *DeliveryEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryEstimate.ProtoReflect.Descriptor instead.
+func (*DeliveryEstimate) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeliveryEstimate) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *DeliveryEstimate) GetDeliverable() bool {
+	if x != nil {
+		return x.Deliverable
+	}
+	return false
+}
+
+func (x *DeliveryEstimate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DeliveryEstimate) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *DeliveryEstimate) GetHandlingDays() int32 {
+	if x != nil {
+		return x.HandlingDays
+	}
+	return 0
+}
+
+func (x *DeliveryEstimate) GetTransitDays() int32 {
+	if x != nil {
+		return x.TransitDays
+	}
+	return 0
+}
+
+func (x *DeliveryEstimate) GetEstimatedDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedDate
+	}
+	return nil
+}
+
+func (x *DeliveryEstimate) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *DeliveryEstimate) GetWeightGrams() int32 {
+	if x != nil {
+		return x.WeightGrams
+	}
+	return 0
+}
+
+// how a seller charges shipping, see pkg/delivery. free_above is 0 without a threshold
+type ShippingRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"` // UUID
+	Rule          string                 `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+	FlatFee       float64                `protobuf:"fixed64,3,opt,name=flat_fee,json=flatFee,proto3" json:"flat_fee,omitempty"`
+	FreeAbove     float64                `protobuf:"fixed64,4,opt,name=free_above,json=freeAbove,proto3" json:"free_above,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingRule) Reset() {
+	*x = ShippingRule{}
+	mi := &file_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingRule) ProtoMessage() {}
+
+func (x *ShippingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingRule.ProtoReflect.Descriptor instead.
+func (*ShippingRule) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ShippingRule) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *ShippingRule) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *ShippingRule) GetFlatFee() float64 {
+	if x != nil {
+		return x.FlatFee
+	}
+	return 0
+}
+
+func (x *ShippingRule) GetFreeAbove() float64 {
+	if x != nil {
+		return x.FreeAbove
+	}
+	return 0
+}
+
+type CheckDeliveryResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Estimates []*DeliveryEstimate    `protobuf:"bytes,1,rep,name=estimates,proto3" json:"estimates,omitempty"`
+	// one per seller of the deliverable products
+	ShippingRules []*ShippingRule `protobuf:"bytes,2,rep,name=shipping_rules,json=shippingRules,proto3" json:"shipping_rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckDeliveryResponse) Reset() {
+	*x = CheckDeliveryResponse{}
+	mi := &file_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDeliveryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDeliveryResponse) ProtoMessage() {}
+
+func (x *CheckDeliveryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDeliveryResponse.ProtoReflect.Descriptor instead.
+func (*CheckDeliveryResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CheckDeliveryResponse) GetEstimates() []*DeliveryEstimate {
+	if x != nil {
+		return x.Estimates
+	}
+	return nil
+}
+
+func (x *CheckDeliveryResponse) GetShippingRules() []*ShippingRule {
+	if x != nil {
+		return x.ShippingRules
+	}
+	return nil
+}
+
 var File_inventory_proto protoreflect.FileDescriptor
 
 const file_inventory_proto_rawDesc = "" +
 	"\n" +
 	"\x0finventory.proto\x12\tinventory\x1a\x1fgoogle/protobuf/timestamp.proto\"'\n" +
 	"\x15GetProductByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xbc\x02\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"/\n" +
+	"\x14EraseUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"Q\n" +
+	"\x14CheckDeliveryRequest\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\x12\x18\n" +
+	"\apincode\x18\x02 \x01(\x05R\apincode\"\xbc\x02\n" +
 	"\x16GetProductByIDResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -200,9 +713,39 @@ const file_inventory_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2i\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"4\n" +
+	"\n" +
+	"ExportFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"E\n" +
+	"\x16ExportUserDataResponse\x12+\n" +
+	"\x05files\x18\x01 \x03(\v2\x15.inventory.ExportFileR\x05files\"\x17\n" +
+	"\x15EraseUserDataResponse\"\xca\x02\n" +
+	"\x10DeliveryEstimate\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12 \n" +
+	"\vdeliverable\x18\x02 \x01(\bR\vdeliverable\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x12\n" +
+	"\x04zone\x18\x04 \x01(\tR\x04zone\x12#\n" +
+	"\rhandling_days\x18\x05 \x01(\x05R\fhandlingDays\x12!\n" +
+	"\ftransit_days\x18\x06 \x01(\x05R\vtransitDays\x12A\n" +
+	"\x0eestimated_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\restimatedDate\x12\x1b\n" +
+	"\tseller_id\x18\b \x01(\tR\bsellerId\x12!\n" +
+	"\fweight_grams\x18\t \x01(\x05R\vweightGrams\"y\n" +
+	"\fShippingRule\x12\x1b\n" +
+	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x12\n" +
+	"\x04rule\x18\x02 \x01(\tR\x04rule\x12\x19\n" +
+	"\bflat_fee\x18\x03 \x01(\x01R\aflatFee\x12\x1d\n" +
+	"\n" +
+	"free_above\x18\x04 \x01(\x01R\tfreeAbove\"\x92\x01\n" +
+	"\x15CheckDeliveryResponse\x129\n" +
+	"\testimates\x18\x01 \x03(\v2\x1b.inventory.DeliveryEstimateR\testimates\x12>\n" +
+	"\x0eshipping_rules\x18\x02 \x03(\v2\x17.inventory.ShippingRuleR\rshippingRules2\xe8\x02\n" +
 	"\x10InventoryService\x12U\n" +
-	"\x0eGetProductByID\x12 .inventory.GetProductByIDRequest\x1a!.inventory.GetProductByIDResponseB\x13Z\x11proto/inventorypbb\x06proto3"
+	"\x0eGetProductByID\x12 .inventory.GetProductByIDRequest\x1a!.inventory.GetProductByIDResponse\x12U\n" +
+	"\x0eExportUserData\x12 .inventory.ExportUserDataRequest\x1a!.inventory.ExportUserDataResponse\x12R\n" +
+	"\rEraseUserData\x12\x1f.inventory.EraseUserDataRequest\x1a .inventory.EraseUserDataResponse\x12R\n" +
+	"\rCheckDelivery\x12\x1f.inventory.CheckDeliveryRequest\x1a .inventory.CheckDeliveryResponseB\x13Z\x11proto/inventorypbb\x06proto3"
 
 var (
 	file_inventory_proto_rawDescOnce sync.Once
@@ -216,22 +759,41 @@ func file_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_proto_rawDescData
 }
 
-var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_inventory_proto_goTypes = []any{
 	(*GetProductByIDRequest)(nil),  // 0: inventory.GetProductByIDRequest
-	(*GetProductByIDResponse)(nil), // 1: inventory.GetProductByIDResponse
-	(*timestamppb.Timestamp)(nil),  // 2: google.protobuf.Timestamp
+	(*ExportUserDataRequest)(nil),  // 1: inventory.ExportUserDataRequest
+	(*EraseUserDataRequest)(nil),   // 2: inventory.EraseUserDataRequest
+	(*CheckDeliveryRequest)(nil),   // 3: inventory.CheckDeliveryRequest
+	(*GetProductByIDResponse)(nil), // 4: inventory.GetProductByIDResponse
+	(*ExportFile)(nil),             // 5: inventory.ExportFile
+	(*ExportUserDataResponse)(nil), // 6: inventory.ExportUserDataResponse
+	(*EraseUserDataResponse)(nil),  // 7: inventory.EraseUserDataResponse
+	(*DeliveryEstimate)(nil),       // 8: inventory.DeliveryEstimate
+	(*ShippingRule)(nil),           // 9: inventory.ShippingRule
+	(*CheckDeliveryResponse)(nil),  // 10: inventory.CheckDeliveryResponse
+	(*timestamppb.Timestamp)(nil),  // 11: google.protobuf.Timestamp
 }
 var file_inventory_proto_depIdxs = []int32{
-	2, // 0: inventory.GetProductByIDResponse.created_at:type_name -> google.protobuf.Timestamp
-	2, // 1: inventory.GetProductByIDResponse.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: inventory.InventoryService.GetProductByID:input_type -> inventory.GetProductByIDRequest
-	1, // 3: inventory.InventoryService.GetProductByID:output_type -> inventory.GetProductByIDResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	11, // 0: inventory.GetProductByIDResponse.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: inventory.GetProductByIDResponse.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 2: inventory.ExportUserDataResponse.files:type_name -> inventory.ExportFile
+	11, // 3: inventory.DeliveryEstimate.estimated_date:type_name -> google.protobuf.Timestamp
+	8,  // 4: inventory.CheckDeliveryResponse.estimates:type_name -> inventory.DeliveryEstimate
+	9,  // 5: inventory.CheckDeliveryResponse.shipping_rules:type_name -> inventory.ShippingRule
+	0,  // 6: inventory.InventoryService.GetProductByID:input_type -> inventory.GetProductByIDRequest
+	1,  // 7: inventory.InventoryService.ExportUserData:input_type -> inventory.ExportUserDataRequest
+	2,  // 8: inventory.InventoryService.EraseUserData:input_type -> inventory.EraseUserDataRequest
+	3,  // 9: inventory.InventoryService.CheckDelivery:input_type -> inventory.CheckDeliveryRequest
+	4,  // 10: inventory.InventoryService.GetProductByID:output_type -> inventory.GetProductByIDResponse
+	6,  // 11: inventory.InventoryService.ExportUserData:output_type -> inventory.ExportUserDataResponse
+	7,  // 12: inventory.InventoryService.EraseUserData:output_type -> inventory.EraseUserDataResponse
+	10, // 13: inventory.InventoryService.CheckDelivery:output_type -> inventory.CheckDeliveryResponse
+	10, // [10:14] is the sub-list for method output_type
+	6,  // [6:10] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_inventory_proto_init() }
@@ -245,7 +807,7 @@ func file_inventory_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_proto_rawDesc), len(file_inventory_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,9 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	InventoryService_GetProductByID_FullMethodName = "/inventory.InventoryService/GetProductByID"
+	InventoryService_ExportUserData_FullMethodName = "/inventory.InventoryService/ExportUserData"
+	InventoryService_EraseUserData_FullMethodName  = "/inventory.InventoryService/EraseUserData"
+	InventoryService_CheckDelivery_FullMethodName  = "/inventory.InventoryService/CheckDelivery"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -31,6 +34,13 @@ const (
 // --------------------
 type InventoryServiceClient interface {
 	GetProductByID(ctx context.Context, in *GetProductByIDRequest, opts ...grpc.CallOption) (*GetProductByIDResponse, error)
+	// the reviews, wishlists and stock subscriptions of a user for the account data export
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	// removes the personal data of a user whose account is being deleted
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
+	// whether the sellers of the products ship to the pincode, by when and at what
+	// shipping rules, checked at checkout
+	CheckDelivery(ctx context.Context, in *CheckDeliveryRequest, opts ...grpc.CallOption) (*CheckDeliveryResponse, error)
 }
 
 type inventoryServiceClient struct {
@@ -51,6 +61,36 @@ func (c *inventoryServiceClient) GetProductByID(ctx context.Context, in *GetProd
 	return out, nil
 }
 
+func (c *inventoryServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, InventoryService_EraseUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryServiceClient) CheckDelivery(ctx context.Context, in *CheckDeliveryRequest, opts ...grpc.CallOption) (*CheckDeliveryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckDeliveryResponse)
+	err := c.cc.Invoke(ctx, InventoryService_CheckDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -60,6 +100,13 @@ func (c *inventoryServiceClient) GetProductByID(ctx context.Context, in *GetProd
 // --------------------
 type InventoryServiceServer interface {
 	GetProductByID(context.Context, *GetProductByIDRequest) (*GetProductByIDResponse, error)
+	// the reviews, wishlists and stock subscriptions of a user for the account data export
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	// removes the personal data of a user whose account is being deleted
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
+	// whether the sellers of the products ship to the pincode, by when and at what
+	// shipping rules, checked at checkout
+	CheckDelivery(context.Context, *CheckDeliveryRequest) (*CheckDeliveryResponse, error)
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -73,6 +120,15 @@ type UnimplementedInventoryServiceServer struct{}
 func (UnimplementedInventoryServiceServer) GetProductByID(context.Context, *GetProductByIDRequest) (*GetProductByIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProductByID not implemented")
 }
+func (UnimplementedInventoryServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedInventoryServiceServer) EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseUserData not implemented")
+}
+func (UnimplementedInventoryServiceServer) CheckDelivery(context.Context, *CheckDeliveryRequest) (*CheckDeliveryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckDelivery not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -112,6 +168,60 @@ func _InventoryService_GetProductByID_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_EraseUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InventoryService_CheckDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).CheckDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_CheckDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).CheckDelivery(ctx, req.(*CheckDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -123,6 +233,18 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProductByID",
 			Handler:    _InventoryService_GetProductByID_Handler,
 		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _InventoryService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _InventoryService_EraseUserData_Handler,
+		},
+		{
+			MethodName: "CheckDelivery",
+			Handler:    _InventoryService_CheckDelivery_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory.proto",
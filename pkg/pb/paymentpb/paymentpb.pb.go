@@ -182,6 +182,380 @@ func (x *GetOrderItemByUserAndProductIDResponse) GetUpdatedAt() *timestamppb.Tim
 	return nil
 }
 
+type GetSellerFulfilmentStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerID      string                 `protobuf:"bytes,1,opt,name=sellerID,proto3" json:"sellerID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerFulfilmentStatsRequest) Reset() {
+	*x = GetSellerFulfilmentStatsRequest{}
+	mi := &file_paymentpb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerFulfilmentStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerFulfilmentStatsRequest) ProtoMessage() {}
+
+func (x *GetSellerFulfilmentStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerFulfilmentStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetSellerFulfilmentStatsRequest) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSellerFulfilmentStatsRequest) GetSellerID() string {
+	if x != nil {
+		return x.SellerID
+	}
+	return ""
+}
+
+// counts are over the seller's order items, rates are fractions from 0 to 1
+type GetSellerFulfilmentStatsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	TotalItems         int64                  `protobuf:"varint,1,opt,name=totalItems,proto3" json:"totalItems,omitempty"`
+	ShippedItems       int64                  `protobuf:"varint,2,opt,name=shippedItems,proto3" json:"shippedItems,omitempty"`
+	ShippedOnTime      int64                  `protobuf:"varint,3,opt,name=shippedOnTime,proto3" json:"shippedOnTime,omitempty"`
+	DeliveredItems     int64                  `protobuf:"varint,4,opt,name=deliveredItems,proto3" json:"deliveredItems,omitempty"`
+	CancelledItems     int64                  `protobuf:"varint,5,opt,name=cancelledItems,proto3" json:"cancelledItems,omitempty"`
+	ReturnedItems      int64                  `protobuf:"varint,6,opt,name=returnedItems,proto3" json:"returnedItems,omitempty"`
+	OnTimeShippingRate float64                `protobuf:"fixed64,7,opt,name=onTimeShippingRate,proto3" json:"onTimeShippingRate,omitempty"`
+	CancellationRate   float64                `protobuf:"fixed64,8,opt,name=cancellationRate,proto3" json:"cancellationRate,omitempty"`
+	ReturnRate         float64                `protobuf:"fixed64,9,opt,name=returnRate,proto3" json:"returnRate,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetSellerFulfilmentStatsResponse) Reset() {
+	*x = GetSellerFulfilmentStatsResponse{}
+	mi := &file_paymentpb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerFulfilmentStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerFulfilmentStatsResponse) ProtoMessage() {}
+
+func (x *GetSellerFulfilmentStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerFulfilmentStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetSellerFulfilmentStatsResponse) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetTotalItems() int64 {
+	if x != nil {
+		return x.TotalItems
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetShippedItems() int64 {
+	if x != nil {
+		return x.ShippedItems
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetShippedOnTime() int64 {
+	if x != nil {
+		return x.ShippedOnTime
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetDeliveredItems() int64 {
+	if x != nil {
+		return x.DeliveredItems
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetCancelledItems() int64 {
+	if x != nil {
+		return x.CancelledItems
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetReturnedItems() int64 {
+	if x != nil {
+		return x.ReturnedItems
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetOnTimeShippingRate() float64 {
+	if x != nil {
+		return x.OnTimeShippingRate
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetCancellationRate() float64 {
+	if x != nil {
+		return x.CancellationRate
+	}
+	return 0
+}
+
+func (x *GetSellerFulfilmentStatsResponse) GetReturnRate() float64 {
+	if x != nil {
+		return x.ReturnRate
+	}
+	return 0
+}
+
+type ExportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
+	mi := &file_paymentpb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataRequest) ProtoMessage() {}
+
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExportUserDataRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+// a json file for the data export of a user, eg. orders.json
+type ExportFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFile) Reset() {
+	*x = ExportFile{}
+	mi := &file_paymentpb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFile) ProtoMessage() {}
+
+func (x *ExportFile) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFile.ProtoReflect.Descriptor instead.
+func (*ExportFile) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExportFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportFile) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ExportUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*ExportFile          `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUserDataResponse) Reset() {
+	*x = ExportUserDataResponse{}
+	mi := &file_paymentpb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUserDataResponse) ProtoMessage() {}
+
+func (x *ExportUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ExportUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExportUserDataResponse) GetFiles() []*ExportFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type EraseUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataRequest) Reset() {
+	*x = EraseUserDataRequest{}
+	mi := &file_paymentpb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataRequest) ProtoMessage() {}
+
+func (x *EraseUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataRequest.ProtoReflect.Descriptor instead.
+func (*EraseUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EraseUserDataRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type EraseUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EraseUserDataResponse) Reset() {
+	*x = EraseUserDataResponse{}
+	mi := &file_paymentpb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EraseUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseUserDataResponse) ProtoMessage() {}
+
+func (x *EraseUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_paymentpb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseUserDataResponse.ProtoReflect.Descriptor instead.
+func (*EraseUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_paymentpb_proto_rawDescGZIP(), []int{8}
+}
+
 var File_paymentpb_proto protoreflect.FileDescriptor
 
 const file_paymentpb_proto_rawDesc = "" +
@@ -199,9 +573,39 @@ const file_paymentpb_proto_rawDesc = "" +
 	"\vtotalAmount\x18\x06 \x01(\x01R\vtotalAmount\x12\x16\n" +
 	"\x06status\x18\a \x01(\tR\x06status\x128\n" +
 	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x128\n" +
-	"\tupdatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt2\x98\x01\n" +
+	"\tupdatedAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"=\n" +
+	"\x1fGetSellerFulfilmentStatsRequest\x12\x1a\n" +
+	"\bsellerID\x18\x01 \x01(\tR\bsellerID\"\xfe\x02\n" +
+	" GetSellerFulfilmentStatsResponse\x12\x1e\n" +
+	"\n" +
+	"totalItems\x18\x01 \x01(\x03R\n" +
+	"totalItems\x12\"\n" +
+	"\fshippedItems\x18\x02 \x01(\x03R\fshippedItems\x12$\n" +
+	"\rshippedOnTime\x18\x03 \x01(\x03R\rshippedOnTime\x12&\n" +
+	"\x0edeliveredItems\x18\x04 \x01(\x03R\x0edeliveredItems\x12&\n" +
+	"\x0ecancelledItems\x18\x05 \x01(\x03R\x0ecancelledItems\x12$\n" +
+	"\rreturnedItems\x18\x06 \x01(\x03R\rreturnedItems\x12.\n" +
+	"\x12onTimeShippingRate\x18\a \x01(\x01R\x12onTimeShippingRate\x12*\n" +
+	"\x10cancellationRate\x18\b \x01(\x01R\x10cancellationRate\x12\x1e\n" +
+	"\n" +
+	"returnRate\x18\t \x01(\x01R\n" +
+	"returnRate\"/\n" +
+	"\x15ExportUserDataRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"4\n" +
+	"\n" +
+	"ExportFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"E\n" +
+	"\x16ExportUserDataResponse\x12+\n" +
+	"\x05files\x18\x01 \x03(\v2\x15.paymentpb.ExportFileR\x05files\".\n" +
+	"\x14EraseUserDataRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x17\n" +
+	"\x15EraseUserDataResponse2\xb8\x03\n" +
 	"\x0ePaymentService\x12\x85\x01\n" +
-	"\x1eGetOrderItemByUserAndProductID\x120.paymentpb.GetOrderItemByUserAndProductIDRequest\x1a1.paymentpb.GetOrderItemByUserAndProductIDResponseB;Z9github.com/amankhys/brocamp/ecom/pkg/pb/payment/paymentpbb\x06proto3"
+	"\x1eGetOrderItemByUserAndProductID\x120.paymentpb.GetOrderItemByUserAndProductIDRequest\x1a1.paymentpb.GetOrderItemByUserAndProductIDResponse\x12s\n" +
+	"\x18GetSellerFulfilmentStats\x12*.paymentpb.GetSellerFulfilmentStatsRequest\x1a+.paymentpb.GetSellerFulfilmentStatsResponse\x12U\n" +
+	"\x0eExportUserData\x12 .paymentpb.ExportUserDataRequest\x1a!.paymentpb.ExportUserDataResponse\x12R\n" +
+	"\rEraseUserData\x12\x1f.paymentpb.EraseUserDataRequest\x1a .paymentpb.EraseUserDataResponseB;Z9github.com/amankhys/brocamp/ecom/pkg/pb/payment/paymentpbb\x06proto3"
 
 var (
 	file_paymentpb_proto_rawDescOnce sync.Once
@@ -215,22 +619,36 @@ func file_paymentpb_proto_rawDescGZIP() []byte {
 	return file_paymentpb_proto_rawDescData
 }
 
-var file_paymentpb_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_paymentpb_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_paymentpb_proto_goTypes = []any{
 	(*GetOrderItemByUserAndProductIDRequest)(nil),  // 0: paymentpb.GetOrderItemByUserAndProductIDRequest
 	(*GetOrderItemByUserAndProductIDResponse)(nil), // 1: paymentpb.GetOrderItemByUserAndProductIDResponse
-	(*timestamppb.Timestamp)(nil),                  // 2: google.protobuf.Timestamp
+	(*GetSellerFulfilmentStatsRequest)(nil),        // 2: paymentpb.GetSellerFulfilmentStatsRequest
+	(*GetSellerFulfilmentStatsResponse)(nil),       // 3: paymentpb.GetSellerFulfilmentStatsResponse
+	(*ExportUserDataRequest)(nil),                  // 4: paymentpb.ExportUserDataRequest
+	(*ExportFile)(nil),                             // 5: paymentpb.ExportFile
+	(*ExportUserDataResponse)(nil),                 // 6: paymentpb.ExportUserDataResponse
+	(*EraseUserDataRequest)(nil),                   // 7: paymentpb.EraseUserDataRequest
+	(*EraseUserDataResponse)(nil),                  // 8: paymentpb.EraseUserDataResponse
+	(*timestamppb.Timestamp)(nil),                  // 9: google.protobuf.Timestamp
 }
 var file_paymentpb_proto_depIdxs = []int32{
-	2, // 0: paymentpb.GetOrderItemByUserAndProductIDResponse.createdAt:type_name -> google.protobuf.Timestamp
-	2, // 1: paymentpb.GetOrderItemByUserAndProductIDResponse.updatedAt:type_name -> google.protobuf.Timestamp
-	0, // 2: paymentpb.PaymentService.GetOrderItemByUserAndProductID:input_type -> paymentpb.GetOrderItemByUserAndProductIDRequest
-	1, // 3: paymentpb.PaymentService.GetOrderItemByUserAndProductID:output_type -> paymentpb.GetOrderItemByUserAndProductIDResponse
-	3, // [3:4] is the sub-list for method output_type
-	2, // [2:3] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	9, // 0: paymentpb.GetOrderItemByUserAndProductIDResponse.createdAt:type_name -> google.protobuf.Timestamp
+	9, // 1: paymentpb.GetOrderItemByUserAndProductIDResponse.updatedAt:type_name -> google.protobuf.Timestamp
+	5, // 2: paymentpb.ExportUserDataResponse.files:type_name -> paymentpb.ExportFile
+	0, // 3: paymentpb.PaymentService.GetOrderItemByUserAndProductID:input_type -> paymentpb.GetOrderItemByUserAndProductIDRequest
+	2, // 4: paymentpb.PaymentService.GetSellerFulfilmentStats:input_type -> paymentpb.GetSellerFulfilmentStatsRequest
+	4, // 5: paymentpb.PaymentService.ExportUserData:input_type -> paymentpb.ExportUserDataRequest
+	7, // 6: paymentpb.PaymentService.EraseUserData:input_type -> paymentpb.EraseUserDataRequest
+	1, // 7: paymentpb.PaymentService.GetOrderItemByUserAndProductID:output_type -> paymentpb.GetOrderItemByUserAndProductIDResponse
+	3, // 8: paymentpb.PaymentService.GetSellerFulfilmentStats:output_type -> paymentpb.GetSellerFulfilmentStatsResponse
+	6, // 9: paymentpb.PaymentService.ExportUserData:output_type -> paymentpb.ExportUserDataResponse
+	8, // 10: paymentpb.PaymentService.EraseUserData:output_type -> paymentpb.EraseUserDataResponse
+	7, // [7:11] is the sub-list for method output_type
+	3, // [3:7] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_paymentpb_proto_init() }
@@ -244,7 +662,7 @@ func file_paymentpb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_paymentpb_proto_rawDesc), len(file_paymentpb_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
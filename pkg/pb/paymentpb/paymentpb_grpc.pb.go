@@ -20,6 +20,9 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	PaymentService_GetOrderItemByUserAndProductID_FullMethodName = "/paymentpb.PaymentService/GetOrderItemByUserAndProductID"
+	PaymentService_GetSellerFulfilmentStats_FullMethodName       = "/paymentpb.PaymentService/GetSellerFulfilmentStats"
+	PaymentService_ExportUserData_FullMethodName                 = "/paymentpb.PaymentService/ExportUserData"
+	PaymentService_EraseUserData_FullMethodName                  = "/paymentpb.PaymentService/EraseUserData"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -27,6 +30,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PaymentServiceClient interface {
 	GetOrderItemByUserAndProductID(ctx context.Context, in *GetOrderItemByUserAndProductIDRequest, opts ...grpc.CallOption) (*GetOrderItemByUserAndProductIDResponse, error)
+	GetSellerFulfilmentStats(ctx context.Context, in *GetSellerFulfilmentStatsRequest, opts ...grpc.CallOption) (*GetSellerFulfilmentStatsResponse, error)
+	// the orders, returns and wallet history of a user for the account data export
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	// removes the personal data of a user whose account is being deleted, orders and
+	// payments are kept. FailedPrecondition while the user has orders on the way
+	EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -47,11 +56,47 @@ func (c *paymentServiceClient) GetOrderItemByUserAndProductID(ctx context.Contex
 	return out, nil
 }
 
+func (c *paymentServiceClient) GetSellerFulfilmentStats(ctx context.Context, in *GetSellerFulfilmentStatsRequest, opts ...grpc.CallOption) (*GetSellerFulfilmentStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSellerFulfilmentStatsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetSellerFulfilmentStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ExportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) EraseUserData(ctx context.Context, in *EraseUserDataRequest, opts ...grpc.CallOption) (*EraseUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EraseUserDataResponse)
+	err := c.cc.Invoke(ctx, PaymentService_EraseUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
 type PaymentServiceServer interface {
 	GetOrderItemByUserAndProductID(context.Context, *GetOrderItemByUserAndProductIDRequest) (*GetOrderItemByUserAndProductIDResponse, error)
+	GetSellerFulfilmentStats(context.Context, *GetSellerFulfilmentStatsRequest) (*GetSellerFulfilmentStatsResponse, error)
+	// the orders, returns and wallet history of a user for the account data export
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	// removes the personal data of a user whose account is being deleted, orders and
+	// payments are kept. FailedPrecondition while the user has orders on the way
+	EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -65,6 +110,15 @@ type UnimplementedPaymentServiceServer struct{}
 func (UnimplementedPaymentServiceServer) GetOrderItemByUserAndProductID(context.Context, *GetOrderItemByUserAndProductIDRequest) (*GetOrderItemByUserAndProductIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOrderItemByUserAndProductID not implemented")
 }
+func (UnimplementedPaymentServiceServer) GetSellerFulfilmentStats(context.Context, *GetSellerFulfilmentStatsRequest) (*GetSellerFulfilmentStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSellerFulfilmentStats not implemented")
+}
+func (UnimplementedPaymentServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedPaymentServiceServer) EraseUserData(context.Context, *EraseUserDataRequest) (*EraseUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EraseUserData not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -104,6 +158,60 @@ func _PaymentService_GetOrderItemByUserAndProductID_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_GetSellerFulfilmentStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSellerFulfilmentStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetSellerFulfilmentStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetSellerFulfilmentStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetSellerFulfilmentStats(ctx, req.(*GetSellerFulfilmentStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ExportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_EraseUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).EraseUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_EraseUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).EraseUserData(ctx, req.(*EraseUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +223,18 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrderItemByUserAndProductID",
 			Handler:    _PaymentService_GetOrderItemByUserAndProductID_Handler,
 		},
+		{
+			MethodName: "GetSellerFulfilmentStats",
+			Handler:    _PaymentService_GetSellerFulfilmentStats_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _PaymentService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "EraseUserData",
+			Handler:    _PaymentService_EraseUserData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "paymentpb.proto",
@@ -9,6 +9,7 @@ package userpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -188,6 +189,9 @@ func (x *GetAddressBySellerIDRequest) GetSellerID() string {
 type GetAddressBySellerIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Exists        bool                   `protobuf:"varint,1,opt,name=exists,proto3" json:"exists,omitempty"`
+	Pincode       int32                  `protobuf:"varint,2,opt,name=pincode,proto3" json:"pincode,omitempty"`
+	District      string                 `protobuf:"bytes,3,opt,name=district,proto3" json:"district,omitempty"`
+	State         string                 `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,11 +233,280 @@ func (x *GetAddressBySellerIDResponse) GetExists() bool {
 	return false
 }
 
+func (x *GetAddressBySellerIDResponse) GetPincode() int32 {
+	if x != nil {
+		return x.Pincode
+	}
+	return 0
+}
+
+func (x *GetAddressBySellerIDResponse) GetDistrict() string {
+	if x != nil {
+		return x.District
+	}
+	return ""
+}
+
+func (x *GetAddressBySellerIDResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type GetSellerByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SellerID      string                 `protobuf:"bytes,1,opt,name=sellerID,proto3" json:"sellerID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerByIDRequest) Reset() {
+	*x = GetSellerByIDRequest{}
+	mi := &file_userpb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerByIDRequest) ProtoMessage() {}
+
+func (x *GetSellerByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userpb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetSellerByIDRequest) Descriptor() ([]byte, []int) {
+	return file_userpb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetSellerByIDRequest) GetSellerID() string {
+	if x != nil {
+		return x.SellerID
+	}
+	return ""
+}
+
+type GetSellerByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	About         string                 `protobuf:"bytes,3,opt,name=about,proto3" json:"about,omitempty"`
+	UserVerified  bool                   `protobuf:"varint,4,opt,name=userVerified,proto3" json:"userVerified,omitempty"`
+	IsBlocked     bool                   `protobuf:"varint,5,opt,name=isBlocked,proto3" json:"isBlocked,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSellerByIDResponse) Reset() {
+	*x = GetSellerByIDResponse{}
+	mi := &file_userpb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSellerByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSellerByIDResponse) ProtoMessage() {}
+
+func (x *GetSellerByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userpb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSellerByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetSellerByIDResponse) Descriptor() ([]byte, []int) {
+	return file_userpb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetSellerByIDResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSellerByIDResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetSellerByIDResponse) GetAbout() string {
+	if x != nil {
+		return x.About
+	}
+	return ""
+}
+
+func (x *GetSellerByIDResponse) GetUserVerified() bool {
+	if x != nil {
+		return x.UserVerified
+	}
+	return false
+}
+
+func (x *GetSellerByIDResponse) GetIsBlocked() bool {
+	if x != nil {
+		return x.IsBlocked
+	}
+	return false
+}
+
+func (x *GetSellerByIDResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetUserByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserID        string                 `protobuf:"bytes,1,opt,name=userID,proto3" json:"userID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByIDRequest) Reset() {
+	*x = GetUserByIDRequest{}
+	mi := &file_userpb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByIDRequest) ProtoMessage() {}
+
+func (x *GetUserByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_userpb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetUserByIDRequest) Descriptor() ([]byte, []int) {
+	return file_userpb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUserByIDRequest) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+type GetUserByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	IsBlocked     bool                   `protobuf:"varint,5,opt,name=isBlocked,proto3" json:"isBlocked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserByIDResponse) Reset() {
+	*x = GetUserByIDResponse{}
+	mi := &file_userpb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserByIDResponse) ProtoMessage() {}
+
+func (x *GetUserByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_userpb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetUserByIDResponse) Descriptor() ([]byte, []int) {
+	return file_userpb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetUserByIDResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetUserByIDResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetUserByIDResponse) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *GetUserByIDResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *GetUserByIDResponse) GetIsBlocked() bool {
+	if x != nil {
+		return x.IsBlocked
+	}
+	return false
+}
+
 var File_userpb_proto protoreflect.FileDescriptor
 
 const file_userpb_proto_rawDesc = "" +
 	"\n" +
-	"\fuserpb.proto\x12\x06userpb\"9\n" +
+	"\fuserpb.proto\x12\x06userpb\x1a\x1fgoogle/protobuf/timestamp.proto\"9\n" +
 	"\x19GetUserBySessionIDRequest\x12\x1c\n" +
 	"\tsessionID\x18\x01 \x01(\tR\tsessionID\"\x80\x01\n" +
 	"\x1aGetUserBySessionIDResponse\x12\x0e\n" +
@@ -243,12 +516,34 @@ const file_userpb_proto_rawDesc = "" +
 	"\x04role\x18\x04 \x01(\tR\x04role\x12\x14\n" +
 	"\x05phone\x18\x05 \x01(\tR\x05phone\"9\n" +
 	"\x1bGetAddressBySellerIDRequest\x12\x1a\n" +
-	"\bsellerID\x18\x01 \x01(\tR\bsellerID\"6\n" +
+	"\bsellerID\x18\x01 \x01(\tR\bsellerID\"\x82\x01\n" +
 	"\x1cGetAddressBySellerIDResponse\x12\x16\n" +
-	"\x06exists\x18\x01 \x01(\bR\x06exists2\xcd\x01\n" +
+	"\x06exists\x18\x01 \x01(\bR\x06exists\x12\x18\n" +
+	"\apincode\x18\x02 \x01(\x05R\apincode\x12\x1a\n" +
+	"\bdistrict\x18\x03 \x01(\tR\bdistrict\x12\x14\n" +
+	"\x05state\x18\x04 \x01(\tR\x05state\"2\n" +
+	"\x14GetSellerByIDRequest\x12\x1a\n" +
+	"\bsellerID\x18\x01 \x01(\tR\bsellerID\"\xcd\x01\n" +
+	"\x15GetSellerByIDResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05about\x18\x03 \x01(\tR\x05about\x12\"\n" +
+	"\fuserVerified\x18\x04 \x01(\bR\fuserVerified\x12\x1c\n" +
+	"\tisBlocked\x18\x05 \x01(\bR\tisBlocked\x128\n" +
+	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\",\n" +
+	"\x12GetUserByIDRequest\x12\x16\n" +
+	"\x06userID\x18\x01 \x01(\tR\x06userID\"\x81\x01\n" +
+	"\x13GetUserByIDResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\x12\x1c\n" +
+	"\tisBlocked\x18\x05 \x01(\bR\tisBlocked2\xe3\x02\n" +
 	"\vUserService\x12[\n" +
 	"\x12GetUserBySessionID\x12!.userpb.GetUserBySessionIDRequest\x1a\".userpb.GetUserBySessionIDResponse\x12a\n" +
-	"\x14GetAddressBySellerID\x12#.userpb.GetAddressBySellerIDRequest\x1a$.userpb.GetAddressBySellerIDResponseB5Z3github.com/amankhys/brocamp/ecom/pkg/pb/user/userpbb\x06proto3"
+	"\x14GetAddressBySellerID\x12#.userpb.GetAddressBySellerIDRequest\x1a$.userpb.GetAddressBySellerIDResponse\x12L\n" +
+	"\rGetSellerByID\x12\x1c.userpb.GetSellerByIDRequest\x1a\x1d.userpb.GetSellerByIDResponse\x12F\n" +
+	"\vGetUserByID\x12\x1a.userpb.GetUserByIDRequest\x1a\x1b.userpb.GetUserByIDResponseB5Z3github.com/amankhys/brocamp/ecom/pkg/pb/user/userpbb\x06proto3"
 
 var (
 	file_userpb_proto_rawDescOnce sync.Once
@@ -262,23 +557,33 @@ func file_userpb_proto_rawDescGZIP() []byte {
 	return file_userpb_proto_rawDescData
 }
 
-var file_userpb_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_userpb_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_userpb_proto_goTypes = []any{
 	(*GetUserBySessionIDRequest)(nil),    // 0: userpb.GetUserBySessionIDRequest
 	(*GetUserBySessionIDResponse)(nil),   // 1: userpb.GetUserBySessionIDResponse
 	(*GetAddressBySellerIDRequest)(nil),  // 2: userpb.GetAddressBySellerIDRequest
 	(*GetAddressBySellerIDResponse)(nil), // 3: userpb.GetAddressBySellerIDResponse
+	(*GetSellerByIDRequest)(nil),         // 4: userpb.GetSellerByIDRequest
+	(*GetSellerByIDResponse)(nil),        // 5: userpb.GetSellerByIDResponse
+	(*GetUserByIDRequest)(nil),           // 6: userpb.GetUserByIDRequest
+	(*GetUserByIDResponse)(nil),          // 7: userpb.GetUserByIDResponse
+	(*timestamppb.Timestamp)(nil),        // 8: google.protobuf.Timestamp
 }
 var file_userpb_proto_depIdxs = []int32{
-	0, // 0: userpb.UserService.GetUserBySessionID:input_type -> userpb.GetUserBySessionIDRequest
-	2, // 1: userpb.UserService.GetAddressBySellerID:input_type -> userpb.GetAddressBySellerIDRequest
-	1, // 2: userpb.UserService.GetUserBySessionID:output_type -> userpb.GetUserBySessionIDResponse
-	3, // 3: userpb.UserService.GetAddressBySellerID:output_type -> userpb.GetAddressBySellerIDResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	8, // 0: userpb.GetSellerByIDResponse.createdAt:type_name -> google.protobuf.Timestamp
+	0, // 1: userpb.UserService.GetUserBySessionID:input_type -> userpb.GetUserBySessionIDRequest
+	2, // 2: userpb.UserService.GetAddressBySellerID:input_type -> userpb.GetAddressBySellerIDRequest
+	4, // 3: userpb.UserService.GetSellerByID:input_type -> userpb.GetSellerByIDRequest
+	6, // 4: userpb.UserService.GetUserByID:input_type -> userpb.GetUserByIDRequest
+	1, // 5: userpb.UserService.GetUserBySessionID:output_type -> userpb.GetUserBySessionIDResponse
+	3, // 6: userpb.UserService.GetAddressBySellerID:output_type -> userpb.GetAddressBySellerIDResponse
+	5, // 7: userpb.UserService.GetSellerByID:output_type -> userpb.GetSellerByIDResponse
+	7, // 8: userpb.UserService.GetUserByID:output_type -> userpb.GetUserByIDResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_userpb_proto_init() }
@@ -292,7 +597,7 @@ func file_userpb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_userpb_proto_rawDesc), len(file_userpb_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
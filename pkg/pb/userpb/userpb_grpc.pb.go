@@ -21,6 +21,8 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	UserService_GetUserBySessionID_FullMethodName   = "/userpb.UserService/GetUserBySessionID"
 	UserService_GetAddressBySellerID_FullMethodName = "/userpb.UserService/GetAddressBySellerID"
+	UserService_GetSellerByID_FullMethodName        = "/userpb.UserService/GetSellerByID"
+	UserService_GetUserByID_FullMethodName          = "/userpb.UserService/GetUserByID"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -29,6 +31,8 @@ const (
 type UserServiceClient interface {
 	GetUserBySessionID(ctx context.Context, in *GetUserBySessionIDRequest, opts ...grpc.CallOption) (*GetUserBySessionIDResponse, error)
 	GetAddressBySellerID(ctx context.Context, in *GetAddressBySellerIDRequest, opts ...grpc.CallOption) (*GetAddressBySellerIDResponse, error)
+	GetSellerByID(ctx context.Context, in *GetSellerByIDRequest, opts ...grpc.CallOption) (*GetSellerByIDResponse, error)
+	GetUserByID(ctx context.Context, in *GetUserByIDRequest, opts ...grpc.CallOption) (*GetUserByIDResponse, error)
 }
 
 type userServiceClient struct {
@@ -59,12 +63,34 @@ func (c *userServiceClient) GetAddressBySellerID(ctx context.Context, in *GetAdd
 	return out, nil
 }
 
+func (c *userServiceClient) GetSellerByID(ctx context.Context, in *GetSellerByIDRequest, opts ...grpc.CallOption) (*GetSellerByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSellerByIDResponse)
+	err := c.cc.Invoke(ctx, UserService_GetSellerByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetUserByID(ctx context.Context, in *GetUserByIDRequest, opts ...grpc.CallOption) (*GetUserByIDResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserByIDResponse)
+	err := c.cc.Invoke(ctx, UserService_GetUserByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
 type UserServiceServer interface {
 	GetUserBySessionID(context.Context, *GetUserBySessionIDRequest) (*GetUserBySessionIDResponse, error)
 	GetAddressBySellerID(context.Context, *GetAddressBySellerIDRequest) (*GetAddressBySellerIDResponse, error)
+	GetSellerByID(context.Context, *GetSellerByIDRequest) (*GetSellerByIDResponse, error)
+	GetUserByID(context.Context, *GetUserByIDRequest) (*GetUserByIDResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -81,6 +107,12 @@ func (UnimplementedUserServiceServer) GetUserBySessionID(context.Context, *GetUs
 func (UnimplementedUserServiceServer) GetAddressBySellerID(context.Context, *GetAddressBySellerIDRequest) (*GetAddressBySellerIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAddressBySellerID not implemented")
 }
+func (UnimplementedUserServiceServer) GetSellerByID(context.Context, *GetSellerByIDRequest) (*GetSellerByIDResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSellerByID not implemented")
+}
+func (UnimplementedUserServiceServer) GetUserByID(context.Context, *GetUserByIDRequest) (*GetUserByIDResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserByID not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -138,6 +170,42 @@ func _UserService_GetAddressBySellerID_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetSellerByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSellerByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetSellerByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetSellerByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetSellerByID(ctx, req.(*GetSellerByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetUserByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetUserByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetUserByID(ctx, req.(*GetUserByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +221,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAddressBySellerID",
 			Handler:    _UserService_GetAddressBySellerID_Handler,
 		},
+		{
+			MethodName: "GetSellerByID",
+			Handler:    _UserService_GetSellerByID_Handler,
+		},
+		{
+			MethodName: "GetUserByID",
+			Handler:    _UserService_GetUserByID_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "userpb.proto",
@@ -0,0 +1,130 @@
+package pincode
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Place is the district and state a pincode belongs to
+type Place struct {
+	District string `json:"district"`
+	State    string `json:"state"`
+}
+
+// Directory maps pincodes to their place, loaded from a csv of the india post
+// pincode directory. a nil or empty Directory knows no pincodes
+type Directory struct {
+	places map[int]Place
+}
+
+// column names accepted for each field, the india post export uses the second ones
+var columns = map[string][]string{
+	"pincode":  {"pincode", "pin"},
+	"district": {"district", "districtname"},
+	"state":    {"state", "statename"},
+}
+
+// Load reads a csv with a header naming the pincode, district and state columns,
+// other columns are ignored. a pincode listed more than once (one row per post
+// office) keeps the place of its first row
+func Load(r io.Reader) (*Directory, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	idx := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for field, names := range columns {
+			for _, n := range names {
+				if name == n {
+					idx[field] = i
+				}
+			}
+		}
+	}
+	for field := range columns {
+		if _, ok := idx[field]; !ok {
+			return nil, errors.New("pincode: no " + field + " column in the header")
+		}
+	}
+
+	d := &Directory{places: make(map[int]Place)}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(record) <= idx["pincode"] || len(record) <= idx["district"] || len(record) <= idx["state"] {
+			continue
+		}
+		pin, err := strconv.Atoi(strings.TrimSpace(record[idx["pincode"]]))
+		if err != nil {
+			continue
+		}
+		if _, ok := d.places[pin]; ok {
+			continue
+		}
+		d.places[pin] = Place{
+			District: titleCase(record[idx["district"]]),
+			State:    titleCase(record[idx["state"]]),
+		}
+	}
+	return d, nil
+}
+
+// LoadFile loads the directory from the csv at path
+func LoadFile(path string) (*Directory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+func (d *Directory) Lookup(pin int) (Place, bool) {
+	if d == nil {
+		return Place{}, false
+	}
+	p, ok := d.places[pin]
+	return p, ok
+}
+
+// Len is the number of pincodes known, 0 when no dataset was loaded
+func (d *Directory) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.places)
+}
+
+// SameName compares district or state names ignoring case, spacing and & for and
+func SameName(a, b string) bool {
+	return normalize(a) == normalize(b)
+}
+
+func normalize(s string) string {
+	s = strings.ToLower(strings.ReplaceAll(s, "&", " and "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// the dataset is in upper case, addresses are shown in title case
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, w := range words {
+		if w == "and" || w == "of" {
+			continue
+		}
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,94 @@
+package pincode
+
+import (
+	"strings"
+	"testing"
+)
+
+// a few rows in the layout of the india post export, one row per post office
+const sample = `officename,pincode,officetype,Deliverystatus,divisionname,regionname,circlename,Taluk,Districtname,statename
+Connaught Place S.O,110001,S.O,Non-Delivery,New Delhi Central,Delhi,Delhi,New Delhi,CENTRAL DELHI,DELHI
+Parliament House S.O,110001,S.O,Delivery,New Delhi Central,Delhi,Delhi,New Delhi,NEW DELHI,DELHI
+Ernakulam H.O,682011,H.O,Delivery,Ernakulam,Kochi,Kerala,Kanayannur,ERNAKULAM,KERALA
+Port Blair H.O,744101,H.O,Delivery,Andaman & Nicobar Islands,Port Blair,West Bengal,Port Blair,SOUTH ANDAMAN,ANDAMAN AND NICOBAR ISLANDS
+Bad Row S.O,notapin,S.O,Delivery,X,X,X,X,X,X
+Short Row,400001
+`
+
+func TestLoad(t *testing.T) {
+	d, err := Load(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() != 3 {
+		t.Errorf("Len = %d, want 3", d.Len())
+	}
+	tests := []struct {
+		pin  int
+		want Place
+		ok   bool
+	}{
+		// the first row of a pincode wins
+		{110001, Place{District: "Central Delhi", State: "Delhi"}, true},
+		{682011, Place{District: "Ernakulam", State: "Kerala"}, true},
+		// and and of stay lower case
+		{744101, Place{District: "South Andaman", State: "Andaman and Nicobar Islands"}, true},
+		// the short row is skipped
+		{400001, Place{}, false},
+		{999999, Place{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := d.Lookup(tt.pin)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Lookup(%d) = %+v, %v, want %+v, %v", tt.pin, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLoadPlainHeader(t *testing.T) {
+	d, err := Load(strings.NewReader("Pincode, District ,State\n560001,bengaluru urban,karnataka\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := d.Lookup(560001); !ok || got != (Place{District: "Bengaluru Urban", State: "Karnataka"}) {
+		t.Errorf("Lookup = %+v, %v", got, ok)
+	}
+}
+
+func TestLoadMissingColumn(t *testing.T) {
+	for _, header := range []string{"pincode,district", "pincode,state", "district,state", ""} {
+		if _, err := Load(strings.NewReader(header + "\n")); err == nil {
+			t.Errorf("header %q loaded without an error", header)
+		}
+	}
+}
+
+func TestNilDirectory(t *testing.T) {
+	// without a dataset nothing is known and nothing panics
+	var d *Directory
+	if d.Len() != 0 {
+		t.Errorf("Len = %d, want 0", d.Len())
+	}
+	if _, ok := d.Lookup(110001); ok {
+		t.Error("nil directory knows a pincode")
+	}
+}
+
+func TestSameName(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Tamil Nadu", "tamil nadu", true},
+		{"Tamil  Nadu ", " TAMIL NADU", true},
+		{"Jammu & Kashmir", "Jammu and Kashmir", true},
+		{"Andaman&Nicobar Islands", "andaman and nicobar islands", true},
+		{"Kerala", "Karnataka", false},
+		{"West Bengal", "Bengal", false},
+	}
+	for _, tt := range tests {
+		if got := SameName(tt.a, tt.b); got != tt.want {
+			t.Errorf("SameName(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	State
+	windowStart time.Time
+	updatedAt   time.Time
+}
+
+// Memory keeps the counters in a map, they are lost on restart and not shared
+// between instances
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]*memoryEntry)}
+}
+
+func (m *Memory) Hit(ctx context.Context, key string, window time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	e, ok := m.entries[key]
+	if !ok {
+		e = &memoryEntry{}
+		m.entries[key] = e
+	}
+	if e.windowStart.Add(window).Before(now) {
+		e.Attempts = 0
+		e.windowStart = now
+	}
+	e.Attempts++
+	e.updatedAt = now
+	return e.Attempts, nil
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		return e.State, nil
+	}
+	return State{}, nil
+}
+
+func (m *Memory) Lock(ctx context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		e = &memoryEntry{}
+		m.entries[key] = e
+	}
+	e.Attempts = 0
+	e.Lockouts++
+	e.LockedUntil = until
+	e.updatedAt = time.Now()
+	return nil
+}
+
+func (m *Memory) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.entries, key)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) Purge(ctx context.Context, idle time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	for key, e := range m.entries {
+		if e.updatedAt.Add(idle).Before(now) && e.LockedUntil.Before(now) {
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Postgres keeps the counters in the rate_limits table of the service database:
+//
+//	CREATE TABLE rate_limits (
+//	    key TEXT PRIMARY KEY,
+//	    attempts INTEGER NOT NULL DEFAULT 0,
+//	    window_start TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+//	    lockouts INTEGER NOT NULL DEFAULT 0,
+//	    locked_until TIMESTAMPTZ,
+//	    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+//	);
+type Postgres struct {
+	DB *sql.DB
+}
+
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{DB: db}
+}
+
+func (p *Postgres) Hit(ctx context.Context, key string, window time.Duration) (int, error) {
+	// a single upsert so concurrent attempts can't both read the old count
+	var n int
+	err := p.DB.QueryRowContext(ctx, `
+		INSERT INTO rate_limits (key, attempts, window_start, updated_at)
+		VALUES ($1, 1, now(), now())
+		ON CONFLICT (key) DO UPDATE SET
+			attempts = CASE WHEN rate_limits.window_start + make_interval(secs => $2) < now()
+				THEN 1 ELSE rate_limits.attempts + 1 END,
+			window_start = CASE WHEN rate_limits.window_start + make_interval(secs => $2) < now()
+				THEN now() ELSE rate_limits.window_start END,
+			updated_at = now()
+		RETURNING attempts`, key, window.Seconds()).Scan(&n)
+	return n, err
+}
+
+func (p *Postgres) Get(ctx context.Context, key string) (State, error) {
+	var st State
+	var lockedUntil sql.NullTime
+	err := p.DB.QueryRowContext(ctx,
+		`SELECT attempts, lockouts, locked_until FROM rate_limits WHERE key = $1`, key,
+	).Scan(&st.Attempts, &st.Lockouts, &lockedUntil)
+	if err == sql.ErrNoRows {
+		return State{}, nil
+	} else if err != nil {
+		return State{}, err
+	}
+	st.LockedUntil = lockedUntil.Time
+	return st, nil
+}
+
+func (p *Postgres) Lock(ctx context.Context, key string, until time.Time) error {
+	_, err := p.DB.ExecContext(ctx, `
+		INSERT INTO rate_limits (key, lockouts, locked_until, updated_at)
+		VALUES ($1, 1, $2, now())
+		ON CONFLICT (key) DO UPDATE SET
+			attempts = 0,
+			lockouts = rate_limits.lockouts + 1,
+			locked_until = $2,
+			updated_at = now()`, key, until)
+	return err
+}
+
+func (p *Postgres) Reset(ctx context.Context, key string) error {
+	_, err := p.DB.ExecContext(ctx, `DELETE FROM rate_limits WHERE key = $1`, key)
+	return err
+}
+
+func (p *Postgres) Purge(ctx context.Context, idle time.Duration) error {
+	_, err := p.DB.ExecContext(ctx, `
+		DELETE FROM rate_limits
+		WHERE updated_at < now() - make_interval(secs => $1)
+		AND (locked_until IS NULL OR locked_until < now())`, idle.Seconds())
+	return err
+}
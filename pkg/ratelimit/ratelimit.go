@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+)
+
+const BackendMemory = "memory"
+const BackendPostgres = "postgres"
+
+// State is what the store keeps for one key
+type State struct {
+	// failed attempts in the current window
+	Attempts int
+	// how many times the key was locked since its last reset, lockouts grow with it
+	Lockouts    int
+	LockedUntil time.Time
+}
+
+// Store keeps the attempt counters. keys are like "login:account:<email>"
+type Store interface {
+	// Hit counts a failed attempt, the window starts at the first attempt and
+	// the count starts over once it has passed. returns the count after the hit
+	Hit(ctx context.Context, key string, window time.Duration) (int, error)
+	Get(ctx context.Context, key string) (State, error)
+	// Lock locks the key until the given time, counts the lockout and clears the attempts
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	// Purge drops keys that are unlocked and untouched for longer than idle
+	Purge(ctx context.Context, idle time.Duration) error
+}
+
+// New builds the store from the environment. defaults to postgres so the counters
+// are shared by every instance of the service, memory is for single instance runs
+func New(db *sql.DB) (Store, error) {
+	backend := strings.ToLower(os.Getenv(envname.RateLimitBackend))
+	switch backend {
+	case "", BackendPostgres:
+		return NewPostgres(db), nil
+	case BackendMemory:
+		return NewMemory(), nil
+	default:
+		return nil, errors.New("ratelimit: unknown backend " + backend)
+	}
+}
+
+// Policy is how many failed attempts are allowed in a window before the key is
+// locked. every lockout since the last reset doubles the lock up to MaxLockout
+type Policy struct {
+	MaxAttempts int
+	Window      time.Duration
+	Lockout     time.Duration
+	MaxLockout  time.Duration
+}
+
+type Limiter struct {
+	Store  Store
+	Prefix string
+	Policy Policy
+}
+
+func (l *Limiter) key(id string) string {
+	return l.Prefix + ":" + strings.ToLower(id)
+}
+
+// Check returns how long id stays locked, zero when it may try
+func (l *Limiter) Check(ctx context.Context, id string) (time.Duration, error) {
+	st, err := l.Store.Get(ctx, l.key(id))
+	if err != nil {
+		return 0, err
+	}
+	if wait := time.Until(st.LockedUntil); wait > 0 {
+		return wait, nil
+	}
+	return 0, nil
+}
+
+// Fail records a failed attempt and returns the lock duration when it
+// used up the last allowed attempt
+func (l *Limiter) Fail(ctx context.Context, id string) (time.Duration, error) {
+	key := l.key(id)
+	n, err := l.Store.Hit(ctx, key, l.Policy.Window)
+	if err != nil || n < l.Policy.MaxAttempts {
+		return 0, err
+	}
+	st, err := l.Store.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	lock := l.Policy.Lockout
+	for i := 0; i < st.Lockouts && lock < l.Policy.MaxLockout; i++ {
+		lock *= 2
+	}
+	if lock > l.Policy.MaxLockout {
+		lock = l.Policy.MaxLockout
+	}
+	if err = l.Store.Lock(ctx, key, time.Now().Add(lock)); err != nil {
+		return 0, err
+	}
+	return lock, nil
+}
+
+// Reset forgets the attempts and lockouts of id, called after a success
+func (l *Limiter) Reset(ctx context.Context, id string) error {
+	return l.Store.Reset(ctx, l.key(id))
+}
@@ -5,27 +5,93 @@ import (
 	"time"
 )
 
-const sessionCookieName string = "session_id"
+const accessCookieName string = "access_token"
+const refreshCookieName string = "refresh_token"
+const oauthStateCookieName string = "oauth_state"
 
-func SetSessionCookie(w http.ResponseWriter, sessionID string) {
-	var cookie = &http.Cookie{
-		Name:     sessionCookieName,
-		Value:    sessionID,
-		Expires:  time.Now().Add(7 * time.Hour * 24),
+// SetAuthCookies sets the access and refresh tokens, the refresh token cookie
+// is strict so it is never sent along with requests from other sites
+func SetAuthCookies(w http.ResponseWriter, accessToken string, accessExpiresAt time.Time, refreshToken string, refreshExpiresAt time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessCookieName,
+		Value:    accessToken,
+		Expires:  accessExpiresAt,
 		HttpOnly: true,
 		Secure:   true,
 		SameSite: http.SameSiteLaxMode,
 		Path:     "/",
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshCookieName,
+		Value:    refreshToken,
+		Expires:  refreshExpiresAt,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     "/",
+	})
+}
+
+func DeleteAuthCookies(w http.ResponseWriter) {
+	for _, c := range []*http.Cookie{
+		{Name: accessCookieName, Path: "/"},
+		{Name: refreshCookieName, Path: "/"},
+	} {
+		c.Expires = time.Unix(0, 0)
+		c.MaxAge = -1
+		c.HttpOnly = true
+		c.Secure = true
+		http.SetCookie(w, c)
 	}
-	http.SetCookie(w, cookie)
 }
 
-func DeleteSessionCookie(w http.ResponseWriter, cookie *http.Cookie) {
-	cookie.Expires = time.Unix(0, 0)
-	cookie.MaxAge = -1
-	http.SetCookie(w, cookie)
+// GetAccessToken reads the access token from the Authorization bearer header,
+// falling back to the cookie for browsers
+func GetAccessToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); len(auth) > 7 && auth[:7] == "Bearer " {
+		return auth[7:]
+	}
+	cookie, err := r.Cookie(accessCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
 }
 
-func GetSessionCookie(r *http.Request) (*http.Cookie, error) {
-	return r.Cookie(sessionCookieName)
+func GetRefreshCookie(r *http.Request) (*http.Cookie, error) {
+	return r.Cookie(refreshCookieName)
+}
+
+// SetOAuthStateCookie ties an oauth flow to the browser that started it, the
+// callback only accepts a state that matches this cookie. it is lax so it is
+// sent along with the redirect back from the provider
+func SetOAuthStateCookie(w http.ResponseWriter, state string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookieName,
+		Value:    state,
+		MaxAge:   int(ttl.Seconds()),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/auth",
+	})
+}
+
+func GetOAuthStateCookie(r *http.Request) string {
+	cookie, err := r.Cookie(oauthStateCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+func DeleteOAuthStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookieName,
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		Path:     "/auth",
+	})
 }
@@ -0,0 +1,104 @@
+package sessions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func cookiesByName(rec *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := make(map[string]*http.Cookie)
+	for _, c := range rec.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	return cookies
+}
+
+func TestSetAuthCookies(t *testing.T) {
+	rec := httptest.NewRecorder()
+	accessExpiresAt := time.Now().Add(15 * time.Minute).Truncate(time.Second)
+	refreshExpiresAt := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+	SetAuthCookies(rec, "access", accessExpiresAt, "refresh", refreshExpiresAt)
+
+	cookies := cookiesByName(rec)
+	tests := []struct {
+		name      string
+		value     string
+		expiresAt time.Time
+		sameSite  http.SameSite
+	}{
+		{accessCookieName, "access", accessExpiresAt, http.SameSiteLaxMode},
+		// the refresh token must never ride along with a request from another site
+		{refreshCookieName, "refresh", refreshExpiresAt, http.SameSiteStrictMode},
+	}
+	for _, tt := range tests {
+		c, ok := cookies[tt.name]
+		if !ok {
+			t.Errorf("cookie %s not set", tt.name)
+			continue
+		}
+		if c.Value != tt.value || !c.Expires.Equal(tt.expiresAt) {
+			t.Errorf("cookie %s = %q expiring %s, want %q expiring %s", tt.name, c.Value, c.Expires, tt.value, tt.expiresAt)
+		}
+		if !c.HttpOnly || !c.Secure || c.SameSite != tt.sameSite || c.Path != "/" {
+			t.Errorf("cookie %s attributes = %+v", tt.name, c)
+		}
+	}
+}
+
+func TestDeleteAuthCookies(t *testing.T) {
+	rec := httptest.NewRecorder()
+	DeleteAuthCookies(rec)
+	cookies := cookiesByName(rec)
+	for _, name := range []string{accessCookieName, refreshCookieName} {
+		c, ok := cookies[name]
+		if !ok {
+			t.Errorf("cookie %s not cleared", name)
+			continue
+		}
+		if c.MaxAge >= 0 || c.Value != "" || c.Path != "/" {
+			t.Errorf("cookie %s = %+v, want an expired empty cookie", name, c)
+		}
+	}
+}
+
+func TestGetAccessToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		want   string
+	}{
+		{"bearer header", "Bearer header-token", "", "header-token"},
+		{"header wins over cookie", "Bearer header-token", "cookie-token", "header-token"},
+		{"cookie", "", "cookie-token", "cookie-token"},
+		{"other scheme falls back to cookie", "Basic abc", "cookie-token", "cookie-token"},
+		{"empty bearer", "Bearer ", "", ""},
+		{"nothing", "", "", ""},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			r.Header.Set("Authorization", tt.header)
+		}
+		if tt.cookie != "" {
+			r.AddCookie(&http.Cookie{Name: accessCookieName, Value: tt.cookie})
+		}
+		if got := GetAccessToken(r); got != tt.want {
+			t.Errorf("%s: GetAccessToken = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetRefreshCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/auth/refresh", nil)
+	if _, err := GetRefreshCookie(r); err != http.ErrNoCookie {
+		t.Errorf("GetRefreshCookie without a cookie error = %v, want ErrNoCookie", err)
+	}
+	r.AddCookie(&http.Cookie{Name: refreshCookieName, Value: "refresh"})
+	c, err := GetRefreshCookie(r)
+	if err != nil || c.Value != "refresh" {
+		t.Errorf("GetRefreshCookie = %v %v, want refresh", c, err)
+	}
+}
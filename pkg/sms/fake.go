@@ -0,0 +1,48 @@
+package sms
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is one text a Fake provider got
+type Message struct {
+	To   string
+	Text string
+}
+
+// Fake keeps the messages in memory instead of sending them, for tests and local
+// runs without a gateway. set Err to make every send fail
+type Fake struct {
+	Err error
+
+	mu   sync.Mutex
+	sent []Message
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Send(ctx context.Context, to string, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	f.sent = append(f.sent, Message{To: to, Text: message})
+	return nil
+}
+
+// Sent returns a copy of every message sent so far
+func (f *Fake) Sent() []Message {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Message{}, f.sent...)
+}
+
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = nil
+}
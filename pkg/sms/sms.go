@@ -0,0 +1,87 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+)
+
+// names of the providers SMS_PROVIDER picks from
+const (
+	ProviderGateway = "gateway"
+	ProviderFake    = "fake"
+)
+
+// Provider sends a text message to a phone number in international format
+type Provider interface {
+	Send(ctx context.Context, to string, message string) error
+}
+
+// New returns the provider set in SMS_PROVIDER, the http gateway by default
+func New() (Provider, error) {
+	switch name := strings.ToLower(os.Getenv(envname.SMSProvider)); name {
+	case "", ProviderGateway:
+		return &Gateway{
+			URL:    os.Getenv(envname.SMSGatewayURL),
+			APIKey: os.Getenv(envname.SMSAPIKey),
+		}, nil
+	case ProviderFake:
+		return NewFake(), nil
+	default:
+		return nil, errors.New("sms: unknown provider " + name)
+	}
+}
+
+// Number formats a 10 digit indian phone number the way providers expect it
+func Number(phone int64) string {
+	return "+91" + strconv.FormatInt(phone, 10)
+}
+
+// Gateway posts {"to", "message"} to an http sms gateway with the api key as a bearer token
+type Gateway struct {
+	URL    string
+	APIKey string
+	Client *http.Client
+}
+
+func (g *Gateway) Send(ctx context.Context, to string, message string) error {
+	if g.URL == "" {
+		return errors.New("sms: gateway url not set")
+	}
+	body, err := json.Marshal(map[string]string{
+		"to":      to,
+		"message": message,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+g.APIKey)
+	}
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sms: %s responded %s", req.URL.Host, resp.Status)
+	}
+	return nil
+}
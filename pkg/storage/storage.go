@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+)
+
+const BackendLocal = "local"
+const BackendS3 = "s3"
+
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage is where uploaded files (product images, documents etc) live.
+// keys are slash separated paths like "products/<id>/<file>.jpg"
+type Storage interface {
+	// Put stores the content under key and returns the public url of it
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+// New builds the storage backend from the environment.
+// defaults to the local filesystem when STORAGE_BACKEND is not set
+func New() (Storage, error) {
+	backend := strings.ToLower(os.Getenv(envname.StorageBackend))
+	switch backend {
+	case "", BackendLocal:
+		dir := os.Getenv(envname.StorageLocalDir)
+		if dir == "" {
+			dir = "./static/uploads"
+		}
+		baseURL := os.Getenv(envname.StorageBaseURL)
+		if baseURL == "" {
+			baseURL = "/media"
+		}
+		return NewLocal(dir, baseURL)
+	case BackendS3:
+		return NewS3(S3Config{
+			Endpoint:  os.Getenv(envname.S3Endpoint),
+			Region:    os.Getenv(envname.S3Region),
+			Bucket:    os.Getenv(envname.S3Bucket),
+			AccessKey: os.Getenv(envname.S3AccessKey),
+			SecretKey: os.Getenv(envname.S3SecretKey),
+			UseSSL:    os.Getenv(envname.S3UseSSL) == "true",
+			BaseURL:   os.Getenv(envname.StorageBaseURL),
+		})
+	default:
+		return nil, errors.New("storage: unknown backend " + backend)
+	}
+}
+
+// NewPrivate builds the storage for files that must never be public, like kyc
+// documents. It has its own dir or bucket so the public /media handler can't
+// reach it, and the files are only read back through the service's handlers.
+// defaults to the local filesystem when PRIVATE_STORAGE_BACKEND is not set
+func NewPrivate() (Storage, error) {
+	backend := strings.ToLower(os.Getenv(envname.PrivateStorageBackend))
+	switch backend {
+	case "", BackendLocal:
+		dir := os.Getenv(envname.PrivateStorageLocalDir)
+		if dir == "" {
+			dir = "./private/uploads"
+		}
+		publicDir := os.Getenv(envname.StorageLocalDir)
+		if publicDir == "" {
+			publicDir = "./static/uploads"
+		}
+		if inside(dir, publicDir) {
+			return nil, errors.New("storage: private dir must not be inside the public dir " + publicDir)
+		}
+		// no base url, the files are never served from a path
+		return NewLocal(dir, "")
+	case BackendS3:
+		bucket := os.Getenv(envname.PrivateS3Bucket)
+		if bucket == "" || bucket == os.Getenv(envname.S3Bucket) {
+			return nil, errors.New("storage: private s3 bucket must be set and differ from the public one")
+		}
+		return NewS3(S3Config{
+			Endpoint:  os.Getenv(envname.S3Endpoint),
+			Region:    os.Getenv(envname.S3Region),
+			Bucket:    bucket,
+			AccessKey: os.Getenv(envname.S3AccessKey),
+			SecretKey: os.Getenv(envname.S3SecretKey),
+			UseSSL:    os.Getenv(envname.S3UseSSL) == "true",
+		})
+	default:
+		return nil, errors.New("storage: unknown backend " + backend)
+	}
+}
+
+// inside reports whether dir is parent or somewhere under it
+func inside(dir, parent string) bool {
+	d, err1 := filepath.Abs(dir)
+	p, err2 := filepath.Abs(parent)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	rel, err := filepath.Rel(p, d)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// cleanKey stops keys from escaping the storage root
+func cleanKey(key string) (string, error) {
+	key = strings.TrimLeft(key, "/")
+	if key == "" || strings.Contains(key, "..") || strings.Contains(key, "\\") {
+		return "", errors.New("storage: invalid key " + key)
+	}
+	return key, nil
+}
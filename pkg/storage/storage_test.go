@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCleanKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"products/1/a.jpg", "products/1/a.jpg", true},
+		{"/products/1/a.jpg", "products/1/a.jpg", true},
+		{"", "", false},
+		{"/", "", false},
+		{"../etc/passwd", "", false},
+		{"products/../../etc/passwd", "", false},
+		{"products\\a.jpg", "", false},
+	}
+	for _, tt := range tests {
+		got, err := cleanKey(tt.key)
+		if (err == nil) != tt.ok {
+			t.Errorf("cleanKey(%q) error = %v, want ok %v", tt.key, err, tt.ok)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("cleanKey(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+// testStorage runs the behaviour every backend shares
+func testStorage(t *testing.T, st Storage) {
+	t.Helper()
+	ctx := context.Background()
+	key := "products/1/a.jpg"
+	data := []byte("not really a jpeg")
+
+	url, err := st.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/jpeg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if url != st.URL(key) {
+		t.Errorf("Put returned url %q, URL gives %q", url, st.URL(key))
+	}
+	if !strings.HasSuffix(url, "/"+key) {
+		t.Errorf("url %q doesn't end with the key", url)
+	}
+
+	rc, err := st.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Get = %q, want %q", got, data)
+	}
+
+	// putting the key again replaces the content
+	data = []byte("replaced")
+	if _, err = st.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/jpeg"); err != nil {
+		t.Fatal(err)
+	}
+	// a leading slash names the same file, as it does for Put
+	rc, err = st.Get(ctx, "/"+key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ = io.ReadAll(rc)
+	rc.Close()
+	if !bytes.Equal(got, data) {
+		t.Errorf("Get after replace = %q, want %q", got, data)
+	}
+
+	if err = st.Delete(ctx, "/"+key); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = st.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	if _, err = st.Get(ctx, "products/1/missing.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a missing key error = %v, want ErrNotFound", err)
+	}
+
+	if _, err = st.Put(ctx, "../outside.jpg", bytes.NewReader(data), int64(len(data)), "image/jpeg"); err == nil {
+		t.Error("Put of a key escaping the root succeeded")
+	}
+	if _, err = st.Get(ctx, "../outside.jpg"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Get of a key escaping the root error = %v, want an invalid key", err)
+	}
+	if err = st.Delete(ctx, "../outside.jpg"); err == nil {
+		t.Error("Delete of a key escaping the root succeeded")
+	}
+}
+
+func TestLocal(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewLocal(dir, "/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStorage(t, l)
+
+	if got := l.URL("/products/1/a.jpg"); got != "/media/products/1/a.jpg" {
+		t.Errorf("URL = %q, want /media/products/1/a.jpg", got)
+	}
+	if err = l.Delete(context.Background(), "products/1/missing.jpg"); err != nil {
+		t.Errorf("Delete of a missing key = %v, want nil", err)
+	}
+}
+
+func TestLocalHandler(t *testing.T) {
+	l, err := NewLocal(t.TempDir(), "/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("served")
+	if _, err = l.Put(context.Background(), "products/1/a.txt", bytes.NewReader(data), int64(len(data)), "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	l.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/media/products/1/a.txt", nil))
+	if rec.Code != http.StatusOK || rec.Body.String() != "served" {
+		t.Errorf("GET /media/products/1/a.txt = %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestMemory(t *testing.T) {
+	testStorage(t, NewMemory())
+}
+
+// fakeS3 is enough of the s3 api for the minio client: put, head, get and
+// delete of objects in path style urls. signatures aren't checked
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	types   map[string]string
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{objects: make(map[string][]byte), types: make(map[string]string)}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		body, err := readS3Body(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[path] = body
+		f.types[path] = r.Header.Get("Content-Type")
+		w.Header().Set("ETag", `"fake"`)
+	case http.MethodHead, http.MethodGet:
+		data, ok := f.objects[path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Type", f.types[path])
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("ETag", `"fake"`)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, path)
+		delete(f.types, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+// readS3Body reads a put body, unwrapping the aws-chunked encoding the
+// client streams with over plain http
+func readS3Body(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+	var body []byte
+	br := bufio.NewReader(r.Body)
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return body, nil
+		}
+		chunk := make([]byte, size+2) // data and its trailing \r\n
+		if _, err = io.ReadFull(br, chunk); err != nil {
+			return nil, err
+		}
+		body = append(body, chunk[:size]...)
+	}
+}
+
+func TestS3(t *testing.T) {
+	srv := httptest.NewServer(newFakeS3())
+	defer srv.Close()
+	s, err := NewS3(S3Config{
+		Endpoint:  strings.TrimPrefix(srv.URL, "http://"),
+		Region:    "us-east-1",
+		Bucket:    "uploads",
+		AccessKey: "access",
+		SecretKey: "secretsecret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStorage(t, s)
+
+	if want := srv.URL + "/uploads/products/1/a.jpg"; s.URL("products/1/a.jpg") != want {
+		t.Errorf("URL = %q, want %q", s.URL("products/1/a.jpg"), want)
+	}
+	if _, err = NewS3(S3Config{Bucket: "uploads"}); err == nil {
+		t.Error("NewS3 without an endpoint succeeded")
+	}
+}
@@ -24,3 +24,56 @@ func GetClientIPString(r *http.Request) string {
 func GetUserAgent(r *http.Request) string {
 	return r.Header.Get("User-Agent")
 }
+
+// ParseUserAgent gives a rough device, browser and os of a User-Agent for showing
+// a user their sessions, it is only meant to be readable and not exact
+func ParseUserAgent(ua string) (device string, browser string, os string) {
+	l := strings.ToLower(ua)
+
+	switch {
+	case strings.Contains(l, "ipad") || strings.Contains(l, "tablet"):
+		device = "tablet"
+	case strings.Contains(l, "mobi") || strings.Contains(l, "iphone") || strings.Contains(l, "android"):
+		device = "mobile"
+	case ua == "":
+		device = "unknown"
+	default:
+		device = "desktop"
+	}
+
+	// order matters, most browsers also claim to be chrome and safari
+	switch {
+	case strings.Contains(l, "edg/"):
+		browser = "Edge"
+	case strings.Contains(l, "opr/") || strings.Contains(l, "opera"):
+		browser = "Opera"
+	case strings.Contains(l, "firefox/") || strings.Contains(l, "fxios/"):
+		browser = "Firefox"
+	case strings.Contains(l, "chrome/") || strings.Contains(l, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(l, "safari/"):
+		browser = "Safari"
+	case strings.Contains(l, "postman"):
+		browser = "Postman"
+	case strings.Contains(l, "curl/"):
+		browser = "curl"
+	default:
+		browser = "unknown"
+	}
+
+	switch {
+	case strings.Contains(l, "windows"):
+		os = "Windows"
+	case strings.Contains(l, "iphone") || strings.Contains(l, "ipad") || strings.Contains(l, "ios"):
+		os = "iOS"
+	case strings.Contains(l, "android"):
+		os = "Android"
+	case strings.Contains(l, "mac os") || strings.Contains(l, "macintosh"):
+		os = "macOS"
+	case strings.Contains(l, "linux"):
+		os = "Linux"
+	default:
+		os = "unknown"
+	}
+	return device, browser, os
+}
@@ -1,38 +1,200 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-var SecretKey = []byte("secret-key")
+// access tokens are verified locally by every service so they are kept short,
+// sessions are kept alive by rotating the refresh token stored in the sessions table
+const AccessTokenTTL = 15 * time.Minute
+const RefreshTokenTTL = 7 * 24 * time.Hour
 
-func CreateToken(username string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"username": username,
-			"exp":      time.Now().Add(time.Hour * 24).Unix(),
-		})
+// minimum length of a HS256 secret
+const minJWTSecretLength = 32
 
-	tokenString, err := token.SignedString(SecretKey)
+var ErrInvalidToken = errors.New("invalid token")
+
+// AccessClaims is what an access token carries, enough for a handler
+// to act on the user without looking them up
+type AccessClaims struct {
+	UserID    uuid.UUID `json:"uid"`
+	SessionID uuid.UUID `json:"sid"`
+	Role      string    `json:"role"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	// roles assigned to the user and the permissions they grant, read when the
+	// token is issued so changes apply from the next refresh
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"perms,omitempty"`
+	// set on tokens an admin got to act as the user
+	Impersonation *Impersonation `json:"imp,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Impersonation names the impersonation a token belongs to and the admin behind it
+type Impersonation struct {
+	ID      uuid.UUID `json:"id"`
+	ActorID uuid.UUID `json:"act"`
+}
+
+// HasRole is true for the account role and any role assigned on top of it
+func (c *AccessClaims) HasRole(role string) bool {
+	if c.Role == role {
+		return true
+	}
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *AccessClaims) HasPermission(permission string) bool {
+	for _, p := range c.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type jwtKeySet struct {
+	activeKID string
+	keys      map[string][]byte
+}
+
+var (
+	jwtKeys    jwtKeySet
+	jwtKeysErr error
+	jwtOnce    sync.Once
+)
+
+// loadJWTKeys reads JWT_KEYS as comma separated kid:secret pairs. tokens are signed
+// with the key named by JWT_ACTIVE_KID and verified with whichever key their kid
+// names, so a retired key can stay in the list until its tokens have expired
+func loadJWTKeys() (jwtKeySet, error) {
+	jwtOnce.Do(func() {
+		ks := jwtKeySet{
+			activeKID: os.Getenv(envname.JWTActiveKID),
+			keys:      make(map[string][]byte),
+		}
+		for _, pair := range strings.Split(os.Getenv(envname.JWTKeys), ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			kid, secret, ok := strings.Cut(pair, ":")
+			if !ok || kid == "" {
+				jwtKeysErr = fmt.Errorf("jwt: malformed key %q in %s, expected kid:secret", kid, envname.JWTKeys)
+				return
+			}
+			if len(secret) < minJWTSecretLength {
+				jwtKeysErr = fmt.Errorf("jwt: secret of key %s shorter than %d characters", kid, minJWTSecretLength)
+				return
+			}
+			ks.keys[kid] = []byte(secret)
+		}
+		if _, ok := ks.keys[ks.activeKID]; !ok {
+			jwtKeysErr = fmt.Errorf("jwt: active key %q not found in %s", ks.activeKID, envname.JWTKeys)
+			return
+		}
+		jwtKeys = ks
+	})
+	return jwtKeys, jwtKeysErr
+}
+
+// CreateAccessToken signs an access token for the user of the session with the active key
+func CreateAccessToken(userID, sessionID uuid.UUID, role, name, email string, roles, permissions []string) (string, time.Time, error) {
+	expiresAt := time.Now().Add(AccessTokenTTL)
+	token, err := signAccessToken(AccessClaims{
+		UserID:      userID,
+		SessionID:   sessionID,
+		Role:        role,
+		Name:        name,
+		Email:       email,
+		Roles:       roles,
+		Permissions: permissions,
+	}, expiresAt)
+	return token, expiresAt, err
+}
+
+// CreateImpersonationToken signs a token for the target user that carries the
+// impersonation, it has no session and can't be refreshed
+func CreateImpersonationToken(userID uuid.UUID, role, name, email string, roles, permissions []string, imp Impersonation, expiresAt time.Time) (string, error) {
+	return signAccessToken(AccessClaims{
+		UserID:        userID,
+		SessionID:     imp.ID,
+		Role:          role,
+		Name:          name,
+		Email:         email,
+		Roles:         roles,
+		Permissions:   permissions,
+		Impersonation: &imp,
+	}, expiresAt)
+}
+
+func signAccessToken(claims AccessClaims, expiresAt time.Time) (string, error) {
+	ks, err := loadJWTKeys()
 	if err != nil {
 		return "", err
 	}
-	return tokenString, nil
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		Subject:   claims.UserID.String(),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = ks.activeKID
+	return token.SignedString(ks.keys[ks.activeKID])
 }
 
-func VerifyToken(tokenString string) error {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return SecretKey, nil
-	})
+// VerifyAccessToken checks the signature and expiry of an access token, no network call is made
+func VerifyAccessToken(tokenString string) (*AccessClaims, error) {
+	ks, err := loadJWTKeys()
+	if err != nil {
+		return nil, err
+	}
+	var claims AccessClaims
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := ks.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if !token.Valid || claims.UserID == uuid.Nil {
+		return nil, ErrInvalidToken
 	}
-	if !token.Valid {
-		return fmt.Errorf("invalid token")
+	return &claims, nil
+}
+
+// GenerateRefreshToken returns a new opaque refresh token and the hash of it,
+// only the hash is stored so a leaked sessions table can't be used to log in
+func GenerateRefreshToken() (string, string, error) {
+	token, err := GenerateRandomString(32)
+	if err != nil {
+		return "", "", err
 	}
+	return token, HashRefreshToken(token), nil
+}
 
-	return nil
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,214 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	secretA = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	secretB = "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+)
+
+// setJWTKeys points the key set at new settings, the keys are otherwise read once per process
+func setJWTKeys(t *testing.T, keys, activeKID string) {
+	t.Helper()
+	t.Setenv(envname.JWTKeys, keys)
+	t.Setenv(envname.JWTActiveKID, activeKID)
+	jwtOnce = sync.Once{}
+	jwtKeys = jwtKeySet{}
+	jwtKeysErr = nil
+	t.Cleanup(func() {
+		jwtOnce = sync.Once{}
+		jwtKeys = jwtKeySet{}
+		jwtKeysErr = nil
+	})
+}
+
+func newAccessToken(t *testing.T, userID, sessionID uuid.UUID) string {
+	t.Helper()
+	token, _, err := CreateAccessToken(userID, sessionID, "user", "Test User", "test@example.com", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func tokenKID(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &AccessClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestAccessTokenRoundTrip(t *testing.T) {
+	setJWTKeys(t, "k1:"+secretA, "k1")
+	userID, sessionID := uuid.New(), uuid.New()
+	before := time.Now()
+	token, expiresAt, err := CreateAccessToken(userID, sessionID, "seller", "Test User", "test@example.com", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := expiresAt.Sub(before); d < AccessTokenTTL-time.Second || d > AccessTokenTTL+time.Second {
+		t.Errorf("token expires in %s, want %s", d, AccessTokenTTL)
+	}
+	if kid := tokenKID(t, token); kid != "k1" {
+		t.Errorf("kid = %q, want k1", kid)
+	}
+
+	claims, err := VerifyAccessToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims.UserID != userID || claims.SessionID != sessionID {
+		t.Errorf("claims carry user %s session %s, want %s %s", claims.UserID, claims.SessionID, userID, sessionID)
+	}
+	if claims.Role != "seller" || claims.Name != "Test User" || claims.Email != "test@example.com" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if claims.Subject != userID.String() {
+		t.Errorf("subject = %q, want %q", claims.Subject, userID.String())
+	}
+}
+
+func TestAccessTokenKeyRotation(t *testing.T) {
+	userID, sessionID := uuid.New(), uuid.New()
+
+	setJWTKeys(t, "k1:"+secretA, "k1")
+	oldToken := newAccessToken(t, userID, sessionID)
+
+	// k2 is added and made active, k1 stays around to verify tokens it signed
+	setJWTKeys(t, "k1:"+secretA+", k2:"+secretB, "k2")
+	newToken := newAccessToken(t, userID, sessionID)
+	if kid := tokenKID(t, newToken); kid != "k2" {
+		t.Errorf("kid after rotation = %q, want k2", kid)
+	}
+	for name, token := range map[string]string{"old": oldToken, "new": newToken} {
+		if _, err := VerifyAccessToken(token); err != nil {
+			t.Errorf("%s token rejected during rotation: %v", name, err)
+		}
+	}
+
+	// k1 retired
+	setJWTKeys(t, "k2:"+secretB, "k2")
+	if _, err := VerifyAccessToken(oldToken); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token of a retired key error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := VerifyAccessToken(newToken); err != nil {
+		t.Errorf("token of the active key rejected: %v", err)
+	}
+}
+
+func TestVerifyAccessTokenRejects(t *testing.T) {
+	setJWTKeys(t, "k1:"+secretA, "k1")
+	userID := uuid.New()
+	sign := func(method jwt.SigningMethod, kid string, key any, claims AccessClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		s, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	valid := func() AccessClaims {
+		return AccessClaims{
+			UserID: userID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Subject:   userID.String(),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			},
+		}
+	}
+	expired := valid()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	noExpiry := valid()
+	noExpiry.ExpiresAt = nil
+	noUser := valid()
+	noUser.UserID = uuid.Nil
+
+	good := sign(jwt.SigningMethodHS256, "k1", []byte(secretA), valid())
+	parts := strings.Split(good, ".")
+	tampered := parts[0] + "." + strings.TrimRight(parts[1], "=") + "x." + parts[2]
+
+	tests := map[string]string{
+		"unknown kid":  sign(jwt.SigningMethodHS256, "k9", []byte(secretA), valid()),
+		"no kid":       sign(jwt.SigningMethodHS256, "", []byte(secretA), valid()),
+		"wrong secret": sign(jwt.SigningMethodHS256, "k1", []byte(secretB), valid()),
+		"other hmac":   sign(jwt.SigningMethodHS512, "k1", []byte(secretA), valid()),
+		"alg none":     sign(jwt.SigningMethodNone, "k1", jwt.UnsafeAllowNoneSignatureType, valid()),
+		"expired":      sign(jwt.SigningMethodHS256, "k1", []byte(secretA), expired),
+		"no expiry":    sign(jwt.SigningMethodHS256, "k1", []byte(secretA), noExpiry),
+		"no user":      sign(jwt.SigningMethodHS256, "k1", []byte(secretA), noUser),
+		"tampered":     tampered,
+		"garbage":      "not.a.token",
+		"empty":        "",
+	}
+	for name, token := range tests {
+		if _, err := VerifyAccessToken(token); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: error = %v, want ErrInvalidToken", name, err)
+		}
+	}
+	if _, err := VerifyAccessToken(good); err != nil {
+		t.Errorf("valid token rejected: %v", err)
+	}
+}
+
+func TestLoadJWTKeysErrors(t *testing.T) {
+	tests := map[string][2]string{
+		"malformed pair": {"k1" + secretA, "k1"},
+		"empty kid":      {":" + secretA, "k1"},
+		"short secret":   {"k1:short", "k1"},
+		"unknown active": {"k1:" + secretA, "k2"},
+		"no keys":        {"", "k1"},
+	}
+	for name, tt := range tests {
+		setJWTKeys(t, tt[0], tt[1])
+		if _, _, err := CreateAccessToken(uuid.New(), uuid.New(), "user", "", "", nil, nil); err == nil {
+			t.Errorf("%s: CreateAccessToken succeeded", name)
+		}
+		if _, err := VerifyAccessToken("x.y.z"); err == nil || errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: VerifyAccessToken error = %v, want the key error", name, err)
+		}
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	token, hash, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token == "" || hash == token {
+		t.Fatalf("GenerateRefreshToken = %q %q", token, hash)
+	}
+	if hash != HashRefreshToken(token) {
+		t.Error("hash of the token doesn't match HashRefreshToken")
+	}
+	if len(hash) != 64 {
+		t.Errorf("hash is %d characters, want a hex sha256", len(hash))
+	}
+
+	// the rotated token has to differ or the reuse check could never tell them apart
+	next, nextHash, err := GenerateRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next == token || nextHash == hash {
+		t.Error("two refresh tokens are the same")
+	}
+	if HashRefreshToken(token+"x") == hash {
+		t.Error("different tokens hash the same")
+	}
+}
@@ -37,3 +37,32 @@ const CouponDiscountTypePercentage = "percentage"
 const CouponDiscountTypeFlat = "flat"
 
 const EcomName = "Toy Stores Ecom"
+
+const AttributeTypeText = "text"
+const AttributeTypeNumber = "number"
+const AttributeTypeBoolean = "boolean"
+const AttributeTypeEnum = "enum"
+
+const StatusImportJobPending = "pending"
+const StatusImportJobProcessing = "processing"
+const StatusImportJobCompleted = "completed"
+const StatusImportJobFailed = "failed"
+
+const ReviewStatusVisible = "visible"
+const ReviewStatusFlagged = "flagged"
+const ReviewStatusHidden = "hidden"
+
+const OnboardingStatusDraft = "draft"
+const OnboardingStatusSubmitted = "submitted"
+const OnboardingStatusApproved = "approved"
+const OnboardingStatusRejected = "rejected"
+
+const SellerDocumentGSTCertificate = "gst_certificate"
+const SellerDocumentPAN = "pan"
+const SellerDocumentBankProof = "bank_proof"
+
+const DocumentStatusPending = "pending"
+const DocumentStatusApproved = "approved"
+const DocumentStatusRejected = "rejected"
+
+const IdentityProviderGoogle = "google"
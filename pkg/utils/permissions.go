@@ -0,0 +1,52 @@
+package utils
+
+// permissions are resource:action pairs granted to roles in the user service
+// database, admin routes are guarded by them instead of by the account role
+const PermUsersRead = "users:read"
+const PermUsersBlock = "users:block"
+const PermUsersImpersonate = "users:impersonate"
+const PermSellersReview = "sellers:review"
+const PermRolesRead = "roles:read"
+const PermRolesWrite = "roles:write"
+const PermSecurityWrite = "security:write"
+const PermProductsModerate = "products:moderate"
+const PermReviewsModerate = "reviews:moderate"
+const PermCategoriesWrite = "categories:write"
+const PermOrdersRead = "orders:read"
+const PermOrdersDeliver = "orders:deliver"
+const PermCouponsRead = "coupons:read"
+const PermCouponsWrite = "coupons:write"
+const PermReportsRead = "reports:read"
+const PermAuditRead = "audit:read"
+
+// staff roles an admin account can be given besides admin, which has every permission
+const SupportAgentRole = "support_agent"
+const FinanceAdminRole = "finance_admin"
+
+var Permissions = []string{
+	PermUsersRead,
+	PermUsersBlock,
+	PermUsersImpersonate,
+	PermSellersReview,
+	PermRolesRead,
+	PermRolesWrite,
+	PermSecurityWrite,
+	PermProductsModerate,
+	PermReviewsModerate,
+	PermCategoriesWrite,
+	PermOrdersRead,
+	PermOrdersDeliver,
+	PermCouponsRead,
+	PermCouponsWrite,
+	PermReportsRead,
+	PermAuditRead,
+}
+
+func IsPermission(p string) bool {
+	for _, perm := range Permissions {
+		if perm == p {
+			return true
+		}
+	}
+	return false
+}
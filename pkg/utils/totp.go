@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP as in RFC 6238 with the defaults every authenticator app understands,
+// HMAC-SHA1, 6 digits and a 30 second step
+const TOTPPeriod = 30
+const totpDigits = 6
+
+// codes from one step before and after are accepted to allow for clock drift
+const totpSkew = 1
+
+const RecoveryCodeCount = 10
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a new base32 encoded 160 bit secret
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPProvisioningURI is the otpauth:// uri authenticator apps read from a QR code
+func TOTPProvisioningURI(issuer, account, secret string) string {
+	// spaces are escaped as %20, some apps show a + from query escaping as is
+	label := url.PathEscape(issuer + ":" + account)
+	return fmt.Sprintf("otpauth://totp/%s?secret=%s&issuer=%s&algorithm=SHA1&digits=%d&period=%d",
+		label, secret, url.PathEscape(issuer), totpDigits, TOTPPeriod)
+}
+
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	// dynamic truncation, RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	bin := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, bin%1000000)
+}
+
+// ValidateTOTP checks a code against the secret at time t and returns the step it
+// matched. steps up to lastStep are refused so a code can't be used twice
+func ValidateTOTP(secret, code string, t time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	current := t.Unix() / TOTPPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns RecoveryCodeCount single use codes like "k3xq-9fzt-2mbw"
+// along with their hashes, only the hashes are stored
+func GenerateRecoveryCodes() ([]string, []string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	codes := make([]string, 0, RecoveryCodeCount)
+	hashes := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		var sb strings.Builder
+		for j := 0; j < 12; j++ {
+			if j > 0 && j%4 == 0 {
+				sb.WriteByte('-')
+			}
+			// rand.Int is uniform, a byte modulo the alphabet would favour its first letters
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+			if err != nil {
+				return nil, nil, err
+			}
+			sb.WriteByte(alphabet[n.Int64()])
+		}
+		codes = append(codes, sb.String())
+		hashes = append(hashes, HashRecoveryCode(sb.String()))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode ignores case, spaces and dashes so codes can be typed loosely
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
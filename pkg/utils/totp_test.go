@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B uses the ascii key "12345678901234567890" for SHA1
+var rfcSecret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	// the RFC lists 8 digit codes, a 6 digit code is the last 6 of them
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	key := []byte("12345678901234567890")
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/TOTPPeriod); got != tt.want {
+			t.Errorf("totpCode at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+		step, ok := ValidateTOTP(rfcSecret, tt.want, time.Unix(tt.unix, 0), 0)
+		if !ok || step != tt.unix/TOTPPeriod {
+			t.Errorf("ValidateTOTP at %d = (%d, %v), want (%d, true)", tt.unix, step, ok, tt.unix/TOTPPeriod)
+		}
+	}
+}
+
+func TestValidateTOTPSkew(t *testing.T) {
+	// 1111111111 is step 37037037, its code is 050471
+	const code = "050471"
+	const step = int64(1111111111 / TOTPPeriod)
+	base := time.Unix(step*TOTPPeriod, 0)
+
+	tests := []struct {
+		name     string
+		at       time.Time
+		lastStep int64
+		code     string
+		want     bool
+	}{
+		{"same step", base, 0, code, true},
+		{"one step late", base.Add(TOTPPeriod * time.Second), 0, code, true},
+		{"one step early", base.Add(-TOTPPeriod * time.Second), 0, code, true},
+		{"two steps late", base.Add(2 * TOTPPeriod * time.Second), 0, code, false},
+		{"two steps early", base.Add(-2 * TOTPPeriod * time.Second), 0, code, false},
+		{"already used", base, step, code, false},
+		{"older step used", base, step - 1, code, true},
+		{"surrounding spaces", base, 0, " " + code + " ", true},
+		{"wrong code", base, 0, "123456", false},
+		{"short code", base, 0, "05047", false},
+		{"empty code", base, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ValidateTOTP(rfcSecret, tt.code, tt.at, tt.lastStep)
+			if ok != tt.want {
+				t.Fatalf("ValidateTOTP ok = %v, want %v", ok, tt.want)
+			}
+			if ok && got != step {
+				t.Errorf("ValidateTOTP step = %d, want %d", got, step)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPSecret(t *testing.T) {
+	at := time.Unix(59, 0)
+	// secrets are typed by hand sometimes, lower case still decodes
+	if _, ok := ValidateTOTP(strings.ToLower(rfcSecret), "287082", at, 0); !ok {
+		t.Error("lower case secret refused")
+	}
+	if _, ok := ValidateTOTP("not base32!", "287082", at, 0); ok {
+		t.Error("invalid secret accepted")
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) != 20 {
+		t.Fatalf("secret %q decodes to %d bytes, err %v", secret, len(key), err)
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != RecoveryCodeCount || len(hashes) != RecoveryCodeCount {
+		t.Fatalf("got %d codes and %d hashes, want %d", len(codes), len(hashes), RecoveryCodeCount)
+	}
+	format := regexp.MustCompile(`^[a-hjkmnp-z2-9]{4}-[a-hjkmnp-z2-9]{4}-[a-hjkmnp-z2-9]{4}$`)
+	seen := make(map[string]bool)
+	for i, c := range codes {
+		if !format.MatchString(c) {
+			t.Errorf("code %q has the wrong format", c)
+		}
+		if seen[c] {
+			t.Errorf("code %q repeated", c)
+		}
+		seen[c] = true
+		if hashes[i] != HashRecoveryCode(c) {
+			t.Errorf("hash of %q doesn't match", c)
+		}
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("k3xq-9fzt-2mbw")
+	for _, typed := range []string{"K3XQ-9FZT-2MBW", "k3xq 9fzt 2mbw", "k3xq9fzt2mbw"} {
+		if got := HashRecoveryCode(typed); got != want {
+			t.Errorf("HashRecoveryCode(%q) differs from the canonical code", typed)
+		}
+	}
+	if HashRecoveryCode("k3xq-9fzt-2mbx") == want {
+		t.Error("different codes hash the same")
+	}
+}
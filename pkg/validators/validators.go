@@ -2,7 +2,10 @@ package validators
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
 	"github.com/google/uuid"
 )
 
@@ -22,6 +25,9 @@ var (
 	couponNameRegex = regexp.MustCompile(`^[A-Z0-9]{3,}$`)
 
 	reviewRatingRegex = regexp.MustCompile(`^[1-5]$`)
+
+	slugRegex          = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	attributeNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]{1,39}$`)
 )
 
 func ValidateUUIDStr(uuidStr string) bool {
@@ -94,3 +100,54 @@ func ValidateCouponPrice(price float64) bool {
 func ValidateReviewRating(rating string) bool {
 	return reviewRatingRegex.MatchString(rating)
 }
+
+// category validators
+func ValidateSlug(slug string) bool {
+	return slugRegex.MatchString(slug)
+}
+
+func ValidateAttributeName(name string) bool {
+	return attributeNameRegex.MatchString(name)
+}
+
+func ValidateAttributeType(dataType string) bool {
+	switch dataType {
+	case utils.AttributeTypeText, utils.AttributeTypeNumber, utils.AttributeTypeBoolean, utils.AttributeTypeEnum:
+		return true
+	}
+	return false
+}
+
+// ValidateAttributeValue checks value against the attribute type and returns
+// the value in the form it is stored in, eg. "Yes" -> "true" for booleans
+func ValidateAttributeValue(dataType string, options []string, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > 100 {
+		return "", false
+	}
+	switch dataType {
+	case utils.AttributeTypeText:
+		return value, true
+	case utils.AttributeTypeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", false
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	case utils.AttributeTypeBoolean:
+		switch strings.ToLower(value) {
+		case "true", "yes", "1":
+			return "true", true
+		case "false", "no", "0":
+			return "false", true
+		}
+		return "", false
+	case utils.AttributeTypeEnum:
+		for _, o := range options {
+			if strings.EqualFold(o, value) {
+				return o, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,390 @@
+package user_service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/grpcclient"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/inventorypb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/paymentpb"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// the data export and account deletion ask the other services for their part
+var inventoryClient = grpcclient.NewInventoryClient()
+var paymentClient = grpcclient.NewPaymentClient()
+
+// how long a deletion can be cancelled before the account is anonymised
+const accountDeletionGrace = 30 * 24 * time.Hour
+
+const accountDeletionBatch = 50
+
+// a deletion the payment service refuses waits a day for the orders to arrive,
+// one that failed is retried after an hour, doubled on every failure up to a day
+const accountDeletionPostpone = 24 * time.Hour
+const accountDeletionRetry = time.Hour
+
+type exportFile struct {
+	Name string
+	Data []byte
+}
+
+// the profile, addresses and wallet of the user from this service
+func localExportFiles(q *db.Queries, userID uuid.UUID) ([]exportFile, error) {
+	user, err := q.GetUserById(context.TODO(), userID)
+	if err != nil {
+		return nil, err
+	}
+	profile := struct {
+		ID            uuid.UUID `json:"id"`
+		Name          string    `json:"name"`
+		Email         string    `json:"email"`
+		Phone         *int64    `json:"phone"`
+		PhoneVerified bool      `json:"phone_verified"`
+		Role          string    `json:"role"`
+		EmailVerified bool      `json:"email_verified"`
+	}{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		PhoneVerified: user.PhoneVerified,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+	}
+	if user.Phone.Valid {
+		profile.Phone = &user.Phone.Int64
+	}
+
+	rows, err := q.GetAddressesByUserID(context.TODO(), userID)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []addressData{}
+	for _, a := range rows {
+		addresses = append(addresses, newAddressData(a))
+	}
+	wallet, err := q.GetWalletByUserID(context.TODO(), userID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	walletResp := struct {
+		Savings float64 `json:"savings"`
+	}{wallet.Savings}
+
+	var files []exportFile
+	for _, f := range []struct {
+		name string
+		data any
+	}{
+		{"profile.json", profile},
+		{"addresses.json", addresses},
+		{"wallet.json", walletResp},
+	} {
+		b, err := json.MarshalIndent(f.data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, exportFile{Name: f.name, Data: b})
+	}
+	return files, nil
+}
+
+// download everything the services keep about the user as a zip of json files
+func (u *User) ExportAccountHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	// the export is the user's personal data, not something support needs to see
+	if refuseImpersonation(w, r) {
+		return
+	}
+
+	files, err := localExportFiles(u.DB, user.ID)
+	if err != nil {
+		log.Warn("error gathering user data in ExportAccountHandler:", err.Error())
+		http.Error(w, "internal error gathering account data", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 30*time.Second)
+	defer cancel()
+	payments, err := paymentClient.ExportUserData(ctx, &paymentpb.ExportUserDataRequest{UserID: user.ID.String()})
+	if err != nil {
+		log.Warn("error fetching payment service data in ExportAccountHandler:", err.Error())
+		http.Error(w, "internal error gathering order data", http.StatusInternalServerError)
+		return
+	}
+	for _, f := range payments.GetFiles() {
+		files = append(files, exportFile{Name: f.GetName(), Data: f.GetData()})
+	}
+	inventory, err := inventoryClient.ExportUserData(ctx, &inventorypb.ExportUserDataRequest{UserId: user.ID.String()})
+	if err != nil {
+		log.Warn("error fetching inventory service data in ExportAccountHandler:", err.Error())
+		http.Error(w, "internal error gathering review and wishlist data", http.StatusInternalServerError)
+		return
+	}
+	for _, f := range inventory.GetFiles() {
+		files = append(files, exportFile{Name: f.GetName(), Data: f.GetData()})
+	}
+
+	// build the whole zip first so a failure can still be answered with an error
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := zw.Create(f.Name)
+		if err == nil {
+			_, err = fw.Write(f.Data)
+		}
+		if err != nil {
+			log.Warn("error writing export zip in ExportAccountHandler:", err.Error())
+			http.Error(w, "internal error building export", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err = zw.Close(); err != nil {
+		log.Warn("error closing export zip in ExportAccountHandler:", err.Error())
+		http.Error(w, "internal error building export", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%s-%s.zip"`, user.ID, time.Now().Format("20060102")))
+	w.Write(buf.Bytes())
+}
+
+type respAccountDeletion struct {
+	RequestedAt time.Time `json:"requested_at"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// schedule the account for deletion after the grace period, the password confirms it
+func (u *User) DeleteAccountHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	if refuseImpersonation(w, r) {
+		return
+	}
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password == "" {
+		http.Error(w, "password required to delete the account", http.StatusBadRequest)
+		return
+	}
+
+	// wrong passwords count as failed logins of the account
+	if locked(w, loginAccountLimiter, user.Email) {
+		return
+	}
+	account, err := u.DB.GetUserWithPasswordByEmail(context.TODO(), user.Email)
+	if err != nil {
+		log.Warn("error fetching user in DeleteAccountHandler:", err.Error())
+		http.Error(w, "internal error fetching user", http.StatusInternalServerError)
+		return
+	}
+	if err = utils.ComparePassword(req.Password, account.Password); err != nil {
+		recordFailure(loginAccountLimiter, user.Email)
+		http.Error(w, "wrong password", http.StatusUnauthorized)
+		return
+	}
+
+	deletion, err := u.DB.AddAccountDeletion(context.TODO(), db.AddAccountDeletionParams{
+		UserID:      user.ID,
+		ScheduledAt: time.Now().Add(accountDeletionGrace),
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "account deletion already scheduled. see GET /user/account/deletion", http.StatusConflict)
+		return
+	} else if err != nil {
+		log.Warn("error scheduling account deletion in DeleteAccountHandler:", err.Error())
+		http.Error(w, "internal error scheduling account deletion", http.StatusInternalServerError)
+		return
+	}
+
+	err = mail.SendNotificationMail("Your account is scheduled for deletion",
+		fmt.Sprintf("Your account and its personal data will be deleted on %s. Orders and payments are kept without your personal details for our accounts. Any wallet balance left then is forfeited. Log in and cancel the deletion before that date if you change your mind.",
+			deletion.ScheduledAt.Format("02 Jan 2006")),
+		user.Email)
+	if err != nil {
+		log.Warn("error mailing deletion notice in DeleteAccountHandler:", err.Error())
+	}
+
+	var resp struct {
+		Message  string              `json:"message"`
+		Deletion respAccountDeletion `json:"deletion"`
+	}
+	resp.Message = "account scheduled for deletion. cancel it with DELETE /user/account/deletion before the scheduled date"
+	resp.Deletion = respAccountDeletion{RequestedAt: deletion.RequestedAt, ScheduledAt: deletion.ScheduledAt}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// the pending deletion of the account, if any
+func (u *User) AccountDeletionHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	deletion, err := u.DB.GetAccountDeletionByUserID(context.TODO(), user.ID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no account deletion scheduled", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching account deletion in AccountDeletionHandler:", err.Error())
+		http.Error(w, "internal error fetching account deletion", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(respAccountDeletion{RequestedAt: deletion.RequestedAt, ScheduledAt: deletion.ScheduledAt})
+}
+
+// cancel the pending deletion during the grace period
+func (u *User) CancelAccountDeletionHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	if refuseImpersonation(w, r) {
+		return
+	}
+	n, err := u.DB.CancelAccountDeletion(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error cancelling account deletion in CancelAccountDeletionHandler:", err.Error())
+		http.Error(w, "internal error cancelling account deletion", http.StatusInternalServerError)
+		return
+	}
+	if n == 0 {
+		http.Error(w, "no account deletion scheduled", http.StatusNotFound)
+		return
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("account deletion cancelled"))
+}
+
+// eraseAccount removes the personal data of the user from every service and
+// anonymises the users row. each step can run again so a failed run is retried
+// by the next one
+func eraseAccount(userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+
+	// the payment service refuses while orders are on the way, the deletion
+	// then waits for the next run
+	_, err := paymentClient.EraseUserData(ctx, &paymentpb.EraseUserDataRequest{UserID: userID.String()})
+	if err != nil {
+		return fmt.Errorf("payment service: %w", err)
+	}
+	_, err = inventoryClient.EraseUserData(ctx, &inventorypb.EraseUserDataRequest{UserId: userID.String()})
+	if err != nil {
+		return fmt.Errorf("inventory service: %w", err)
+	}
+	// the kyc files go before their rows, a run that fails here still finds them
+	documents, err := DB.GetSellerDocumentsBySellerID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, d := range documents {
+		if err = documentStore.Delete(ctx, d.FileKey); err != nil {
+			return fmt.Errorf("document storage: %w", err)
+		}
+	}
+
+	tx, err := dbConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := DB.WithTx(tx)
+	for _, erase := range []func(context.Context, uuid.UUID) error{
+		qtx.DeleteAddressesByUserID,
+		qtx.DeleteIdentitiesByUserID,
+		qtx.DeleteNotificationsByUserID,
+		qtx.DeleteOTPsByUserID,
+		qtx.DeleteForgotOTPsByUserID,
+		qtx.DeleteSellerDocumentsBySellerID,
+		qtx.DeleteSellerOnboardingBySellerID,
+		// before the email and the email changes it is matched by are gone
+		qtx.DeleteMailOutboxByUserID,
+		qtx.DeleteEmailChangesByUserID,
+		qtx.DeletePhoneVerificationsByUserID,
+		qtx.DeleteUserTOTP,
+		qtx.DeleteRecoveryCodesByUserID,
+		qtx.AnonymizeUserByID,
+		qtx.CompleteAccountDeletion,
+	} {
+		if err = erase(ctx, userID); err != nil {
+			return err
+		}
+	}
+	if _, err = qtx.RevokeSessionsByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	auditLog.RecordSystem(ctx, audit.Entry{
+		Action:     audit.ActionUserErase,
+		EntityType: audit.EntityUser,
+		EntityID:   userID.String(),
+	})
+	return nil
+}
+
+// accountDeletionBackoff is the wait after a failed erase, attempts is how many
+// runs were put off before this one
+func accountDeletionBackoff(attempts int32) time.Duration {
+	wait := accountDeletionRetry
+	for i := int32(0); i < attempts && wait < accountDeletionPostpone; i++ {
+		wait *= 2
+	}
+	return min(wait, accountDeletionPostpone)
+}
+
+// AccountDeletionCron anonymises the accounts whose grace period ran out, hourly
+func AccountDeletionCron() {
+	for {
+		due, err := DB.GetDueAccountDeletions(context.TODO(), accountDeletionBatch)
+		if err != nil {
+			log.Error("error fetching due account deletions in AccountDeletionCron:", err.Error())
+		}
+		for _, d := range due {
+			err = eraseAccount(d.UserID)
+			if err == nil {
+				log.Infof("deleted account %s", d.UserID)
+				continue
+			}
+			wait := accountDeletionPostpone
+			if status.Code(err) == codes.FailedPrecondition {
+				log.Infof("postponing deletion of account %s: %s", d.UserID, err.Error())
+			} else {
+				log.Errorf("error deleting account %s in AccountDeletionCron: %s", d.UserID, err.Error())
+				wait = accountDeletionBackoff(d.Attempts)
+			}
+			err = DB.PostponeAccountDeletion(context.TODO(), db.PostponeAccountDeletionParams{
+				UserID:        d.UserID,
+				NextAttemptAt: sql.NullTime{Time: time.Now().Add(wait), Valid: true},
+			})
+			if err != nil {
+				log.Error("error postponing account deletion in AccountDeletionCron:", err.Error())
+			}
+		}
+		time.Sleep(time.Hour)
+	}
+}
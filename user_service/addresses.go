@@ -0,0 +1,463 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pincode"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// pincodes the addresses are checked against, loaded from PINCODE_DATA
+var pincodes = loadPincodes()
+
+func loadPincodes() *pincode.Directory {
+	path := os.Getenv(envname.PincodeData)
+	if path == "" {
+		log.Warn("PINCODE_DATA not set, addresses only get the pincode range check")
+		return nil
+	}
+	d, err := pincode.LoadFile(path)
+	if err != nil {
+		log.Fatal("error loading pincode data: ", err)
+	}
+	log.Info("loaded ", d.Len(), " pincodes from ", path)
+	return d
+}
+
+var addressLabels = map[string]bool{"home": true, "work": true, "other": true}
+
+type addressRequest struct {
+	ID           uuid.UUID `json:"id"`
+	Label        string    `json:"label"`
+	ContactName  string    `json:"contact_name"`
+	ContactPhone string    `json:"contact_phone"`
+	BuildingName string    `json:"building_name"`
+	StreetName   string    `json:"street_name"`
+	Town         string    `json:"town"`
+	District     string    `json:"district"`
+	State        string    `json:"state"`
+	Pincode      int32     `json:"pincode"`
+	Latitude     *float64  `json:"latitude"`
+	Longitude    *float64  `json:"longitude"`
+	// left out on edit keeps the address' current flag
+	DefaultShipping *bool `json:"default_shipping"`
+	DefaultBilling  *bool `json:"default_billing"`
+}
+
+// validate checks the request and fills an empty district or state from the pincode
+// data, a state not matching the pincode is rejected. districts are only filled in,
+// the dataset names them by postal division and users often know them otherwise
+func (req *addressRequest) validate() []string {
+	var Err []string
+	req.District = strings.TrimSpace(req.District)
+	req.State = strings.TrimSpace(req.State)
+	if !validators.ValidatePincode(int(req.Pincode)) {
+		Err = append(Err, "invalid pincode")
+	} else if pincodes.Len() > 0 {
+		place, ok := pincodes.Lookup(int(req.Pincode))
+		if !ok {
+			Err = append(Err, "unknown pincode")
+		} else {
+			if req.District == "" {
+				req.District = place.District
+			}
+			if req.State == "" {
+				req.State = place.State
+			} else if !pincode.SameName(req.State, place.State) {
+				Err = append(Err, "pincode "+strconv.Itoa(int(req.Pincode))+" is in "+place.State+", not "+req.State)
+			}
+		}
+	}
+	if !validators.ValidateAddress(req.BuildingName) {
+		Err = append(Err, "invalid building name")
+	}
+	if !validators.ValidateAddress(req.StreetName) {
+		Err = append(Err, "invalid street name")
+	}
+	if !validators.ValidateAddress(req.Town) {
+		Err = append(Err, "invalid town name")
+	}
+	if !validators.ValidateAddress(req.District) {
+		Err = append(Err, "invalid district name")
+	}
+	if !validators.ValidateAddress(req.State) {
+		Err = append(Err, "invalid state name")
+	}
+	if req.Label != "" && !addressLabels[req.Label] {
+		Err = append(Err, "invalid label, use home, work or other")
+	}
+	if req.ContactName != "" && !validators.ValidateName(req.ContactName) {
+		Err = append(Err, "invalid contact name")
+	}
+	if req.ContactPhone != "" && !validators.ValidatePhone(req.ContactPhone) {
+		Err = append(Err, "invalid contact phone")
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		Err = append(Err, "latitude and longitude go together")
+	} else if req.Latitude != nil && (*req.Latitude < -90 || *req.Latitude > 90 || *req.Longitude < -180 || *req.Longitude > 180) {
+		Err = append(Err, "invalid latitude or longitude")
+	}
+	return Err
+}
+
+func (req *addressRequest) contactName() sql.NullString {
+	return sql.NullString{String: req.ContactName, Valid: req.ContactName != ""}
+}
+
+// validate has checked the phone already
+func (req *addressRequest) contactPhone() sql.NullInt64 {
+	phone, err := strconv.ParseInt(req.ContactPhone, 10, 64)
+	return sql.NullInt64{Int64: phone, Valid: req.ContactPhone != "" && err == nil}
+}
+
+func (req *addressRequest) latitude() sql.NullFloat64 {
+	if req.Latitude == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *req.Latitude, Valid: true}
+}
+
+func (req *addressRequest) longitude() sql.NullFloat64 {
+	if req.Longitude == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *req.Longitude, Valid: true}
+}
+
+// addressData is an address as the handlers and the account export show it
+type addressData struct {
+	ID              uuid.UUID `json:"id"`
+	Label           string    `json:"label"`
+	ContactName     *string   `json:"contact_name"`
+	ContactPhone    *int64    `json:"contact_phone"`
+	BuildingName    string    `json:"building_name"`
+	StreetName      string    `json:"street_name"`
+	Town            string    `json:"town"`
+	District        string    `json:"district"`
+	State           string    `json:"state"`
+	Pincode         int32     `json:"pincode"`
+	Latitude        *float64  `json:"latitude"`
+	Longitude       *float64  `json:"longitude"`
+	DefaultShipping bool      `json:"default_shipping"`
+	DefaultBilling  bool      `json:"default_billing"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func newAddressData(a db.Address) addressData {
+	data := addressData{
+		ID:              a.ID,
+		Label:           a.Label,
+		BuildingName:    a.BuildingName,
+		StreetName:      a.StreetName,
+		Town:            a.Town,
+		District:        a.District,
+		State:           a.State,
+		Pincode:         a.Pincode,
+		DefaultShipping: a.IsDefaultShipping,
+		DefaultBilling:  a.IsDefaultBilling,
+		CreatedAt:       a.CreatedAt,
+		UpdatedAt:       a.UpdatedAt,
+	}
+	if a.ContactName.Valid {
+		data.ContactName = &a.ContactName.String
+	}
+	if a.ContactPhone.Valid {
+		data.ContactPhone = &a.ContactPhone.Int64
+	}
+	if a.Latitude.Valid {
+		data.Latitude = &a.Latitude.Float64
+		data.Longitude = &a.Longitude.Float64
+	}
+	return data
+}
+
+// the address handlers of users and sellers share these, sellers keep a single address
+
+func getAddresses(w http.ResponseWriter, q *db.Queries, userID uuid.UUID) {
+	addresses, err := q.GetAddressesByUserID(context.TODO(), userID)
+	if err != nil {
+		log.Warn("error fetching addresses in GetAddressesHandler:", err.Error())
+		http.Error(w, "internal server error fetching addresses by userID", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Data    []addressData `json:"data"`
+		Message string        `json:"message"`
+	}
+	resp.Data = []addressData{}
+	for _, a := range addresses {
+		resp.Data = append(resp.Data, newAddressData(a))
+	}
+	resp.Message = "successfully fetched all address of the user"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// the first address of a user becomes both defaults, a later one only takes
+// the defaults asked for in the request
+func addAddress(w http.ResponseWriter, r *http.Request, q *db.Queries, userID uuid.UUID, role string) {
+	var req addressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request data format", http.StatusBadRequest)
+		return
+	}
+	if Err := req.validate(); len(Err) > 0 {
+		http.Error(w, strings.Join(Err, "\n"), http.StatusBadRequest)
+		return
+	}
+	if req.Label == "" {
+		req.Label = "home"
+	}
+	if role == utils.SellerRole {
+		addresses, err := q.GetAddressesByUserID(context.TODO(), userID)
+		if err != nil {
+			log.Warn("error fetching seller addresses in AddAddressHandler:", err.Error())
+			http.Error(w, "internal error fetching address for seller to check if the seller already has an address", http.StatusInternalServerError)
+			return
+		}
+		if len(addresses) > 0 {
+			http.Error(w, "seller already has an address. Cannot add another address", http.StatusUnauthorized)
+			return
+		}
+	}
+	defaultShipping := req.DefaultShipping != nil && *req.DefaultShipping
+	defaultBilling := req.DefaultBilling != nil && *req.DefaultBilling
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in AddAddressHandler:", err.Error())
+		http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := q.WithTx(tx)
+	if defaultShipping {
+		if err = qtx.ClearDefaultShippingAddress(context.TODO(), userID); err != nil {
+			log.Warn("error clearing default shipping address in AddAddressHandler:", err.Error())
+			http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+			return
+		}
+	}
+	if defaultBilling {
+		if err = qtx.ClearDefaultBillingAddress(context.TODO(), userID); err != nil {
+			log.Warn("error clearing default billing address in AddAddressHandler:", err.Error())
+			http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+			return
+		}
+	}
+	added, err := qtx.AddAddressByUserID(context.TODO(), db.AddAddressByUserIDParams{
+		UserID:            userID,
+		Type:              role,
+		BuildingName:      req.BuildingName,
+		StreetName:        req.StreetName,
+		Town:              req.Town,
+		District:          req.District,
+		State:             req.State,
+		Pincode:           req.Pincode,
+		Label:             req.Label,
+		ContactName:       req.contactName(),
+		ContactPhone:      req.contactPhone(),
+		Latitude:          req.latitude(),
+		Longitude:         req.longitude(),
+		IsDefaultShipping: defaultShipping,
+		IsDefaultBilling:  defaultBilling,
+	})
+	if err != nil {
+		log.Warn("error adding valid user address in AddAddressHandler:", err.Error())
+		http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+		return
+	}
+	if err = qtx.SetMissingDefaultAddresses(context.TODO(), userID); err != nil {
+		log.Warn("error setting default addresses in AddAddressHandler:", err.Error())
+		http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+		return
+	}
+	added, err = qtx.GetAddressByID(context.TODO(), added.ID)
+	if err != nil {
+		log.Warn("error fetching added address in AddAddressHandler:", err.Error())
+		http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing in AddAddressHandler:", err.Error())
+		http.Error(w, "internal server error adding user address", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Data    addressData `json:"address"`
+		Message string      `json:"message"`
+	}
+	resp.Data = newAddressData(added)
+	resp.Message = "successfully added address"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// edit replaces the address, except the label and default flags which are kept when
+// left out. a default moves by setting it on another address, it can't be unset
+func editAddress(w http.ResponseWriter, r *http.Request, q *db.Queries, userID uuid.UUID) {
+	var req addressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "wrong request format", http.StatusBadRequest)
+		return
+	}
+	if Err := req.validate(); len(Err) > 0 {
+		http.Error(w, strings.Join(Err, "\n"), http.StatusBadRequest)
+		return
+	}
+
+	address, err := q.GetAddressByID(context.TODO(), req.ID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid addressID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching address in EditAddressHandler:", err.Error())
+		http.Error(w, "internal error fetching address", http.StatusInternalServerError)
+		return
+	} else if address.UserID != userID {
+		http.Error(w, "not the current user's address to change; unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if req.Label == "" {
+		req.Label = address.Label
+	}
+	if req.DefaultShipping != nil && !*req.DefaultShipping && address.IsDefaultShipping {
+		http.Error(w, "set another address as the default shipping address instead", http.StatusBadRequest)
+		return
+	}
+	if req.DefaultBilling != nil && !*req.DefaultBilling && address.IsDefaultBilling {
+		http.Error(w, "set another address as the default billing address instead", http.StatusBadRequest)
+		return
+	}
+	setShipping := req.DefaultShipping != nil && *req.DefaultShipping && !address.IsDefaultShipping
+	setBilling := req.DefaultBilling != nil && *req.DefaultBilling && !address.IsDefaultBilling
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in EditAddressHandler:", err.Error())
+		http.Error(w, "internal error editing address for valid address", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := q.WithTx(tx)
+	if setShipping {
+		if err = qtx.ClearDefaultShippingAddress(context.TODO(), userID); err != nil {
+			log.Warn("error clearing default shipping address in EditAddressHandler:", err.Error())
+			http.Error(w, "internal error editing address for valid address", http.StatusInternalServerError)
+			return
+		}
+	}
+	if setBilling {
+		if err = qtx.ClearDefaultBillingAddress(context.TODO(), userID); err != nil {
+			log.Warn("error clearing default billing address in EditAddressHandler:", err.Error())
+			http.Error(w, "internal error editing address for valid address", http.StatusInternalServerError)
+			return
+		}
+	}
+	edited, err := qtx.EditAddressByID(context.TODO(), db.EditAddressByIDParams{
+		ID:                address.ID,
+		BuildingName:      req.BuildingName,
+		StreetName:        req.StreetName,
+		Town:              req.Town,
+		District:          req.District,
+		State:             req.State,
+		Pincode:           req.Pincode,
+		Label:             req.Label,
+		ContactName:       req.contactName(),
+		ContactPhone:      req.contactPhone(),
+		Latitude:          req.latitude(),
+		Longitude:         req.longitude(),
+		IsDefaultShipping: address.IsDefaultShipping || setShipping,
+		IsDefaultBilling:  address.IsDefaultBilling || setBilling,
+	})
+	if err != nil {
+		log.Warn("error editing address in EditAddressHandler:", err.Error())
+		http.Error(w, "internal error editing address for valid address", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing in EditAddressHandler:", err.Error())
+		http.Error(w, "internal error editing address for valid address", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Data    addressData `json:"data"`
+		Message string      `json:"message"`
+	}
+	resp.Data = newAddressData(edited)
+	resp.Message = "successfully edited address"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// deleting a default address passes the default on to the newest address left
+func deleteAddress(w http.ResponseWriter, r *http.Request, q *db.Queries, userID uuid.UUID) {
+	var req struct {
+		AddressID uuid.UUID `json:"address_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request data format", http.StatusBadRequest)
+		return
+	}
+
+	address, err := q.GetAddressByID(context.TODO(), req.AddressID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid addressID", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching address in DeleteAddressHandler:", err.Error())
+		http.Error(w, "internal error fetching address from address_id", http.StatusInternalServerError)
+		return
+	} else if address.UserID != userID {
+		http.Error(w, "not user's addres to delete. Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in DeleteAddressHandler:", err.Error())
+		http.Error(w, "internal server error deleting valid address deleting request", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := q.WithTx(tx)
+	if err = qtx.DeleteAddressByID(context.TODO(), address.ID); err != nil {
+		log.Warn("error deleting address in DeleteAddressHandler:", err.Error())
+		http.Error(w, "internal server error deleting valid address deleting request", http.StatusInternalServerError)
+		return
+	}
+	if err = qtx.SetMissingDefaultAddresses(context.TODO(), userID); err != nil {
+		log.Warn("error setting default addresses in DeleteAddressHandler:", err.Error())
+		http.Error(w, "internal server error deleting valid address deleting request", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing in DeleteAddressHandler:", err.Error())
+		http.Error(w, "internal server error deleting valid address deleting request", http.StatusInternalServerError)
+		return
+	}
+
+	var resp struct {
+		Message string `json:"message"`
+	}
+	resp.Message = "successfully deleted the address with id:" + address.ID.String()
+	w.Header().Set("content-type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
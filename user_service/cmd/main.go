@@ -2,10 +2,12 @@ package main
 
 import (
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"user_service"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
 	"github.com/joho/godotenv"
 )
 
@@ -21,6 +23,29 @@ func main() {
 	mux := http.NewServeMux()
 	user_service.RegisterRoutes(mux)
 
+	// grpc server for the other services
+	grpcPort := "50051"
+	if p := os.Getenv(envname.GRPCPort); p != "" {
+		grpcPort = p
+	}
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		log.Printf("Starting user_service grpc server on port %s", grpcPort)
+		if err := user_service.NewGRPCServer().Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	// purge expired sessions in the background
+	go user_service.SessionSweeperCron()
+	// send the mails every service queues
+	go user_service.MailOutboxCron()
+	// anonymise the accounts whose deletion grace period ran out
+	go user_service.AccountDeletionCron()
+
 	port := "7777"
 	if p := os.Getenv("PORT"); p != "" {
 		port = p
@@ -0,0 +1,73 @@
+-- no row is returned when the user already asked for deletion
+-- name: AddAccountDeletion :one
+insert into account_deletions
+(user_id, scheduled_at)
+values ($1, $2)
+on conflict (user_id) do nothing
+returning *;
+
+-- name: GetAccountDeletionByUserID :one
+select * from account_deletions
+where user_id = $1;
+
+-- name: CancelAccountDeletion :execrows
+delete from account_deletions
+where user_id = $1 and completed_at is null;
+
+-- deletions that were put off wait for their next attempt, so they can't keep
+-- the ones behind them out of the batch
+-- name: GetDueAccountDeletions :many
+select * from account_deletions
+where completed_at is null and scheduled_at <= current_timestamp
+and (next_attempt_at is null or next_attempt_at <= current_timestamp)
+order by coalesce(next_attempt_at, scheduled_at)
+limit $1;
+
+-- name: PostponeAccountDeletion :exec
+update account_deletions
+set attempts = attempts + 1, next_attempt_at = $2
+where user_id = $1;
+
+-- name: CompleteAccountDeletion :exec
+update account_deletions
+set completed_at = current_timestamp
+where user_id = $1;
+
+-- the email stays unique and can't receive mail, the blank password never matches
+-- name: AnonymizeUserByID :exec
+update users
+set name = 'Deleted User', email = 'deleted-' || id::text || '@deleted.invalid', phone = null,
+phone_verified = false, password = '', email_verified = false, gst_no = null, about = null,
+is_blocked = true, updated_at = current_timestamp
+where id = $1;
+
+-- name: DeleteIdentitiesByUserID :exec
+delete from identities
+where user_id = $1;
+
+-- name: DeleteNotificationsByUserID :exec
+delete from notifications
+where user_id = $1;
+
+-- name: DeleteOTPsByUserID :exec
+delete from otps
+where user_id = $1;
+
+-- name: DeleteForgotOTPsByUserID :exec
+delete from forgot_otps
+where user_id = $1;
+
+-- name: DeleteSellerDocumentsBySellerID :exec
+delete from seller_documents
+where seller_id = $1;
+
+-- name: DeleteSellerOnboardingBySellerID :exec
+delete from seller_onboardings
+where seller_id = $1;
+
+-- queued mails carry no user id, they are matched by the address of the user
+-- and any address an email change was started to
+-- name: DeleteMailOutboxByUserID :exec
+delete from mail_outbox
+where recipient = (select u.email from users u where u.id = $1)
+or recipient in (select ec.new_email from email_changes ec where ec.user_id = $1);
@@ -1,9 +1,10 @@
 -- name: AddAddressByUserID :one
 insert into addresses
-(user_id, type, building_name, street_name, town, district, state, pincode)
+(user_id, type, building_name, street_name, town, district, state, pincode,
+label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing)
 values
-($1, $2, $3, $4, $5, $6, $7, $8)
-returning id, building_name, street_name, town, district, state, pincode;
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+returning *;
 
 -- name: GetAddressByID :one
 select * from addresses
@@ -15,14 +16,46 @@ where user_id = $1
 limit 1;
 
 -- name: GetAddressesByUserID :many
-select id, building_name, street_name, town, district, state, pincode from addresses
-where user_id = $1;
+select * from addresses
+where user_id = $1
+order by is_default_shipping desc, is_default_billing desc, created_at;
+
+-- name: GetDefaultShippingAddressByUserID :one
+select * from addresses
+where user_id = $1 and is_default_shipping;
 
 -- name: EditAddressByID :one
 update addresses
-set building_name = $2, street_name = $3, town = $4, district = $5, state = $6, pincode = $7, updated_at = current_timestamp
+set building_name = $2, street_name = $3, town = $4, district = $5, state = $6, pincode = $7,
+label = $8, contact_name = $9, contact_phone = $10, latitude = $11, longitude = $12,
+is_default_shipping = $13, is_default_billing = $14, updated_at = current_timestamp
 where id = $1
-returning id, building_name, street_name, town, district, state, pincode;
+returning *;
+
+-- name: ClearDefaultShippingAddress :exec
+update addresses
+set is_default_shipping = false
+where user_id = $1 and is_default_shipping;
+
+-- name: ClearDefaultBillingAddress :exec
+update addresses
+set is_default_billing = false
+where user_id = $1 and is_default_billing;
+
+-- name: SetMissingDefaultAddresses :exec
+-- the newest address becomes the default when the user has none,
+-- so the first address added and the one left after a delete are defaults
+update addresses
+set is_default_shipping = is_default_shipping or not exists (
+        select 1 from addresses a where a.user_id = $1 and a.is_default_shipping),
+    is_default_billing = is_default_billing or not exists (
+        select 1 from addresses a where a.user_id = $1 and a.is_default_billing)
+where id = (
+    select id from addresses
+    where user_id = $1
+    order by created_at desc
+    limit 1
+);
 
 -- name: DeleteAddressByID :exec
 delete from addresses
@@ -30,4 +63,4 @@ where id = $1;
 
 -- name: DeleteAddressesByUserID :exec
 delete from addresses
-where user_id = $1;
\ No newline at end of file
+where user_id = $1;
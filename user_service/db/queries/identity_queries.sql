@@ -0,0 +1,33 @@
+-- name: AddOAuthState :exec
+insert into oauth_states
+(state_hash, code_verifier, purpose, role, user_id)
+values ($1, $2, $3, $4, $5);
+
+-- a state works once, it is deleted as it is read
+-- name: ConsumeOAuthState :one
+delete from oauth_states
+where state_hash = $1 and expires_at > current_timestamp
+returning *;
+
+-- name: DeleteExpiredOAuthStates :execresult
+delete from oauth_states
+where expires_at <= current_timestamp;
+
+-- name: AddIdentity :one
+insert into identities
+(user_id, provider, subject, email)
+values ($1, $2, $3, $4)
+returning *;
+
+-- name: GetIdentityByProviderSubject :one
+select * from identities
+where provider = $1 and subject = $2;
+
+-- name: GetIdentitiesByUserID :many
+select * from identities
+where user_id = $1
+order by created_at;
+
+-- name: DeleteIdentityByUserIDAndProvider :execresult
+delete from identities
+where user_id = $1 and provider = $2;
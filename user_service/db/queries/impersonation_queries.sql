@@ -0,0 +1,31 @@
+-- name: AddImpersonation :one
+insert into impersonations
+(admin_id, target_id, reason, expires_at)
+values ($1, $2, $3, $4)
+returning *;
+
+-- name: StopImpersonation :one
+update impersonations
+set ended_at = current_timestamp
+where id = $1 and admin_id = $2 and ended_at is null and expires_at > current_timestamp
+returning *;
+
+-- name: GetImpersonations :many
+select i.*, a.email as admin_email, t.email as target_email
+from impersonations i
+join users a on a.id = i.admin_id
+join users t on t.id = i.target_id
+where (sqlc.narg('admin_id')::uuid is null or i.admin_id = sqlc.narg('admin_id'))
+and (sqlc.narg('target_id')::uuid is null or i.target_id = sqlc.narg('target_id'))
+order by i.started_at desc
+limit $1 offset $2;
+
+-- name: AddImpersonationEvent :exec
+insert into impersonation_events
+(impersonation_id, kind)
+values ($1, $2);
+
+-- name: GetImpersonationEvents :many
+select * from impersonation_events
+where impersonation_id = $1
+order by created_at;
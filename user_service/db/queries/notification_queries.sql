@@ -0,0 +1,21 @@
+-- name: GetNotificationsByUserID :many
+select * from notifications
+where user_id = $1
+and (sqlc.narg('unread')::boolean is null or (read_at is null) = sqlc.narg('unread'))
+order by created_at desc
+limit $2 offset $3;
+
+-- name: CountUnreadNotifications :one
+select count(*) from notifications
+where user_id = $1 and read_at is null;
+
+-- name: MarkNotificationRead :one
+update notifications
+set read_at = coalesce(read_at, current_timestamp)
+where id = $1 and user_id = $2
+returning *;
+
+-- name: MarkAllNotificationsRead :execrows
+update notifications
+set read_at = current_timestamp
+where user_id = $1 and read_at is null;
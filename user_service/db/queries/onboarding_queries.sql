@@ -0,0 +1,67 @@
+-- name: GetOrCreateSellerOnboarding :one
+insert into seller_onboardings
+(seller_id)
+values ($1)
+on conflict (seller_id) do update set seller_id = excluded.seller_id
+returning *;
+
+-- name: GetSellerOnboardingBySellerID :one
+select * from seller_onboardings
+where seller_id = $1;
+
+-- name: GetSellerOnboardingsByStatus :many
+select so.seller_id, u.name, u.email, u.gst_no, so.status, so.submitted_at, so.reviewed_at
+from seller_onboardings so
+inner join users u
+on so.seller_id = u.id
+where so.status = $1
+order by so.submitted_at nulls last, so.created_at;
+
+-- name: SetSellerOnboardingDraft :exec
+-- replacing a document of a rejected application reopens it
+update seller_onboardings
+set status = 'draft', updated_at = current_timestamp
+where seller_id = $1 and status = 'rejected';
+
+-- name: SubmitSellerOnboarding :one
+update seller_onboardings
+set status = 'submitted', rejection_reason = null, submitted_at = current_timestamp,
+reviewed_at = null, reviewed_by = null, updated_at = current_timestamp
+where seller_id = $1 and status = 'draft'
+returning *;
+
+-- name: ReviewSellerOnboarding :one
+update seller_onboardings
+set status = @status, rejection_reason = @rejection_reason, reviewed_at = current_timestamp,
+reviewed_by = @reviewed_by, updated_at = current_timestamp
+where seller_id = @seller_id and status = 'submitted'
+returning *;
+
+-- name: UpsertSellerDocument :one
+insert into seller_documents
+(seller_id, kind, file_key, content_type)
+values ($1, $2, $3, $4)
+on conflict (seller_id, kind) do update
+set file_key = excluded.file_key, content_type = excluded.content_type, status = 'pending',
+rejection_reason = null, reviewed_at = null, reviewed_by = null, uploaded_at = current_timestamp
+returning *;
+
+-- name: GetSellerDocumentsBySellerID :many
+select * from seller_documents
+where seller_id = $1
+order by kind;
+
+-- name: GetSellerDocumentByID :one
+select * from seller_documents
+where id = $1;
+
+-- name: GetSellerDocumentBySellerIDAndKind :one
+select * from seller_documents
+where seller_id = $1 and kind = $2;
+
+-- name: ReviewSellerDocumentByID :one
+update seller_documents
+set status = @status, rejection_reason = @rejection_reason, reviewed_at = current_timestamp,
+reviewed_by = @reviewed_by
+where id = @id
+returning *;
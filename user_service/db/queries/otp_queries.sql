@@ -4,11 +4,28 @@ WHERE user_id = $1 and expires_at > current_timestamp
 ORDER BY created_at DESC
 LIMIT 1;
 
+-- no row is returned while the last otp of the user is younger than a minute,
+-- so otps can't be resent back to back
 -- name: AddOTP :one
 insert into otps
-(user_id) values ($1)
+(user_id)
+select @user_id::uuid
+where not exists (
+    select 1 from otps
+    where user_id = @user_id::uuid and created_at > current_timestamp - interval '1 minute'
+)
 returning *;
 
+-- name: IncrementOTPAttempts :one
+update otps
+set attempts = attempts + 1
+where id = $1
+returning attempts;
+
+-- name: DeleteOTPByID :exec
+delete from otps
+where id = $1;
+
 -- name: DeleteOTPByEmail :execresult
 delete from otps
 where user_id = (select user_id from users where email = $1);
@@ -19,11 +36,27 @@ where user_id  = $1 and expires_at > current_timestamp
 order by created_at DESC
 limit 1;
 
+-- same resend cooldown as AddOTP
 -- name: AddForgotOTPByUserID :one
 insert into forgot_otps 
-(user_id) values ($1)
+(user_id)
+select @user_id::uuid
+where not exists (
+    select 1 from forgot_otps
+    where user_id = @user_id::uuid and created_at > current_timestamp - interval '1 minute'
+)
 returning *;
 
+-- name: IncrementForgotOTPAttempts :one
+update forgot_otps
+set attempts = attempts + 1
+where id = $1
+returning attempts;
+
+-- name: DeleteForgotOTPByID :exec
+delete from forgot_otps
+where id = $1;
+
 -- name: DeleteForgotOTPByEmail :exec
 delete from forgot_otps
-where user_id = (select user_id from users where email = $1);
\ No newline at end of file
+where user_id = (select user_id from users where email = $1);
@@ -0,0 +1,52 @@
+-- name: GetRoles :many
+select * from roles
+order by staff, name;
+
+-- name: GetRoleByName :one
+select * from roles
+where name = $1;
+
+-- name: AddRole :one
+insert into roles
+(name, description)
+values ($1, $2)
+returning *;
+
+-- name: GetRolePermissions :many
+select * from role_permissions
+order by role, permission;
+
+-- name: DeleteRolePermissions :exec
+delete from role_permissions
+where role = $1;
+
+-- name: AddRolePermission :exec
+insert into role_permissions
+(role, permission)
+values ($1, $2)
+on conflict do nothing;
+
+-- name: GetRolesByUserID :many
+select role from user_roles
+where user_id = $1
+order by role;
+
+-- name: GetPermissionsByUserID :many
+select distinct rp.permission from user_roles ur
+join role_permissions rp on rp.role = ur.role
+where ur.user_id = $1
+order by rp.permission;
+
+-- name: AddUserRole :one
+insert into user_roles
+(user_id, role, assigned_by)
+values ($1, $2, $3)
+returning *;
+
+-- name: DeleteUserRole :execresult
+delete from user_roles
+where user_id = $1 and role = $2;
+
+-- name: CountUsersWithRole :one
+select count(*) from user_roles
+where role = $1;
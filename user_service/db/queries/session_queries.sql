@@ -2,10 +2,15 @@
 select * from sessions
 where id = $1;
 
--- name: GetAllSessionsByUserID :one
+-- name: GetAllSessionsByUserID :many
 select * from sessions
 where user_id = $1;
 
+-- name: GetActiveSessionsByUserID :many
+select * from sessions
+where user_id = $1 and revoked_at is null and expires_at > current_timestamp
+order by last_used_at desc;
+
 -- name: GetUserBySessionID :one
 select 
     u.id, 
@@ -21,20 +26,55 @@ select
 from sessions s
 join users u
 on s.user_id = u.id
-where s.id = $1;
+where s.id = $1 and s.revoked_at is null and s.expires_at > current_timestamp;
 
 
 -- name: AddSession :one
 insert into sessions
-(user_id, ip_address, user_agent )
+(user_id, ip_address, user_agent, refresh_token_hash)
 values
-($1, $2, $3)
+($1, $2, $3, $4)
 returning *;
 
--- name: DeleteSessionByID :execresult
-delete from sessions
-where id = $1;
+-- name: GetSessionByRefreshTokenHash :one
+select * from sessions
+where refresh_token_hash = $1;
+
+-- name: GetSessionByPreviousTokenHash :one
+select * from sessions
+where previous_token_hash = $1;
+
+-- name: RotateSessionRefreshToken :one
+-- only a live session whose current token is presented can be rotated
+update sessions
+set previous_token_hash = refresh_token_hash, refresh_token_hash = @new_token_hash,
+last_used_at = current_timestamp, expires_at = current_timestamp + interval '7 days'
+where id = @id and refresh_token_hash = @old_token_hash
+and revoked_at is null and expires_at > current_timestamp
+returning *;
+
+-- name: RevokeSessionByID :execresult
+update sessions
+set revoked_at = current_timestamp
+where id = $1 and revoked_at is null;
+
+-- name: RevokeSessionsByUserID :execresult
+update sessions
+set revoked_at = current_timestamp
+where user_id = $1 and revoked_at is null;
+
+-- name: RevokeSessionByIDAndUserID :execresult
+update sessions
+set revoked_at = current_timestamp
+where id = $1 and user_id = $2 and revoked_at is null;
+
+-- name: RevokeOtherSessionsByUserID :execresult
+update sessions
+set revoked_at = current_timestamp
+where user_id = @user_id and id <> @current_session_id and revoked_at is null;
 
--- name: DeleteSessionsByuserID :execresult
+-- name: DeleteExpiredSessions :execresult
+-- revoked sessions are kept for a day so a replayed refresh token is still recognised
 delete from sessions
-where user_id = $1;
\ No newline at end of file
+where expires_at < current_timestamp
+or revoked_at < current_timestamp - interval '1 day';
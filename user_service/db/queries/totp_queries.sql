@@ -0,0 +1,89 @@
+-- name: GetUserTOTPByUserID :one
+select * from user_totps
+where user_id = $1;
+
+-- name: UpsertPendingUserTOTP :one
+-- a new setup replaces an earlier unconfirmed one, never an enabled one
+insert into user_totps
+(user_id, secret)
+values ($1, $2)
+on conflict (user_id) do update
+set secret = excluded.secret, last_used_step = 0, created_at = current_timestamp
+where user_totps.enabled = false
+returning *;
+
+-- name: EnableUserTOTP :one
+update user_totps
+set enabled = true, enabled_at = current_timestamp, last_used_step = @step
+where user_id = @user_id and enabled = false
+returning *;
+
+-- name: SetUserTOTPLastUsedStep :execresult
+-- the step only moves forward, two requests with the same code can't both pass
+update user_totps
+set last_used_step = @step
+where user_id = @user_id and last_used_step < @step;
+
+-- name: DeleteUserTOTP :exec
+delete from user_totps
+where user_id = $1;
+
+-- name: AddRecoveryCode :exec
+insert into user_recovery_codes
+(user_id, code_hash)
+values ($1, $2);
+
+-- name: DeleteRecoveryCodesByUserID :exec
+delete from user_recovery_codes
+where user_id = $1;
+
+-- name: UseRecoveryCode :execresult
+update user_recovery_codes
+set used_at = current_timestamp
+where user_id = $1 and code_hash = $2 and used_at is null;
+
+-- name: GetUnusedRecoveryCodeCount :one
+select count(*) from user_recovery_codes
+where user_id = $1 and used_at is null;
+
+-- name: AddLoginChallenge :one
+insert into login_challenges
+(user_id, token_hash, purpose)
+values ($1, $2, $3)
+returning *;
+
+-- name: GetLoginChallengeByTokenHash :one
+select * from login_challenges
+where token_hash = $1 and expires_at > current_timestamp;
+
+-- name: IncLoginChallengeAttempts :one
+update login_challenges
+set attempts = attempts + 1
+where id = $1
+returning attempts;
+
+-- name: DeleteLoginChallengeByID :exec
+delete from login_challenges
+where id = $1;
+
+-- name: DeleteExpiredLoginChallenges :execresult
+delete from login_challenges
+where expires_at < current_timestamp;
+
+-- name: GetRole2FAPolicies :many
+select * from role_2fa_policies
+order by role;
+
+-- name: IsRole2FARequired :one
+select exists (
+    select 1 from role_2fa_policies
+    where role = $1 and require_2fa = true
+);
+
+-- name: UpsertRole2FAPolicy :one
+insert into role_2fa_policies
+(role, require_2fa, updated_by)
+values ($1, $2, $3)
+on conflict (role) do update
+set require_2fa = excluded.require_2fa, updated_by = excluded.updated_by, updated_at = current_timestamp
+returning *;
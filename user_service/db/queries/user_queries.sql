@@ -1,12 +1,13 @@
+-- a changed phone has to be verified again
 -- name: EditUserByID :one
 update users
-set name = $2, phone = $3, updated_at = current_timestamp
+set name = $2, phone = $3, phone_verified = phone_verified and phone is not distinct from $3, updated_at = current_timestamp
 where id = $1
 returning *;
 
 -- name: EditSellerByID :one
 update users
-set name = $2, about = $3, phone = $4, updated_at = current_timestamp
+set name = $2, about = $3, phone = $4, phone_verified = phone_verified and phone is not distinct from $4, updated_at = current_timestamp
 where id = $1
 returning *;
 
@@ -58,10 +59,14 @@ SELECT id, name, email, phone, role, is_blocked, email_verified, user_verified F
 WHERE role = $1;
 
 -- name: GetUserById :one
-SELECT id, name, email, phone, role, is_blocked, email_verified, user_verified, gst_no, about FROM users
+SELECT id, name, email, phone, phone_verified, role, is_blocked, email_verified, user_verified, gst_no, about FROM users
 WHERE id = $1;
 
 
+-- name: GetSellerByID :one
+SELECT id, name, about, user_verified, is_blocked, created_at FROM users
+WHERE id = $1 and role = 'seller';
+
 -- name: GetUserWithPasswordByEmail :one
 SELECT * FROM users
 WHERE email = $1;
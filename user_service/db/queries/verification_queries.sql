@@ -0,0 +1,74 @@
+-- name: GetValidEmailChangeByUserID :one
+select * from email_changes
+where user_id = $1 and expires_at > current_timestamp
+order by created_at desc
+limit 1;
+
+-- same resend cooldown as AddOTP
+-- name: AddEmailChange :one
+insert into email_changes
+(user_id, new_email)
+select @user_id::uuid, @new_email::text
+where not exists (
+    select 1 from email_changes
+    where user_id = @user_id::uuid and created_at > current_timestamp - interval '1 minute'
+)
+returning *;
+
+-- name: IncrementEmailChangeAttempts :one
+update email_changes
+set attempts = attempts + 1
+where id = $1
+returning attempts;
+
+-- name: DeleteEmailChangesByUserID :exec
+delete from email_changes
+where user_id = $1;
+
+-- name: DeleteExpiredEmailChanges :execresult
+delete from email_changes
+where expires_at < current_timestamp;
+
+-- the new email was proven by the otp so it is verified as well
+-- name: ChangeUserEmail :one
+update users
+set email = $2, email_verified = true, updated_at = current_timestamp
+where id = $1
+returning *;
+
+-- name: GetValidPhoneVerificationByUserID :one
+select * from phone_verifications
+where user_id = $1 and expires_at > current_timestamp
+order by created_at desc
+limit 1;
+
+-- same resend cooldown as AddOTP
+-- name: AddPhoneVerification :one
+insert into phone_verifications
+(user_id, phone)
+select @user_id::uuid, @phone::bigint
+where not exists (
+    select 1 from phone_verifications
+    where user_id = @user_id::uuid and created_at > current_timestamp - interval '1 minute'
+)
+returning *;
+
+-- name: IncrementPhoneVerificationAttempts :one
+update phone_verifications
+set attempts = attempts + 1
+where id = $1
+returning attempts;
+
+-- name: DeletePhoneVerificationsByUserID :exec
+delete from phone_verifications
+where user_id = $1;
+
+-- name: DeleteExpiredPhoneVerifications :execresult
+delete from phone_verifications
+where expires_at < current_timestamp;
+
+-- no row is updated when the phone was edited after the code was sent
+-- name: VerifyUserPhone :execrows
+update users
+set phone_verified = true, updated_at = current_timestamp
+where id = $1 and phone = $2;
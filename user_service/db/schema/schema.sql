@@ -9,6 +9,8 @@ CREATE TABLE IF NOT EXISTS users (
     password TEXT NOT NULL,
     role TEXT NOT NULL CHECK (role IN ('user', 'seller', 'admin')),
     email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    -- set once the phone confirms an sms code, cleared when the phone is edited
+    phone_verified BOOLEAN NOT NULL DEFAULT FALSE,
     user_verified BOOLEAN NOT NULL DEFAULT FALSE,
     is_blocked BOOLEAN NOT NULL DEFAULT FALSE,
     gst_no TEXT UNIQUE,
@@ -28,8 +30,17 @@ CREATE TABLE IF NOT EXISTS addresses (
     district TEXT NOT NULL,
     state TEXT NOT NULL,
     pincode INTEGER NOT NULL CHECK (pincode >= 100000 AND pincode <= 999999),
+    label TEXT NOT NULL DEFAULT 'home' CHECK (label IN ('home', 'work', 'other')),
+    contact_name TEXT,
+    contact_phone BIGINT CHECK (contact_phone >= 1000000000 AND contact_phone <= 9999999999),
+    latitude DOUBLE PRECISION CHECK (latitude >= -90 AND latitude <= 90),
+    longitude DOUBLE PRECISION CHECK (longitude >= -180 AND longitude <= 180),
+    is_default_shipping BOOLEAN NOT NULL DEFAULT FALSE,
+    is_default_billing BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at),
+    -- a location is only useful with both coordinates
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
 );
 
 -- add partial index after creating address schema 
@@ -38,12 +49,23 @@ CREATE UNIQUE INDEX unique_seller_address_per_user
 ON addresses(user_id) 
 WHERE type = 'seller';
 
+-- at most one default shipping and one default billing address per user
+CREATE UNIQUE INDEX unique_default_shipping_address_per_user
+ON addresses(user_id)
+WHERE is_default_shipping;
+
+CREATE UNIQUE INDEX unique_default_billing_address_per_user
+ON addresses(user_id)
+WHERE is_default_billing;
+
 
 -- Login OTPs Table
 CREATE TABLE IF NOT EXISTS otps(
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     otp INTEGER NOT NULL DEFAULT FLOOR(RANDOM() * 999999),
+    -- wrong guesses against this otp, it is dropped after 5
+    attempts INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '10 minutes')
 );
@@ -53,20 +75,56 @@ CREATE TABLE IF NOT EXISTS forgot_otps(
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     otp INTEGER NOT NULL DEFAULT FLOOR(RANDOM() * 999999),
+    -- wrong guesses against this otp, it is dropped after 5
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '10 minutes')
+);
+
+-- Email Change OTPs Table
+-- the otp is mailed to new_email, the account keeps its email until it is confirmed
+CREATE TABLE IF NOT EXISTS email_changes(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email TEXT NOT NULL,
+    otp INTEGER NOT NULL DEFAULT FLOOR(RANDOM() * 999999),
+    -- wrong guesses against this otp, it is dropped after 5
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '10 minutes')
+);
+
+-- Phone Verification Codes Table
+-- the code is texted to phone and only verifies it while it is still the phone of the user
+CREATE TABLE IF NOT EXISTS phone_verifications(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phone BIGINT NOT NULL,
+    otp INTEGER NOT NULL DEFAULT FLOOR(RANDOM() * 999999),
+    -- wrong guesses against this code, it is dropped after 5
+    attempts INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '10 minutes')
 );
 
 -- Sessions Table
+-- a session holds the hash of its current refresh token, every refresh rotates it and
+-- keeps the previous hash so a replayed old token can be detected and the session revoked
 CREATE TABLE IF NOT EXISTS sessions (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
     user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     ip_address TEXT NOT NULL,
     user_agent TEXT NOT NULL,
+    refresh_token_hash TEXT NOT NULL UNIQUE,
+    previous_token_hash TEXT,
+    revoked_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '7 days')
 );
 
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_token_hash ON sessions(previous_token_hash);
+
 -- User/Seller wallet table
 CREATE TABLE IF NOT EXISTS wallets (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
@@ -75,3 +133,291 @@ CREATE TABLE IF NOT EXISTS wallets (
     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at>= created_at)
 );
+
+-- Seller onboarding, one application per seller that moves
+-- draft -> submitted -> approved/rejected. a rejected application goes back to
+-- draft once the seller replaces a document
+CREATE TABLE IF NOT EXISTS seller_onboardings (
+    seller_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'submitted', 'approved', 'rejected')),
+    rejection_reason TEXT,
+    submitted_at TIMESTAMPTZ,
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP CHECK (updated_at >= created_at)
+);
+
+-- KYC documents of the application, the latest upload of each kind replaces the old one
+CREATE TABLE IF NOT EXISTS seller_documents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seller_id UUID NOT NULL REFERENCES seller_onboardings(seller_id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('gst_certificate', 'pan', 'bank_proof')),
+    file_key TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    rejection_reason TEXT,
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    uploaded_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (seller_id, kind)
+);
+
+-- TOTP two factor auth, the secret is encrypted with pkg/crypt. a row with
+-- enabled = false is a setup that hasn't been confirmed with a code yet
+CREATE TABLE IF NOT EXISTS user_totps (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    -- last TOTP time step used, codes of older steps are refused so a code works once
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    enabled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- single use recovery codes, only the sha256 of a code is kept
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
+
+-- second step of a login, the password was right and a TOTP code is awaited.
+-- purpose enroll is used when the role requires 2FA and the user hasn't set it up
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL UNIQUE,
+    purpose TEXT NOT NULL CHECK (purpose IN ('verify', 'enroll')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '5 minutes')
+);
+
+-- roles admins require 2FA for
+CREATE TABLE IF NOT EXISTS role_2fa_policies (
+    role TEXT PRIMARY KEY CHECK (role IN ('user', 'seller', 'admin')),
+    require_2fa BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- failed attempt counters of the login and otp limiters (pkg/ratelimit)
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key TEXT PRIMARY KEY,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    lockouts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- external sign-in accounts linked to a user, subject is the provider's user id
+CREATE TABLE IF NOT EXISTS identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider TEXT NOT NULL CHECK (provider IN ('google')),
+    subject TEXT NOT NULL,
+    email TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (provider, subject),
+    UNIQUE (user_id, provider)
+);
+
+-- pending oauth redirects, the state sent to the provider is kept hashed along
+-- with the PKCE verifier and removed when the callback uses it
+CREATE TABLE IF NOT EXISTS oauth_states (
+    state_hash TEXT PRIMARY KEY,
+    code_verifier TEXT NOT NULL,
+    purpose TEXT NOT NULL CHECK (purpose IN ('login', 'link')),
+    -- role a new account signs up as on login, the user linking on link
+    role TEXT NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'seller')),
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL DEFAULT (CURRENT_TIMESTAMP + INTERVAL '10 minutes')
+);
+
+-- roles map to sets of permissions (utils.Permissions). the account role of every
+-- user is a role too, staff roles are given to admin accounts on top of it
+CREATE TABLE IF NOT EXISTS roles (
+    name TEXT PRIMARY KEY CHECK (name ~ '^[a-z][a-z_]{2,31}$'),
+    description TEXT NOT NULL DEFAULT '',
+    -- only staff roles can be assigned and only to admin accounts
+    staff BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission TEXT NOT NULL,
+    PRIMARY KEY (role, permission)
+);
+
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role TEXT NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    assigned_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, role)
+);
+
+INSERT INTO roles (name, description, staff) VALUES
+    ('user', 'customer account', FALSE),
+    ('seller', 'seller account', FALSE),
+    ('admin', 'full access to the admin api', TRUE),
+    ('support_agent', 'looks up users and orders, blocks users and moderates reviews', TRUE),
+    ('finance_admin', 'orders, coupons and sales reports', TRUE)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role, permission) VALUES
+    ('admin', 'users:read'),
+    ('admin', 'users:block'),
+    ('admin', 'users:impersonate'),
+    ('admin', 'sellers:review'),
+    ('admin', 'roles:read'),
+    ('admin', 'roles:write'),
+    ('admin', 'security:write'),
+    ('admin', 'products:moderate'),
+    ('admin', 'reviews:moderate'),
+    ('admin', 'categories:write'),
+    ('admin', 'orders:read'),
+    ('admin', 'orders:deliver'),
+    ('admin', 'coupons:read'),
+    ('admin', 'coupons:write'),
+    ('admin', 'reports:read'),
+    ('admin', 'audit:read'),
+    ('support_agent', 'users:read'),
+    ('support_agent', 'users:block'),
+    ('support_agent', 'users:impersonate'),
+    ('support_agent', 'reviews:moderate'),
+    ('support_agent', 'orders:read'),
+    ('finance_admin', 'orders:read'),
+    ('finance_admin', 'orders:deliver'),
+    ('finance_admin', 'coupons:read'),
+    ('finance_admin', 'coupons:write'),
+    ('finance_admin', 'reports:read')
+ON CONFLICT DO NOTHING;
+
+-- every account starts with its account role
+CREATE OR REPLACE FUNCTION add_account_role() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO user_roles (user_id, role) VALUES (NEW.id, NEW.role)
+    ON CONFLICT DO NOTHING;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS users_add_account_role ON users;
+CREATE TRIGGER users_add_account_role AFTER INSERT ON users
+FOR EACH ROW EXECUTE FUNCTION add_account_role();
+
+-- accounts made before roles existed
+INSERT INTO user_roles (user_id, role)
+SELECT id, role FROM users
+ON CONFLICT DO NOTHING;
+
+-- an admin acting as a user or seller to see what they see, the impersonation
+-- token is refused by every service once ended_at is set or expires_at passes
+CREATE TABLE IF NOT EXISTS impersonations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    admin_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ
+);
+
+-- audit trail of impersonations, written by every service (pkg/impersonation)
+CREATE TABLE IF NOT EXISTS impersonation_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    impersonation_id UUID NOT NULL REFERENCES impersonations(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('start', 'stop', 'request')),
+    service TEXT NOT NULL DEFAULT 'user_service',
+    method TEXT NOT NULL DEFAULT '',
+    path TEXT NOT NULL DEFAULT '',
+    -- write requests are refused while impersonating
+    blocked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_impersonation_events_impersonation_id ON impersonation_events(impersonation_id);
+
+-- append only log of administrative and financial actions (pkg/audit)
+CREATE TABLE IF NOT EXISTS user_audit_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    -- null for actions of crons
+    actor_id UUID,
+    actor_role TEXT NOT NULL,
+    impersonator_id UUID,
+    action TEXT NOT NULL,
+    entity_type TEXT NOT NULL,
+    entity_id TEXT NOT NULL,
+    before JSONB,
+    after JSONB,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_user_audit_logs_created_at ON user_audit_logs(created_at);
+CREATE INDEX IF NOT EXISTS idx_user_audit_logs_entity ON user_audit_logs(entity_type, entity_id);
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit log % is append only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS user_audit_logs_append_only ON user_audit_logs;
+CREATE TRIGGER user_audit_logs_append_only BEFORE UPDATE OR DELETE ON user_audit_logs
+FOR EACH ROW EXECUTE FUNCTION audit_logs_append_only();
+
+DROP TRIGGER IF EXISTS user_audit_logs_no_truncate ON user_audit_logs;
+CREATE TRIGGER user_audit_logs_no_truncate BEFORE TRUNCATE ON user_audit_logs
+FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_append_only();
+
+-- in-app inbox, written by every service through pkg/notify
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('order_placed', 'order_shipped', 'order_delivered', 'order_cancelled', 'refund_issued', 'payout_received')),
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}',
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, created_at);
+
+-- mails queued by every service through pkg/mail, sent by the outbox worker of the user service
+CREATE TABLE IF NOT EXISTS mail_outbox (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template TEXT NOT NULL,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    html_body TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_mail_outbox_due ON mail_outbox(next_attempt_at) WHERE status IN ('pending', 'sending');
+
+-- accounts users asked to delete. the account stays usable until scheduled_at so the
+-- request can be cancelled, then it is anonymised and completed_at is set. the users
+-- row is kept since orders and vendor payments reference it
+CREATE TABLE IF NOT EXISTS account_deletions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ,
+    -- runs that postponed or failed the erase, the next one waits until next_attempt_at
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_account_deletions_due ON account_deletions(coalesce(next_attempt_at, scheduled_at)) WHERE completed_at IS NULL;
@@ -0,0 +1,228 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: account_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const addAccountDeletion = `-- name: AddAccountDeletion :one
+insert into account_deletions
+(user_id, scheduled_at)
+values ($1, $2)
+on conflict (user_id) do nothing
+returning user_id, requested_at, scheduled_at, completed_at, attempts, next_attempt_at
+`
+
+type AddAccountDeletionParams struct {
+	UserID      uuid.UUID `json:"user_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// no row is returned when the user already asked for deletion
+func (q *Queries) AddAccountDeletion(ctx context.Context, arg AddAccountDeletionParams) (AccountDeletion, error) {
+	row := q.queryRow(ctx, q.addAccountDeletionStmt, addAccountDeletion, arg.UserID, arg.ScheduledAt)
+	var i AccountDeletion
+	err := row.Scan(
+		&i.UserID,
+		&i.RequestedAt,
+		&i.ScheduledAt,
+		&i.CompletedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+	)
+	return i, err
+}
+
+const anonymizeUserByID = `-- name: AnonymizeUserByID :exec
+update users
+set name = 'Deleted User', email = 'deleted-' || id::text || '@deleted.invalid', phone = null,
+phone_verified = false, password = '', email_verified = false, gst_no = null, about = null,
+is_blocked = true, updated_at = current_timestamp
+where id = $1
+`
+
+// the email stays unique and can't receive mail, the blank password never matches
+func (q *Queries) AnonymizeUserByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.anonymizeUserByIDStmt, anonymizeUserByID, id)
+	return err
+}
+
+const cancelAccountDeletion = `-- name: CancelAccountDeletion :execrows
+delete from account_deletions
+where user_id = $1 and completed_at is null
+`
+
+func (q *Queries) CancelAccountDeletion(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.cancelAccountDeletionStmt, cancelAccountDeletion, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const completeAccountDeletion = `-- name: CompleteAccountDeletion :exec
+update account_deletions
+set completed_at = current_timestamp
+where user_id = $1
+`
+
+func (q *Queries) CompleteAccountDeletion(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.completeAccountDeletionStmt, completeAccountDeletion, userID)
+	return err
+}
+
+const deleteForgotOTPsByUserID = `-- name: DeleteForgotOTPsByUserID :exec
+delete from forgot_otps
+where user_id = $1
+`
+
+func (q *Queries) DeleteForgotOTPsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteForgotOTPsByUserIDStmt, deleteForgotOTPsByUserID, userID)
+	return err
+}
+
+const deleteIdentitiesByUserID = `-- name: DeleteIdentitiesByUserID :exec
+delete from identities
+where user_id = $1
+`
+
+func (q *Queries) DeleteIdentitiesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteIdentitiesByUserIDStmt, deleteIdentitiesByUserID, userID)
+	return err
+}
+
+const deleteMailOutboxByUserID = `-- name: DeleteMailOutboxByUserID :exec
+delete from mail_outbox
+where recipient = (select u.email from users u where u.id = $1)
+or recipient in (select ec.new_email from email_changes ec where ec.user_id = $1)
+`
+
+// queued mails carry no user id, they are matched by the address of the user
+// and any address an email change was started to
+func (q *Queries) DeleteMailOutboxByUserID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteMailOutboxByUserIDStmt, deleteMailOutboxByUserID, id)
+	return err
+}
+
+const deleteNotificationsByUserID = `-- name: DeleteNotificationsByUserID :exec
+delete from notifications
+where user_id = $1
+`
+
+func (q *Queries) DeleteNotificationsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteNotificationsByUserIDStmt, deleteNotificationsByUserID, userID)
+	return err
+}
+
+const deleteOTPsByUserID = `-- name: DeleteOTPsByUserID :exec
+delete from otps
+where user_id = $1
+`
+
+func (q *Queries) DeleteOTPsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteOTPsByUserIDStmt, deleteOTPsByUserID, userID)
+	return err
+}
+
+const deleteSellerDocumentsBySellerID = `-- name: DeleteSellerDocumentsBySellerID :exec
+delete from seller_documents
+where seller_id = $1
+`
+
+func (q *Queries) DeleteSellerDocumentsBySellerID(ctx context.Context, sellerID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteSellerDocumentsBySellerIDStmt, deleteSellerDocumentsBySellerID, sellerID)
+	return err
+}
+
+const deleteSellerOnboardingBySellerID = `-- name: DeleteSellerOnboardingBySellerID :exec
+delete from seller_onboardings
+where seller_id = $1
+`
+
+func (q *Queries) DeleteSellerOnboardingBySellerID(ctx context.Context, sellerID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteSellerOnboardingBySellerIDStmt, deleteSellerOnboardingBySellerID, sellerID)
+	return err
+}
+
+const getAccountDeletionByUserID = `-- name: GetAccountDeletionByUserID :one
+select user_id, requested_at, scheduled_at, completed_at, attempts, next_attempt_at from account_deletions
+where user_id = $1
+`
+
+func (q *Queries) GetAccountDeletionByUserID(ctx context.Context, userID uuid.UUID) (AccountDeletion, error) {
+	row := q.queryRow(ctx, q.getAccountDeletionByUserIDStmt, getAccountDeletionByUserID, userID)
+	var i AccountDeletion
+	err := row.Scan(
+		&i.UserID,
+		&i.RequestedAt,
+		&i.ScheduledAt,
+		&i.CompletedAt,
+		&i.Attempts,
+		&i.NextAttemptAt,
+	)
+	return i, err
+}
+
+const getDueAccountDeletions = `-- name: GetDueAccountDeletions :many
+select user_id, requested_at, scheduled_at, completed_at, attempts, next_attempt_at from account_deletions
+where completed_at is null and scheduled_at <= current_timestamp
+and (next_attempt_at is null or next_attempt_at <= current_timestamp)
+order by coalesce(next_attempt_at, scheduled_at)
+limit $1
+`
+
+// deletions that were put off wait for their next attempt, so they can't keep
+// the ones behind them out of the batch
+func (q *Queries) GetDueAccountDeletions(ctx context.Context, limit int32) ([]AccountDeletion, error) {
+	rows, err := q.query(ctx, q.getDueAccountDeletionsStmt, getDueAccountDeletions, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AccountDeletion{}
+	for rows.Next() {
+		var i AccountDeletion
+		if err := rows.Scan(
+			&i.UserID,
+			&i.RequestedAt,
+			&i.ScheduledAt,
+			&i.CompletedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const postponeAccountDeletion = `-- name: PostponeAccountDeletion :exec
+update account_deletions
+set attempts = attempts + 1, next_attempt_at = $2
+where user_id = $1
+`
+
+type PostponeAccountDeletionParams struct {
+	UserID        uuid.UUID    `json:"user_id"`
+	NextAttemptAt sql.NullTime `json:"next_attempt_at"`
+}
+
+func (q *Queries) PostponeAccountDeletion(ctx context.Context, arg PostponeAccountDeletionParams) error {
+	_, err := q.exec(ctx, q.postponeAccountDeletionStmt, postponeAccountDeletion, arg.UserID, arg.NextAttemptAt)
+	return err
+}
@@ -7,40 +7,39 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
 const addAddressByUserID = `-- name: AddAddressByUserID :one
 insert into addresses
-(user_id, type, building_name, street_name, town, district, state, pincode)
+(user_id, type, building_name, street_name, town, district, state, pincode,
+label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing)
 values
-($1, $2, $3, $4, $5, $6, $7, $8)
-returning id, building_name, street_name, town, district, state, pincode
+($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+returning id, user_id, type, building_name, street_name, town, district, state, pincode, label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing, created_at, updated_at
 `
 
 type AddAddressByUserIDParams struct {
-	UserID       uuid.UUID `json:"user_id"`
-	Type         string    `json:"type"`
-	BuildingName string    `json:"building_name"`
-	StreetName   string    `json:"street_name"`
-	Town         string    `json:"town"`
-	District     string    `json:"district"`
-	State        string    `json:"state"`
-	Pincode      int32     `json:"pincode"`
+	UserID            uuid.UUID       `json:"user_id"`
+	Type              string          `json:"type"`
+	BuildingName      string          `json:"building_name"`
+	StreetName        string          `json:"street_name"`
+	Town              string          `json:"town"`
+	District          string          `json:"district"`
+	State             string          `json:"state"`
+	Pincode           int32           `json:"pincode"`
+	Label             string          `json:"label"`
+	ContactName       sql.NullString  `json:"contact_name"`
+	ContactPhone      sql.NullInt64   `json:"contact_phone"`
+	Latitude          sql.NullFloat64 `json:"latitude"`
+	Longitude         sql.NullFloat64 `json:"longitude"`
+	IsDefaultShipping bool            `json:"is_default_shipping"`
+	IsDefaultBilling  bool            `json:"is_default_billing"`
 }
 
-type AddAddressByUserIDRow struct {
-	ID           uuid.UUID `json:"id"`
-	BuildingName string    `json:"building_name"`
-	StreetName   string    `json:"street_name"`
-	Town         string    `json:"town"`
-	District     string    `json:"district"`
-	State        string    `json:"state"`
-	Pincode      int32     `json:"pincode"`
-}
-
-func (q *Queries) AddAddressByUserID(ctx context.Context, arg AddAddressByUserIDParams) (AddAddressByUserIDRow, error) {
+func (q *Queries) AddAddressByUserID(ctx context.Context, arg AddAddressByUserIDParams) (Address, error) {
 	row := q.queryRow(ctx, q.addAddressByUserIDStmt, addAddressByUserID,
 		arg.UserID,
 		arg.Type,
@@ -50,20 +49,60 @@ func (q *Queries) AddAddressByUserID(ctx context.Context, arg AddAddressByUserID
 		arg.District,
 		arg.State,
 		arg.Pincode,
+		arg.Label,
+		arg.ContactName,
+		arg.ContactPhone,
+		arg.Latitude,
+		arg.Longitude,
+		arg.IsDefaultShipping,
+		arg.IsDefaultBilling,
 	)
-	var i AddAddressByUserIDRow
+	var i Address
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
+		&i.Type,
 		&i.BuildingName,
 		&i.StreetName,
 		&i.Town,
 		&i.District,
 		&i.State,
 		&i.Pincode,
+		&i.Label,
+		&i.ContactName,
+		&i.ContactPhone,
+		&i.Latitude,
+		&i.Longitude,
+		&i.IsDefaultShipping,
+		&i.IsDefaultBilling,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const clearDefaultBillingAddress = `-- name: ClearDefaultBillingAddress :exec
+update addresses
+set is_default_billing = false
+where user_id = $1 and is_default_billing
+`
+
+func (q *Queries) ClearDefaultBillingAddress(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.clearDefaultBillingAddressStmt, clearDefaultBillingAddress, userID)
+	return err
+}
+
+const clearDefaultShippingAddress = `-- name: ClearDefaultShippingAddress :exec
+update addresses
+set is_default_shipping = false
+where user_id = $1 and is_default_shipping
+`
+
+func (q *Queries) ClearDefaultShippingAddress(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.clearDefaultShippingAddressStmt, clearDefaultShippingAddress, userID)
+	return err
+}
+
 const deleteAddressByID = `-- name: DeleteAddressByID :exec
 delete from addresses
 where id = $1
@@ -86,32 +125,31 @@ func (q *Queries) DeleteAddressesByUserID(ctx context.Context, userID uuid.UUID)
 
 const editAddressByID = `-- name: EditAddressByID :one
 update addresses
-set building_name = $2, street_name = $3, town = $4, district = $5, state = $6, pincode = $7, updated_at = current_timestamp
+set building_name = $2, street_name = $3, town = $4, district = $5, state = $6, pincode = $7,
+label = $8, contact_name = $9, contact_phone = $10, latitude = $11, longitude = $12,
+is_default_shipping = $13, is_default_billing = $14, updated_at = current_timestamp
 where id = $1
-returning id, building_name, street_name, town, district, state, pincode
+returning id, user_id, type, building_name, street_name, town, district, state, pincode, label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing, created_at, updated_at
 `
 
 type EditAddressByIDParams struct {
-	ID           uuid.UUID `json:"id"`
-	BuildingName string    `json:"building_name"`
-	StreetName   string    `json:"street_name"`
-	Town         string    `json:"town"`
-	District     string    `json:"district"`
-	State        string    `json:"state"`
-	Pincode      int32     `json:"pincode"`
-}
-
-type EditAddressByIDRow struct {
-	ID           uuid.UUID `json:"id"`
-	BuildingName string    `json:"building_name"`
-	StreetName   string    `json:"street_name"`
-	Town         string    `json:"town"`
-	District     string    `json:"district"`
-	State        string    `json:"state"`
-	Pincode      int32     `json:"pincode"`
+	ID                uuid.UUID       `json:"id"`
+	BuildingName      string          `json:"building_name"`
+	StreetName        string          `json:"street_name"`
+	Town              string          `json:"town"`
+	District          string          `json:"district"`
+	State             string          `json:"state"`
+	Pincode           int32           `json:"pincode"`
+	Label             string          `json:"label"`
+	ContactName       sql.NullString  `json:"contact_name"`
+	ContactPhone      sql.NullInt64   `json:"contact_phone"`
+	Latitude          sql.NullFloat64 `json:"latitude"`
+	Longitude         sql.NullFloat64 `json:"longitude"`
+	IsDefaultShipping bool            `json:"is_default_shipping"`
+	IsDefaultBilling  bool            `json:"is_default_billing"`
 }
 
-func (q *Queries) EditAddressByID(ctx context.Context, arg EditAddressByIDParams) (EditAddressByIDRow, error) {
+func (q *Queries) EditAddressByID(ctx context.Context, arg EditAddressByIDParams) (Address, error) {
 	row := q.queryRow(ctx, q.editAddressByIDStmt, editAddressByID,
 		arg.ID,
 		arg.BuildingName,
@@ -120,22 +158,40 @@ func (q *Queries) EditAddressByID(ctx context.Context, arg EditAddressByIDParams
 		arg.District,
 		arg.State,
 		arg.Pincode,
+		arg.Label,
+		arg.ContactName,
+		arg.ContactPhone,
+		arg.Latitude,
+		arg.Longitude,
+		arg.IsDefaultShipping,
+		arg.IsDefaultBilling,
 	)
-	var i EditAddressByIDRow
+	var i Address
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
+		&i.Type,
 		&i.BuildingName,
 		&i.StreetName,
 		&i.Town,
 		&i.District,
 		&i.State,
 		&i.Pincode,
+		&i.Label,
+		&i.ContactName,
+		&i.ContactPhone,
+		&i.Latitude,
+		&i.Longitude,
+		&i.IsDefaultShipping,
+		&i.IsDefaultBilling,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getAddressByID = `-- name: GetAddressByID :one
-select id, user_id, type, building_name, street_name, town, district, state, pincode, created_at, updated_at from addresses
+select id, user_id, type, building_name, street_name, town, district, state, pincode, label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing, created_at, updated_at from addresses
 where id = $1
 `
 
@@ -152,6 +208,13 @@ func (q *Queries) GetAddressByID(ctx context.Context, id uuid.UUID) (Address, er
 		&i.District,
 		&i.State,
 		&i.Pincode,
+		&i.Label,
+		&i.ContactName,
+		&i.ContactPhone,
+		&i.Latitude,
+		&i.Longitude,
+		&i.IsDefaultShipping,
+		&i.IsDefaultBilling,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -159,7 +222,7 @@ func (q *Queries) GetAddressByID(ctx context.Context, id uuid.UUID) (Address, er
 }
 
 const getAddressBySellerID = `-- name: GetAddressBySellerID :one
-select id, user_id, type, building_name, street_name, town, district, state, pincode, created_at, updated_at from addresses
+select id, user_id, type, building_name, street_name, town, district, state, pincode, label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing, created_at, updated_at from addresses
 where user_id = $1
 limit 1
 `
@@ -177,6 +240,13 @@ func (q *Queries) GetAddressBySellerID(ctx context.Context, userID uuid.UUID) (A
 		&i.District,
 		&i.State,
 		&i.Pincode,
+		&i.Label,
+		&i.ContactName,
+		&i.ContactPhone,
+		&i.Latitude,
+		&i.Longitude,
+		&i.IsDefaultShipping,
+		&i.IsDefaultBilling,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -184,37 +254,39 @@ func (q *Queries) GetAddressBySellerID(ctx context.Context, userID uuid.UUID) (A
 }
 
 const getAddressesByUserID = `-- name: GetAddressesByUserID :many
-select id, building_name, street_name, town, district, state, pincode from addresses
+select id, user_id, type, building_name, street_name, town, district, state, pincode, label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing, created_at, updated_at from addresses
 where user_id = $1
+order by is_default_shipping desc, is_default_billing desc, created_at
 `
 
-type GetAddressesByUserIDRow struct {
-	ID           uuid.UUID `json:"id"`
-	BuildingName string    `json:"building_name"`
-	StreetName   string    `json:"street_name"`
-	Town         string    `json:"town"`
-	District     string    `json:"district"`
-	State        string    `json:"state"`
-	Pincode      int32     `json:"pincode"`
-}
-
-func (q *Queries) GetAddressesByUserID(ctx context.Context, userID uuid.UUID) ([]GetAddressesByUserIDRow, error) {
+func (q *Queries) GetAddressesByUserID(ctx context.Context, userID uuid.UUID) ([]Address, error) {
 	rows, err := q.query(ctx, q.getAddressesByUserIDStmt, getAddressesByUserID, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []GetAddressesByUserIDRow{}
+	items := []Address{}
 	for rows.Next() {
-		var i GetAddressesByUserIDRow
+		var i Address
 		if err := rows.Scan(
 			&i.ID,
+			&i.UserID,
+			&i.Type,
 			&i.BuildingName,
 			&i.StreetName,
 			&i.Town,
 			&i.District,
 			&i.State,
 			&i.Pincode,
+			&i.Label,
+			&i.ContactName,
+			&i.ContactPhone,
+			&i.Latitude,
+			&i.Longitude,
+			&i.IsDefaultShipping,
+			&i.IsDefaultBilling,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -228,3 +300,55 @@ func (q *Queries) GetAddressesByUserID(ctx context.Context, userID uuid.UUID) ([
 	}
 	return items, nil
 }
+
+const getDefaultShippingAddressByUserID = `-- name: GetDefaultShippingAddressByUserID :one
+select id, user_id, type, building_name, street_name, town, district, state, pincode, label, contact_name, contact_phone, latitude, longitude, is_default_shipping, is_default_billing, created_at, updated_at from addresses
+where user_id = $1 and is_default_shipping
+`
+
+func (q *Queries) GetDefaultShippingAddressByUserID(ctx context.Context, userID uuid.UUID) (Address, error) {
+	row := q.queryRow(ctx, q.getDefaultShippingAddressByUserIDStmt, getDefaultShippingAddressByUserID, userID)
+	var i Address
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Type,
+		&i.BuildingName,
+		&i.StreetName,
+		&i.Town,
+		&i.District,
+		&i.State,
+		&i.Pincode,
+		&i.Label,
+		&i.ContactName,
+		&i.ContactPhone,
+		&i.Latitude,
+		&i.Longitude,
+		&i.IsDefaultShipping,
+		&i.IsDefaultBilling,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setMissingDefaultAddresses = `-- name: SetMissingDefaultAddresses :exec
+update addresses
+set is_default_shipping = is_default_shipping or not exists (
+        select 1 from addresses a where a.user_id = $1 and a.is_default_shipping),
+    is_default_billing = is_default_billing or not exists (
+        select 1 from addresses a where a.user_id = $1 and a.is_default_billing)
+where id = (
+    select id from addresses
+    where user_id = $1
+    order by created_at desc
+    limit 1
+)
+`
+
+// the newest address becomes the default when the user has none,
+// so the first address added and the one left after a delete are defaults
+func (q *Queries) SetMissingDefaultAddresses(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.setMissingDefaultAddressesStmt, setMissingDefaultAddresses, userID)
+	return err
+}
@@ -24,18 +24,51 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.addAccountDeletionStmt, err = db.PrepareContext(ctx, addAccountDeletion); err != nil {
+		return nil, fmt.Errorf("error preparing query AddAccountDeletion: %w", err)
+	}
 	if q.addAddressByUserIDStmt, err = db.PrepareContext(ctx, addAddressByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query AddAddressByUserID: %w", err)
 	}
 	if q.addAndVerifyUserStmt, err = db.PrepareContext(ctx, addAndVerifyUser); err != nil {
 		return nil, fmt.Errorf("error preparing query AddAndVerifyUser: %w", err)
 	}
+	if q.addEmailChangeStmt, err = db.PrepareContext(ctx, addEmailChange); err != nil {
+		return nil, fmt.Errorf("error preparing query AddEmailChange: %w", err)
+	}
 	if q.addForgotOTPByUserIDStmt, err = db.PrepareContext(ctx, addForgotOTPByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query AddForgotOTPByUserID: %w", err)
 	}
+	if q.addIdentityStmt, err = db.PrepareContext(ctx, addIdentity); err != nil {
+		return nil, fmt.Errorf("error preparing query AddIdentity: %w", err)
+	}
+	if q.addImpersonationStmt, err = db.PrepareContext(ctx, addImpersonation); err != nil {
+		return nil, fmt.Errorf("error preparing query AddImpersonation: %w", err)
+	}
+	if q.addImpersonationEventStmt, err = db.PrepareContext(ctx, addImpersonationEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query AddImpersonationEvent: %w", err)
+	}
+	if q.addLoginChallengeStmt, err = db.PrepareContext(ctx, addLoginChallenge); err != nil {
+		return nil, fmt.Errorf("error preparing query AddLoginChallenge: %w", err)
+	}
+	if q.addOAuthStateStmt, err = db.PrepareContext(ctx, addOAuthState); err != nil {
+		return nil, fmt.Errorf("error preparing query AddOAuthState: %w", err)
+	}
 	if q.addOTPStmt, err = db.PrepareContext(ctx, addOTP); err != nil {
 		return nil, fmt.Errorf("error preparing query AddOTP: %w", err)
 	}
+	if q.addPhoneVerificationStmt, err = db.PrepareContext(ctx, addPhoneVerification); err != nil {
+		return nil, fmt.Errorf("error preparing query AddPhoneVerification: %w", err)
+	}
+	if q.addRecoveryCodeStmt, err = db.PrepareContext(ctx, addRecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query AddRecoveryCode: %w", err)
+	}
+	if q.addRoleStmt, err = db.PrepareContext(ctx, addRole); err != nil {
+		return nil, fmt.Errorf("error preparing query AddRole: %w", err)
+	}
+	if q.addRolePermissionStmt, err = db.PrepareContext(ctx, addRolePermission); err != nil {
+		return nil, fmt.Errorf("error preparing query AddRolePermission: %w", err)
+	}
 	if q.addSavingsToWalletByUserIDStmt, err = db.PrepareContext(ctx, addSavingsToWalletByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query AddSavingsToWalletByUserID: %w", err)
 	}
@@ -48,35 +81,125 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addUserStmt, err = db.PrepareContext(ctx, addUser); err != nil {
 		return nil, fmt.Errorf("error preparing query AddUser: %w", err)
 	}
+	if q.addUserRoleStmt, err = db.PrepareContext(ctx, addUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query AddUserRole: %w", err)
+	}
 	if q.addWalletByUserIDStmt, err = db.PrepareContext(ctx, addWalletByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query AddWalletByUserID: %w", err)
 	}
+	if q.anonymizeUserByIDStmt, err = db.PrepareContext(ctx, anonymizeUserByID); err != nil {
+		return nil, fmt.Errorf("error preparing query AnonymizeUserByID: %w", err)
+	}
 	if q.blockUserByIDStmt, err = db.PrepareContext(ctx, blockUserByID); err != nil {
 		return nil, fmt.Errorf("error preparing query BlockUserByID: %w", err)
 	}
+	if q.cancelAccountDeletionStmt, err = db.PrepareContext(ctx, cancelAccountDeletion); err != nil {
+		return nil, fmt.Errorf("error preparing query CancelAccountDeletion: %w", err)
+	}
 	if q.changeNameByUserIDStmt, err = db.PrepareContext(ctx, changeNameByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query ChangeNameByUserID: %w", err)
 	}
 	if q.changePasswordByUserIDStmt, err = db.PrepareContext(ctx, changePasswordByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query ChangePasswordByUserID: %w", err)
 	}
+	if q.changeUserEmailStmt, err = db.PrepareContext(ctx, changeUserEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query ChangeUserEmail: %w", err)
+	}
+	if q.clearDefaultBillingAddressStmt, err = db.PrepareContext(ctx, clearDefaultBillingAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearDefaultBillingAddress: %w", err)
+	}
+	if q.clearDefaultShippingAddressStmt, err = db.PrepareContext(ctx, clearDefaultShippingAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearDefaultShippingAddress: %w", err)
+	}
+	if q.completeAccountDeletionStmt, err = db.PrepareContext(ctx, completeAccountDeletion); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteAccountDeletion: %w", err)
+	}
+	if q.consumeOAuthStateStmt, err = db.PrepareContext(ctx, consumeOAuthState); err != nil {
+		return nil, fmt.Errorf("error preparing query ConsumeOAuthState: %w", err)
+	}
+	if q.countUnreadNotificationsStmt, err = db.PrepareContext(ctx, countUnreadNotifications); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUnreadNotifications: %w", err)
+	}
+	if q.countUsersWithRoleStmt, err = db.PrepareContext(ctx, countUsersWithRole); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUsersWithRole: %w", err)
+	}
 	if q.deleteAddressByIDStmt, err = db.PrepareContext(ctx, deleteAddressByID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAddressByID: %w", err)
 	}
 	if q.deleteAddressesByUserIDStmt, err = db.PrepareContext(ctx, deleteAddressesByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAddressesByUserID: %w", err)
 	}
+	if q.deleteEmailChangesByUserIDStmt, err = db.PrepareContext(ctx, deleteEmailChangesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteEmailChangesByUserID: %w", err)
+	}
+	if q.deleteExpiredEmailChangesStmt, err = db.PrepareContext(ctx, deleteExpiredEmailChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredEmailChanges: %w", err)
+	}
+	if q.deleteExpiredLoginChallengesStmt, err = db.PrepareContext(ctx, deleteExpiredLoginChallenges); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredLoginChallenges: %w", err)
+	}
+	if q.deleteExpiredOAuthStatesStmt, err = db.PrepareContext(ctx, deleteExpiredOAuthStates); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredOAuthStates: %w", err)
+	}
+	if q.deleteExpiredPhoneVerificationsStmt, err = db.PrepareContext(ctx, deleteExpiredPhoneVerifications); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredPhoneVerifications: %w", err)
+	}
+	if q.deleteExpiredSessionsStmt, err = db.PrepareContext(ctx, deleteExpiredSessions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteExpiredSessions: %w", err)
+	}
 	if q.deleteForgotOTPByEmailStmt, err = db.PrepareContext(ctx, deleteForgotOTPByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteForgotOTPByEmail: %w", err)
 	}
+	if q.deleteForgotOTPByIDStmt, err = db.PrepareContext(ctx, deleteForgotOTPByID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteForgotOTPByID: %w", err)
+	}
+	if q.deleteForgotOTPsByUserIDStmt, err = db.PrepareContext(ctx, deleteForgotOTPsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteForgotOTPsByUserID: %w", err)
+	}
+	if q.deleteIdentitiesByUserIDStmt, err = db.PrepareContext(ctx, deleteIdentitiesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdentitiesByUserID: %w", err)
+	}
+	if q.deleteIdentityByUserIDAndProviderStmt, err = db.PrepareContext(ctx, deleteIdentityByUserIDAndProvider); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIdentityByUserIDAndProvider: %w", err)
+	}
+	if q.deleteLoginChallengeByIDStmt, err = db.PrepareContext(ctx, deleteLoginChallengeByID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteLoginChallengeByID: %w", err)
+	}
+	if q.deleteMailOutboxByUserIDStmt, err = db.PrepareContext(ctx, deleteMailOutboxByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteMailOutboxByUserID: %w", err)
+	}
+	if q.deleteNotificationsByUserIDStmt, err = db.PrepareContext(ctx, deleteNotificationsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNotificationsByUserID: %w", err)
+	}
 	if q.deleteOTPByEmailStmt, err = db.PrepareContext(ctx, deleteOTPByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteOTPByEmail: %w", err)
 	}
-	if q.deleteSessionByIDStmt, err = db.PrepareContext(ctx, deleteSessionByID); err != nil {
-		return nil, fmt.Errorf("error preparing query DeleteSessionByID: %w", err)
+	if q.deleteOTPByIDStmt, err = db.PrepareContext(ctx, deleteOTPByID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOTPByID: %w", err)
+	}
+	if q.deleteOTPsByUserIDStmt, err = db.PrepareContext(ctx, deleteOTPsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOTPsByUserID: %w", err)
+	}
+	if q.deletePhoneVerificationsByUserIDStmt, err = db.PrepareContext(ctx, deletePhoneVerificationsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeletePhoneVerificationsByUserID: %w", err)
+	}
+	if q.deleteRecoveryCodesByUserIDStmt, err = db.PrepareContext(ctx, deleteRecoveryCodesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRecoveryCodesByUserID: %w", err)
 	}
-	if q.deleteSessionsByuserIDStmt, err = db.PrepareContext(ctx, deleteSessionsByuserID); err != nil {
-		return nil, fmt.Errorf("error preparing query DeleteSessionsByuserID: %w", err)
+	if q.deleteRolePermissionsStmt, err = db.PrepareContext(ctx, deleteRolePermissions); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRolePermissions: %w", err)
+	}
+	if q.deleteSellerDocumentsBySellerIDStmt, err = db.PrepareContext(ctx, deleteSellerDocumentsBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSellerDocumentsBySellerID: %w", err)
+	}
+	if q.deleteSellerOnboardingBySellerIDStmt, err = db.PrepareContext(ctx, deleteSellerOnboardingBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSellerOnboardingBySellerID: %w", err)
+	}
+	if q.deleteUserRoleStmt, err = db.PrepareContext(ctx, deleteUserRole); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserRole: %w", err)
+	}
+	if q.deleteUserTOTPStmt, err = db.PrepareContext(ctx, deleteUserTOTP); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUserTOTP: %w", err)
 	}
 	if q.editAddressByIDStmt, err = db.PrepareContext(ctx, editAddressByID); err != nil {
 		return nil, fmt.Errorf("error preparing query EditAddressByID: %w", err)
@@ -87,6 +210,15 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.editUserByIDStmt, err = db.PrepareContext(ctx, editUserByID); err != nil {
 		return nil, fmt.Errorf("error preparing query EditUserByID: %w", err)
 	}
+	if q.enableUserTOTPStmt, err = db.PrepareContext(ctx, enableUserTOTP); err != nil {
+		return nil, fmt.Errorf("error preparing query EnableUserTOTP: %w", err)
+	}
+	if q.getAccountDeletionByUserIDStmt, err = db.PrepareContext(ctx, getAccountDeletionByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAccountDeletionByUserID: %w", err)
+	}
+	if q.getActiveSessionsByUserIDStmt, err = db.PrepareContext(ctx, getActiveSessionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveSessionsByUserID: %w", err)
+	}
 	if q.getAddressByIDStmt, err = db.PrepareContext(ctx, getAddressByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAddressByID: %w", err)
 	}
@@ -108,9 +240,81 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getAllUsersByRoleUserStmt, err = db.PrepareContext(ctx, getAllUsersByRoleUser); err != nil {
 		return nil, fmt.Errorf("error preparing query GetAllUsersByRoleUser: %w", err)
 	}
+	if q.getDefaultShippingAddressByUserIDStmt, err = db.PrepareContext(ctx, getDefaultShippingAddressByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDefaultShippingAddressByUserID: %w", err)
+	}
+	if q.getDueAccountDeletionsStmt, err = db.PrepareContext(ctx, getDueAccountDeletions); err != nil {
+		return nil, fmt.Errorf("error preparing query GetDueAccountDeletions: %w", err)
+	}
+	if q.getIdentitiesByUserIDStmt, err = db.PrepareContext(ctx, getIdentitiesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdentitiesByUserID: %w", err)
+	}
+	if q.getIdentityByProviderSubjectStmt, err = db.PrepareContext(ctx, getIdentityByProviderSubject); err != nil {
+		return nil, fmt.Errorf("error preparing query GetIdentityByProviderSubject: %w", err)
+	}
+	if q.getImpersonationEventsStmt, err = db.PrepareContext(ctx, getImpersonationEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query GetImpersonationEvents: %w", err)
+	}
+	if q.getImpersonationsStmt, err = db.PrepareContext(ctx, getImpersonations); err != nil {
+		return nil, fmt.Errorf("error preparing query GetImpersonations: %w", err)
+	}
+	if q.getLoginChallengeByTokenHashStmt, err = db.PrepareContext(ctx, getLoginChallengeByTokenHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLoginChallengeByTokenHash: %w", err)
+	}
+	if q.getNotificationsByUserIDStmt, err = db.PrepareContext(ctx, getNotificationsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetNotificationsByUserID: %w", err)
+	}
+	if q.getOrCreateSellerOnboardingStmt, err = db.PrepareContext(ctx, getOrCreateSellerOnboarding); err != nil {
+		return nil, fmt.Errorf("error preparing query GetOrCreateSellerOnboarding: %w", err)
+	}
+	if q.getPermissionsByUserIDStmt, err = db.PrepareContext(ctx, getPermissionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetPermissionsByUserID: %w", err)
+	}
+	if q.getRole2FAPoliciesStmt, err = db.PrepareContext(ctx, getRole2FAPolicies); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRole2FAPolicies: %w", err)
+	}
+	if q.getRoleByNameStmt, err = db.PrepareContext(ctx, getRoleByName); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRoleByName: %w", err)
+	}
+	if q.getRolePermissionsStmt, err = db.PrepareContext(ctx, getRolePermissions); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRolePermissions: %w", err)
+	}
+	if q.getRolesStmt, err = db.PrepareContext(ctx, getRoles); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRoles: %w", err)
+	}
+	if q.getRolesByUserIDStmt, err = db.PrepareContext(ctx, getRolesByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetRolesByUserID: %w", err)
+	}
+	if q.getSellerByIDStmt, err = db.PrepareContext(ctx, getSellerByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerByID: %w", err)
+	}
+	if q.getSellerDocumentByIDStmt, err = db.PrepareContext(ctx, getSellerDocumentByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerDocumentByID: %w", err)
+	}
+	if q.getSellerDocumentBySellerIDAndKindStmt, err = db.PrepareContext(ctx, getSellerDocumentBySellerIDAndKind); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerDocumentBySellerIDAndKind: %w", err)
+	}
+	if q.getSellerDocumentsBySellerIDStmt, err = db.PrepareContext(ctx, getSellerDocumentsBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerDocumentsBySellerID: %w", err)
+	}
+	if q.getSellerOnboardingBySellerIDStmt, err = db.PrepareContext(ctx, getSellerOnboardingBySellerID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerOnboardingBySellerID: %w", err)
+	}
+	if q.getSellerOnboardingsByStatusStmt, err = db.PrepareContext(ctx, getSellerOnboardingsByStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSellerOnboardingsByStatus: %w", err)
+	}
+	if q.getSessionByPreviousTokenHashStmt, err = db.PrepareContext(ctx, getSessionByPreviousTokenHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSessionByPreviousTokenHash: %w", err)
+	}
+	if q.getSessionByRefreshTokenHashStmt, err = db.PrepareContext(ctx, getSessionByRefreshTokenHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSessionByRefreshTokenHash: %w", err)
+	}
 	if q.getSessionDetailsByIDStmt, err = db.PrepareContext(ctx, getSessionDetailsByID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSessionDetailsByID: %w", err)
 	}
+	if q.getUnusedRecoveryCodeCountStmt, err = db.PrepareContext(ctx, getUnusedRecoveryCodeCount); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUnusedRecoveryCodeCount: %w", err)
+	}
 	if q.getUserByEmailStmt, err = db.PrepareContext(ctx, getUserByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserByEmail: %w", err)
 	}
@@ -120,24 +324,108 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getUserBySessionIDStmt, err = db.PrepareContext(ctx, getUserBySessionID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserBySessionID: %w", err)
 	}
+	if q.getUserTOTPByUserIDStmt, err = db.PrepareContext(ctx, getUserTOTPByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUserTOTPByUserID: %w", err)
+	}
 	if q.getUserWithPasswordByEmailStmt, err = db.PrepareContext(ctx, getUserWithPasswordByEmail); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUserWithPasswordByEmail: %w", err)
 	}
+	if q.getValidEmailChangeByUserIDStmt, err = db.PrepareContext(ctx, getValidEmailChangeByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetValidEmailChangeByUserID: %w", err)
+	}
 	if q.getValidForgotOTPByUserIDStmt, err = db.PrepareContext(ctx, getValidForgotOTPByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetValidForgotOTPByUserID: %w", err)
 	}
 	if q.getValidOTPByUserIDStmt, err = db.PrepareContext(ctx, getValidOTPByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetValidOTPByUserID: %w", err)
 	}
+	if q.getValidPhoneVerificationByUserIDStmt, err = db.PrepareContext(ctx, getValidPhoneVerificationByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetValidPhoneVerificationByUserID: %w", err)
+	}
 	if q.getWalletByUserIDStmt, err = db.PrepareContext(ctx, getWalletByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query GetWalletByUserID: %w", err)
 	}
+	if q.incLoginChallengeAttemptsStmt, err = db.PrepareContext(ctx, incLoginChallengeAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query IncLoginChallengeAttempts: %w", err)
+	}
+	if q.incrementEmailChangeAttemptsStmt, err = db.PrepareContext(ctx, incrementEmailChangeAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query IncrementEmailChangeAttempts: %w", err)
+	}
+	if q.incrementForgotOTPAttemptsStmt, err = db.PrepareContext(ctx, incrementForgotOTPAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query IncrementForgotOTPAttempts: %w", err)
+	}
+	if q.incrementOTPAttemptsStmt, err = db.PrepareContext(ctx, incrementOTPAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query IncrementOTPAttempts: %w", err)
+	}
+	if q.incrementPhoneVerificationAttemptsStmt, err = db.PrepareContext(ctx, incrementPhoneVerificationAttempts); err != nil {
+		return nil, fmt.Errorf("error preparing query IncrementPhoneVerificationAttempts: %w", err)
+	}
+	if q.isRole2FARequiredStmt, err = db.PrepareContext(ctx, isRole2FARequired); err != nil {
+		return nil, fmt.Errorf("error preparing query IsRole2FARequired: %w", err)
+	}
+	if q.markAllNotificationsReadStmt, err = db.PrepareContext(ctx, markAllNotificationsRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkAllNotificationsRead: %w", err)
+	}
+	if q.markNotificationReadStmt, err = db.PrepareContext(ctx, markNotificationRead); err != nil {
+		return nil, fmt.Errorf("error preparing query MarkNotificationRead: %w", err)
+	}
+	if q.postponeAccountDeletionStmt, err = db.PrepareContext(ctx, postponeAccountDeletion); err != nil {
+		return nil, fmt.Errorf("error preparing query PostponeAccountDeletion: %w", err)
+	}
 	if q.retractSavingsFromWalletByUserIDStmt, err = db.PrepareContext(ctx, retractSavingsFromWalletByUserID); err != nil {
 		return nil, fmt.Errorf("error preparing query RetractSavingsFromWalletByUserID: %w", err)
 	}
+	if q.reviewSellerDocumentByIDStmt, err = db.PrepareContext(ctx, reviewSellerDocumentByID); err != nil {
+		return nil, fmt.Errorf("error preparing query ReviewSellerDocumentByID: %w", err)
+	}
+	if q.reviewSellerOnboardingStmt, err = db.PrepareContext(ctx, reviewSellerOnboarding); err != nil {
+		return nil, fmt.Errorf("error preparing query ReviewSellerOnboarding: %w", err)
+	}
+	if q.revokeOtherSessionsByUserIDStmt, err = db.PrepareContext(ctx, revokeOtherSessionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeOtherSessionsByUserID: %w", err)
+	}
+	if q.revokeSessionByIDStmt, err = db.PrepareContext(ctx, revokeSessionByID); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeSessionByID: %w", err)
+	}
+	if q.revokeSessionByIDAndUserIDStmt, err = db.PrepareContext(ctx, revokeSessionByIDAndUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeSessionByIDAndUserID: %w", err)
+	}
+	if q.revokeSessionsByUserIDStmt, err = db.PrepareContext(ctx, revokeSessionsByUserID); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeSessionsByUserID: %w", err)
+	}
+	if q.rotateSessionRefreshTokenStmt, err = db.PrepareContext(ctx, rotateSessionRefreshToken); err != nil {
+		return nil, fmt.Errorf("error preparing query RotateSessionRefreshToken: %w", err)
+	}
+	if q.setMissingDefaultAddressesStmt, err = db.PrepareContext(ctx, setMissingDefaultAddresses); err != nil {
+		return nil, fmt.Errorf("error preparing query SetMissingDefaultAddresses: %w", err)
+	}
+	if q.setSellerOnboardingDraftStmt, err = db.PrepareContext(ctx, setSellerOnboardingDraft); err != nil {
+		return nil, fmt.Errorf("error preparing query SetSellerOnboardingDraft: %w", err)
+	}
+	if q.setUserTOTPLastUsedStepStmt, err = db.PrepareContext(ctx, setUserTOTPLastUsedStep); err != nil {
+		return nil, fmt.Errorf("error preparing query SetUserTOTPLastUsedStep: %w", err)
+	}
+	if q.stopImpersonationStmt, err = db.PrepareContext(ctx, stopImpersonation); err != nil {
+		return nil, fmt.Errorf("error preparing query StopImpersonation: %w", err)
+	}
+	if q.submitSellerOnboardingStmt, err = db.PrepareContext(ctx, submitSellerOnboarding); err != nil {
+		return nil, fmt.Errorf("error preparing query SubmitSellerOnboarding: %w", err)
+	}
 	if q.unblockUserByIDStmt, err = db.PrepareContext(ctx, unblockUserByID); err != nil {
 		return nil, fmt.Errorf("error preparing query UnblockUserByID: %w", err)
 	}
+	if q.upsertPendingUserTOTPStmt, err = db.PrepareContext(ctx, upsertPendingUserTOTP); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertPendingUserTOTP: %w", err)
+	}
+	if q.upsertRole2FAPolicyStmt, err = db.PrepareContext(ctx, upsertRole2FAPolicy); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertRole2FAPolicy: %w", err)
+	}
+	if q.upsertSellerDocumentStmt, err = db.PrepareContext(ctx, upsertSellerDocument); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertSellerDocument: %w", err)
+	}
+	if q.useRecoveryCodeStmt, err = db.PrepareContext(ctx, useRecoveryCode); err != nil {
+		return nil, fmt.Errorf("error preparing query UseRecoveryCode: %w", err)
+	}
 	if q.verifySellerByIDStmt, err = db.PrepareContext(ctx, verifySellerByID); err != nil {
 		return nil, fmt.Errorf("error preparing query VerifySellerByID: %w", err)
 	}
@@ -147,11 +435,19 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.verifyUserByIDStmt, err = db.PrepareContext(ctx, verifyUserByID); err != nil {
 		return nil, fmt.Errorf("error preparing query VerifyUserByID: %w", err)
 	}
+	if q.verifyUserPhoneStmt, err = db.PrepareContext(ctx, verifyUserPhone); err != nil {
+		return nil, fmt.Errorf("error preparing query VerifyUserPhone: %w", err)
+	}
 	return &q, nil
 }
 
 func (q *Queries) Close() error {
 	var err error
+	if q.addAccountDeletionStmt != nil {
+		if cerr := q.addAccountDeletionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addAccountDeletionStmt: %w", cerr)
+		}
+	}
 	if q.addAddressByUserIDStmt != nil {
 		if cerr := q.addAddressByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addAddressByUserIDStmt: %w", cerr)
@@ -162,16 +458,66 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addAndVerifyUserStmt: %w", cerr)
 		}
 	}
+	if q.addEmailChangeStmt != nil {
+		if cerr := q.addEmailChangeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addEmailChangeStmt: %w", cerr)
+		}
+	}
 	if q.addForgotOTPByUserIDStmt != nil {
 		if cerr := q.addForgotOTPByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addForgotOTPByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.addIdentityStmt != nil {
+		if cerr := q.addIdentityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addIdentityStmt: %w", cerr)
+		}
+	}
+	if q.addImpersonationStmt != nil {
+		if cerr := q.addImpersonationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addImpersonationStmt: %w", cerr)
+		}
+	}
+	if q.addImpersonationEventStmt != nil {
+		if cerr := q.addImpersonationEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addImpersonationEventStmt: %w", cerr)
+		}
+	}
+	if q.addLoginChallengeStmt != nil {
+		if cerr := q.addLoginChallengeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addLoginChallengeStmt: %w", cerr)
+		}
+	}
+	if q.addOAuthStateStmt != nil {
+		if cerr := q.addOAuthStateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addOAuthStateStmt: %w", cerr)
+		}
+	}
 	if q.addOTPStmt != nil {
 		if cerr := q.addOTPStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addOTPStmt: %w", cerr)
 		}
 	}
+	if q.addPhoneVerificationStmt != nil {
+		if cerr := q.addPhoneVerificationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addPhoneVerificationStmt: %w", cerr)
+		}
+	}
+	if q.addRecoveryCodeStmt != nil {
+		if cerr := q.addRecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addRecoveryCodeStmt: %w", cerr)
+		}
+	}
+	if q.addRoleStmt != nil {
+		if cerr := q.addRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addRoleStmt: %w", cerr)
+		}
+	}
+	if q.addRolePermissionStmt != nil {
+		if cerr := q.addRolePermissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addRolePermissionStmt: %w", cerr)
+		}
+	}
 	if q.addSavingsToWalletByUserIDStmt != nil {
 		if cerr := q.addSavingsToWalletByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addSavingsToWalletByUserIDStmt: %w", cerr)
@@ -192,16 +538,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addUserStmt: %w", cerr)
 		}
 	}
+	if q.addUserRoleStmt != nil {
+		if cerr := q.addUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addUserRoleStmt: %w", cerr)
+		}
+	}
 	if q.addWalletByUserIDStmt != nil {
 		if cerr := q.addWalletByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addWalletByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.anonymizeUserByIDStmt != nil {
+		if cerr := q.anonymizeUserByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing anonymizeUserByIDStmt: %w", cerr)
+		}
+	}
 	if q.blockUserByIDStmt != nil {
 		if cerr := q.blockUserByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing blockUserByIDStmt: %w", cerr)
 		}
 	}
+	if q.cancelAccountDeletionStmt != nil {
+		if cerr := q.cancelAccountDeletionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing cancelAccountDeletionStmt: %w", cerr)
+		}
+	}
 	if q.changeNameByUserIDStmt != nil {
 		if cerr := q.changeNameByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing changeNameByUserIDStmt: %w", cerr)
@@ -212,6 +573,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing changePasswordByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.changeUserEmailStmt != nil {
+		if cerr := q.changeUserEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing changeUserEmailStmt: %w", cerr)
+		}
+	}
+	if q.clearDefaultBillingAddressStmt != nil {
+		if cerr := q.clearDefaultBillingAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearDefaultBillingAddressStmt: %w", cerr)
+		}
+	}
+	if q.clearDefaultShippingAddressStmt != nil {
+		if cerr := q.clearDefaultShippingAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearDefaultShippingAddressStmt: %w", cerr)
+		}
+	}
+	if q.completeAccountDeletionStmt != nil {
+		if cerr := q.completeAccountDeletionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeAccountDeletionStmt: %w", cerr)
+		}
+	}
+	if q.consumeOAuthStateStmt != nil {
+		if cerr := q.consumeOAuthStateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing consumeOAuthStateStmt: %w", cerr)
+		}
+	}
+	if q.countUnreadNotificationsStmt != nil {
+		if cerr := q.countUnreadNotificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUnreadNotificationsStmt: %w", cerr)
+		}
+	}
+	if q.countUsersWithRoleStmt != nil {
+		if cerr := q.countUsersWithRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUsersWithRoleStmt: %w", cerr)
+		}
+	}
 	if q.deleteAddressByIDStmt != nil {
 		if cerr := q.deleteAddressByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAddressByIDStmt: %w", cerr)
@@ -222,24 +618,124 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteAddressesByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.deleteEmailChangesByUserIDStmt != nil {
+		if cerr := q.deleteEmailChangesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteEmailChangesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredEmailChangesStmt != nil {
+		if cerr := q.deleteExpiredEmailChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredEmailChangesStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredLoginChallengesStmt != nil {
+		if cerr := q.deleteExpiredLoginChallengesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredLoginChallengesStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredOAuthStatesStmt != nil {
+		if cerr := q.deleteExpiredOAuthStatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredOAuthStatesStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredPhoneVerificationsStmt != nil {
+		if cerr := q.deleteExpiredPhoneVerificationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredPhoneVerificationsStmt: %w", cerr)
+		}
+	}
+	if q.deleteExpiredSessionsStmt != nil {
+		if cerr := q.deleteExpiredSessionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteExpiredSessionsStmt: %w", cerr)
+		}
+	}
 	if q.deleteForgotOTPByEmailStmt != nil {
 		if cerr := q.deleteForgotOTPByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteForgotOTPByEmailStmt: %w", cerr)
 		}
 	}
+	if q.deleteForgotOTPByIDStmt != nil {
+		if cerr := q.deleteForgotOTPByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteForgotOTPByIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteForgotOTPsByUserIDStmt != nil {
+		if cerr := q.deleteForgotOTPsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteForgotOTPsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteIdentitiesByUserIDStmt != nil {
+		if cerr := q.deleteIdentitiesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdentitiesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteIdentityByUserIDAndProviderStmt != nil {
+		if cerr := q.deleteIdentityByUserIDAndProviderStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIdentityByUserIDAndProviderStmt: %w", cerr)
+		}
+	}
+	if q.deleteLoginChallengeByIDStmt != nil {
+		if cerr := q.deleteLoginChallengeByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteLoginChallengeByIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteMailOutboxByUserIDStmt != nil {
+		if cerr := q.deleteMailOutboxByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteMailOutboxByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteNotificationsByUserIDStmt != nil {
+		if cerr := q.deleteNotificationsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNotificationsByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.deleteOTPByEmailStmt != nil {
 		if cerr := q.deleteOTPByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteOTPByEmailStmt: %w", cerr)
 		}
 	}
-	if q.deleteSessionByIDStmt != nil {
-		if cerr := q.deleteSessionByIDStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing deleteSessionByIDStmt: %w", cerr)
+	if q.deleteOTPByIDStmt != nil {
+		if cerr := q.deleteOTPByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOTPByIDStmt: %w", cerr)
 		}
 	}
-	if q.deleteSessionsByuserIDStmt != nil {
-		if cerr := q.deleteSessionsByuserIDStmt.Close(); cerr != nil {
-			err = fmt.Errorf("error closing deleteSessionsByuserIDStmt: %w", cerr)
+	if q.deleteOTPsByUserIDStmt != nil {
+		if cerr := q.deleteOTPsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOTPsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deletePhoneVerificationsByUserIDStmt != nil {
+		if cerr := q.deletePhoneVerificationsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deletePhoneVerificationsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteRecoveryCodesByUserIDStmt != nil {
+		if cerr := q.deleteRecoveryCodesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRecoveryCodesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteRolePermissionsStmt != nil {
+		if cerr := q.deleteRolePermissionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRolePermissionsStmt: %w", cerr)
+		}
+	}
+	if q.deleteSellerDocumentsBySellerIDStmt != nil {
+		if cerr := q.deleteSellerDocumentsBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSellerDocumentsBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteSellerOnboardingBySellerIDStmt != nil {
+		if cerr := q.deleteSellerOnboardingBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSellerOnboardingBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserRoleStmt != nil {
+		if cerr := q.deleteUserRoleStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserRoleStmt: %w", cerr)
+		}
+	}
+	if q.deleteUserTOTPStmt != nil {
+		if cerr := q.deleteUserTOTPStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUserTOTPStmt: %w", cerr)
 		}
 	}
 	if q.editAddressByIDStmt != nil {
@@ -257,6 +753,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing editUserByIDStmt: %w", cerr)
 		}
 	}
+	if q.enableUserTOTPStmt != nil {
+		if cerr := q.enableUserTOTPStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing enableUserTOTPStmt: %w", cerr)
+		}
+	}
+	if q.getAccountDeletionByUserIDStmt != nil {
+		if cerr := q.getAccountDeletionByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAccountDeletionByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getActiveSessionsByUserIDStmt != nil {
+		if cerr := q.getActiveSessionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveSessionsByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getAddressByIDStmt != nil {
 		if cerr := q.getAddressByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getAddressByIDStmt: %w", cerr)
@@ -292,11 +803,131 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getAllUsersByRoleUserStmt: %w", cerr)
 		}
 	}
+	if q.getDefaultShippingAddressByUserIDStmt != nil {
+		if cerr := q.getDefaultShippingAddressByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDefaultShippingAddressByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getDueAccountDeletionsStmt != nil {
+		if cerr := q.getDueAccountDeletionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getDueAccountDeletionsStmt: %w", cerr)
+		}
+	}
+	if q.getIdentitiesByUserIDStmt != nil {
+		if cerr := q.getIdentitiesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdentitiesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getIdentityByProviderSubjectStmt != nil {
+		if cerr := q.getIdentityByProviderSubjectStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getIdentityByProviderSubjectStmt: %w", cerr)
+		}
+	}
+	if q.getImpersonationEventsStmt != nil {
+		if cerr := q.getImpersonationEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getImpersonationEventsStmt: %w", cerr)
+		}
+	}
+	if q.getImpersonationsStmt != nil {
+		if cerr := q.getImpersonationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getImpersonationsStmt: %w", cerr)
+		}
+	}
+	if q.getLoginChallengeByTokenHashStmt != nil {
+		if cerr := q.getLoginChallengeByTokenHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLoginChallengeByTokenHashStmt: %w", cerr)
+		}
+	}
+	if q.getNotificationsByUserIDStmt != nil {
+		if cerr := q.getNotificationsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getNotificationsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getOrCreateSellerOnboardingStmt != nil {
+		if cerr := q.getOrCreateSellerOnboardingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getOrCreateSellerOnboardingStmt: %w", cerr)
+		}
+	}
+	if q.getPermissionsByUserIDStmt != nil {
+		if cerr := q.getPermissionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getPermissionsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getRole2FAPoliciesStmt != nil {
+		if cerr := q.getRole2FAPoliciesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRole2FAPoliciesStmt: %w", cerr)
+		}
+	}
+	if q.getRoleByNameStmt != nil {
+		if cerr := q.getRoleByNameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRoleByNameStmt: %w", cerr)
+		}
+	}
+	if q.getRolePermissionsStmt != nil {
+		if cerr := q.getRolePermissionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRolePermissionsStmt: %w", cerr)
+		}
+	}
+	if q.getRolesStmt != nil {
+		if cerr := q.getRolesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRolesStmt: %w", cerr)
+		}
+	}
+	if q.getRolesByUserIDStmt != nil {
+		if cerr := q.getRolesByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getRolesByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.getSellerByIDStmt != nil {
+		if cerr := q.getSellerByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerByIDStmt: %w", cerr)
+		}
+	}
+	if q.getSellerDocumentByIDStmt != nil {
+		if cerr := q.getSellerDocumentByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerDocumentByIDStmt: %w", cerr)
+		}
+	}
+	if q.getSellerDocumentBySellerIDAndKindStmt != nil {
+		if cerr := q.getSellerDocumentBySellerIDAndKindStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerDocumentBySellerIDAndKindStmt: %w", cerr)
+		}
+	}
+	if q.getSellerDocumentsBySellerIDStmt != nil {
+		if cerr := q.getSellerDocumentsBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerDocumentsBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getSellerOnboardingBySellerIDStmt != nil {
+		if cerr := q.getSellerOnboardingBySellerIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerOnboardingBySellerIDStmt: %w", cerr)
+		}
+	}
+	if q.getSellerOnboardingsByStatusStmt != nil {
+		if cerr := q.getSellerOnboardingsByStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSellerOnboardingsByStatusStmt: %w", cerr)
+		}
+	}
+	if q.getSessionByPreviousTokenHashStmt != nil {
+		if cerr := q.getSessionByPreviousTokenHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSessionByPreviousTokenHashStmt: %w", cerr)
+		}
+	}
+	if q.getSessionByRefreshTokenHashStmt != nil {
+		if cerr := q.getSessionByRefreshTokenHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSessionByRefreshTokenHashStmt: %w", cerr)
+		}
+	}
 	if q.getSessionDetailsByIDStmt != nil {
 		if cerr := q.getSessionDetailsByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getSessionDetailsByIDStmt: %w", cerr)
 		}
 	}
+	if q.getUnusedRecoveryCodeCountStmt != nil {
+		if cerr := q.getUnusedRecoveryCodeCountStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUnusedRecoveryCodeCountStmt: %w", cerr)
+		}
+	}
 	if q.getUserByEmailStmt != nil {
 		if cerr := q.getUserByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserByEmailStmt: %w", cerr)
@@ -312,11 +943,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getUserBySessionIDStmt: %w", cerr)
 		}
 	}
+	if q.getUserTOTPByUserIDStmt != nil {
+		if cerr := q.getUserTOTPByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserTOTPByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getUserWithPasswordByEmailStmt != nil {
 		if cerr := q.getUserWithPasswordByEmailStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUserWithPasswordByEmailStmt: %w", cerr)
 		}
 	}
+	if q.getValidEmailChangeByUserIDStmt != nil {
+		if cerr := q.getValidEmailChangeByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getValidEmailChangeByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getValidForgotOTPByUserIDStmt != nil {
 		if cerr := q.getValidForgotOTPByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getValidForgotOTPByUserIDStmt: %w", cerr)
@@ -327,21 +968,151 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getValidOTPByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.getValidPhoneVerificationByUserIDStmt != nil {
+		if cerr := q.getValidPhoneVerificationByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getValidPhoneVerificationByUserIDStmt: %w", cerr)
+		}
+	}
 	if q.getWalletByUserIDStmt != nil {
 		if cerr := q.getWalletByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getWalletByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.incLoginChallengeAttemptsStmt != nil {
+		if cerr := q.incLoginChallengeAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing incLoginChallengeAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.incrementEmailChangeAttemptsStmt != nil {
+		if cerr := q.incrementEmailChangeAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing incrementEmailChangeAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.incrementForgotOTPAttemptsStmt != nil {
+		if cerr := q.incrementForgotOTPAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing incrementForgotOTPAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.incrementOTPAttemptsStmt != nil {
+		if cerr := q.incrementOTPAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing incrementOTPAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.incrementPhoneVerificationAttemptsStmt != nil {
+		if cerr := q.incrementPhoneVerificationAttemptsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing incrementPhoneVerificationAttemptsStmt: %w", cerr)
+		}
+	}
+	if q.isRole2FARequiredStmt != nil {
+		if cerr := q.isRole2FARequiredStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing isRole2FARequiredStmt: %w", cerr)
+		}
+	}
+	if q.markAllNotificationsReadStmt != nil {
+		if cerr := q.markAllNotificationsReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markAllNotificationsReadStmt: %w", cerr)
+		}
+	}
+	if q.markNotificationReadStmt != nil {
+		if cerr := q.markNotificationReadStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing markNotificationReadStmt: %w", cerr)
+		}
+	}
+	if q.postponeAccountDeletionStmt != nil {
+		if cerr := q.postponeAccountDeletionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing postponeAccountDeletionStmt: %w", cerr)
+		}
+	}
 	if q.retractSavingsFromWalletByUserIDStmt != nil {
 		if cerr := q.retractSavingsFromWalletByUserIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing retractSavingsFromWalletByUserIDStmt: %w", cerr)
 		}
 	}
+	if q.reviewSellerDocumentByIDStmt != nil {
+		if cerr := q.reviewSellerDocumentByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reviewSellerDocumentByIDStmt: %w", cerr)
+		}
+	}
+	if q.reviewSellerOnboardingStmt != nil {
+		if cerr := q.reviewSellerOnboardingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reviewSellerOnboardingStmt: %w", cerr)
+		}
+	}
+	if q.revokeOtherSessionsByUserIDStmt != nil {
+		if cerr := q.revokeOtherSessionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeOtherSessionsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.revokeSessionByIDStmt != nil {
+		if cerr := q.revokeSessionByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeSessionByIDStmt: %w", cerr)
+		}
+	}
+	if q.revokeSessionByIDAndUserIDStmt != nil {
+		if cerr := q.revokeSessionByIDAndUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeSessionByIDAndUserIDStmt: %w", cerr)
+		}
+	}
+	if q.revokeSessionsByUserIDStmt != nil {
+		if cerr := q.revokeSessionsByUserIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeSessionsByUserIDStmt: %w", cerr)
+		}
+	}
+	if q.rotateSessionRefreshTokenStmt != nil {
+		if cerr := q.rotateSessionRefreshTokenStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing rotateSessionRefreshTokenStmt: %w", cerr)
+		}
+	}
+	if q.setMissingDefaultAddressesStmt != nil {
+		if cerr := q.setMissingDefaultAddressesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setMissingDefaultAddressesStmt: %w", cerr)
+		}
+	}
+	if q.setSellerOnboardingDraftStmt != nil {
+		if cerr := q.setSellerOnboardingDraftStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setSellerOnboardingDraftStmt: %w", cerr)
+		}
+	}
+	if q.setUserTOTPLastUsedStepStmt != nil {
+		if cerr := q.setUserTOTPLastUsedStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setUserTOTPLastUsedStepStmt: %w", cerr)
+		}
+	}
+	if q.stopImpersonationStmt != nil {
+		if cerr := q.stopImpersonationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing stopImpersonationStmt: %w", cerr)
+		}
+	}
+	if q.submitSellerOnboardingStmt != nil {
+		if cerr := q.submitSellerOnboardingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing submitSellerOnboardingStmt: %w", cerr)
+		}
+	}
 	if q.unblockUserByIDStmt != nil {
 		if cerr := q.unblockUserByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing unblockUserByIDStmt: %w", cerr)
 		}
 	}
+	if q.upsertPendingUserTOTPStmt != nil {
+		if cerr := q.upsertPendingUserTOTPStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertPendingUserTOTPStmt: %w", cerr)
+		}
+	}
+	if q.upsertRole2FAPolicyStmt != nil {
+		if cerr := q.upsertRole2FAPolicyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertRole2FAPolicyStmt: %w", cerr)
+		}
+	}
+	if q.upsertSellerDocumentStmt != nil {
+		if cerr := q.upsertSellerDocumentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertSellerDocumentStmt: %w", cerr)
+		}
+	}
+	if q.useRecoveryCodeStmt != nil {
+		if cerr := q.useRecoveryCodeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing useRecoveryCodeStmt: %w", cerr)
+		}
+	}
 	if q.verifySellerByIDStmt != nil {
 		if cerr := q.verifySellerByIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing verifySellerByIDStmt: %w", cerr)
@@ -357,6 +1128,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing verifyUserByIDStmt: %w", cerr)
 		}
 	}
+	if q.verifyUserPhoneStmt != nil {
+		if cerr := q.verifyUserPhoneStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing verifyUserPhoneStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -394,95 +1170,289 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                   DBTX
-	tx                                   *sql.Tx
-	addAddressByUserIDStmt               *sql.Stmt
-	addAndVerifyUserStmt                 *sql.Stmt
-	addForgotOTPByUserIDStmt             *sql.Stmt
-	addOTPStmt                           *sql.Stmt
-	addSavingsToWalletByUserIDStmt       *sql.Stmt
-	addSellerStmt                        *sql.Stmt
-	addSessionStmt                       *sql.Stmt
-	addUserStmt                          *sql.Stmt
-	addWalletByUserIDStmt                *sql.Stmt
-	blockUserByIDStmt                    *sql.Stmt
-	changeNameByUserIDStmt               *sql.Stmt
-	changePasswordByUserIDStmt           *sql.Stmt
-	deleteAddressByIDStmt                *sql.Stmt
-	deleteAddressesByUserIDStmt          *sql.Stmt
-	deleteForgotOTPByEmailStmt           *sql.Stmt
-	deleteOTPByEmailStmt                 *sql.Stmt
-	deleteSessionByIDStmt                *sql.Stmt
-	deleteSessionsByuserIDStmt           *sql.Stmt
-	editAddressByIDStmt                  *sql.Stmt
-	editSellerByIDStmt                   *sql.Stmt
-	editUserByIDStmt                     *sql.Stmt
-	getAddressByIDStmt                   *sql.Stmt
-	getAddressBySellerIDStmt             *sql.Stmt
-	getAddressesByUserIDStmt             *sql.Stmt
-	getAllSessionsByUserIDStmt           *sql.Stmt
-	getAllUsersStmt                      *sql.Stmt
-	getAllUsersByRoleSellerStmt          *sql.Stmt
-	getAllUsersByRoleUserStmt            *sql.Stmt
-	getSessionDetailsByIDStmt            *sql.Stmt
-	getUserByEmailStmt                   *sql.Stmt
-	getUserByIdStmt                      *sql.Stmt
-	getUserBySessionIDStmt               *sql.Stmt
-	getUserWithPasswordByEmailStmt       *sql.Stmt
-	getValidForgotOTPByUserIDStmt        *sql.Stmt
-	getValidOTPByUserIDStmt              *sql.Stmt
-	getWalletByUserIDStmt                *sql.Stmt
-	retractSavingsFromWalletByUserIDStmt *sql.Stmt
-	unblockUserByIDStmt                  *sql.Stmt
-	verifySellerByIDStmt                 *sql.Stmt
-	verifySellerEmailByIDStmt            *sql.Stmt
-	verifyUserByIDStmt                   *sql.Stmt
+	db                                     DBTX
+	tx                                     *sql.Tx
+	addAccountDeletionStmt                 *sql.Stmt
+	addAddressByUserIDStmt                 *sql.Stmt
+	addAndVerifyUserStmt                   *sql.Stmt
+	addEmailChangeStmt                     *sql.Stmt
+	addForgotOTPByUserIDStmt               *sql.Stmt
+	addIdentityStmt                        *sql.Stmt
+	addImpersonationStmt                   *sql.Stmt
+	addImpersonationEventStmt              *sql.Stmt
+	addLoginChallengeStmt                  *sql.Stmt
+	addOAuthStateStmt                      *sql.Stmt
+	addOTPStmt                             *sql.Stmt
+	addPhoneVerificationStmt               *sql.Stmt
+	addRecoveryCodeStmt                    *sql.Stmt
+	addRoleStmt                            *sql.Stmt
+	addRolePermissionStmt                  *sql.Stmt
+	addSavingsToWalletByUserIDStmt         *sql.Stmt
+	addSellerStmt                          *sql.Stmt
+	addSessionStmt                         *sql.Stmt
+	addUserStmt                            *sql.Stmt
+	addUserRoleStmt                        *sql.Stmt
+	addWalletByUserIDStmt                  *sql.Stmt
+	anonymizeUserByIDStmt                  *sql.Stmt
+	blockUserByIDStmt                      *sql.Stmt
+	cancelAccountDeletionStmt              *sql.Stmt
+	changeNameByUserIDStmt                 *sql.Stmt
+	changePasswordByUserIDStmt             *sql.Stmt
+	changeUserEmailStmt                    *sql.Stmt
+	clearDefaultBillingAddressStmt         *sql.Stmt
+	clearDefaultShippingAddressStmt        *sql.Stmt
+	completeAccountDeletionStmt            *sql.Stmt
+	consumeOAuthStateStmt                  *sql.Stmt
+	countUnreadNotificationsStmt           *sql.Stmt
+	countUsersWithRoleStmt                 *sql.Stmt
+	deleteAddressByIDStmt                  *sql.Stmt
+	deleteAddressesByUserIDStmt            *sql.Stmt
+	deleteEmailChangesByUserIDStmt         *sql.Stmt
+	deleteExpiredEmailChangesStmt          *sql.Stmt
+	deleteExpiredLoginChallengesStmt       *sql.Stmt
+	deleteExpiredOAuthStatesStmt           *sql.Stmt
+	deleteExpiredPhoneVerificationsStmt    *sql.Stmt
+	deleteExpiredSessionsStmt              *sql.Stmt
+	deleteForgotOTPByEmailStmt             *sql.Stmt
+	deleteForgotOTPByIDStmt                *sql.Stmt
+	deleteForgotOTPsByUserIDStmt           *sql.Stmt
+	deleteIdentitiesByUserIDStmt           *sql.Stmt
+	deleteIdentityByUserIDAndProviderStmt  *sql.Stmt
+	deleteLoginChallengeByIDStmt           *sql.Stmt
+	deleteMailOutboxByUserIDStmt           *sql.Stmt
+	deleteNotificationsByUserIDStmt        *sql.Stmt
+	deleteOTPByEmailStmt                   *sql.Stmt
+	deleteOTPByIDStmt                      *sql.Stmt
+	deleteOTPsByUserIDStmt                 *sql.Stmt
+	deletePhoneVerificationsByUserIDStmt   *sql.Stmt
+	deleteRecoveryCodesByUserIDStmt        *sql.Stmt
+	deleteRolePermissionsStmt              *sql.Stmt
+	deleteSellerDocumentsBySellerIDStmt    *sql.Stmt
+	deleteSellerOnboardingBySellerIDStmt   *sql.Stmt
+	deleteUserRoleStmt                     *sql.Stmt
+	deleteUserTOTPStmt                     *sql.Stmt
+	editAddressByIDStmt                    *sql.Stmt
+	editSellerByIDStmt                     *sql.Stmt
+	editUserByIDStmt                       *sql.Stmt
+	enableUserTOTPStmt                     *sql.Stmt
+	getAccountDeletionByUserIDStmt         *sql.Stmt
+	getActiveSessionsByUserIDStmt          *sql.Stmt
+	getAddressByIDStmt                     *sql.Stmt
+	getAddressBySellerIDStmt               *sql.Stmt
+	getAddressesByUserIDStmt               *sql.Stmt
+	getAllSessionsByUserIDStmt             *sql.Stmt
+	getAllUsersStmt                        *sql.Stmt
+	getAllUsersByRoleSellerStmt            *sql.Stmt
+	getAllUsersByRoleUserStmt              *sql.Stmt
+	getDefaultShippingAddressByUserIDStmt  *sql.Stmt
+	getDueAccountDeletionsStmt             *sql.Stmt
+	getIdentitiesByUserIDStmt              *sql.Stmt
+	getIdentityByProviderSubjectStmt       *sql.Stmt
+	getImpersonationEventsStmt             *sql.Stmt
+	getImpersonationsStmt                  *sql.Stmt
+	getLoginChallengeByTokenHashStmt       *sql.Stmt
+	getNotificationsByUserIDStmt           *sql.Stmt
+	getOrCreateSellerOnboardingStmt        *sql.Stmt
+	getPermissionsByUserIDStmt             *sql.Stmt
+	getRole2FAPoliciesStmt                 *sql.Stmt
+	getRoleByNameStmt                      *sql.Stmt
+	getRolePermissionsStmt                 *sql.Stmt
+	getRolesStmt                           *sql.Stmt
+	getRolesByUserIDStmt                   *sql.Stmt
+	getSellerByIDStmt                      *sql.Stmt
+	getSellerDocumentByIDStmt              *sql.Stmt
+	getSellerDocumentBySellerIDAndKindStmt *sql.Stmt
+	getSellerDocumentsBySellerIDStmt       *sql.Stmt
+	getSellerOnboardingBySellerIDStmt      *sql.Stmt
+	getSellerOnboardingsByStatusStmt       *sql.Stmt
+	getSessionByPreviousTokenHashStmt      *sql.Stmt
+	getSessionByRefreshTokenHashStmt       *sql.Stmt
+	getSessionDetailsByIDStmt              *sql.Stmt
+	getUnusedRecoveryCodeCountStmt         *sql.Stmt
+	getUserByEmailStmt                     *sql.Stmt
+	getUserByIdStmt                        *sql.Stmt
+	getUserBySessionIDStmt                 *sql.Stmt
+	getUserTOTPByUserIDStmt                *sql.Stmt
+	getUserWithPasswordByEmailStmt         *sql.Stmt
+	getValidEmailChangeByUserIDStmt        *sql.Stmt
+	getValidForgotOTPByUserIDStmt          *sql.Stmt
+	getValidOTPByUserIDStmt                *sql.Stmt
+	getValidPhoneVerificationByUserIDStmt  *sql.Stmt
+	getWalletByUserIDStmt                  *sql.Stmt
+	incLoginChallengeAttemptsStmt          *sql.Stmt
+	incrementEmailChangeAttemptsStmt       *sql.Stmt
+	incrementForgotOTPAttemptsStmt         *sql.Stmt
+	incrementOTPAttemptsStmt               *sql.Stmt
+	incrementPhoneVerificationAttemptsStmt *sql.Stmt
+	isRole2FARequiredStmt                  *sql.Stmt
+	markAllNotificationsReadStmt           *sql.Stmt
+	markNotificationReadStmt               *sql.Stmt
+	postponeAccountDeletionStmt            *sql.Stmt
+	retractSavingsFromWalletByUserIDStmt   *sql.Stmt
+	reviewSellerDocumentByIDStmt           *sql.Stmt
+	reviewSellerOnboardingStmt             *sql.Stmt
+	revokeOtherSessionsByUserIDStmt        *sql.Stmt
+	revokeSessionByIDStmt                  *sql.Stmt
+	revokeSessionByIDAndUserIDStmt         *sql.Stmt
+	revokeSessionsByUserIDStmt             *sql.Stmt
+	rotateSessionRefreshTokenStmt          *sql.Stmt
+	setMissingDefaultAddressesStmt         *sql.Stmt
+	setSellerOnboardingDraftStmt           *sql.Stmt
+	setUserTOTPLastUsedStepStmt            *sql.Stmt
+	stopImpersonationStmt                  *sql.Stmt
+	submitSellerOnboardingStmt             *sql.Stmt
+	unblockUserByIDStmt                    *sql.Stmt
+	upsertPendingUserTOTPStmt              *sql.Stmt
+	upsertRole2FAPolicyStmt                *sql.Stmt
+	upsertSellerDocumentStmt               *sql.Stmt
+	useRecoveryCodeStmt                    *sql.Stmt
+	verifySellerByIDStmt                   *sql.Stmt
+	verifySellerEmailByIDStmt              *sql.Stmt
+	verifyUserByIDStmt                     *sql.Stmt
+	verifyUserPhoneStmt                    *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                   tx,
-		tx:                                   tx,
-		addAddressByUserIDStmt:               q.addAddressByUserIDStmt,
-		addAndVerifyUserStmt:                 q.addAndVerifyUserStmt,
-		addForgotOTPByUserIDStmt:             q.addForgotOTPByUserIDStmt,
-		addOTPStmt:                           q.addOTPStmt,
-		addSavingsToWalletByUserIDStmt:       q.addSavingsToWalletByUserIDStmt,
-		addSellerStmt:                        q.addSellerStmt,
-		addSessionStmt:                       q.addSessionStmt,
-		addUserStmt:                          q.addUserStmt,
-		addWalletByUserIDStmt:                q.addWalletByUserIDStmt,
-		blockUserByIDStmt:                    q.blockUserByIDStmt,
-		changeNameByUserIDStmt:               q.changeNameByUserIDStmt,
-		changePasswordByUserIDStmt:           q.changePasswordByUserIDStmt,
-		deleteAddressByIDStmt:                q.deleteAddressByIDStmt,
-		deleteAddressesByUserIDStmt:          q.deleteAddressesByUserIDStmt,
-		deleteForgotOTPByEmailStmt:           q.deleteForgotOTPByEmailStmt,
-		deleteOTPByEmailStmt:                 q.deleteOTPByEmailStmt,
-		deleteSessionByIDStmt:                q.deleteSessionByIDStmt,
-		deleteSessionsByuserIDStmt:           q.deleteSessionsByuserIDStmt,
-		editAddressByIDStmt:                  q.editAddressByIDStmt,
-		editSellerByIDStmt:                   q.editSellerByIDStmt,
-		editUserByIDStmt:                     q.editUserByIDStmt,
-		getAddressByIDStmt:                   q.getAddressByIDStmt,
-		getAddressBySellerIDStmt:             q.getAddressBySellerIDStmt,
-		getAddressesByUserIDStmt:             q.getAddressesByUserIDStmt,
-		getAllSessionsByUserIDStmt:           q.getAllSessionsByUserIDStmt,
-		getAllUsersStmt:                      q.getAllUsersStmt,
-		getAllUsersByRoleSellerStmt:          q.getAllUsersByRoleSellerStmt,
-		getAllUsersByRoleUserStmt:            q.getAllUsersByRoleUserStmt,
-		getSessionDetailsByIDStmt:            q.getSessionDetailsByIDStmt,
-		getUserByEmailStmt:                   q.getUserByEmailStmt,
-		getUserByIdStmt:                      q.getUserByIdStmt,
-		getUserBySessionIDStmt:               q.getUserBySessionIDStmt,
-		getUserWithPasswordByEmailStmt:       q.getUserWithPasswordByEmailStmt,
-		getValidForgotOTPByUserIDStmt:        q.getValidForgotOTPByUserIDStmt,
-		getValidOTPByUserIDStmt:              q.getValidOTPByUserIDStmt,
-		getWalletByUserIDStmt:                q.getWalletByUserIDStmt,
-		retractSavingsFromWalletByUserIDStmt: q.retractSavingsFromWalletByUserIDStmt,
-		unblockUserByIDStmt:                  q.unblockUserByIDStmt,
-		verifySellerByIDStmt:                 q.verifySellerByIDStmt,
-		verifySellerEmailByIDStmt:            q.verifySellerEmailByIDStmt,
-		verifyUserByIDStmt:                   q.verifyUserByIDStmt,
+		db:                                     tx,
+		tx:                                     tx,
+		addAccountDeletionStmt:                 q.addAccountDeletionStmt,
+		addAddressByUserIDStmt:                 q.addAddressByUserIDStmt,
+		addAndVerifyUserStmt:                   q.addAndVerifyUserStmt,
+		addEmailChangeStmt:                     q.addEmailChangeStmt,
+		addForgotOTPByUserIDStmt:               q.addForgotOTPByUserIDStmt,
+		addIdentityStmt:                        q.addIdentityStmt,
+		addImpersonationStmt:                   q.addImpersonationStmt,
+		addImpersonationEventStmt:              q.addImpersonationEventStmt,
+		addLoginChallengeStmt:                  q.addLoginChallengeStmt,
+		addOAuthStateStmt:                      q.addOAuthStateStmt,
+		addOTPStmt:                             q.addOTPStmt,
+		addPhoneVerificationStmt:               q.addPhoneVerificationStmt,
+		addRecoveryCodeStmt:                    q.addRecoveryCodeStmt,
+		addRoleStmt:                            q.addRoleStmt,
+		addRolePermissionStmt:                  q.addRolePermissionStmt,
+		addSavingsToWalletByUserIDStmt:         q.addSavingsToWalletByUserIDStmt,
+		addSellerStmt:                          q.addSellerStmt,
+		addSessionStmt:                         q.addSessionStmt,
+		addUserStmt:                            q.addUserStmt,
+		addUserRoleStmt:                        q.addUserRoleStmt,
+		addWalletByUserIDStmt:                  q.addWalletByUserIDStmt,
+		anonymizeUserByIDStmt:                  q.anonymizeUserByIDStmt,
+		blockUserByIDStmt:                      q.blockUserByIDStmt,
+		cancelAccountDeletionStmt:              q.cancelAccountDeletionStmt,
+		changeNameByUserIDStmt:                 q.changeNameByUserIDStmt,
+		changePasswordByUserIDStmt:             q.changePasswordByUserIDStmt,
+		changeUserEmailStmt:                    q.changeUserEmailStmt,
+		clearDefaultBillingAddressStmt:         q.clearDefaultBillingAddressStmt,
+		clearDefaultShippingAddressStmt:        q.clearDefaultShippingAddressStmt,
+		completeAccountDeletionStmt:            q.completeAccountDeletionStmt,
+		consumeOAuthStateStmt:                  q.consumeOAuthStateStmt,
+		countUnreadNotificationsStmt:           q.countUnreadNotificationsStmt,
+		countUsersWithRoleStmt:                 q.countUsersWithRoleStmt,
+		deleteAddressByIDStmt:                  q.deleteAddressByIDStmt,
+		deleteAddressesByUserIDStmt:            q.deleteAddressesByUserIDStmt,
+		deleteEmailChangesByUserIDStmt:         q.deleteEmailChangesByUserIDStmt,
+		deleteExpiredEmailChangesStmt:          q.deleteExpiredEmailChangesStmt,
+		deleteExpiredLoginChallengesStmt:       q.deleteExpiredLoginChallengesStmt,
+		deleteExpiredOAuthStatesStmt:           q.deleteExpiredOAuthStatesStmt,
+		deleteExpiredPhoneVerificationsStmt:    q.deleteExpiredPhoneVerificationsStmt,
+		deleteExpiredSessionsStmt:              q.deleteExpiredSessionsStmt,
+		deleteForgotOTPByEmailStmt:             q.deleteForgotOTPByEmailStmt,
+		deleteForgotOTPByIDStmt:                q.deleteForgotOTPByIDStmt,
+		deleteForgotOTPsByUserIDStmt:           q.deleteForgotOTPsByUserIDStmt,
+		deleteIdentitiesByUserIDStmt:           q.deleteIdentitiesByUserIDStmt,
+		deleteIdentityByUserIDAndProviderStmt:  q.deleteIdentityByUserIDAndProviderStmt,
+		deleteLoginChallengeByIDStmt:           q.deleteLoginChallengeByIDStmt,
+		deleteMailOutboxByUserIDStmt:           q.deleteMailOutboxByUserIDStmt,
+		deleteNotificationsByUserIDStmt:        q.deleteNotificationsByUserIDStmt,
+		deleteOTPByEmailStmt:                   q.deleteOTPByEmailStmt,
+		deleteOTPByIDStmt:                      q.deleteOTPByIDStmt,
+		deleteOTPsByUserIDStmt:                 q.deleteOTPsByUserIDStmt,
+		deletePhoneVerificationsByUserIDStmt:   q.deletePhoneVerificationsByUserIDStmt,
+		deleteRecoveryCodesByUserIDStmt:        q.deleteRecoveryCodesByUserIDStmt,
+		deleteRolePermissionsStmt:              q.deleteRolePermissionsStmt,
+		deleteSellerDocumentsBySellerIDStmt:    q.deleteSellerDocumentsBySellerIDStmt,
+		deleteSellerOnboardingBySellerIDStmt:   q.deleteSellerOnboardingBySellerIDStmt,
+		deleteUserRoleStmt:                     q.deleteUserRoleStmt,
+		deleteUserTOTPStmt:                     q.deleteUserTOTPStmt,
+		editAddressByIDStmt:                    q.editAddressByIDStmt,
+		editSellerByIDStmt:                     q.editSellerByIDStmt,
+		editUserByIDStmt:                       q.editUserByIDStmt,
+		enableUserTOTPStmt:                     q.enableUserTOTPStmt,
+		getAccountDeletionByUserIDStmt:         q.getAccountDeletionByUserIDStmt,
+		getActiveSessionsByUserIDStmt:          q.getActiveSessionsByUserIDStmt,
+		getAddressByIDStmt:                     q.getAddressByIDStmt,
+		getAddressBySellerIDStmt:               q.getAddressBySellerIDStmt,
+		getAddressesByUserIDStmt:               q.getAddressesByUserIDStmt,
+		getAllSessionsByUserIDStmt:             q.getAllSessionsByUserIDStmt,
+		getAllUsersStmt:                        q.getAllUsersStmt,
+		getAllUsersByRoleSellerStmt:            q.getAllUsersByRoleSellerStmt,
+		getAllUsersByRoleUserStmt:              q.getAllUsersByRoleUserStmt,
+		getDefaultShippingAddressByUserIDStmt:  q.getDefaultShippingAddressByUserIDStmt,
+		getDueAccountDeletionsStmt:             q.getDueAccountDeletionsStmt,
+		getIdentitiesByUserIDStmt:              q.getIdentitiesByUserIDStmt,
+		getIdentityByProviderSubjectStmt:       q.getIdentityByProviderSubjectStmt,
+		getImpersonationEventsStmt:             q.getImpersonationEventsStmt,
+		getImpersonationsStmt:                  q.getImpersonationsStmt,
+		getLoginChallengeByTokenHashStmt:       q.getLoginChallengeByTokenHashStmt,
+		getNotificationsByUserIDStmt:           q.getNotificationsByUserIDStmt,
+		getOrCreateSellerOnboardingStmt:        q.getOrCreateSellerOnboardingStmt,
+		getPermissionsByUserIDStmt:             q.getPermissionsByUserIDStmt,
+		getRole2FAPoliciesStmt:                 q.getRole2FAPoliciesStmt,
+		getRoleByNameStmt:                      q.getRoleByNameStmt,
+		getRolePermissionsStmt:                 q.getRolePermissionsStmt,
+		getRolesStmt:                           q.getRolesStmt,
+		getRolesByUserIDStmt:                   q.getRolesByUserIDStmt,
+		getSellerByIDStmt:                      q.getSellerByIDStmt,
+		getSellerDocumentByIDStmt:              q.getSellerDocumentByIDStmt,
+		getSellerDocumentBySellerIDAndKindStmt: q.getSellerDocumentBySellerIDAndKindStmt,
+		getSellerDocumentsBySellerIDStmt:       q.getSellerDocumentsBySellerIDStmt,
+		getSellerOnboardingBySellerIDStmt:      q.getSellerOnboardingBySellerIDStmt,
+		getSellerOnboardingsByStatusStmt:       q.getSellerOnboardingsByStatusStmt,
+		getSessionByPreviousTokenHashStmt:      q.getSessionByPreviousTokenHashStmt,
+		getSessionByRefreshTokenHashStmt:       q.getSessionByRefreshTokenHashStmt,
+		getSessionDetailsByIDStmt:              q.getSessionDetailsByIDStmt,
+		getUnusedRecoveryCodeCountStmt:         q.getUnusedRecoveryCodeCountStmt,
+		getUserByEmailStmt:                     q.getUserByEmailStmt,
+		getUserByIdStmt:                        q.getUserByIdStmt,
+		getUserBySessionIDStmt:                 q.getUserBySessionIDStmt,
+		getUserTOTPByUserIDStmt:                q.getUserTOTPByUserIDStmt,
+		getUserWithPasswordByEmailStmt:         q.getUserWithPasswordByEmailStmt,
+		getValidEmailChangeByUserIDStmt:        q.getValidEmailChangeByUserIDStmt,
+		getValidForgotOTPByUserIDStmt:          q.getValidForgotOTPByUserIDStmt,
+		getValidOTPByUserIDStmt:                q.getValidOTPByUserIDStmt,
+		getValidPhoneVerificationByUserIDStmt:  q.getValidPhoneVerificationByUserIDStmt,
+		getWalletByUserIDStmt:                  q.getWalletByUserIDStmt,
+		incLoginChallengeAttemptsStmt:          q.incLoginChallengeAttemptsStmt,
+		incrementEmailChangeAttemptsStmt:       q.incrementEmailChangeAttemptsStmt,
+		incrementForgotOTPAttemptsStmt:         q.incrementForgotOTPAttemptsStmt,
+		incrementOTPAttemptsStmt:               q.incrementOTPAttemptsStmt,
+		incrementPhoneVerificationAttemptsStmt: q.incrementPhoneVerificationAttemptsStmt,
+		isRole2FARequiredStmt:                  q.isRole2FARequiredStmt,
+		markAllNotificationsReadStmt:           q.markAllNotificationsReadStmt,
+		markNotificationReadStmt:               q.markNotificationReadStmt,
+		postponeAccountDeletionStmt:            q.postponeAccountDeletionStmt,
+		retractSavingsFromWalletByUserIDStmt:   q.retractSavingsFromWalletByUserIDStmt,
+		reviewSellerDocumentByIDStmt:           q.reviewSellerDocumentByIDStmt,
+		reviewSellerOnboardingStmt:             q.reviewSellerOnboardingStmt,
+		revokeOtherSessionsByUserIDStmt:        q.revokeOtherSessionsByUserIDStmt,
+		revokeSessionByIDStmt:                  q.revokeSessionByIDStmt,
+		revokeSessionByIDAndUserIDStmt:         q.revokeSessionByIDAndUserIDStmt,
+		revokeSessionsByUserIDStmt:             q.revokeSessionsByUserIDStmt,
+		rotateSessionRefreshTokenStmt:          q.rotateSessionRefreshTokenStmt,
+		setMissingDefaultAddressesStmt:         q.setMissingDefaultAddressesStmt,
+		setSellerOnboardingDraftStmt:           q.setSellerOnboardingDraftStmt,
+		setUserTOTPLastUsedStepStmt:            q.setUserTOTPLastUsedStepStmt,
+		stopImpersonationStmt:                  q.stopImpersonationStmt,
+		submitSellerOnboardingStmt:             q.submitSellerOnboardingStmt,
+		unblockUserByIDStmt:                    q.unblockUserByIDStmt,
+		upsertPendingUserTOTPStmt:              q.upsertPendingUserTOTPStmt,
+		upsertRole2FAPolicyStmt:                q.upsertRole2FAPolicyStmt,
+		upsertSellerDocumentStmt:               q.upsertSellerDocumentStmt,
+		useRecoveryCodeStmt:                    q.useRecoveryCodeStmt,
+		verifySellerByIDStmt:                   q.verifySellerByIDStmt,
+		verifySellerEmailByIDStmt:              q.verifySellerEmailByIDStmt,
+		verifyUserByIDStmt:                     q.verifyUserByIDStmt,
+		verifyUserPhoneStmt:                    q.verifyUserPhoneStmt,
 	}
 }
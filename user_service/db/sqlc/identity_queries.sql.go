@@ -0,0 +1,176 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: identity_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const addIdentity = `-- name: AddIdentity :one
+insert into identities
+(user_id, provider, subject, email)
+values ($1, $2, $3, $4)
+returning id, user_id, provider, subject, email, created_at
+`
+
+type AddIdentityParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Provider string    `json:"provider"`
+	Subject  string    `json:"subject"`
+	Email    string    `json:"email"`
+}
+
+func (q *Queries) AddIdentity(ctx context.Context, arg AddIdentityParams) (Identity, error) {
+	row := q.queryRow(ctx, q.addIdentityStmt, addIdentity,
+		arg.UserID,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+	)
+	var i Identity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const addOAuthState = `-- name: AddOAuthState :exec
+insert into oauth_states
+(state_hash, code_verifier, purpose, role, user_id)
+values ($1, $2, $3, $4, $5)
+`
+
+type AddOAuthStateParams struct {
+	StateHash    string        `json:"state_hash"`
+	CodeVerifier string        `json:"code_verifier"`
+	Purpose      string        `json:"purpose"`
+	Role         string        `json:"role"`
+	UserID       uuid.NullUUID `json:"user_id"`
+}
+
+func (q *Queries) AddOAuthState(ctx context.Context, arg AddOAuthStateParams) error {
+	_, err := q.exec(ctx, q.addOAuthStateStmt, addOAuthState,
+		arg.StateHash,
+		arg.CodeVerifier,
+		arg.Purpose,
+		arg.Role,
+		arg.UserID,
+	)
+	return err
+}
+
+const consumeOAuthState = `-- name: ConsumeOAuthState :one
+delete from oauth_states
+where state_hash = $1 and expires_at > current_timestamp
+returning state_hash, code_verifier, purpose, role, user_id, created_at, expires_at
+`
+
+// a state works once, it is deleted as it is read
+func (q *Queries) ConsumeOAuthState(ctx context.Context, stateHash string) (OauthState, error) {
+	row := q.queryRow(ctx, q.consumeOAuthStateStmt, consumeOAuthState, stateHash)
+	var i OauthState
+	err := row.Scan(
+		&i.StateHash,
+		&i.CodeVerifier,
+		&i.Purpose,
+		&i.Role,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteExpiredOAuthStates = `-- name: DeleteExpiredOAuthStates :execresult
+delete from oauth_states
+where expires_at <= current_timestamp
+`
+
+func (q *Queries) DeleteExpiredOAuthStates(ctx context.Context) (sql.Result, error) {
+	return q.exec(ctx, q.deleteExpiredOAuthStatesStmt, deleteExpiredOAuthStates)
+}
+
+const deleteIdentityByUserIDAndProvider = `-- name: DeleteIdentityByUserIDAndProvider :execresult
+delete from identities
+where user_id = $1 and provider = $2
+`
+
+type DeleteIdentityByUserIDAndProviderParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Provider string    `json:"provider"`
+}
+
+func (q *Queries) DeleteIdentityByUserIDAndProvider(ctx context.Context, arg DeleteIdentityByUserIDAndProviderParams) (sql.Result, error) {
+	return q.exec(ctx, q.deleteIdentityByUserIDAndProviderStmt, deleteIdentityByUserIDAndProvider, arg.UserID, arg.Provider)
+}
+
+const getIdentitiesByUserID = `-- name: GetIdentitiesByUserID :many
+select id, user_id, provider, subject, email, created_at from identities
+where user_id = $1
+order by created_at
+`
+
+func (q *Queries) GetIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]Identity, error) {
+	rows, err := q.query(ctx, q.getIdentitiesByUserIDStmt, getIdentitiesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Identity{}
+	for rows.Next() {
+		var i Identity
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Provider,
+			&i.Subject,
+			&i.Email,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getIdentityByProviderSubject = `-- name: GetIdentityByProviderSubject :one
+select id, user_id, provider, subject, email, created_at from identities
+where provider = $1 and subject = $2
+`
+
+type GetIdentityByProviderSubjectParams struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+func (q *Queries) GetIdentityByProviderSubject(ctx context.Context, arg GetIdentityByProviderSubjectParams) (Identity, error) {
+	row := q.queryRow(ctx, q.getIdentityByProviderSubjectStmt, getIdentityByProviderSubject, arg.Provider, arg.Subject)
+	var i Identity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,197 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: impersonation_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const addImpersonation = `-- name: AddImpersonation :one
+insert into impersonations
+(admin_id, target_id, reason, expires_at)
+values ($1, $2, $3, $4)
+returning id, admin_id, target_id, reason, started_at, expires_at, ended_at
+`
+
+type AddImpersonationParams struct {
+	AdminID   uuid.UUID `json:"admin_id"`
+	TargetID  uuid.UUID `json:"target_id"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (q *Queries) AddImpersonation(ctx context.Context, arg AddImpersonationParams) (Impersonation, error) {
+	row := q.queryRow(ctx, q.addImpersonationStmt, addImpersonation,
+		arg.AdminID,
+		arg.TargetID,
+		arg.Reason,
+		arg.ExpiresAt,
+	)
+	var i Impersonation
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.TargetID,
+		&i.Reason,
+		&i.StartedAt,
+		&i.ExpiresAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
+const addImpersonationEvent = `-- name: AddImpersonationEvent :exec
+insert into impersonation_events
+(impersonation_id, kind)
+values ($1, $2)
+`
+
+type AddImpersonationEventParams struct {
+	ImpersonationID uuid.UUID `json:"impersonation_id"`
+	Kind            string    `json:"kind"`
+}
+
+func (q *Queries) AddImpersonationEvent(ctx context.Context, arg AddImpersonationEventParams) error {
+	_, err := q.exec(ctx, q.addImpersonationEventStmt, addImpersonationEvent, arg.ImpersonationID, arg.Kind)
+	return err
+}
+
+const getImpersonationEvents = `-- name: GetImpersonationEvents :many
+select id, impersonation_id, kind, service, method, path, blocked, created_at from impersonation_events
+where impersonation_id = $1
+order by created_at
+`
+
+func (q *Queries) GetImpersonationEvents(ctx context.Context, impersonationID uuid.UUID) ([]ImpersonationEvent, error) {
+	rows, err := q.query(ctx, q.getImpersonationEventsStmt, getImpersonationEvents, impersonationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImpersonationEvent{}
+	for rows.Next() {
+		var i ImpersonationEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.ImpersonationID,
+			&i.Kind,
+			&i.Service,
+			&i.Method,
+			&i.Path,
+			&i.Blocked,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getImpersonations = `-- name: GetImpersonations :many
+select i.id, i.admin_id, i.target_id, i.reason, i.started_at, i.expires_at, i.ended_at, a.email as admin_email, t.email as target_email
+from impersonations i
+join users a on a.id = i.admin_id
+join users t on t.id = i.target_id
+where ($3::uuid is null or i.admin_id = $3)
+and ($4::uuid is null or i.target_id = $4)
+order by i.started_at desc
+limit $1 offset $2
+`
+
+type GetImpersonationsParams struct {
+	Limit    int32         `json:"limit"`
+	Offset   int32         `json:"offset"`
+	AdminID  uuid.NullUUID `json:"admin_id"`
+	TargetID uuid.NullUUID `json:"target_id"`
+}
+
+type GetImpersonationsRow struct {
+	ID          uuid.UUID    `json:"id"`
+	AdminID     uuid.UUID    `json:"admin_id"`
+	TargetID    uuid.UUID    `json:"target_id"`
+	Reason      string       `json:"reason"`
+	StartedAt   time.Time    `json:"started_at"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	EndedAt     sql.NullTime `json:"ended_at"`
+	AdminEmail  string       `json:"admin_email"`
+	TargetEmail string       `json:"target_email"`
+}
+
+func (q *Queries) GetImpersonations(ctx context.Context, arg GetImpersonationsParams) ([]GetImpersonationsRow, error) {
+	rows, err := q.query(ctx, q.getImpersonationsStmt, getImpersonations,
+		arg.Limit,
+		arg.Offset,
+		arg.AdminID,
+		arg.TargetID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetImpersonationsRow{}
+	for rows.Next() {
+		var i GetImpersonationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdminID,
+			&i.TargetID,
+			&i.Reason,
+			&i.StartedAt,
+			&i.ExpiresAt,
+			&i.EndedAt,
+			&i.AdminEmail,
+			&i.TargetEmail,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const stopImpersonation = `-- name: StopImpersonation :one
+update impersonations
+set ended_at = current_timestamp
+where id = $1 and admin_id = $2 and ended_at is null and expires_at > current_timestamp
+returning id, admin_id, target_id, reason, started_at, expires_at, ended_at
+`
+
+type StopImpersonationParams struct {
+	ID      uuid.UUID `json:"id"`
+	AdminID uuid.UUID `json:"admin_id"`
+}
+
+func (q *Queries) StopImpersonation(ctx context.Context, arg StopImpersonationParams) (Impersonation, error) {
+	row := q.queryRow(ctx, q.stopImpersonationStmt, stopImpersonation, arg.ID, arg.AdminID)
+	var i Impersonation
+	err := row.Scan(
+		&i.ID,
+		&i.AdminID,
+		&i.TargetID,
+		&i.Reason,
+		&i.StartedAt,
+		&i.ExpiresAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
@@ -6,50 +6,222 @@ package sqlc
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
 )
 
+type AccountDeletion struct {
+	UserID        uuid.UUID    `json:"user_id"`
+	RequestedAt   time.Time    `json:"requested_at"`
+	ScheduledAt   time.Time    `json:"scheduled_at"`
+	CompletedAt   sql.NullTime `json:"completed_at"`
+	Attempts      int32        `json:"attempts"`
+	NextAttemptAt sql.NullTime `json:"next_attempt_at"`
+}
+
 type Address struct {
-	ID           uuid.UUID `json:"id"`
-	UserID       uuid.UUID `json:"user_id"`
-	Type         string    `json:"type"`
-	BuildingName string    `json:"building_name"`
-	StreetName   string    `json:"street_name"`
-	Town         string    `json:"town"`
-	District     string    `json:"district"`
-	State        string    `json:"state"`
-	Pincode      int32     `json:"pincode"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID                uuid.UUID       `json:"id"`
+	UserID            uuid.UUID       `json:"user_id"`
+	Type              string          `json:"type"`
+	BuildingName      string          `json:"building_name"`
+	StreetName        string          `json:"street_name"`
+	Town              string          `json:"town"`
+	District          string          `json:"district"`
+	State             string          `json:"state"`
+	Pincode           int32           `json:"pincode"`
+	Label             string          `json:"label"`
+	ContactName       sql.NullString  `json:"contact_name"`
+	ContactPhone      sql.NullInt64   `json:"contact_phone"`
+	Latitude          sql.NullFloat64 `json:"latitude"`
+	Longitude         sql.NullFloat64 `json:"longitude"`
+	IsDefaultShipping bool            `json:"is_default_shipping"`
+	IsDefaultBilling  bool            `json:"is_default_billing"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+type EmailChange struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	NewEmail  string    `json:"new_email"`
+	Otp       int32     `json:"otp"`
+	Attempts  int32     `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type ForgotOtp struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
 	Otp       int32     `json:"otp"`
+	Attempts  int32     `json:"attempts"`
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
+type Identity struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Impersonation struct {
+	ID        uuid.UUID    `json:"id"`
+	AdminID   uuid.UUID    `json:"admin_id"`
+	TargetID  uuid.UUID    `json:"target_id"`
+	Reason    string       `json:"reason"`
+	StartedAt time.Time    `json:"started_at"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	EndedAt   sql.NullTime `json:"ended_at"`
+}
+
+type ImpersonationEvent struct {
+	ID              uuid.UUID `json:"id"`
+	ImpersonationID uuid.UUID `json:"impersonation_id"`
+	Kind            string    `json:"kind"`
+	Service         string    `json:"service"`
+	Method          string    `json:"method"`
+	Path            string    `json:"path"`
+	Blocked         bool      `json:"blocked"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type LoginChallenge struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	TokenHash string    `json:"token_hash"`
+	Purpose   string    `json:"purpose"`
+	Attempts  int32     `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type MailOutbox struct {
+	ID            uuid.UUID      `json:"id"`
+	Template      string         `json:"template"`
+	Recipient     string         `json:"recipient"`
+	Subject       string         `json:"subject"`
+	HtmlBody      string         `json:"html_body"`
+	TextBody      string         `json:"text_body"`
+	Status        string         `json:"status"`
+	Attempts      int32          `json:"attempts"`
+	LastError     sql.NullString `json:"last_error"`
+	NextAttemptAt time.Time      `json:"next_attempt_at"`
+	SentAt        sql.NullTime   `json:"sent_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type Notification struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Kind      string          `json:"kind"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data"`
+	ReadAt    sql.NullTime    `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type OauthState struct {
+	StateHash    string        `json:"state_hash"`
+	CodeVerifier string        `json:"code_verifier"`
+	Purpose      string        `json:"purpose"`
+	Role         string        `json:"role"`
+	UserID       uuid.NullUUID `json:"user_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ExpiresAt    time.Time     `json:"expires_at"`
+}
+
 type Otp struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
 	Otp       int32     `json:"otp"`
+	Attempts  int32     `json:"attempts"`
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
-type Session struct {
+type PhoneVerification struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
-	IpAddress string    `json:"ip_address"`
-	UserAgent string    `json:"user_agent"`
+	Phone     int64     `json:"phone"`
+	Otp       int32     `json:"otp"`
+	Attempts  int32     `json:"attempts"`
 	CreatedAt time.Time `json:"created_at"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
+type RateLimit struct {
+	Key         string       `json:"key"`
+	Attempts    int32        `json:"attempts"`
+	WindowStart time.Time    `json:"window_start"`
+	Lockouts    int32        `json:"lockouts"`
+	LockedUntil sql.NullTime `json:"locked_until"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type Role struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Staff       bool      `json:"staff"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Role2faPolicy struct {
+	Role       string        `json:"role"`
+	Require2fa bool          `json:"require_2fa"`
+	UpdatedBy  uuid.NullUUID `json:"updated_by"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type RolePermission struct {
+	Role       string `json:"role"`
+	Permission string `json:"permission"`
+}
+
+type SellerDocument struct {
+	ID              uuid.UUID      `json:"id"`
+	SellerID        uuid.UUID      `json:"seller_id"`
+	Kind            string         `json:"kind"`
+	FileKey         string         `json:"file_key"`
+	ContentType     string         `json:"content_type"`
+	Status          string         `json:"status"`
+	RejectionReason sql.NullString `json:"rejection_reason"`
+	ReviewedAt      sql.NullTime   `json:"reviewed_at"`
+	ReviewedBy      uuid.NullUUID  `json:"reviewed_by"`
+	UploadedAt      time.Time      `json:"uploaded_at"`
+}
+
+type SellerOnboarding struct {
+	SellerID        uuid.UUID      `json:"seller_id"`
+	Status          string         `json:"status"`
+	RejectionReason sql.NullString `json:"rejection_reason"`
+	SubmittedAt     sql.NullTime   `json:"submitted_at"`
+	ReviewedAt      sql.NullTime   `json:"reviewed_at"`
+	ReviewedBy      uuid.NullUUID  `json:"reviewed_by"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+type Session struct {
+	ID                uuid.UUID      `json:"id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	IpAddress         string         `json:"ip_address"`
+	UserAgent         string         `json:"user_agent"`
+	RefreshTokenHash  string         `json:"refresh_token_hash"`
+	PreviousTokenHash sql.NullString `json:"previous_token_hash"`
+	RevokedAt         sql.NullTime   `json:"revoked_at"`
+	LastUsedAt        time.Time      `json:"last_used_at"`
+	CreatedAt         time.Time      `json:"created_at"`
+	ExpiresAt         time.Time      `json:"expires_at"`
+}
+
 type User struct {
 	ID            uuid.UUID      `json:"id"`
 	Name          string         `json:"name"`
@@ -58,6 +230,7 @@ type User struct {
 	Password      string         `json:"password"`
 	Role          string         `json:"role"`
 	EmailVerified bool           `json:"email_verified"`
+	PhoneVerified bool           `json:"phone_verified"`
 	UserVerified  bool           `json:"user_verified"`
 	IsBlocked     bool           `json:"is_blocked"`
 	GstNo         sql.NullString `json:"gst_no"`
@@ -66,6 +239,45 @@ type User struct {
 	UpdatedAt     time.Time      `json:"updated_at"`
 }
 
+type UserAuditLog struct {
+	ID             uuid.UUID             `json:"id"`
+	ActorID        uuid.NullUUID         `json:"actor_id"`
+	ActorRole      string                `json:"actor_role"`
+	ImpersonatorID uuid.NullUUID         `json:"impersonator_id"`
+	Action         string                `json:"action"`
+	EntityType     string                `json:"entity_type"`
+	EntityID       string                `json:"entity_id"`
+	Before         pqtype.NullRawMessage `json:"before"`
+	After          pqtype.NullRawMessage `json:"after"`
+	IpAddress      string                `json:"ip_address"`
+	UserAgent      string                `json:"user_agent"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	CodeHash  string       `json:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type UserRole struct {
+	UserID     uuid.UUID     `json:"user_id"`
+	Role       string        `json:"role"`
+	AssignedBy uuid.NullUUID `json:"assigned_by"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type UserTotp struct {
+	UserID       uuid.UUID    `json:"user_id"`
+	Secret       string       `json:"secret"`
+	Enabled      bool         `json:"enabled"`
+	LastUsedStep int64        `json:"last_used_step"`
+	EnabledAt    sql.NullTime `json:"enabled_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
 type Wallet struct {
 	ID        uuid.UUID `json:"id"`
 	UserID    uuid.UUID `json:"user_id"`
@@ -0,0 +1,119 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: notification_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const countUnreadNotifications = `-- name: CountUnreadNotifications :one
+select count(*) from notifications
+where user_id = $1 and read_at is null
+`
+
+func (q *Queries) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.queryRow(ctx, q.countUnreadNotificationsStmt, countUnreadNotifications, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getNotificationsByUserID = `-- name: GetNotificationsByUserID :many
+select id, user_id, kind, title, body, data, read_at, created_at from notifications
+where user_id = $1
+and ($4::boolean is null or (read_at is null) = $4)
+order by created_at desc
+limit $2 offset $3
+`
+
+type GetNotificationsByUserIDParams struct {
+	UserID uuid.UUID    `json:"user_id"`
+	Limit  int32        `json:"limit"`
+	Offset int32        `json:"offset"`
+	Unread sql.NullBool `json:"unread"`
+}
+
+func (q *Queries) GetNotificationsByUserID(ctx context.Context, arg GetNotificationsByUserIDParams) ([]Notification, error) {
+	rows, err := q.query(ctx, q.getNotificationsByUserIDStmt, getNotificationsByUserID,
+		arg.UserID,
+		arg.Limit,
+		arg.Offset,
+		arg.Unread,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Notification{}
+	for rows.Next() {
+		var i Notification
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Kind,
+			&i.Title,
+			&i.Body,
+			&i.Data,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markAllNotificationsRead = `-- name: MarkAllNotificationsRead :execrows
+update notifications
+set read_at = current_timestamp
+where user_id = $1 and read_at is null
+`
+
+func (q *Queries) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.markAllNotificationsReadStmt, markAllNotificationsRead, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markNotificationRead = `-- name: MarkNotificationRead :one
+update notifications
+set read_at = coalesce(read_at, current_timestamp)
+where id = $1 and user_id = $2
+returning id, user_id, kind, title, body, data, read_at, created_at
+`
+
+type MarkNotificationReadParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (Notification, error) {
+	row := q.queryRow(ctx, q.markNotificationReadStmt, markNotificationRead, arg.ID, arg.UserID)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Kind,
+		&i.Title,
+		&i.Body,
+		&i.Data,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,349 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: onboarding_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const getOrCreateSellerOnboarding = `-- name: GetOrCreateSellerOnboarding :one
+insert into seller_onboardings
+(seller_id)
+values ($1)
+on conflict (seller_id) do update set seller_id = excluded.seller_id
+returning seller_id, status, rejection_reason, submitted_at, reviewed_at, reviewed_by, created_at, updated_at
+`
+
+func (q *Queries) GetOrCreateSellerOnboarding(ctx context.Context, sellerID uuid.UUID) (SellerOnboarding, error) {
+	row := q.queryRow(ctx, q.getOrCreateSellerOnboardingStmt, getOrCreateSellerOnboarding, sellerID)
+	var i SellerOnboarding
+	err := row.Scan(
+		&i.SellerID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.SubmittedAt,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSellerDocumentByID = `-- name: GetSellerDocumentByID :one
+select id, seller_id, kind, file_key, content_type, status, rejection_reason, reviewed_at, reviewed_by, uploaded_at from seller_documents
+where id = $1
+`
+
+func (q *Queries) GetSellerDocumentByID(ctx context.Context, id uuid.UUID) (SellerDocument, error) {
+	row := q.queryRow(ctx, q.getSellerDocumentByIDStmt, getSellerDocumentByID, id)
+	var i SellerDocument
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Kind,
+		&i.FileKey,
+		&i.ContentType,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.UploadedAt,
+	)
+	return i, err
+}
+
+const getSellerDocumentBySellerIDAndKind = `-- name: GetSellerDocumentBySellerIDAndKind :one
+select id, seller_id, kind, file_key, content_type, status, rejection_reason, reviewed_at, reviewed_by, uploaded_at from seller_documents
+where seller_id = $1 and kind = $2
+`
+
+type GetSellerDocumentBySellerIDAndKindParams struct {
+	SellerID uuid.UUID `json:"seller_id"`
+	Kind     string    `json:"kind"`
+}
+
+func (q *Queries) GetSellerDocumentBySellerIDAndKind(ctx context.Context, arg GetSellerDocumentBySellerIDAndKindParams) (SellerDocument, error) {
+	row := q.queryRow(ctx, q.getSellerDocumentBySellerIDAndKindStmt, getSellerDocumentBySellerIDAndKind, arg.SellerID, arg.Kind)
+	var i SellerDocument
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Kind,
+		&i.FileKey,
+		&i.ContentType,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.UploadedAt,
+	)
+	return i, err
+}
+
+const getSellerDocumentsBySellerID = `-- name: GetSellerDocumentsBySellerID :many
+select id, seller_id, kind, file_key, content_type, status, rejection_reason, reviewed_at, reviewed_by, uploaded_at from seller_documents
+where seller_id = $1
+order by kind
+`
+
+func (q *Queries) GetSellerDocumentsBySellerID(ctx context.Context, sellerID uuid.UUID) ([]SellerDocument, error) {
+	rows, err := q.query(ctx, q.getSellerDocumentsBySellerIDStmt, getSellerDocumentsBySellerID, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SellerDocument{}
+	for rows.Next() {
+		var i SellerDocument
+		if err := rows.Scan(
+			&i.ID,
+			&i.SellerID,
+			&i.Kind,
+			&i.FileKey,
+			&i.ContentType,
+			&i.Status,
+			&i.RejectionReason,
+			&i.ReviewedAt,
+			&i.ReviewedBy,
+			&i.UploadedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSellerOnboardingBySellerID = `-- name: GetSellerOnboardingBySellerID :one
+select seller_id, status, rejection_reason, submitted_at, reviewed_at, reviewed_by, created_at, updated_at from seller_onboardings
+where seller_id = $1
+`
+
+func (q *Queries) GetSellerOnboardingBySellerID(ctx context.Context, sellerID uuid.UUID) (SellerOnboarding, error) {
+	row := q.queryRow(ctx, q.getSellerOnboardingBySellerIDStmt, getSellerOnboardingBySellerID, sellerID)
+	var i SellerOnboarding
+	err := row.Scan(
+		&i.SellerID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.SubmittedAt,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSellerOnboardingsByStatus = `-- name: GetSellerOnboardingsByStatus :many
+select so.seller_id, u.name, u.email, u.gst_no, so.status, so.submitted_at, so.reviewed_at
+from seller_onboardings so
+inner join users u
+on so.seller_id = u.id
+where so.status = $1
+order by so.submitted_at nulls last, so.created_at
+`
+
+type GetSellerOnboardingsByStatusRow struct {
+	SellerID    uuid.UUID      `json:"seller_id"`
+	Name        string         `json:"name"`
+	Email       string         `json:"email"`
+	GstNo       sql.NullString `json:"gst_no"`
+	Status      string         `json:"status"`
+	SubmittedAt sql.NullTime   `json:"submitted_at"`
+	ReviewedAt  sql.NullTime   `json:"reviewed_at"`
+}
+
+func (q *Queries) GetSellerOnboardingsByStatus(ctx context.Context, status string) ([]GetSellerOnboardingsByStatusRow, error) {
+	rows, err := q.query(ctx, q.getSellerOnboardingsByStatusStmt, getSellerOnboardingsByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetSellerOnboardingsByStatusRow{}
+	for rows.Next() {
+		var i GetSellerOnboardingsByStatusRow
+		if err := rows.Scan(
+			&i.SellerID,
+			&i.Name,
+			&i.Email,
+			&i.GstNo,
+			&i.Status,
+			&i.SubmittedAt,
+			&i.ReviewedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reviewSellerDocumentByID = `-- name: ReviewSellerDocumentByID :one
+update seller_documents
+set status = $1, rejection_reason = $2, reviewed_at = current_timestamp,
+reviewed_by = $3
+where id = $4
+returning id, seller_id, kind, file_key, content_type, status, rejection_reason, reviewed_at, reviewed_by, uploaded_at
+`
+
+type ReviewSellerDocumentByIDParams struct {
+	Status          string         `json:"status"`
+	RejectionReason sql.NullString `json:"rejection_reason"`
+	ReviewedBy      uuid.NullUUID  `json:"reviewed_by"`
+	ID              uuid.UUID      `json:"id"`
+}
+
+func (q *Queries) ReviewSellerDocumentByID(ctx context.Context, arg ReviewSellerDocumentByIDParams) (SellerDocument, error) {
+	row := q.queryRow(ctx, q.reviewSellerDocumentByIDStmt, reviewSellerDocumentByID,
+		arg.Status,
+		arg.RejectionReason,
+		arg.ReviewedBy,
+		arg.ID,
+	)
+	var i SellerDocument
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Kind,
+		&i.FileKey,
+		&i.ContentType,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.UploadedAt,
+	)
+	return i, err
+}
+
+const reviewSellerOnboarding = `-- name: ReviewSellerOnboarding :one
+update seller_onboardings
+set status = $1, rejection_reason = $2, reviewed_at = current_timestamp,
+reviewed_by = $3, updated_at = current_timestamp
+where seller_id = $4 and status = 'submitted'
+returning seller_id, status, rejection_reason, submitted_at, reviewed_at, reviewed_by, created_at, updated_at
+`
+
+type ReviewSellerOnboardingParams struct {
+	Status          string         `json:"status"`
+	RejectionReason sql.NullString `json:"rejection_reason"`
+	ReviewedBy      uuid.NullUUID  `json:"reviewed_by"`
+	SellerID        uuid.UUID      `json:"seller_id"`
+}
+
+func (q *Queries) ReviewSellerOnboarding(ctx context.Context, arg ReviewSellerOnboardingParams) (SellerOnboarding, error) {
+	row := q.queryRow(ctx, q.reviewSellerOnboardingStmt, reviewSellerOnboarding,
+		arg.Status,
+		arg.RejectionReason,
+		arg.ReviewedBy,
+		arg.SellerID,
+	)
+	var i SellerOnboarding
+	err := row.Scan(
+		&i.SellerID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.SubmittedAt,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setSellerOnboardingDraft = `-- name: SetSellerOnboardingDraft :exec
+update seller_onboardings
+set status = 'draft', updated_at = current_timestamp
+where seller_id = $1 and status = 'rejected'
+`
+
+// replacing a document of a rejected application reopens it
+func (q *Queries) SetSellerOnboardingDraft(ctx context.Context, sellerID uuid.UUID) error {
+	_, err := q.exec(ctx, q.setSellerOnboardingDraftStmt, setSellerOnboardingDraft, sellerID)
+	return err
+}
+
+const submitSellerOnboarding = `-- name: SubmitSellerOnboarding :one
+update seller_onboardings
+set status = 'submitted', rejection_reason = null, submitted_at = current_timestamp,
+reviewed_at = null, reviewed_by = null, updated_at = current_timestamp
+where seller_id = $1 and status = 'draft'
+returning seller_id, status, rejection_reason, submitted_at, reviewed_at, reviewed_by, created_at, updated_at
+`
+
+func (q *Queries) SubmitSellerOnboarding(ctx context.Context, sellerID uuid.UUID) (SellerOnboarding, error) {
+	row := q.queryRow(ctx, q.submitSellerOnboardingStmt, submitSellerOnboarding, sellerID)
+	var i SellerOnboarding
+	err := row.Scan(
+		&i.SellerID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.SubmittedAt,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertSellerDocument = `-- name: UpsertSellerDocument :one
+insert into seller_documents
+(seller_id, kind, file_key, content_type)
+values ($1, $2, $3, $4)
+on conflict (seller_id, kind) do update
+set file_key = excluded.file_key, content_type = excluded.content_type, status = 'pending',
+rejection_reason = null, reviewed_at = null, reviewed_by = null, uploaded_at = current_timestamp
+returning id, seller_id, kind, file_key, content_type, status, rejection_reason, reviewed_at, reviewed_by, uploaded_at
+`
+
+type UpsertSellerDocumentParams struct {
+	SellerID    uuid.UUID `json:"seller_id"`
+	Kind        string    `json:"kind"`
+	FileKey     string    `json:"file_key"`
+	ContentType string    `json:"content_type"`
+}
+
+func (q *Queries) UpsertSellerDocument(ctx context.Context, arg UpsertSellerDocumentParams) (SellerDocument, error) {
+	row := q.queryRow(ctx, q.upsertSellerDocumentStmt, upsertSellerDocument,
+		arg.SellerID,
+		arg.Kind,
+		arg.FileKey,
+		arg.ContentType,
+	)
+	var i SellerDocument
+	err := row.Scan(
+		&i.ID,
+		&i.SellerID,
+		&i.Kind,
+		&i.FileKey,
+		&i.ContentType,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReviewedAt,
+		&i.ReviewedBy,
+		&i.UploadedAt,
+	)
+	return i, err
+}
@@ -14,10 +14,16 @@ import (
 
 const addForgotOTPByUserID = `-- name: AddForgotOTPByUserID :one
 insert into forgot_otps 
-(user_id) values ($1)
-returning id, user_id, otp, created_at, expires_at
+(user_id)
+select $1::uuid
+where not exists (
+    select 1 from forgot_otps
+    where user_id = $1::uuid and created_at > current_timestamp - interval '1 minute'
+)
+returning id, user_id, otp, attempts, created_at, expires_at
 `
 
+// same resend cooldown as AddOTP
 func (q *Queries) AddForgotOTPByUserID(ctx context.Context, userID uuid.UUID) (ForgotOtp, error) {
 	row := q.queryRow(ctx, q.addForgotOTPByUserIDStmt, addForgotOTPByUserID, userID)
 	var i ForgotOtp
@@ -25,6 +31,7 @@ func (q *Queries) AddForgotOTPByUserID(ctx context.Context, userID uuid.UUID) (F
 		&i.ID,
 		&i.UserID,
 		&i.Otp,
+		&i.Attempts,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
@@ -33,10 +40,17 @@ func (q *Queries) AddForgotOTPByUserID(ctx context.Context, userID uuid.UUID) (F
 
 const addOTP = `-- name: AddOTP :one
 insert into otps
-(user_id) values ($1)
-returning id, user_id, otp, created_at, expires_at
+(user_id)
+select $1::uuid
+where not exists (
+    select 1 from otps
+    where user_id = $1::uuid and created_at > current_timestamp - interval '1 minute'
+)
+returning id, user_id, otp, attempts, created_at, expires_at
 `
 
+// no row is returned while the last otp of the user is younger than a minute,
+// so otps can't be resent back to back
 func (q *Queries) AddOTP(ctx context.Context, userID uuid.UUID) (Otp, error) {
 	row := q.queryRow(ctx, q.addOTPStmt, addOTP, userID)
 	var i Otp
@@ -44,6 +58,7 @@ func (q *Queries) AddOTP(ctx context.Context, userID uuid.UUID) (Otp, error) {
 		&i.ID,
 		&i.UserID,
 		&i.Otp,
+		&i.Attempts,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
@@ -60,6 +75,16 @@ func (q *Queries) DeleteForgotOTPByEmail(ctx context.Context, email string) erro
 	return err
 }
 
+const deleteForgotOTPByID = `-- name: DeleteForgotOTPByID :exec
+delete from forgot_otps
+where id = $1
+`
+
+func (q *Queries) DeleteForgotOTPByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteForgotOTPByIDStmt, deleteForgotOTPByID, id)
+	return err
+}
+
 const deleteOTPByEmail = `-- name: DeleteOTPByEmail :execresult
 delete from otps
 where user_id = (select user_id from users where email = $1)
@@ -69,8 +94,18 @@ func (q *Queries) DeleteOTPByEmail(ctx context.Context, email string) (sql.Resul
 	return q.exec(ctx, q.deleteOTPByEmailStmt, deleteOTPByEmail, email)
 }
 
+const deleteOTPByID = `-- name: DeleteOTPByID :exec
+delete from otps
+where id = $1
+`
+
+func (q *Queries) DeleteOTPByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteOTPByIDStmt, deleteOTPByID, id)
+	return err
+}
+
 const getValidForgotOTPByUserID = `-- name: GetValidForgotOTPByUserID :one
-select id, user_id, otp, created_at, expires_at from forgot_otps
+select id, user_id, otp, attempts, created_at, expires_at from forgot_otps
 where user_id  = $1 and expires_at > current_timestamp
 order by created_at DESC
 limit 1
@@ -83,6 +118,7 @@ func (q *Queries) GetValidForgotOTPByUserID(ctx context.Context, userID uuid.UUI
 		&i.ID,
 		&i.UserID,
 		&i.Otp,
+		&i.Attempts,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
@@ -90,7 +126,7 @@ func (q *Queries) GetValidForgotOTPByUserID(ctx context.Context, userID uuid.UUI
 }
 
 const getValidOTPByUserID = `-- name: GetValidOTPByUserID :one
-SELECT id, user_id, otp, created_at, expires_at FROM otps
+SELECT id, user_id, otp, attempts, created_at, expires_at FROM otps
 WHERE user_id = $1 and expires_at > current_timestamp
 ORDER BY created_at DESC
 LIMIT 1
@@ -103,8 +139,37 @@ func (q *Queries) GetValidOTPByUserID(ctx context.Context, userID uuid.UUID) (Ot
 		&i.ID,
 		&i.UserID,
 		&i.Otp,
+		&i.Attempts,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
 	return i, err
 }
+
+const incrementForgotOTPAttempts = `-- name: IncrementForgotOTPAttempts :one
+update forgot_otps
+set attempts = attempts + 1
+where id = $1
+returning attempts
+`
+
+func (q *Queries) IncrementForgotOTPAttempts(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.queryRow(ctx, q.incrementForgotOTPAttemptsStmt, incrementForgotOTPAttempts, id)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const incrementOTPAttempts = `-- name: IncrementOTPAttempts :one
+update otps
+set attempts = attempts + 1
+where id = $1
+returning attempts
+`
+
+func (q *Queries) IncrementOTPAttempts(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.queryRow(ctx, q.incrementOTPAttemptsStmt, incrementOTPAttempts, id)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
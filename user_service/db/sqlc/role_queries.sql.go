@@ -0,0 +1,252 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: role_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const addRole = `-- name: AddRole :one
+insert into roles
+(name, description)
+values ($1, $2)
+returning name, description, staff, created_at
+`
+
+type AddRoleParams struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func (q *Queries) AddRole(ctx context.Context, arg AddRoleParams) (Role, error) {
+	row := q.queryRow(ctx, q.addRoleStmt, addRole, arg.Name, arg.Description)
+	var i Role
+	err := row.Scan(
+		&i.Name,
+		&i.Description,
+		&i.Staff,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const addRolePermission = `-- name: AddRolePermission :exec
+insert into role_permissions
+(role, permission)
+values ($1, $2)
+on conflict do nothing
+`
+
+type AddRolePermissionParams struct {
+	Role       string `json:"role"`
+	Permission string `json:"permission"`
+}
+
+func (q *Queries) AddRolePermission(ctx context.Context, arg AddRolePermissionParams) error {
+	_, err := q.exec(ctx, q.addRolePermissionStmt, addRolePermission, arg.Role, arg.Permission)
+	return err
+}
+
+const addUserRole = `-- name: AddUserRole :one
+insert into user_roles
+(user_id, role, assigned_by)
+values ($1, $2, $3)
+returning user_id, role, assigned_by, created_at
+`
+
+type AddUserRoleParams struct {
+	UserID     uuid.UUID     `json:"user_id"`
+	Role       string        `json:"role"`
+	AssignedBy uuid.NullUUID `json:"assigned_by"`
+}
+
+func (q *Queries) AddUserRole(ctx context.Context, arg AddUserRoleParams) (UserRole, error) {
+	row := q.queryRow(ctx, q.addUserRoleStmt, addUserRole, arg.UserID, arg.Role, arg.AssignedBy)
+	var i UserRole
+	err := row.Scan(
+		&i.UserID,
+		&i.Role,
+		&i.AssignedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const countUsersWithRole = `-- name: CountUsersWithRole :one
+select count(*) from user_roles
+where role = $1
+`
+
+func (q *Queries) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	row := q.queryRow(ctx, q.countUsersWithRoleStmt, countUsersWithRole, role)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteRolePermissions = `-- name: DeleteRolePermissions :exec
+delete from role_permissions
+where role = $1
+`
+
+func (q *Queries) DeleteRolePermissions(ctx context.Context, role string) error {
+	_, err := q.exec(ctx, q.deleteRolePermissionsStmt, deleteRolePermissions, role)
+	return err
+}
+
+const deleteUserRole = `-- name: DeleteUserRole :execresult
+delete from user_roles
+where user_id = $1 and role = $2
+`
+
+type DeleteUserRoleParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+func (q *Queries) DeleteUserRole(ctx context.Context, arg DeleteUserRoleParams) (sql.Result, error) {
+	return q.exec(ctx, q.deleteUserRoleStmt, deleteUserRole, arg.UserID, arg.Role)
+}
+
+const getPermissionsByUserID = `-- name: GetPermissionsByUserID :many
+select distinct rp.permission from user_roles ur
+join role_permissions rp on rp.role = ur.role
+where ur.user_id = $1
+order by rp.permission
+`
+
+func (q *Queries) GetPermissionsByUserID(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.query(ctx, q.getPermissionsByUserIDStmt, getPermissionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		items = append(items, permission)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoleByName = `-- name: GetRoleByName :one
+select name, description, staff, created_at from roles
+where name = $1
+`
+
+func (q *Queries) GetRoleByName(ctx context.Context, name string) (Role, error) {
+	row := q.queryRow(ctx, q.getRoleByNameStmt, getRoleByName, name)
+	var i Role
+	err := row.Scan(
+		&i.Name,
+		&i.Description,
+		&i.Staff,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRolePermissions = `-- name: GetRolePermissions :many
+select role, permission from role_permissions
+order by role, permission
+`
+
+func (q *Queries) GetRolePermissions(ctx context.Context) ([]RolePermission, error) {
+	rows, err := q.query(ctx, q.getRolePermissionsStmt, getRolePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RolePermission{}
+	for rows.Next() {
+		var i RolePermission
+		if err := rows.Scan(&i.Role, &i.Permission); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRoles = `-- name: GetRoles :many
+select name, description, staff, created_at from roles
+order by staff, name
+`
+
+func (q *Queries) GetRoles(ctx context.Context) ([]Role, error) {
+	rows, err := q.query(ctx, q.getRolesStmt, getRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Role{}
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(
+			&i.Name,
+			&i.Description,
+			&i.Staff,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRolesByUserID = `-- name: GetRolesByUserID :many
+select role from user_roles
+where user_id = $1
+order by role
+`
+
+func (q *Queries) GetRolesByUserID(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.query(ctx, q.getRolesByUserIDStmt, getRolesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		items = append(items, role)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -15,63 +15,172 @@ import (
 
 const addSession = `-- name: AddSession :one
 insert into sessions
-(user_id, ip_address, user_agent )
+(user_id, ip_address, user_agent, refresh_token_hash)
 values
-($1, $2, $3)
-returning id, user_id, ip_address, user_agent, created_at, expires_at
+($1, $2, $3, $4)
+returning id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at
 `
 
 type AddSessionParams struct {
-	UserID    uuid.UUID `json:"user_id"`
-	IpAddress string    `json:"ip_address"`
-	UserAgent string    `json:"user_agent"`
+	UserID           uuid.UUID `json:"user_id"`
+	IpAddress        string    `json:"ip_address"`
+	UserAgent        string    `json:"user_agent"`
+	RefreshTokenHash string    `json:"refresh_token_hash"`
 }
 
 func (q *Queries) AddSession(ctx context.Context, arg AddSessionParams) (Session, error) {
-	row := q.queryRow(ctx, q.addSessionStmt, addSession, arg.UserID, arg.IpAddress, arg.UserAgent)
+	row := q.queryRow(ctx, q.addSessionStmt, addSession,
+		arg.UserID,
+		arg.IpAddress,
+		arg.UserAgent,
+		arg.RefreshTokenHash,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.IpAddress,
 		&i.UserAgent,
+		&i.RefreshTokenHash,
+		&i.PreviousTokenHash,
+		&i.RevokedAt,
+		&i.LastUsedAt,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
 	return i, err
 }
 
-const deleteSessionByID = `-- name: DeleteSessionByID :execresult
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execresult
 delete from sessions
-where id = $1
+where expires_at < current_timestamp
+or revoked_at < current_timestamp - interval '1 day'
 `
 
-func (q *Queries) DeleteSessionByID(ctx context.Context, id uuid.UUID) (sql.Result, error) {
-	return q.exec(ctx, q.deleteSessionByIDStmt, deleteSessionByID, id)
+// revoked sessions are kept for a day so a replayed refresh token is still recognised
+func (q *Queries) DeleteExpiredSessions(ctx context.Context) (sql.Result, error) {
+	return q.exec(ctx, q.deleteExpiredSessionsStmt, deleteExpiredSessions)
 }
 
-const deleteSessionsByuserID = `-- name: DeleteSessionsByuserID :execresult
-delete from sessions
-where user_id = $1
+const getActiveSessionsByUserID = `-- name: GetActiveSessionsByUserID :many
+select id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at from sessions
+where user_id = $1 and revoked_at is null and expires_at > current_timestamp
+order by last_used_at desc
 `
 
-func (q *Queries) DeleteSessionsByuserID(ctx context.Context, userID uuid.UUID) (sql.Result, error) {
-	return q.exec(ctx, q.deleteSessionsByuserIDStmt, deleteSessionsByuserID, userID)
+func (q *Queries) GetActiveSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	rows, err := q.query(ctx, q.getActiveSessionsByUserIDStmt, getActiveSessionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.RefreshTokenHash,
+			&i.PreviousTokenHash,
+			&i.RevokedAt,
+			&i.LastUsedAt,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getAllSessionsByUserID = `-- name: GetAllSessionsByUserID :one
-select id, user_id, ip_address, user_agent, created_at, expires_at from sessions
+const getAllSessionsByUserID = `-- name: GetAllSessionsByUserID :many
+select id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at from sessions
 where user_id = $1
 `
 
-func (q *Queries) GetAllSessionsByUserID(ctx context.Context, userID uuid.UUID) (Session, error) {
-	row := q.queryRow(ctx, q.getAllSessionsByUserIDStmt, getAllSessionsByUserID, userID)
+func (q *Queries) GetAllSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	rows, err := q.query(ctx, q.getAllSessionsByUserIDStmt, getAllSessionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.IpAddress,
+			&i.UserAgent,
+			&i.RefreshTokenHash,
+			&i.PreviousTokenHash,
+			&i.RevokedAt,
+			&i.LastUsedAt,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSessionByPreviousTokenHash = `-- name: GetSessionByPreviousTokenHash :one
+select id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at from sessions
+where previous_token_hash = $1
+`
+
+func (q *Queries) GetSessionByPreviousTokenHash(ctx context.Context, previousTokenHash sql.NullString) (Session, error) {
+	row := q.queryRow(ctx, q.getSessionByPreviousTokenHashStmt, getSessionByPreviousTokenHash, previousTokenHash)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.IpAddress,
 		&i.UserAgent,
+		&i.RefreshTokenHash,
+		&i.PreviousTokenHash,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getSessionByRefreshTokenHash = `-- name: GetSessionByRefreshTokenHash :one
+select id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at from sessions
+where refresh_token_hash = $1
+`
+
+func (q *Queries) GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (Session, error) {
+	row := q.queryRow(ctx, q.getSessionByRefreshTokenHashStmt, getSessionByRefreshTokenHash, refreshTokenHash)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.IpAddress,
+		&i.UserAgent,
+		&i.RefreshTokenHash,
+		&i.PreviousTokenHash,
+		&i.RevokedAt,
+		&i.LastUsedAt,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
@@ -79,7 +188,7 @@ func (q *Queries) GetAllSessionsByUserID(ctx context.Context, userID uuid.UUID)
 }
 
 const getSessionDetailsByID = `-- name: GetSessionDetailsByID :one
-select id, user_id, ip_address, user_agent, created_at, expires_at from sessions
+select id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at from sessions
 where id = $1
 `
 
@@ -91,6 +200,10 @@ func (q *Queries) GetSessionDetailsByID(ctx context.Context, id uuid.UUID) (Sess
 		&i.UserID,
 		&i.IpAddress,
 		&i.UserAgent,
+		&i.RefreshTokenHash,
+		&i.PreviousTokenHash,
+		&i.RevokedAt,
+		&i.LastUsedAt,
 		&i.CreatedAt,
 		&i.ExpiresAt,
 	)
@@ -112,7 +225,7 @@ select
 from sessions s
 join users u
 on s.user_id = u.id
-where s.id = $1
+where s.id = $1 and s.revoked_at is null and s.expires_at > current_timestamp
 `
 
 type GetUserBySessionIDRow struct {
@@ -145,3 +258,87 @@ func (q *Queries) GetUserBySessionID(ctx context.Context, id uuid.UUID) (GetUser
 	)
 	return i, err
 }
+
+const revokeOtherSessionsByUserID = `-- name: RevokeOtherSessionsByUserID :execresult
+update sessions
+set revoked_at = current_timestamp
+where user_id = $1 and id <> $2 and revoked_at is null
+`
+
+type RevokeOtherSessionsByUserIDParams struct {
+	UserID           uuid.UUID `json:"user_id"`
+	CurrentSessionID uuid.UUID `json:"current_session_id"`
+}
+
+func (q *Queries) RevokeOtherSessionsByUserID(ctx context.Context, arg RevokeOtherSessionsByUserIDParams) (sql.Result, error) {
+	return q.exec(ctx, q.revokeOtherSessionsByUserIDStmt, revokeOtherSessionsByUserID, arg.UserID, arg.CurrentSessionID)
+}
+
+const revokeSessionByID = `-- name: RevokeSessionByID :execresult
+update sessions
+set revoked_at = current_timestamp
+where id = $1 and revoked_at is null
+`
+
+func (q *Queries) RevokeSessionByID(ctx context.Context, id uuid.UUID) (sql.Result, error) {
+	return q.exec(ctx, q.revokeSessionByIDStmt, revokeSessionByID, id)
+}
+
+const revokeSessionByIDAndUserID = `-- name: RevokeSessionByIDAndUserID :execresult
+update sessions
+set revoked_at = current_timestamp
+where id = $1 and user_id = $2 and revoked_at is null
+`
+
+type RevokeSessionByIDAndUserIDParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) RevokeSessionByIDAndUserID(ctx context.Context, arg RevokeSessionByIDAndUserIDParams) (sql.Result, error) {
+	return q.exec(ctx, q.revokeSessionByIDAndUserIDStmt, revokeSessionByIDAndUserID, arg.ID, arg.UserID)
+}
+
+const revokeSessionsByUserID = `-- name: RevokeSessionsByUserID :execresult
+update sessions
+set revoked_at = current_timestamp
+where user_id = $1 and revoked_at is null
+`
+
+func (q *Queries) RevokeSessionsByUserID(ctx context.Context, userID uuid.UUID) (sql.Result, error) {
+	return q.exec(ctx, q.revokeSessionsByUserIDStmt, revokeSessionsByUserID, userID)
+}
+
+const rotateSessionRefreshToken = `-- name: RotateSessionRefreshToken :one
+update sessions
+set previous_token_hash = refresh_token_hash, refresh_token_hash = $1,
+last_used_at = current_timestamp, expires_at = current_timestamp + interval '7 days'
+where id = $2 and refresh_token_hash = $3
+and revoked_at is null and expires_at > current_timestamp
+returning id, user_id, ip_address, user_agent, refresh_token_hash, previous_token_hash, revoked_at, last_used_at, created_at, expires_at
+`
+
+type RotateSessionRefreshTokenParams struct {
+	NewTokenHash string    `json:"new_token_hash"`
+	ID           uuid.UUID `json:"id"`
+	OldTokenHash string    `json:"old_token_hash"`
+}
+
+// only a live session whose current token is presented can be rotated
+func (q *Queries) RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (Session, error) {
+	row := q.queryRow(ctx, q.rotateSessionRefreshTokenStmt, rotateSessionRefreshToken, arg.NewTokenHash, arg.ID, arg.OldTokenHash)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.IpAddress,
+		&i.UserAgent,
+		&i.RefreshTokenHash,
+		&i.PreviousTokenHash,
+		&i.RevokedAt,
+		&i.LastUsedAt,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
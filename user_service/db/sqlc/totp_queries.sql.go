@@ -0,0 +1,322 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: totp_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const addLoginChallenge = `-- name: AddLoginChallenge :one
+insert into login_challenges
+(user_id, token_hash, purpose)
+values ($1, $2, $3)
+returning id, user_id, token_hash, purpose, attempts, created_at, expires_at
+`
+
+type AddLoginChallengeParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	TokenHash string    `json:"token_hash"`
+	Purpose   string    `json:"purpose"`
+}
+
+func (q *Queries) AddLoginChallenge(ctx context.Context, arg AddLoginChallengeParams) (LoginChallenge, error) {
+	row := q.queryRow(ctx, q.addLoginChallengeStmt, addLoginChallenge, arg.UserID, arg.TokenHash, arg.Purpose)
+	var i LoginChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.Purpose,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const addRecoveryCode = `-- name: AddRecoveryCode :exec
+insert into user_recovery_codes
+(user_id, code_hash)
+values ($1, $2)
+`
+
+type AddRecoveryCodeParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	CodeHash string    `json:"code_hash"`
+}
+
+func (q *Queries) AddRecoveryCode(ctx context.Context, arg AddRecoveryCodeParams) error {
+	_, err := q.exec(ctx, q.addRecoveryCodeStmt, addRecoveryCode, arg.UserID, arg.CodeHash)
+	return err
+}
+
+const deleteExpiredLoginChallenges = `-- name: DeleteExpiredLoginChallenges :execresult
+delete from login_challenges
+where expires_at < current_timestamp
+`
+
+func (q *Queries) DeleteExpiredLoginChallenges(ctx context.Context) (sql.Result, error) {
+	return q.exec(ctx, q.deleteExpiredLoginChallengesStmt, deleteExpiredLoginChallenges)
+}
+
+const deleteLoginChallengeByID = `-- name: DeleteLoginChallengeByID :exec
+delete from login_challenges
+where id = $1
+`
+
+func (q *Queries) DeleteLoginChallengeByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteLoginChallengeByIDStmt, deleteLoginChallengeByID, id)
+	return err
+}
+
+const deleteRecoveryCodesByUserID = `-- name: DeleteRecoveryCodesByUserID :exec
+delete from user_recovery_codes
+where user_id = $1
+`
+
+func (q *Queries) DeleteRecoveryCodesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteRecoveryCodesByUserIDStmt, deleteRecoveryCodesByUserID, userID)
+	return err
+}
+
+const deleteUserTOTP = `-- name: DeleteUserTOTP :exec
+delete from user_totps
+where user_id = $1
+`
+
+func (q *Queries) DeleteUserTOTP(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteUserTOTPStmt, deleteUserTOTP, userID)
+	return err
+}
+
+const enableUserTOTP = `-- name: EnableUserTOTP :one
+update user_totps
+set enabled = true, enabled_at = current_timestamp, last_used_step = $1
+where user_id = $2 and enabled = false
+returning user_id, secret, enabled, last_used_step, enabled_at, created_at
+`
+
+type EnableUserTOTPParams struct {
+	Step   int64     `json:"step"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (UserTotp, error) {
+	row := q.queryRow(ctx, q.enableUserTOTPStmt, enableUserTOTP, arg.Step, arg.UserID)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.Enabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLoginChallengeByTokenHash = `-- name: GetLoginChallengeByTokenHash :one
+select id, user_id, token_hash, purpose, attempts, created_at, expires_at from login_challenges
+where token_hash = $1 and expires_at > current_timestamp
+`
+
+func (q *Queries) GetLoginChallengeByTokenHash(ctx context.Context, tokenHash string) (LoginChallenge, error) {
+	row := q.queryRow(ctx, q.getLoginChallengeByTokenHashStmt, getLoginChallengeByTokenHash, tokenHash)
+	var i LoginChallenge
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TokenHash,
+		&i.Purpose,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getRole2FAPolicies = `-- name: GetRole2FAPolicies :many
+select role, require_2fa, updated_by, updated_at from role_2fa_policies
+order by role
+`
+
+func (q *Queries) GetRole2FAPolicies(ctx context.Context) ([]Role2faPolicy, error) {
+	rows, err := q.query(ctx, q.getRole2FAPoliciesStmt, getRole2FAPolicies)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Role2faPolicy{}
+	for rows.Next() {
+		var i Role2faPolicy
+		if err := rows.Scan(
+			&i.Role,
+			&i.Require2fa,
+			&i.UpdatedBy,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnusedRecoveryCodeCount = `-- name: GetUnusedRecoveryCodeCount :one
+select count(*) from user_recovery_codes
+where user_id = $1 and used_at is null
+`
+
+func (q *Queries) GetUnusedRecoveryCodeCount(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.queryRow(ctx, q.getUnusedRecoveryCodeCountStmt, getUnusedRecoveryCodeCount, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getUserTOTPByUserID = `-- name: GetUserTOTPByUserID :one
+select user_id, secret, enabled, last_used_step, enabled_at, created_at from user_totps
+where user_id = $1
+`
+
+func (q *Queries) GetUserTOTPByUserID(ctx context.Context, userID uuid.UUID) (UserTotp, error) {
+	row := q.queryRow(ctx, q.getUserTOTPByUserIDStmt, getUserTOTPByUserID, userID)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.Enabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const incLoginChallengeAttempts = `-- name: IncLoginChallengeAttempts :one
+update login_challenges
+set attempts = attempts + 1
+where id = $1
+returning attempts
+`
+
+func (q *Queries) IncLoginChallengeAttempts(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.queryRow(ctx, q.incLoginChallengeAttemptsStmt, incLoginChallengeAttempts, id)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const isRole2FARequired = `-- name: IsRole2FARequired :one
+select exists (
+    select 1 from role_2fa_policies
+    where role = $1 and require_2fa = true
+)
+`
+
+func (q *Queries) IsRole2FARequired(ctx context.Context, role string) (bool, error) {
+	row := q.queryRow(ctx, q.isRole2FARequiredStmt, isRole2FARequired, role)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const setUserTOTPLastUsedStep = `-- name: SetUserTOTPLastUsedStep :execresult
+update user_totps
+set last_used_step = $1
+where user_id = $2 and last_used_step < $1
+`
+
+type SetUserTOTPLastUsedStepParams struct {
+	Step   int64     `json:"step"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// the step only moves forward, two requests with the same code can't both pass
+func (q *Queries) SetUserTOTPLastUsedStep(ctx context.Context, arg SetUserTOTPLastUsedStepParams) (sql.Result, error) {
+	return q.exec(ctx, q.setUserTOTPLastUsedStepStmt, setUserTOTPLastUsedStep, arg.Step, arg.UserID)
+}
+
+const upsertPendingUserTOTP = `-- name: UpsertPendingUserTOTP :one
+insert into user_totps
+(user_id, secret)
+values ($1, $2)
+on conflict (user_id) do update
+set secret = excluded.secret, last_used_step = 0, created_at = current_timestamp
+where user_totps.enabled = false
+returning user_id, secret, enabled, last_used_step, enabled_at, created_at
+`
+
+type UpsertPendingUserTOTPParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Secret string    `json:"secret"`
+}
+
+// a new setup replaces an earlier unconfirmed one, never an enabled one
+func (q *Queries) UpsertPendingUserTOTP(ctx context.Context, arg UpsertPendingUserTOTPParams) (UserTotp, error) {
+	row := q.queryRow(ctx, q.upsertPendingUserTOTPStmt, upsertPendingUserTOTP, arg.UserID, arg.Secret)
+	var i UserTotp
+	err := row.Scan(
+		&i.UserID,
+		&i.Secret,
+		&i.Enabled,
+		&i.LastUsedStep,
+		&i.EnabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertRole2FAPolicy = `-- name: UpsertRole2FAPolicy :one
+insert into role_2fa_policies
+(role, require_2fa, updated_by)
+values ($1, $2, $3)
+on conflict (role) do update
+set require_2fa = excluded.require_2fa, updated_by = excluded.updated_by, updated_at = current_timestamp
+returning role, require_2fa, updated_by, updated_at
+`
+
+type UpsertRole2FAPolicyParams struct {
+	Role       string        `json:"role"`
+	Require2fa bool          `json:"require_2fa"`
+	UpdatedBy  uuid.NullUUID `json:"updated_by"`
+}
+
+func (q *Queries) UpsertRole2FAPolicy(ctx context.Context, arg UpsertRole2FAPolicyParams) (Role2faPolicy, error) {
+	row := q.queryRow(ctx, q.upsertRole2FAPolicyStmt, upsertRole2FAPolicy, arg.Role, arg.Require2fa, arg.UpdatedBy)
+	var i Role2faPolicy
+	err := row.Scan(
+		&i.Role,
+		&i.Require2fa,
+		&i.UpdatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const useRecoveryCode = `-- name: UseRecoveryCode :execresult
+update user_recovery_codes
+set used_at = current_timestamp
+where user_id = $1 and code_hash = $2 and used_at is null
+`
+
+type UseRecoveryCodeParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	CodeHash string    `json:"code_hash"`
+}
+
+func (q *Queries) UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (sql.Result, error) {
+	return q.exec(ctx, q.useRecoveryCodeStmt, useRecoveryCode, arg.UserID, arg.CodeHash)
+}
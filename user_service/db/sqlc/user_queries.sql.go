@@ -8,6 +8,7 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -214,9 +215,9 @@ func (q *Queries) ChangePasswordByUserID(ctx context.Context, arg ChangePassword
 
 const editSellerByID = `-- name: EditSellerByID :one
 update users
-set name = $2, about = $3, phone = $4, updated_at = current_timestamp
+set name = $2, about = $3, phone = $4, phone_verified = phone_verified and phone is not distinct from $4, updated_at = current_timestamp
 where id = $1
-returning id, name, email, phone, password, role, email_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at
+returning id, name, email, phone, password, role, email_verified, phone_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at
 `
 
 type EditSellerByIDParams struct {
@@ -242,6 +243,7 @@ func (q *Queries) EditSellerByID(ctx context.Context, arg EditSellerByIDParams)
 		&i.Password,
 		&i.Role,
 		&i.EmailVerified,
+		&i.PhoneVerified,
 		&i.UserVerified,
 		&i.IsBlocked,
 		&i.GstNo,
@@ -254,9 +256,9 @@ func (q *Queries) EditSellerByID(ctx context.Context, arg EditSellerByIDParams)
 
 const editUserByID = `-- name: EditUserByID :one
 update users
-set name = $2, phone = $3, updated_at = current_timestamp
+set name = $2, phone = $3, phone_verified = phone_verified and phone is not distinct from $3, updated_at = current_timestamp
 where id = $1
-returning id, name, email, phone, password, role, email_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at
+returning id, name, email, phone, password, role, email_verified, phone_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at
 `
 
 type EditUserByIDParams struct {
@@ -265,6 +267,7 @@ type EditUserByIDParams struct {
 	Phone sql.NullInt64 `json:"phone"`
 }
 
+// a changed phone has to be verified again
 func (q *Queries) EditUserByID(ctx context.Context, arg EditUserByIDParams) (User, error) {
 	row := q.queryRow(ctx, q.editUserByIDStmt, editUserByID, arg.ID, arg.Name, arg.Phone)
 	var i User
@@ -276,6 +279,7 @@ func (q *Queries) EditUserByID(ctx context.Context, arg EditUserByIDParams) (Use
 		&i.Password,
 		&i.Role,
 		&i.EmailVerified,
+		&i.PhoneVerified,
 		&i.UserVerified,
 		&i.IsBlocked,
 		&i.GstNo,
@@ -437,6 +441,34 @@ func (q *Queries) GetAllUsersByRoleUser(ctx context.Context, role string) ([]Get
 	return items, nil
 }
 
+const getSellerByID = `-- name: GetSellerByID :one
+SELECT id, name, about, user_verified, is_blocked, created_at FROM users
+WHERE id = $1 and role = 'seller'
+`
+
+type GetSellerByIDRow struct {
+	ID           uuid.UUID      `json:"id"`
+	Name         string         `json:"name"`
+	About        sql.NullString `json:"about"`
+	UserVerified bool           `json:"user_verified"`
+	IsBlocked    bool           `json:"is_blocked"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+func (q *Queries) GetSellerByID(ctx context.Context, id uuid.UUID) (GetSellerByIDRow, error) {
+	row := q.queryRow(ctx, q.getSellerByIDStmt, getSellerByID, id)
+	var i GetSellerByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.About,
+		&i.UserVerified,
+		&i.IsBlocked,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT id, name, email, phone, role, is_blocked, email_verified, user_verified, gst_no, about FROM users
 WHERE email = $1
@@ -474,7 +506,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 }
 
 const getUserById = `-- name: GetUserById :one
-SELECT id, name, email, phone, role, is_blocked, email_verified, user_verified, gst_no, about FROM users
+SELECT id, name, email, phone, phone_verified, role, is_blocked, email_verified, user_verified, gst_no, about FROM users
 WHERE id = $1
 `
 
@@ -483,6 +515,7 @@ type GetUserByIdRow struct {
 	Name          string         `json:"name"`
 	Email         string         `json:"email"`
 	Phone         sql.NullInt64  `json:"phone"`
+	PhoneVerified bool           `json:"phone_verified"`
 	Role          string         `json:"role"`
 	IsBlocked     bool           `json:"is_blocked"`
 	EmailVerified bool           `json:"email_verified"`
@@ -499,6 +532,7 @@ func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (GetUserByIdRow
 		&i.Name,
 		&i.Email,
 		&i.Phone,
+		&i.PhoneVerified,
 		&i.Role,
 		&i.IsBlocked,
 		&i.EmailVerified,
@@ -510,7 +544,7 @@ func (q *Queries) GetUserById(ctx context.Context, id uuid.UUID) (GetUserByIdRow
 }
 
 const getUserWithPasswordByEmail = `-- name: GetUserWithPasswordByEmail :one
-SELECT id, name, email, phone, password, role, email_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at FROM users
+SELECT id, name, email, phone, password, role, email_verified, phone_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at FROM users
 WHERE email = $1
 `
 
@@ -525,6 +559,7 @@ func (q *Queries) GetUserWithPasswordByEmail(ctx context.Context, email string)
 		&i.Password,
 		&i.Role,
 		&i.EmailVerified,
+		&i.PhoneVerified,
 		&i.UserVerified,
 		&i.IsBlocked,
 		&i.GstNo,
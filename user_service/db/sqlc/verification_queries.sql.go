@@ -0,0 +1,242 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.30.0
+// source: verification_queries.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const addEmailChange = `-- name: AddEmailChange :one
+insert into email_changes
+(user_id, new_email)
+select $1::uuid, $2::text
+where not exists (
+    select 1 from email_changes
+    where user_id = $1::uuid and created_at > current_timestamp - interval '1 minute'
+)
+returning id, user_id, new_email, otp, attempts, created_at, expires_at
+`
+
+type AddEmailChangeParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	NewEmail string    `json:"new_email"`
+}
+
+// same resend cooldown as AddOTP
+func (q *Queries) AddEmailChange(ctx context.Context, arg AddEmailChangeParams) (EmailChange, error) {
+	row := q.queryRow(ctx, q.addEmailChangeStmt, addEmailChange, arg.UserID, arg.NewEmail)
+	var i EmailChange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.NewEmail,
+		&i.Otp,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const addPhoneVerification = `-- name: AddPhoneVerification :one
+insert into phone_verifications
+(user_id, phone)
+select $1::uuid, $2::bigint
+where not exists (
+    select 1 from phone_verifications
+    where user_id = $1::uuid and created_at > current_timestamp - interval '1 minute'
+)
+returning id, user_id, phone, otp, attempts, created_at, expires_at
+`
+
+type AddPhoneVerificationParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Phone  int64     `json:"phone"`
+}
+
+// same resend cooldown as AddOTP
+func (q *Queries) AddPhoneVerification(ctx context.Context, arg AddPhoneVerificationParams) (PhoneVerification, error) {
+	row := q.queryRow(ctx, q.addPhoneVerificationStmt, addPhoneVerification, arg.UserID, arg.Phone)
+	var i PhoneVerification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Phone,
+		&i.Otp,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const changeUserEmail = `-- name: ChangeUserEmail :one
+update users
+set email = $2, email_verified = true, updated_at = current_timestamp
+where id = $1
+returning id, name, email, phone, password, role, email_verified, phone_verified, user_verified, is_blocked, gst_no, about, created_at, updated_at
+`
+
+type ChangeUserEmailParams struct {
+	ID    uuid.UUID `json:"id"`
+	Email string    `json:"email"`
+}
+
+// the new email was proven by the otp so it is verified as well
+func (q *Queries) ChangeUserEmail(ctx context.Context, arg ChangeUserEmailParams) (User, error) {
+	row := q.queryRow(ctx, q.changeUserEmailStmt, changeUserEmail, arg.ID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Phone,
+		&i.Password,
+		&i.Role,
+		&i.EmailVerified,
+		&i.PhoneVerified,
+		&i.UserVerified,
+		&i.IsBlocked,
+		&i.GstNo,
+		&i.About,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteEmailChangesByUserID = `-- name: DeleteEmailChangesByUserID :exec
+delete from email_changes
+where user_id = $1
+`
+
+func (q *Queries) DeleteEmailChangesByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deleteEmailChangesByUserIDStmt, deleteEmailChangesByUserID, userID)
+	return err
+}
+
+const deleteExpiredEmailChanges = `-- name: DeleteExpiredEmailChanges :execresult
+delete from email_changes
+where expires_at < current_timestamp
+`
+
+func (q *Queries) DeleteExpiredEmailChanges(ctx context.Context) (sql.Result, error) {
+	return q.exec(ctx, q.deleteExpiredEmailChangesStmt, deleteExpiredEmailChanges)
+}
+
+const deleteExpiredPhoneVerifications = `-- name: DeleteExpiredPhoneVerifications :execresult
+delete from phone_verifications
+where expires_at < current_timestamp
+`
+
+func (q *Queries) DeleteExpiredPhoneVerifications(ctx context.Context) (sql.Result, error) {
+	return q.exec(ctx, q.deleteExpiredPhoneVerificationsStmt, deleteExpiredPhoneVerifications)
+}
+
+const deletePhoneVerificationsByUserID = `-- name: DeletePhoneVerificationsByUserID :exec
+delete from phone_verifications
+where user_id = $1
+`
+
+func (q *Queries) DeletePhoneVerificationsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.exec(ctx, q.deletePhoneVerificationsByUserIDStmt, deletePhoneVerificationsByUserID, userID)
+	return err
+}
+
+const getValidEmailChangeByUserID = `-- name: GetValidEmailChangeByUserID :one
+select id, user_id, new_email, otp, attempts, created_at, expires_at from email_changes
+where user_id = $1 and expires_at > current_timestamp
+order by created_at desc
+limit 1
+`
+
+func (q *Queries) GetValidEmailChangeByUserID(ctx context.Context, userID uuid.UUID) (EmailChange, error) {
+	row := q.queryRow(ctx, q.getValidEmailChangeByUserIDStmt, getValidEmailChangeByUserID, userID)
+	var i EmailChange
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.NewEmail,
+		&i.Otp,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getValidPhoneVerificationByUserID = `-- name: GetValidPhoneVerificationByUserID :one
+select id, user_id, phone, otp, attempts, created_at, expires_at from phone_verifications
+where user_id = $1 and expires_at > current_timestamp
+order by created_at desc
+limit 1
+`
+
+func (q *Queries) GetValidPhoneVerificationByUserID(ctx context.Context, userID uuid.UUID) (PhoneVerification, error) {
+	row := q.queryRow(ctx, q.getValidPhoneVerificationByUserIDStmt, getValidPhoneVerificationByUserID, userID)
+	var i PhoneVerification
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Phone,
+		&i.Otp,
+		&i.Attempts,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const incrementEmailChangeAttempts = `-- name: IncrementEmailChangeAttempts :one
+update email_changes
+set attempts = attempts + 1
+where id = $1
+returning attempts
+`
+
+func (q *Queries) IncrementEmailChangeAttempts(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.queryRow(ctx, q.incrementEmailChangeAttemptsStmt, incrementEmailChangeAttempts, id)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const incrementPhoneVerificationAttempts = `-- name: IncrementPhoneVerificationAttempts :one
+update phone_verifications
+set attempts = attempts + 1
+where id = $1
+returning attempts
+`
+
+func (q *Queries) IncrementPhoneVerificationAttempts(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.queryRow(ctx, q.incrementPhoneVerificationAttemptsStmt, incrementPhoneVerificationAttempts, id)
+	var attempts int32
+	err := row.Scan(&attempts)
+	return attempts, err
+}
+
+const verifyUserPhone = `-- name: VerifyUserPhone :execrows
+update users
+set phone_verified = true, updated_at = current_timestamp
+where id = $1 and phone = $2
+`
+
+type VerifyUserPhoneParams struct {
+	ID    uuid.UUID     `json:"id"`
+	Phone sql.NullInt64 `json:"phone"`
+}
+
+// no row is updated when the phone was edited after the code was sent
+func (q *Queries) VerifyUserPhone(ctx context.Context, arg VerifyUserPhoneParams) (int64, error) {
+	result, err := q.exec(ctx, q.verifyUserPhoneStmt, verifyUserPhone, arg.ID, arg.Phone)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,121 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/pb/userpb"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserServer answers the other services' questions about users and sellers
+type UserServer struct {
+	userpb.UnimplementedUserServiceServer
+	DB *db.Queries
+}
+
+// NewGRPCServer returns a grpc server with the user service registered,
+// the service main serves it next to the http mux
+func NewGRPCServer() *grpc.Server {
+	srv := grpc.NewServer()
+	userpb.RegisterUserServiceServer(srv, &UserServer{DB: DB})
+	return srv
+}
+
+func (us *UserServer) GetUserBySessionID(ctx context.Context, req *userpb.GetUserBySessionIDRequest) (*userpb.GetUserBySessionIDResponse, error) {
+	sessionID, err := uuid.Parse(req.GetSessionID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session id")
+	}
+	user, err := us.DB.GetUserBySessionID(ctx, sessionID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "no user for the session")
+	} else if err != nil {
+		log.Error("error fetching user in grpc GetUserBySessionID:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching user")
+	}
+	var phone string
+	if user.Phone.Valid {
+		phone = strconv.FormatInt(user.Phone.Int64, 10)
+	}
+	return &userpb.GetUserBySessionIDResponse{
+		Id:    user.ID.String(),
+		Name:  user.Name,
+		Email: user.Email,
+		Role:  user.Role,
+		Phone: phone,
+	}, nil
+}
+
+func (us *UserServer) GetAddressBySellerID(ctx context.Context, req *userpb.GetAddressBySellerIDRequest) (*userpb.GetAddressBySellerIDResponse, error) {
+	sellerID, err := uuid.Parse(req.GetSellerID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid seller id")
+	}
+	address, err := us.DB.GetAddressBySellerID(ctx, sellerID)
+	if err == sql.ErrNoRows {
+		return &userpb.GetAddressBySellerIDResponse{Exists: false}, nil
+	} else if err != nil {
+		log.Error("error fetching address in grpc GetAddressBySellerID:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching address")
+	}
+	return &userpb.GetAddressBySellerIDResponse{
+		Exists:   true,
+		Pincode:  address.Pincode,
+		District: address.District,
+		State:    address.State,
+	}, nil
+}
+
+// GetSellerByID returns the public profile of a seller, NotFound when the id isn't a seller
+func (us *UserServer) GetSellerByID(ctx context.Context, req *userpb.GetSellerByIDRequest) (*userpb.GetSellerByIDResponse, error) {
+	sellerID, err := uuid.Parse(req.GetSellerID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid seller id")
+	}
+	seller, err := us.DB.GetSellerByID(ctx, sellerID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "no seller with the id")
+	} else if err != nil {
+		log.Error("error fetching seller in grpc GetSellerByID:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching seller")
+	}
+	return &userpb.GetSellerByIDResponse{
+		Id:           seller.ID.String(),
+		Name:         seller.Name,
+		About:        seller.About.String,
+		UserVerified: seller.UserVerified,
+		IsBlocked:    seller.IsBlocked,
+		CreatedAt:    timestamppb.New(seller.CreatedAt),
+	}, nil
+}
+
+// GetUserByID is used by services that only keep the user id, eg. to mail a user
+func (us *UserServer) GetUserByID(ctx context.Context, req *userpb.GetUserByIDRequest) (*userpb.GetUserByIDResponse, error) {
+	userID, err := uuid.Parse(req.GetUserID())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	user, err := us.DB.GetUserById(ctx, userID)
+	if err == sql.ErrNoRows {
+		return nil, status.Error(codes.NotFound, "no user with the id")
+	} else if err != nil {
+		log.Error("error fetching user in grpc GetUserByID:", err.Error())
+		return nil, status.Error(codes.Internal, "internal error fetching user")
+	}
+	return &userpb.GetUserByIDResponse{
+		Id:        user.ID.String(),
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		IsBlocked: user.IsBlocked,
+	}, nil
+}
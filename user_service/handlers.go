@@ -9,12 +9,13 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	db "user_service/db/sqlc"
 
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/envname"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/helpers"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/impersonation"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
 	middleware "github.com/amankhys/multi_vendor_ecommerce_go/pkg/middlewares"
 	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sessions"
@@ -30,6 +31,7 @@ import (
 // get db connection and get the *db.Queries()
 var dbConn = repository.NewDBConfig("guest")
 var DB = db.New(dbConn)
+var auditLog = audit.New(dbConn, "user_audit_logs")
 
 // helper struct
 var helper = &helpers.Helper{
@@ -39,12 +41,20 @@ var helper = &helpers.Helper{
 // load the env for clientID and clientSecret for googelAuthConfig
 var clientId = os.Getenv(envname.GoogleClientID)
 var clientSecret = os.Getenv(envname.GoogleSecretKey)
+var redirectURL = googleRedirectURL()
+
+func googleRedirectURL() string {
+	if u := os.Getenv(envname.GoogleRedirectURL); u != "" {
+		return u
+	}
+	return "http://localhost:7777/auth/callback"
+}
 
 // set the config for google auth and give it the guest struct object
 var conf = &oauth2.Config{
 	ClientID:     clientId,
 	ClientSecret: clientSecret,
-	RedirectURL:  "http://localhost:7777/auth/callback",
+	RedirectURL:  redirectURL,
 	Scopes:       []string{"email", "profile"},
 	Endpoint:     google.Endpoint,
 }
@@ -57,10 +67,16 @@ var g = Guest{
 
 // register the routes for the guest
 func RegisterRoutes(mux *http.ServeMux) {
+	// requests made while an admin impersonates someone are checked and recorded
+	middleware.SetImpersonationRecorder(impersonation.NewRecorder(dbConn, "user_service"))
+	// mails are queued and sent by MailOutboxCron
+	mail.SetOutbox(mailOutbox)
+
 	// guest side
 	mux.HandleFunc("GET /home", g.HomeHandler)
 
 	mux.HandleFunc("POST /login", g.LoginHandler)
+	mux.HandleFunc("POST /login/2fa", g.LoginTwoFactorHandler)
 	mux.HandleFunc("POST /user_signup", g.UserSignUpHandler)
 	mux.HandleFunc("POST /seller_signup", g.SellerSignUpHandler)
 	mux.HandleFunc("POST /user_signup_otp", g.UserSignUpOTPHandler)
@@ -71,34 +87,78 @@ func RegisterRoutes(mux *http.ServeMux) {
 
 	mux.HandleFunc("GET /auth/login", g.OauthHandler)
 	mux.HandleFunc("GET /auth/callback", g.OauthCallbackHandler)
+	mux.HandleFunc("POST /auth/google/link", g.LinkGoogleHandler)
+	mux.HandleFunc("DELETE /auth/google/link", g.UnlinkGoogleHandler)
+	mux.HandleFunc("GET /auth/identities", g.IdentitiesHandler)
 
 	// user side
 	s := &Seller{DB: DB}
 	u := &User{DB: DB}
 	a := &Admin{DB: DB}
+	mux.HandleFunc("POST /auth/refresh", g.RefreshTokenHandler)
 	mux.HandleFunc("GET /logout", g.LogoutHandler)
 	mux.HandleFunc("DELETE /delete_all_sessions", g.DeleteSessionHistoryHandler)
+	mux.HandleFunc("GET /sessions", g.SessionsHandler)
+	mux.HandleFunc("DELETE /sessions/others", g.RevokeOtherSessionsHandler)
+	mux.HandleFunc("DELETE /sessions/{id}", g.RevokeSessionHandler)
+	mux.HandleFunc("GET /auth/2fa", g.TwoFactorStatusHandler)
+	mux.HandleFunc("POST /auth/2fa/setup", g.TwoFactorSetupHandler)
+	mux.HandleFunc("POST /auth/2fa/enable", g.TwoFactorEnableHandler)
+	mux.HandleFunc("POST /auth/2fa/disable", g.TwoFactorDisableHandler)
+	mux.HandleFunc("POST /auth/2fa/recovery_codes", g.RecoveryCodesHandler)
+	mux.HandleFunc("GET /notifications", g.NotificationsHandler)
+	mux.HandleFunc("PUT /notifications/read_all", g.ReadAllNotificationsHandler)
+	mux.HandleFunc("PUT /notifications/{id}/read", g.ReadNotificationHandler)
+	mux.HandleFunc("POST /account/email/change", g.ChangeEmailHandler)
+	mux.HandleFunc("POST /account/email/confirm", g.ConfirmEmailChangeHandler)
+	mux.HandleFunc("POST /account/phone/verify", g.VerifyPhoneHandler)
+	mux.HandleFunc("POST /account/phone/confirm", g.ConfirmPhoneHandler)
 	mux.HandleFunc("GET /user/profile", middleware.AuthenticateUserMiddleware(u.GetProfileHandler, utils.UserRole))
 	mux.HandleFunc("PUT /user/profile/edit", middleware.AuthenticateUserMiddleware(u.EditProfileHandler, utils.UserRole))
 	mux.HandleFunc("GET /user/address", middleware.AuthenticateUserMiddleware(u.GetAddressesHandler, utils.UserRole))
 	mux.HandleFunc("POST /user/address/add", middleware.AuthenticateUserMiddleware(u.AddAddressHandler, utils.UserRole))
 	mux.HandleFunc("PUT /user/address/edit", middleware.AuthenticateUserMiddleware(u.EditAddressHandler, utils.UserRole))
 	mux.HandleFunc("DELETE /user/address/delete", middleware.AuthenticateUserMiddleware(u.DeleteAddressHandler, utils.UserRole))
+	mux.HandleFunc("GET /user/account/export", middleware.AuthenticateUserMiddleware(u.ExportAccountHandler, utils.UserRole))
+	mux.HandleFunc("DELETE /user/account", middleware.AuthenticateUserMiddleware(u.DeleteAccountHandler, utils.UserRole))
+	mux.HandleFunc("GET /user/account/deletion", middleware.AuthenticateUserMiddleware(u.AccountDeletionHandler, utils.UserRole))
+	mux.HandleFunc("DELETE /user/account/deletion", middleware.AuthenticateUserMiddleware(u.CancelAccountDeletionHandler, utils.UserRole))
 
 	// seller side
 	mux.HandleFunc("PUT /seller/profile/edit", middleware.AuthenticateUserMiddleware(s.EditProfileHandler, utils.SellerRole))
 	mux.HandleFunc("GET /seller/address", middleware.AuthenticateUserMiddleware(s.GetAddressesHandler, utils.SellerRole))
 	mux.HandleFunc("POST /seller/address/add", middleware.AuthenticateUserMiddleware(s.AddAddressHandler, utils.SellerRole))
 	mux.HandleFunc("PUT /seller/address/edit", middleware.AuthenticateUserMiddleware(s.EditAddressHandler, utils.SellerRole))
+	mux.HandleFunc("GET /seller/onboarding", middleware.AuthenticateUserMiddleware(s.GetOnboardingHandler, utils.SellerRole))
+	mux.HandleFunc("POST /seller/onboarding/document", middleware.AuthenticateUserMiddleware(s.UploadOnboardingDocumentHandler, utils.SellerRole))
+	mux.HandleFunc("POST /seller/onboarding/submit", middleware.AuthenticateUserMiddleware(s.SubmitOnboardingHandler, utils.SellerRole))
 
 	// admin side
-	mux.HandleFunc("GET /admin/allusers", middleware.AuthenticateUserMiddleware(a.AdminAllUsersHandler, utils.AdminRole))
-	mux.HandleFunc("PUT /admin/user/block", middleware.AuthenticateUserMiddleware(a.BlockUserHandler, utils.AdminRole))
-	mux.HandleFunc("PUT /admin/user/unblock", middleware.AuthenticateUserMiddleware(a.UnblockUserHandler, utils.AdminRole))
-
-	mux.HandleFunc("GET /admin/users", middleware.AuthenticateUserMiddleware(a.AdminUsersHandler, utils.AdminRole))
-	mux.HandleFunc("GET /admin/sellers", middleware.AuthenticateUserMiddleware(a.AdminSellersHandler, utils.AdminRole))
-	mux.HandleFunc("POST /admin/verify_seller", middleware.AuthenticateUserMiddleware(a.VerifySellerHandler, utils.AdminRole))
+	mux.HandleFunc("GET /admin/allusers", middleware.AuthorizeMiddleware(a.AdminAllUsersHandler, utils.PermUsersRead))
+	mux.HandleFunc("PUT /admin/user/block", middleware.AuthorizeMiddleware(a.BlockUserHandler, utils.PermUsersBlock))
+	mux.HandleFunc("PUT /admin/user/unblock", middleware.AuthorizeMiddleware(a.UnblockUserHandler, utils.PermUsersBlock))
+
+	mux.HandleFunc("GET /admin/users", middleware.AuthorizeMiddleware(a.AdminUsersHandler, utils.PermUsersRead))
+	mux.HandleFunc("GET /admin/sellers", middleware.AuthorizeMiddleware(a.AdminSellersHandler, utils.PermUsersRead))
+	// sellers are verified by approving their onboarding application
+	mux.HandleFunc("GET /admin/2fa_policies", middleware.AuthorizeMiddleware(a.TwoFactorPoliciesHandler, utils.PermSecurityWrite))
+	mux.HandleFunc("PUT /admin/2fa_policy", middleware.AuthorizeMiddleware(a.EditTwoFactorPolicyHandler, utils.PermSecurityWrite))
+	mux.HandleFunc("GET /admin/onboardings", middleware.AuthorizeMiddleware(a.OnboardingsHandler, utils.PermSellersReview))
+	mux.HandleFunc("GET /admin/onboarding", middleware.AuthorizeMiddleware(a.OnboardingHandler, utils.PermSellersReview))
+	mux.HandleFunc("GET /admin/onboarding/document", middleware.AuthorizeMiddleware(a.OnboardingDocumentHandler, utils.PermSellersReview))
+	mux.HandleFunc("PUT /admin/onboarding/document/review", middleware.AuthorizeMiddleware(a.ReviewOnboardingDocumentHandler, utils.PermSellersReview))
+	mux.HandleFunc("PUT /admin/onboarding/review", middleware.AuthorizeMiddleware(a.ReviewOnboardingHandler, utils.PermSellersReview))
+	mux.HandleFunc("GET /admin/roles", middleware.AuthorizeMiddleware(a.RolesHandler, utils.PermRolesRead))
+	mux.HandleFunc("POST /admin/role", middleware.AuthorizeMiddleware(a.AddRoleHandler, utils.PermRolesWrite))
+	mux.HandleFunc("PUT /admin/role/permissions", middleware.AuthorizeMiddleware(a.EditRolePermissionsHandler, utils.PermRolesWrite))
+	mux.HandleFunc("GET /admin/user/roles", middleware.AuthorizeMiddleware(a.UserRolesHandler, utils.PermRolesRead))
+	mux.HandleFunc("POST /admin/user/role", middleware.AuthorizeMiddleware(a.AssignRoleHandler, utils.PermRolesWrite))
+	mux.HandleFunc("DELETE /admin/user/role", middleware.AuthorizeMiddleware(a.RemoveRoleHandler, utils.PermRolesWrite))
+	mux.HandleFunc("POST /admin/impersonate", middleware.AuthorizeMiddleware(a.StartImpersonationHandler, utils.PermUsersImpersonate))
+	mux.HandleFunc("POST /admin/impersonation/stop", middleware.AuthorizeMiddleware(a.StopImpersonationHandler, utils.PermUsersImpersonate))
+	mux.HandleFunc("GET /admin/impersonations", middleware.AuthorizeMiddleware(a.ImpersonationsHandler, utils.PermUsersRead))
+	mux.HandleFunc("GET /admin/impersonation/events", middleware.AuthorizeMiddleware(a.ImpersonationEventsHandler, utils.PermUsersRead))
+	mux.HandleFunc("GET /admin/audit", middleware.AuthorizeMiddleware(auditLog.Handler(), utils.PermAuditRead))
 
 }
 
@@ -377,6 +437,12 @@ func (g *Guest) UserSignUpOTPHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// refuse locked out accounts and ips before checking the otp
+	ip := utils.GetClientIPString(r)
+	if locked(w, otpIPLimiter, ip) || locked(w, otpAccountLimiter, req.Email) {
+		return
+	}
+
 	// check if the user exists
 	user, err := g.DB.GetUserByEmail(context.TODO(), req.Email)
 	if err != nil {
@@ -391,12 +457,18 @@ func (g *Guest) UserSignUpOTPHandler(w http.ResponseWriter, r *http.Request) {
 	otp, err := g.DB.GetValidOTPByUserID(context.TODO(), user.ID)
 	if err == sql.ErrNoRows {
 		//make and  resend otp if there is no valid otp currently
-		http.Error(w, "no valid otp available. generating another otp", http.StatusBadRequest)
 		otp, err := g.DB.AddOTP(context.TODO(), user.ID)
 		if err == sql.ErrNoRows {
-			log.Warn("no otp generated")
+			// the last otp was sent less than a minute ago
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "no valid otp available. wait a minute before requesting another otp", http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			log.Warn("error adding otp in UserSignUpOTPHandler:", err.Error())
+			http.Error(w, "internal error generating otp", http.StatusInternalServerError)
 			return
 		}
+		http.Error(w, "no valid otp available. generating another otp", http.StatusBadRequest)
 		err = mail.SendOTPMail(int(otp.Otp), otp.ExpiresAt, user.Email)
 		if err != nil {
 			log.Warn("error sending otp:", err.Error())
@@ -424,9 +496,25 @@ func (g *Guest) UserSignUpOTPHandler(w http.ResponseWriter, r *http.Request) {
 
 	// check if the otpn from the reqest is correct
 	if req.Otp != int(otp.Otp) {
+		recordFailure(otpIPLimiter, ip)
+		recordFailure(otpAccountLimiter, req.Email)
+		attempts, err := g.DB.IncrementOTPAttempts(context.TODO(), otp.ID)
+		if err != nil {
+			log.Warn("error counting otp attempts in UserSignUpOTPHandler:", err.Error())
+		} else if attempts >= maxOTPAttempts {
+			// a used up otp is dropped, the next request sends a new one
+			if err = g.DB.DeleteOTPByID(context.TODO(), otp.ID); err != nil {
+				log.Warn("error deleting used up otp in UserSignUpOTPHandler:", err.Error())
+			}
+			http.Error(w, "invalid otp. too many wrong attempts, request a new otp", http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "invalid otp", http.StatusBadRequest)
 		return
 	}
+	if err = otpAccountLimiter.Reset(context.TODO(), req.Email); err != nil {
+		log.Warn("error resetting otp attempts in UserSignUpOTPHandler:", err.Error())
+	}
 
 	// verify user
 	verifiedUser, err := g.DB.VerifyUserByID(context.TODO(), user.ID)
@@ -507,6 +595,12 @@ func (g *Guest) SellerSignUpOTPHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// refuse locked out accounts and ips before checking the otp
+	ip := utils.GetClientIPString(r)
+	if locked(w, otpIPLimiter, ip) || locked(w, otpAccountLimiter, req.Email) {
+		return
+	}
+
 	// check if the user exists and is not verified
 	user, err := g.DB.GetUserByEmail(context.TODO(), req.Email)
 	if err != nil {
@@ -521,12 +615,18 @@ func (g *Guest) SellerSignUpOTPHandler(w http.ResponseWriter, r *http.Request) {
 	otp, err := g.DB.GetValidOTPByUserID(context.TODO(), user.ID)
 	if err == sql.ErrNoRows {
 		// resend otp when there is no valid otp available
-		http.Error(w, "no valid otp available. generating another otp", http.StatusBadRequest)
 		otp, err := g.DB.AddOTP(context.TODO(), user.ID)
 		if err == sql.ErrNoRows {
-			log.Warn("no otp generated")
+			// the last otp was sent less than a minute ago
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "no valid otp available. wait a minute before requesting another otp", http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			log.Warn("error adding otp in SellerSignUpOTPHandler:", err.Error())
+			http.Error(w, "internal error generating otp", http.StatusInternalServerError)
 			return
 		}
+		http.Error(w, "no valid otp available. generating another otp", http.StatusBadRequest)
 		err = mail.SendOTPMail(int(otp.Otp), otp.ExpiresAt, user.Email)
 		if err != nil {
 			result, err := g.DB.DeleteOTPByEmail(context.TODO(), user.Email)
@@ -554,9 +654,25 @@ func (g *Guest) SellerSignUpOTPHandler(w http.ResponseWriter, r *http.Request) {
 
 	// check if the otp is correct
 	if req.Otp != int(otp.Otp) {
+		recordFailure(otpIPLimiter, ip)
+		recordFailure(otpAccountLimiter, req.Email)
+		attempts, err := g.DB.IncrementOTPAttempts(context.TODO(), otp.ID)
+		if err != nil {
+			log.Warn("error counting otp attempts in SellerSignUpOTPHandler:", err.Error())
+		} else if attempts >= maxOTPAttempts {
+			// a used up otp is dropped, the next request sends a new one
+			if err = g.DB.DeleteOTPByID(context.TODO(), otp.ID); err != nil {
+				log.Warn("error deleting used up otp in SellerSignUpOTPHandler:", err.Error())
+			}
+			http.Error(w, "invalid otp. too many wrong attempts, request a new otp", http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "invalid otp", http.StatusBadRequest)
 		return
 	}
+	if err = otpAccountLimiter.Reset(context.TODO(), req.Email); err != nil {
+		log.Warn("error resetting otp attempts in SellerSignUpOTPHandler:", err.Error())
+	}
 
 	// verify user
 	verifiedSeller, err := g.DB.VerifySellerEmailByID(context.TODO(), user.ID)
@@ -618,9 +734,17 @@ func (g *Guest) LoginHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// refuse locked out accounts and ips before looking at the password
+	ip := utils.GetClientIPString(r)
+	if locked(w, loginIPLimiter, ip) || locked(w, loginAccountLimiter, req.Email) {
+		return
+	}
+
 	// compare email and password
 	user, err := g.DB.GetUserWithPasswordByEmail(context.TODO(), req.Email)
 	if err != nil {
+		recordFailure(loginIPLimiter, ip)
+		recordFailure(loginAccountLimiter, req.Email)
 		http.Error(w, "invalid email", http.StatusUnauthorized)
 		return
 	}
@@ -628,260 +752,102 @@ func (g *Guest) LoginHandler(w http.ResponseWriter, r *http.Request) {
 	err = utils.ComparePassword(req.Password, user.Password)
 	if err != nil {
 		log.Warn(err)
+		recordFailure(loginIPLimiter, ip)
+		recordFailure(loginAccountLimiter, req.Email)
 		http.Error(w, "wrong password", http.StatusUnauthorized)
 		return
-	} else if !user.UserVerified {
+	}
+	// sellers log in once their email is verified so they can go through onboarding,
+	// selling is held back until the onboarding is approved
+	if !user.EmailVerified || (user.Role != utils.SellerRole && !user.UserVerified) {
 		message := fmt.Sprintf("%s not verified", user.Role)
 		http.Error(w, message, http.StatusUnauthorized)
 		return
 	}
 
-	// make arg to create sessionID
-	var arg = db.AddSessionParams{
-		UserID:    user.ID,
-		IpAddress: utils.GetClientIPString(r),
-		UserAgent: utils.GetUserAgent(r),
+	// sellers and admins with 2FA finish the login at /login/2fa
+	if startTwoFactorLogin(w, g.DB, user.ID, user.Role, user.Email) {
+		return
+	}
+	// with 2FA the failed logins are only cleared once the code is right
+	if err = loginAccountLimiter.Reset(context.TODO(), req.Email); err != nil {
+		log.Warn("error resetting login attempts in LoginHandler:", err.Error())
 	}
-	session, err := g.DB.AddSession(context.TODO(), arg)
+
+	// start a session and issue the access and refresh tokens
+	tokens, err := startSession(w, r, g.DB, user.ID, user.Role, user.Name, user.Email)
 	if err != nil {
-		http.Error(w, "invalid data format", http.StatusBadRequest)
+		log.Warn("error starting session in LoginHandler:", err.Error())
+		http.Error(w, "internal error starting session", http.StatusInternalServerError)
 		return
 	}
-	sessions.SetSessionCookie(w, session.ID.String())
-	w.Header().Set("Content-Type", "text/plain")
-	message := fmt.Sprintf("%s of id: %s has successfully logged in\n", user.Role, user.ID.String())
-	w.Write([]byte(message))
+	var resp struct {
+		Message string     `json:"message"`
+		Tokens  respTokens `json:"tokens"`
+	}
+	resp.Message = fmt.Sprintf("%s of id: %s has successfully logged in", user.Role, user.ID.String())
+	resp.Tokens = tokens
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
 }
 
+// revoke the session of the refresh token, the access token already issued
+// stays valid until it expires
 func (g *Guest) LogoutHandler(w http.ResponseWriter, r *http.Request) {
-	cookie, err := sessions.GetSessionCookie(r)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	refreshToken := refreshTokenFromRequest(r)
+	sessions.DeleteAuthCookies(w)
+	if refreshToken == "" {
+		http.Error(w, "refresh token required", http.StatusBadRequest)
 		return
 	}
-	sessions.DeleteSessionCookie(w, cookie)
-	http.Redirect(w, r, "/", http.StatusSeeOther)
-	id, err := uuid.Parse(cookie.Name)
-	if err != nil {
-		http.Error(w, "sessionID not in a valid format.", http.StatusBadRequest)
+	session, err := sessionByRefreshToken(g.DB, refreshToken)
+	if err == sql.ErrNoRows || err == errRefreshTokenReused {
+		http.Error(w, "session already ended", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching session in LogoutHandler:", err.Error())
+		http.Error(w, "internal error terminating session", http.StatusInternalServerError)
 		return
 	}
-	// didn't check the result of query since there is only one matching id
-	// no need to check if it is affected for more than one rows
-	_, err = g.DB.DeleteSessionByID(context.TODO(), id)
+	_, err = g.DB.RevokeSessionByID(context.TODO(), session.ID)
 	if err != nil {
-		log.Warn("error deleting sessionByID")
+		log.Warn("error revoking session in LogoutHandler:", err.Error())
 		http.Error(w, "internal error terminating session", http.StatusInternalServerError)
 		return
 	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("successfully logged out"))
 }
 
+// revoke every session of the user the refresh token belongs to
 func (g *Guest) DeleteSessionHistoryHandler(w http.ResponseWriter, r *http.Request) {
-	// get session cookie
-	cookie, err := sessions.GetSessionCookie(r)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	id, err := uuid.Parse(cookie.Name)
-	if err != nil {
-		http.Error(w, "sessionID not in a valid format.", http.StatusBadRequest)
+	refreshToken := refreshTokenFromRequest(r)
+	if refreshToken == "" {
+		http.Error(w, "refresh token required", http.StatusBadRequest)
 		return
 	}
-
-	// verify the sessionID from cookie
-	session, err := g.DB.GetSessionDetailsByID(context.TODO(), id)
-	if err == sql.ErrNoRows {
-		http.Error(w, "not a valid sessionID", http.StatusBadRequest)
+	session, err := sessionByRefreshToken(g.DB, refreshToken)
+	if err == sql.ErrNoRows || err == errRefreshTokenReused {
+		http.Error(w, "session already expired. Only authorized for a valid session.", http.StatusUnauthorized)
 		return
 	} else if err != nil {
-		log.Warn("internal error deleting session row by id")
-		http.Error(w, "internal error termainating session", http.StatusInternalServerError)
-		return
-	}
-	if time.Now().Compare(session.ExpiresAt) == -1 {
-		http.Error(w, "session already expired. Only authorized for a valid session.", http.StatusUnauthorized)
+		log.Warn("error fetching session in DeleteSessionHistoryHandler:", err.Error())
+		http.Error(w, "internal error terminating sessions", http.StatusInternalServerError)
 		return
 	}
 
-	// get userID from sessionID and delete all session instances
-	userID := session.UserID
-	_, err = g.DB.DeleteSessionsByuserID(context.TODO(), userID)
+	_, err = g.DB.RevokeSessionsByUserID(context.TODO(), session.UserID)
 	if err != nil {
-		log.Warn("intenral error deleting sessions by userID in DeleteSessionHistoryHandler")
+		log.Warn("intenral error revoking sessions by userID in DeleteSessionHistoryHandler")
 		http.Error(w, "internal server error deleting all session history", http.StatusInternalServerError)
 		return
 	}
+	sessions.DeleteAuthCookies(w)
 	w.Header().Add("Content-Type", "text/plain")
 	message := "successfully deleted all sessions history for the user"
 	w.Write([]byte(message))
 }
 
-func (g *Guest) OauthHandler(w http.ResponseWriter, r *http.Request) {
-	url := g.config.AuthCodeURL("helloo world", oauth2.AccessTypeOffline)
-	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
-}
-
-func (g *Guest) OauthCallbackHandler(w http.ResponseWriter, r *http.Request) {
-	code := r.URL.Query().Get("code")
-
-	// Exchanging the code for an access token
-	t, err := g.config.Exchange(context.Background(), code)
-	if err != nil {
-		log.Warn("internal error fetching response from the googleapi")
-		http.Error(w, "internal error fetching response from the googleapi", http.StatusInternalServerError)
-		return
-	}
-
-	// Creating an HTTP client to make authenticated request using the access key.
-	// This client method also regenerate the access key using the refresh key.
-	client := g.config.Client(context.Background(), t)
-
-	// Getting the user public details from google API endpoint
-	UserInfo, err := client.Get("https://www.googleapis.com/oauth2/v2/userinfo")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	// Closing the request body when this function returns.
-	// This is a good practice to avoid memory leak
-	defer UserInfo.Body.Close()
-
-	type Goauth struct {
-		Id            string `json:"id"`
-		Email         string `json:"email"`
-		VerifiedEmail bool   `json:"verified_email"`
-		Name          string `json:"name"`
-		GivenName     string `json:"given_name"`
-		FamilyName    string `json:"family_name"`
-		Picture       string `json:"picture"`
-		Locate        string `json:"locale"`
-	}
-
-	// decode the userInfo.Body to our own Gauth jsonUserData var
-	var jsonUserData Goauth
-	if err = json.NewDecoder(UserInfo.Body).Decode(&jsonUserData); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	// check if the user with the email already exists
-	user, err := g.DB.GetUserByEmail(context.TODO(), jsonUserData.Email)
-	if err == sql.ErrNoRows {
-		// create a new user for the signed-in user and make a random hashed password for it then create the user
-		password, err := utils.GenerateRandomString(16)
-		if err != nil {
-			log.Warn("error producing random string for google password:", err.Error())
-			http.Error(w, "intenral error producing password for google user.", http.StatusInternalServerError)
-			return
-		}
-		hashedPassword, err := utils.HashPassword(password)
-		if err != nil {
-			log.Warn("intenral error hashing password for google user")
-			http.Error(w, "internal error producing password for google user.", http.StatusInternalServerError)
-			return
-		}
-
-		// add user  arg
-		var arg = db.AddAndVerifyUserParams{
-			Name:     jsonUserData.Name,
-			Email:    jsonUserData.Email,
-			Password: hashedPassword,
-		}
-		// add user
-		addUser, err := g.DB.AddAndVerifyUser(context.TODO(), arg)
-		if err != nil {
-			log.Warn("error adding user for google user:", err.Error())
-			http.Error(w, "internal error adding user for google user.", http.StatusInternalServerError)
-			return
-		}
-
-		// add session args
-		var sessionArg = db.AddSessionParams{
-			UserID:    addUser.ID,
-			IpAddress: utils.GetClientIPString(r),
-			UserAgent: utils.GetUserAgent(r),
-		}
-		// add session and set cookie
-		session, err := g.DB.AddSession(context.TODO(), sessionArg)
-		if err != nil {
-			log.Warn("error adding session for google user:", err.Error())
-			http.Error(w, "user added successfully. Unable to add session internal error", http.StatusOK)
-			return
-		}
-		sessions.SetSessionCookie(w, session.ID.String())
-
-		// make response
-		var resp struct {
-			Data    db.AddAndVerifyUserRow `json:"data"`
-			Message string                 `json:"message"`
-		}
-		resp.Data = addUser
-		resp.Message = "user has been successfully authenticated and logged in."
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(resp)
-
-		// handle failing to fetch and check if the user email already exists from the database
-	} else if err != nil {
-		log.Warn("internal error fetching user from GetUserByEmail and != sql.ErrNoRows and err != nil", err.Error())
-		http.Error(w, "error fetching user data to check if the user already exists.", http.StatusInternalServerError)
-		return
-	}
-	// check if the user is verified if the user was already registered. If not verify user and log
-	if !user.EmailVerified {
-		verifiedUser, err := g.DB.VerifyUserByID(context.TODO(), user.ID)
-		if err != nil {
-			log.Warn("internal server error verifying user on google authentication:", err.Error())
-			http.Error(w, "internal server error  verifying user and setting sessionID cookie.", http.StatusInternalServerError)
-			return
-		}
-		user.EmailVerified = verifiedUser.EmailVerified
-		user.UserVerified = verifiedUser.UserVerified
-	}
-
-	// make add sessionArg
-	var sessionArg = db.AddSessionParams{
-		UserID:    user.ID,
-		IpAddress: utils.GetClientIPString(r),
-		UserAgent: utils.GetUserAgent(r),
-	}
-	// create session
-	session, err := g.DB.AddSession(context.TODO(), sessionArg)
-	if err != nil {
-		log.Warn("unable to add goolge auth user session:", err.Error())
-		http.Error(w, "unable to add google auth user session", http.StatusInternalServerError)
-		return
-	}
-	sessions.SetSessionCookie(w, session.ID.String())
-
-	type respUser struct {
-		ID    uuid.UUID     `json:"id"`
-		Name  string        `json:"name"`
-		Email string        `json:"email"`
-		Phone sql.NullInt64 `json:"phone"`
-		Role  string        `json:"role"`
-	}
-
-	var respUserData = respUser{
-		ID:    user.ID,
-		Name:  user.Name,
-		Email: user.Email,
-		Phone: user.Phone,
-		Role:  user.Role,
-	}
-
-	// send response
-	w.Header().Set("Content-Type", "application/json")
-	var resp struct {
-		Data    respUser `json:"data"`
-		Message string   `json:"message"`
-	}
-	resp.Data = respUserData
-	resp.Message = "user logged in successfully and added session cookie."
-	json.NewEncoder(w).Encode(resp)
-}
-
 func (g *Guest) ForgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Email string `json:"email"`
@@ -913,7 +879,12 @@ func (g *Guest) ForgotPasswordHandler(w http.ResponseWriter, r *http.Request) {
 
 	// generate forgotOTP and send mail
 	forgotOTP, err := g.DB.AddForgotOTPByUserID(context.TODO(), user.ID)
-	if err != nil {
+	if err == sql.ErrNoRows {
+		// the last otp was sent less than a minute ago
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "an otp was just sent. wait a minute before requesting another one", http.StatusTooManyRequests)
+		return
+	} else if err != nil {
 		log.Warn("internal error adding forgot_otp by userID:", err.Error())
 		http.Error(w, "internal error adding forgot_otp by userID", http.StatusInternalServerError)
 		return
@@ -969,6 +940,12 @@ func (g *Guest) ForgotOTPHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// refuse locked out accounts and ips before checking the otp
+	ip := utils.GetClientIPString(r)
+	if locked(w, otpIPLimiter, ip) || locked(w, otpAccountLimiter, req.Email) {
+		return
+	}
+
 	// get user and check
 	user, err := g.DB.GetUserByEmail(context.TODO(), req.Email)
 	if err == sql.ErrNoRows {
@@ -986,11 +963,18 @@ func (g *Guest) ForgotOTPHandler(w http.ResponseWriter, r *http.Request) {
 	forgotOtp, err := g.DB.GetValidForgotOTPByUserID(context.TODO(), user.ID)
 	if err == sql.ErrNoRows {
 		// make and send new valid otp if no valid forgot otp exists
-		http.Error(w, "no valid forgot otps. generating a valid otp.", http.StatusBadRequest)
 		addedOtp, err := g.DB.AddForgotOTPByUserID(context.TODO(), user.ID)
-		if err != nil {
-			log.Warn("error adding otp")
+		if err == sql.ErrNoRows {
+			// the last otp was sent less than a minute ago
+			w.Header().Set("Retry-After", "60")
+			http.Error(w, "no valid forgot otps. wait a minute before requesting another otp", http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			log.Warn("error adding otp in ForgotOTPHandler:", err.Error())
+			http.Error(w, "internal error generating otp", http.StatusInternalServerError)
+			return
 		}
+		http.Error(w, "no valid forgot otps. generating a valid otp.", http.StatusBadRequest)
 		err = mail.SendForgotOTPMail(int(addedOtp.Otp), addedOtp.ExpiresAt, user.Email)
 		if err != nil {
 			log.Warn("error sending forgot otp mail:", err.Error())
@@ -1017,9 +1001,25 @@ func (g *Guest) ForgotOTPHandler(w http.ResponseWriter, r *http.Request) {
 
 		// if there is a valid otp check if the otp is the same as the request
 	} else if int(forgotOtp.Otp) != req.Otp {
+		recordFailure(otpIPLimiter, ip)
+		recordFailure(otpAccountLimiter, req.Email)
+		attempts, err := g.DB.IncrementForgotOTPAttempts(context.TODO(), forgotOtp.ID)
+		if err != nil {
+			log.Warn("error counting otp attempts in ForgotOTPHandler:", err.Error())
+		} else if attempts >= maxOTPAttempts {
+			// a used up otp is dropped, the next request sends a new one
+			if err = g.DB.DeleteForgotOTPByID(context.TODO(), forgotOtp.ID); err != nil {
+				log.Warn("error deleting used up otp in ForgotOTPHandler:", err.Error())
+			}
+			http.Error(w, "incorrect otp. too many wrong attempts, request a new otp", http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "incorrect otp", http.StatusBadRequest)
 		return
 	}
+	if err = otpAccountLimiter.Reset(context.TODO(), req.Email); err != nil {
+		log.Warn("error resetting otp attempts in ForgotOTPHandler:", err.Error())
+	}
 
 	// set password after confirming correct user and  valid otp
 	hash, err := utils.HashPassword(req.Password)
@@ -1102,17 +1102,27 @@ func (u *User) GetProfileHandler(w http.ResponseWriter, r *http.Request) {
 		addressesResp = append(addressesResp, temp)
 	}
 
+	// the phone verification isn't kept on the session user
+	account, err := u.DB.GetUserById(context.TODO(), user.ID)
+	if err != nil {
+		log.Error("error fetching user in GetProfileHandler for user:", err.Error())
+		http.Error(w, "internal error: unable to fetch necessary data", http.StatusInternalServerError)
+		return
+	}
+
 	var resp struct {
-		ID        uuid.UUID     `json:"user_id"`
-		Name      string        `json:"name"`
-		Phone     sql.NullInt64 `json:"phone"`
-		Email     string        `json:"email"`
-		Wallet    float64       `json:"wallet_savings"`
-		Addresses []addressResp `json:"addresses"`
+		ID            uuid.UUID     `json:"user_id"`
+		Name          string        `json:"name"`
+		Phone         sql.NullInt64 `json:"phone"`
+		PhoneVerified bool          `json:"phone_verified"`
+		Email         string        `json:"email"`
+		Wallet        float64       `json:"wallet_savings"`
+		Addresses     []addressResp `json:"addresses"`
 	}
 	resp.ID = user.ID
 	resp.Name = user.Name
 	resp.Phone = user.Phone
+	resp.PhoneVerified = account.PhoneVerified
 	resp.Email = user.Email
 	resp.Wallet = wallet.Savings
 	resp.Addresses = addressesResp
@@ -1211,14 +1221,14 @@ func (u *User) GetAddressesHandler(w http.ResponseWriter, r *http.Request) {
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.GetAddressesHelper(w, r, user)
+	getAddresses(w, u.DB, user.ID)
 }
 func (u *User) AddAddressHandler(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserHelper(w, r)
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.AddAddressHelper(w, r, user)
+	addAddress(w, r, u.DB, user.ID, user.Role)
 }
 
 func (u *User) EditAddressHandler(w http.ResponseWriter, r *http.Request) {
@@ -1226,7 +1236,7 @@ func (u *User) EditAddressHandler(w http.ResponseWriter, r *http.Request) {
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.EditAddressHelper(w, r, user)
+	editAddress(w, r, u.DB, user.ID)
 }
 
 func (u *User) DeleteAddressHandler(w http.ResponseWriter, r *http.Request) {
@@ -1234,7 +1244,7 @@ func (u *User) DeleteAddressHandler(w http.ResponseWriter, r *http.Request) {
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.DeleteAddressHelper(w, r, user)
+	deleteAddress(w, r, u.DB, user.ID)
 }
 
 // seller side
@@ -1407,14 +1417,14 @@ func (s *Seller) GetAddressesHandler(w http.ResponseWriter, r *http.Request) {
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.GetAddressesHelper(w, r, user)
+	getAddresses(w, s.DB, user.ID)
 }
 func (s *Seller) AddAddressHandler(w http.ResponseWriter, r *http.Request) {
 	user := helper.GetUserHelper(w, r)
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.AddAddressHelper(w, r, user)
+	addAddress(w, r, s.DB, user.ID, user.Role)
 }
 
 func (s *Seller) EditAddressHandler(w http.ResponseWriter, r *http.Request) {
@@ -1422,7 +1432,7 @@ func (s *Seller) EditAddressHandler(w http.ResponseWriter, r *http.Request) {
 	if user.ID == uuid.Nil {
 		return
 	}
-	helper.EditAddressHelper(w, r, user)
+	editAddress(w, r, s.DB, user.ID)
 }
 
 type Admin struct{ DB *db.Queries }
@@ -1496,68 +1506,6 @@ func (a *Admin) AdminSellersHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 
 }
-func (a *Admin) VerifySellerHandler(w http.ResponseWriter, r *http.Request) {
-	// check if the request is in correct format
-	var req struct {
-		Email string `json:"email"`
-	}
-	req.Email = r.URL.Query().Get("email")
-	if !validators.ValidateEmail(req.Email) {
-		http.Error(w, "invalid email format:", http.StatusBadRequest)
-		return
-	}
-
-	// check if the seller exists and is not verified
-	user, err := a.DB.GetUserByEmail(context.TODO(), req.Email)
-	if err == sql.ErrNoRows {
-		http.Error(w, "seller does not exist.", http.StatusBadRequest)
-		return
-	} else if err != nil {
-		log.Warn("error fetching user by email")
-		http.Error(w, "internal server error while fetching user", http.StatusInternalServerError)
-		return
-	} else if user.Role != utils.SellerRole {
-		http.Error(w, "the given details is not that of a seller", http.StatusBadRequest)
-		return
-	} else if user.UserVerified {
-		http.Error(w, "seller already verified", http.StatusBadRequest)
-		return
-	} else if !user.EmailVerified {
-		http.Error(w, "seller email not yet verified.", http.StatusUnauthorized)
-		return
-	}
-
-	// verify seller
-	seller, err := a.DB.VerifySellerByID(context.TODO(), user.ID)
-	if err != nil {
-		log.Warn("verify seller by id failed for a valid seller.")
-		http.Error(w, "internal server error while verifying seller", http.StatusInternalServerError)
-		return
-	}
-	// make errors and messages slice for the response
-	var Err []string
-	var Messages []string
-	// add wallet for the seller
-	_, err = a.DB.AddWalletByUserID(context.TODO(), seller.ID)
-	if err != nil {
-		log.Warn("error adding wallet for seller after verifying account:", err.Error())
-		Err = append(Err, "error adding wallet for seller after verifying account")
-	} else {
-		msg := fmt.Sprintf("successfully added wallet for seller: %s", seller.ID.String())
-		Messages = append(Messages, msg)
-	}
-	var resp struct {
-		Data     db.VerifySellerByIDRow `json:"data"`
-		Messages []string               `json:"message"`
-		Err      []string               `json:"errors"`
-	}
-	resp.Data = seller
-	resp.Messages = append(Messages, "successfully verified seller")
-	resp.Err = Err
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
-}
-
 func (a *Admin) BlockUserHandler(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		UserIDStr string `json:"user_id"`
@@ -1591,6 +1539,17 @@ func (a *Admin) BlockUserHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "error blocking user", http.StatusInternalServerError)
 		return
 	}
+	// the access tokens already out expire on their own, revoking the sessions stops any refresh
+	if _, err = a.DB.RevokeSessionsByUserID(context.TODO(), userID); err != nil {
+		log.Warn("error revoking sessions of blocked user:", err.Error())
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionUserBlock,
+		EntityType: audit.EntityUser,
+		EntityID:   userID.String(),
+		Before:     map[string]any{"is_blocked": false},
+		After:      map[string]any{"is_blocked": true},
+	})
 	log.Infof("blocked user: %s", blockedUser.Email)
 	message := fmt.Sprintf("succesfully blocked user: %s", blockedUser.ID.String())
 	w.Header().Set("Content-Type", "application/json")
@@ -1617,6 +1576,13 @@ func (a *Admin) UnblockUserHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "error unblocking user", http.StatusInternalServerError)
 		return
 	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionUserUnblock,
+		EntityType: audit.EntityUser,
+		EntityID:   userID.String(),
+		Before:     map[string]any{"is_blocked": true},
+		After:      map[string]any{"is_blocked": false},
+	})
 	log.Infof("unblocked user: %s", user.ID.String())
 	message := fmt.Sprintf("succesfully unblocked user: %s", user.ID.String())
 	w.Header().Set("Content-Type", "application/json")
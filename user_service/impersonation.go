@@ -0,0 +1,236 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/impersonation"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultImpersonationMinutes = 30
+const maxImpersonationMinutes = 60
+
+// start acting as a user or seller. the token is only returned in the body so the
+// admin's own cookies are left alone, and every service only lets it read
+func (a *Admin) StartImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	admin := helper.GetUserHelper(w, r)
+	if admin.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		UserID  uuid.UUID `json:"user_id"`
+		Reason  string    `json:"reason"`
+		Minutes int       `json:"minutes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.Reason == "" {
+		http.Error(w, "a reason is required to impersonate a user", http.StatusBadRequest)
+		return
+	}
+	if req.Minutes == 0 {
+		req.Minutes = defaultImpersonationMinutes
+	} else if req.Minutes < 0 || req.Minutes > maxImpersonationMinutes {
+		http.Error(w, "minutes should be between 1 and "+strconv.Itoa(maxImpersonationMinutes), http.StatusBadRequest)
+		return
+	}
+	if req.UserID == admin.ID {
+		http.Error(w, "can't impersonate yourself", http.StatusBadRequest)
+		return
+	}
+
+	target, err := a.DB.GetUserById(context.TODO(), req.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no user with the id", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching user in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	} else if target.Role == utils.AdminRole {
+		http.Error(w, "admins can't be impersonated", http.StatusForbidden)
+		return
+	}
+	roles, err := a.DB.GetRolesByUserID(context.TODO(), target.ID)
+	if err != nil {
+		log.Warn("error fetching user roles in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+	permissions, err := a.DB.GetPermissionsByUserID(context.TODO(), target.ID)
+	if err != nil {
+		log.Warn("error fetching user permissions in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+
+	// the impersonation and its start event are saved together
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := a.DB.WithTx(tx)
+	imp, err := qtx.AddImpersonation(context.TODO(), db.AddImpersonationParams{
+		AdminID:   admin.ID,
+		TargetID:  target.ID,
+		Reason:    req.Reason,
+		ExpiresAt: time.Now().Add(time.Duration(req.Minutes) * time.Minute),
+	})
+	if err != nil {
+		log.Warn("error adding impersonation in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+	err = qtx.AddImpersonationEvent(context.TODO(), db.AddImpersonationEventParams{
+		ImpersonationID: imp.ID,
+		Kind:            impersonation.EventStart,
+	})
+	if err != nil {
+		log.Warn("error adding impersonation event in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+	token, err := utils.CreateImpersonationToken(target.ID, target.Role, target.Name, target.Email, roles, permissions,
+		utils.Impersonation{ID: imp.ID, ActorID: admin.ID}, imp.ExpiresAt)
+	if err != nil {
+		log.Error("error signing impersonation token in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing impersonation in StartImpersonationHandler:", err.Error())
+		http.Error(w, "internal error starting impersonation", http.StatusInternalServerError)
+		return
+	}
+	log.Infof("admin %s started impersonating %s: %s", admin.ID, target.ID, req.Reason)
+
+	var resp struct {
+		ImpersonationID uuid.UUID `json:"impersonation_id"`
+		AccessToken     string    `json:"access_token"`
+		TokenType       string    `json:"token_type"`
+		ExpiresAt       time.Time `json:"expires_at"`
+		Message         string    `json:"message"`
+	}
+	resp.ImpersonationID = imp.ID
+	resp.AccessToken = token
+	resp.TokenType = "Bearer"
+	resp.ExpiresAt = imp.ExpiresAt
+	resp.Message = "impersonating " + target.Email + ". the token is read only and every request made with it is recorded"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// end an impersonation the admin started, its token is refused from then on
+func (a *Admin) StopImpersonationHandler(w http.ResponseWriter, r *http.Request) {
+	admin := helper.GetUserHelper(w, r)
+	if admin.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		ImpersonationID uuid.UUID `json:"impersonation_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	imp, err := a.DB.StopImpersonation(context.TODO(), db.StopImpersonationParams{
+		ID:      req.ImpersonationID,
+		AdminID: admin.ID,
+	})
+	if err == sql.ErrNoRows {
+		http.Error(w, "no running impersonation of yours with the id", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error stopping impersonation in StopImpersonationHandler:", err.Error())
+		http.Error(w, "internal error stopping impersonation", http.StatusInternalServerError)
+		return
+	}
+	err = a.DB.AddImpersonationEvent(context.TODO(), db.AddImpersonationEventParams{
+		ImpersonationID: imp.ID,
+		Kind:            impersonation.EventStop,
+	})
+	if err != nil {
+		log.Warn("error adding impersonation event in StopImpersonationHandler:", err.Error())
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("impersonation stopped"))
+}
+
+// list impersonations, filtered by admin_id and target_id
+func (a *Admin) ImpersonationsHandler(w http.ResponseWriter, r *http.Request) {
+	var arg db.GetImpersonationsParams
+	if s := r.URL.Query().Get("admin_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			http.Error(w, "invalid admin_id", http.StatusBadRequest)
+			return
+		}
+		arg.AdminID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	if s := r.URL.Query().Get("target_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			http.Error(w, "invalid target_id", http.StatusBadRequest)
+			return
+		}
+		arg.TargetID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	arg.Limit = int32(limit)
+	arg.Offset = int32((page - 1) * limit)
+
+	list, err := a.DB.GetImpersonations(context.TODO(), arg)
+	if err != nil {
+		log.Warn("error fetching impersonations in ImpersonationsHandler:", err.Error())
+		http.Error(w, "internal error fetching impersonations", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data []db.GetImpersonationsRow `json:"data"`
+	}
+	resp.Data = append([]db.GetImpersonationsRow{}, list...)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// the start, stop and every request of one impersonation
+func (a *Admin) ImpersonationEventsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	events, err := a.DB.GetImpersonationEvents(context.TODO(), id)
+	if err != nil {
+		log.Warn("error fetching impersonation events in ImpersonationEventsHandler:", err.Error())
+		http.Error(w, "internal error fetching impersonation events", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data []db.ImpersonationEvent `json:"data"`
+	}
+	resp.Data = append([]db.ImpersonationEvent{}, events...)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,16 @@
+package user_service
+
+import (
+	"context"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+)
+
+// mails of every service share the outbox table, only this service drains it
+var mailOutbox = mail.NewOutbox(dbConn)
+
+// MailOutboxCron sends the queued mails and retries the failed ones, run it in its
+// own goroutine from the service main
+func MailOutboxCron() {
+	mail.NewWorker(mailOutbox, mail.NewSMTP()).Run(context.Background())
+}
@@ -0,0 +1,141 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type respNotification struct {
+	ID        uuid.UUID       `json:"id"`
+	Kind      string          `json:"kind"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data"`
+	Read      bool            `json:"read"`
+	ReadAt    *time.Time      `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func toRespNotification(n db.Notification) respNotification {
+	resp := respNotification{
+		ID:        n.ID,
+		Kind:      n.Kind,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		Read:      n.ReadAt.Valid,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.ReadAt.Valid {
+		resp.ReadAt = &n.ReadAt.Time
+	}
+	return resp
+}
+
+// the inbox of the user, newest first. unread=true lists only the unread ones
+func (g *Guest) NotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	arg := db.GetNotificationsByUserIDParams{UserID: claims.UserID}
+	if s := r.URL.Query().Get("unread"); s != "" {
+		unread, err := strconv.ParseBool(s)
+		if err != nil {
+			http.Error(w, "unread should be true or false", http.StatusBadRequest)
+			return
+		}
+		arg.Unread = sql.NullBool{Bool: unread, Valid: true}
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	arg.Limit = int32(limit)
+	arg.Offset = int32((page - 1) * limit)
+
+	list, err := g.DB.GetNotificationsByUserID(context.TODO(), arg)
+	if err != nil {
+		log.Warn("error fetching notifications in NotificationsHandler:", err.Error())
+		http.Error(w, "internal error fetching notifications", http.StatusInternalServerError)
+		return
+	}
+	unread, err := g.DB.CountUnreadNotifications(context.TODO(), claims.UserID)
+	if err != nil {
+		log.Warn("error counting unread notifications in NotificationsHandler:", err.Error())
+		http.Error(w, "internal error fetching notifications", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data   []respNotification `json:"data"`
+		Unread int64              `json:"unread"`
+		Page   int                `json:"page"`
+		Limit  int                `json:"limit"`
+	}
+	resp.Data = []respNotification{}
+	for _, n := range list {
+		resp.Data = append(resp.Data, toRespNotification(n))
+	}
+	resp.Unread = unread
+	resp.Page = page
+	resp.Limit = limit
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// mark one notification of the user as read
+func (g *Guest) ReadNotificationHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid notification id", http.StatusBadRequest)
+		return
+	}
+	n, err := g.DB.MarkNotificationRead(context.TODO(), db.MarkNotificationReadParams{ID: id, UserID: claims.UserID})
+	if err == sql.ErrNoRows {
+		http.Error(w, "no notification with the id", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error marking notification read in ReadNotificationHandler:", err.Error())
+		http.Error(w, "internal error marking notification read", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data respNotification `json:"data"`
+	}
+	resp.Data = toRespNotification(n)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// mark every unread notification of the user as read
+func (g *Guest) ReadAllNotificationsHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	n, err := g.DB.MarkAllNotificationsRead(context.TODO(), claims.UserID)
+	if err != nil {
+		log.Warn("error marking notifications read in ReadAllNotificationsHandler:", err.Error())
+		http.Error(w, "internal error marking notifications read", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte(strconv.FormatInt(n, 10) + " notifications marked read"))
+}
@@ -0,0 +1,402 @@
+package user_service
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sessions"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+const oauthPurposeLogin = "login"
+const oauthPurposeLink = "link"
+
+const googleUserInfoURL = "https://www.googleapis.com/oauth2/v2/userinfo"
+
+// same as the expiry of the oauth_states rows
+const oauthStateTTL = 10 * time.Minute
+
+type googleUser struct {
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	VerifiedEmail bool   `json:"verified_email"`
+	Name          string `json:"name"`
+}
+
+type respIdentity struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// redirectToGoogle keeps a single use state with its PKCE verifier, binds it to
+// the browser with a cookie and sends the browser to the google consent page
+func (g *Guest) redirectToGoogle(w http.ResponseWriter, r *http.Request, purpose, role string, userID uuid.NullUUID) {
+	state, stateHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		log.Warn("error generating oauth state:", err.Error())
+		http.Error(w, "internal error starting google sign-in", http.StatusInternalServerError)
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+	err = g.DB.AddOAuthState(context.TODO(), db.AddOAuthStateParams{
+		StateHash:    stateHash,
+		CodeVerifier: verifier,
+		Purpose:      purpose,
+		Role:         role,
+		UserID:       userID,
+	})
+	if err != nil {
+		log.Warn("error adding oauth state:", err.Error())
+		http.Error(w, "internal error starting google sign-in", http.StatusInternalServerError)
+		return
+	}
+	sessions.SetOAuthStateCookie(w, state, oauthStateTTL)
+	url := g.config.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier))
+	// see other so a POST to start linking turns into a GET to google
+	http.Redirect(w, r, url, http.StatusSeeOther)
+}
+
+// sign in with google, role=seller makes a new account a seller account
+func (g *Guest) OauthHandler(w http.ResponseWriter, r *http.Request) {
+	role := r.URL.Query().Get("role")
+	if role == "" {
+		role = utils.UserRole
+	}
+	if role != utils.UserRole && role != utils.SellerRole {
+		http.Error(w, "role should be user or seller", http.StatusBadRequest)
+		return
+	}
+	g.redirectToGoogle(w, r, oauthPurposeLogin, role, uuid.NullUUID{})
+}
+
+// start linking google to the logged in account
+func (g *Guest) LinkGoogleHandler(w http.ResponseWriter, r *http.Request) {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return
+	}
+	g.redirectToGoogle(w, r, oauthPurposeLink, utils.UserRole, uuid.NullUUID{UUID: claims.UserID, Valid: true})
+}
+
+// fetchGoogleUser exchanges the code with the PKCE verifier and reads the google profile
+func (g *Guest) fetchGoogleUser(code, verifier string) (googleUser, error) {
+	t, err := g.config.Exchange(context.Background(), code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return googleUser{}, err
+	}
+	res, err := g.config.Client(context.Background(), t).Get(googleUserInfoURL)
+	if err != nil {
+		return googleUser{}, err
+	}
+	defer res.Body.Close()
+	var gu googleUser
+	err = json.NewDecoder(res.Body).Decode(&gu)
+	return gu, err
+}
+
+func (g *Guest) OauthCallbackHandler(w http.ResponseWriter, r *http.Request) {
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		http.Error(w, "missing oauth state", http.StatusBadRequest)
+		return
+	}
+	// a state from a flow another browser started, eg. a link url sent to
+	// someone, is refused before it is used up
+	cookieState := sessions.GetOAuthStateCookie(r)
+	sessions.DeleteOAuthStateCookie(w)
+	if subtle.ConstantTimeCompare([]byte(cookieState), []byte(state)) != 1 {
+		http.Error(w, "oauth state doesn't match this browser. start the sign-in again", http.StatusBadRequest)
+		return
+	}
+	oauthState, err := g.DB.ConsumeOAuthState(context.TODO(), utils.HashRefreshToken(state))
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid or expired oauth state. start the sign-in again", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching oauth state in OauthCallbackHandler:", err.Error())
+		http.Error(w, "internal error checking oauth state", http.StatusInternalServerError)
+		return
+	}
+	// the account google gets linked to has to be the one logged in here
+	if oauthState.Purpose == oauthPurposeLink {
+		claims := accountClaims(w, r)
+		if claims == nil {
+			return
+		}
+		if !oauthState.UserID.Valid || claims.UserID != oauthState.UserID.UUID {
+			http.Error(w, "google linking was started by another account", http.StatusForbidden)
+			return
+		}
+	}
+	if e := r.URL.Query().Get("error"); e != "" {
+		http.Error(w, "google sign-in was not completed: "+e, http.StatusBadRequest)
+		return
+	}
+
+	gu, err := g.fetchGoogleUser(r.URL.Query().Get("code"), oauthState.CodeVerifier)
+	if err != nil {
+		log.Warn("error fetching google user in OauthCallbackHandler:", err.Error())
+		http.Error(w, "error fetching user from google", http.StatusBadGateway)
+		return
+	}
+	if gu.ID == "" || !gu.VerifiedEmail {
+		http.Error(w, "google account has no verified email", http.StatusBadRequest)
+		return
+	}
+
+	if oauthState.Purpose == oauthPurposeLink {
+		g.linkGoogle(w, oauthState.UserID.UUID, gu)
+		return
+	}
+	g.loginWithGoogle(w, r, oauthState.Role, gu)
+}
+
+// linkGoogle links the google account to the user who started the link
+func (g *Guest) linkGoogle(w http.ResponseWriter, userID uuid.UUID, gu googleUser) {
+	identity, err := g.DB.GetIdentityByProviderSubject(context.TODO(), db.GetIdentityByProviderSubjectParams{
+		Provider: utils.IdentityProviderGoogle,
+		Subject:  gu.ID,
+	})
+	if err == nil {
+		if identity.UserID == userID {
+			http.Error(w, "google account already linked", http.StatusConflict)
+		} else {
+			http.Error(w, "google account is linked to another account", http.StatusConflict)
+		}
+		return
+	} else if err != sql.ErrNoRows {
+		log.Warn("error fetching identity in OauthCallbackHandler:", err.Error())
+		http.Error(w, "internal error linking google account", http.StatusInternalServerError)
+		return
+	}
+
+	linked, err := g.DB.GetIdentitiesByUserID(context.TODO(), userID)
+	if err != nil {
+		log.Warn("error fetching identities in OauthCallbackHandler:", err.Error())
+		http.Error(w, "internal error linking google account", http.StatusInternalServerError)
+		return
+	}
+	for _, l := range linked {
+		if l.Provider == utils.IdentityProviderGoogle {
+			http.Error(w, "another google account is linked. unlink it first", http.StatusConflict)
+			return
+		}
+	}
+
+	identity, err = g.DB.AddIdentity(context.TODO(), db.AddIdentityParams{
+		UserID:   userID,
+		Provider: utils.IdentityProviderGoogle,
+		Subject:  gu.ID,
+		Email:    gu.Email,
+	})
+	if err != nil {
+		log.Warn("error adding identity in OauthCallbackHandler:", err.Error())
+		http.Error(w, "internal error linking google account", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data    respIdentity `json:"data"`
+		Message string       `json:"message"`
+	}
+	resp.Data = respIdentity{Provider: identity.Provider, Email: identity.Email, CreatedAt: identity.CreatedAt}
+	resp.Message = "google account linked. you can now sign in with it"
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// createGoogleAccount signs up a new user or seller for a google account nobody has
+// linked, sellers still go through onboarding before they can sell
+func (g *Guest) createGoogleAccount(role string, gu googleUser) (uuid.UUID, error) {
+	// the account gets a random password, the owner can set one with /forgot_password
+	password, err := utils.GenerateRandomString(16)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer tx.Rollback()
+	qtx := g.DB.WithTx(tx)
+
+	var userID uuid.UUID
+	if role == utils.SellerRole {
+		seller, err := qtx.AddSeller(context.TODO(), db.AddSellerParams{
+			Name:     gu.Name,
+			Email:    gu.Email,
+			Password: hashedPassword,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if _, err = qtx.VerifySellerEmailByID(context.TODO(), seller.ID); err != nil {
+			return uuid.Nil, err
+		}
+		userID = seller.ID
+	} else {
+		user, err := qtx.AddAndVerifyUser(context.TODO(), db.AddAndVerifyUserParams{
+			Name:     gu.Name,
+			Email:    gu.Email,
+			Password: hashedPassword,
+		})
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if _, err = qtx.AddWalletByUserID(context.TODO(), user.ID); err != nil {
+			return uuid.Nil, err
+		}
+		userID = user.ID
+	}
+	_, err = qtx.AddIdentity(context.TODO(), db.AddIdentityParams{
+		UserID:   userID,
+		Provider: utils.IdentityProviderGoogle,
+		Subject:  gu.ID,
+		Email:    gu.Email,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return userID, tx.Commit()
+}
+
+// loginWithGoogle logs in the account linked to the google account, or signs up a
+// new one. an existing password account with the same email is never taken over,
+// its owner has to link google from the account first
+func (g *Guest) loginWithGoogle(w http.ResponseWriter, r *http.Request, role string, gu googleUser) {
+	var userID uuid.UUID
+	identity, err := g.DB.GetIdentityByProviderSubject(context.TODO(), db.GetIdentityByProviderSubjectParams{
+		Provider: utils.IdentityProviderGoogle,
+		Subject:  gu.ID,
+	})
+	if err == sql.ErrNoRows {
+		_, err = g.DB.GetUserByEmail(context.TODO(), gu.Email)
+		if err == nil {
+			http.Error(w, "an account with this email already exists. log in with its password and link google at /auth/google/link", http.StatusConflict)
+			return
+		} else if err != sql.ErrNoRows {
+			log.Warn("error fetching user by email in OauthCallbackHandler:", err.Error())
+			http.Error(w, "internal error signing in with google", http.StatusInternalServerError)
+			return
+		}
+		userID, err = g.createGoogleAccount(role, gu)
+		if err != nil {
+			log.Warn("error adding google account in OauthCallbackHandler:", err.Error())
+			http.Error(w, "internal error adding account for google user", http.StatusInternalServerError)
+			return
+		}
+	} else if err != nil {
+		log.Warn("error fetching identity in OauthCallbackHandler:", err.Error())
+		http.Error(w, "internal error signing in with google", http.StatusInternalServerError)
+		return
+	} else {
+		userID = identity.UserID
+	}
+
+	user, err := g.DB.GetUserById(context.TODO(), userID)
+	if err != nil {
+		log.Warn("error fetching user in OauthCallbackHandler:", err.Error())
+		http.Error(w, "internal error signing in with google", http.StatusInternalServerError)
+		return
+	}
+	if user.IsBlocked {
+		http.Error(w, "user is blocked", http.StatusForbidden)
+		return
+	}
+
+	// google login does not skip 2FA
+	if startTwoFactorLogin(w, g.DB, user.ID, user.Role, user.Email) {
+		return
+	}
+
+	tokens, err := startSession(w, r, g.DB, user.ID, user.Role, user.Name, user.Email)
+	if err != nil {
+		log.Warn("unable to add goolge auth user session:", err.Error())
+		http.Error(w, "unable to add google auth user session", http.StatusInternalServerError)
+		return
+	}
+
+	type respUser struct {
+		ID    uuid.UUID     `json:"id"`
+		Name  string        `json:"name"`
+		Email string        `json:"email"`
+		Phone sql.NullInt64 `json:"phone"`
+		Role  string        `json:"role"`
+	}
+	var resp struct {
+		Data    respUser   `json:"data"`
+		Tokens  respTokens `json:"tokens"`
+		Message string     `json:"message"`
+	}
+	resp.Data = respUser{
+		ID:    user.ID,
+		Name:  user.Name,
+		Email: user.Email,
+		Phone: user.Phone,
+		Role:  user.Role,
+	}
+	resp.Tokens = tokens
+	resp.Message = "user logged in successfully and added token cookies."
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// unlink google from the logged in account
+func (g *Guest) UnlinkGoogleHandler(w http.ResponseWriter, r *http.Request) {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return
+	}
+	result, err := g.DB.DeleteIdentityByUserIDAndProvider(context.TODO(), db.DeleteIdentityByUserIDAndProviderParams{
+		UserID:   claims.UserID,
+		Provider: utils.IdentityProviderGoogle,
+	})
+	if err != nil {
+		log.Warn("error deleting identity in UnlinkGoogleHandler:", err.Error())
+		http.Error(w, "internal error unlinking google account", http.StatusInternalServerError)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		http.Error(w, "no google account linked", http.StatusNotFound)
+		return
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("google account unlinked. accounts made with google can set a password at /forgot_password"))
+}
+
+// list the sign-in accounts linked to the logged in account
+func (g *Guest) IdentitiesHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	list, err := g.DB.GetIdentitiesByUserID(context.TODO(), claims.UserID)
+	if err != nil {
+		log.Warn("error fetching identities in IdentitiesHandler:", err.Error())
+		http.Error(w, "internal error fetching linked accounts", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data []respIdentity `json:"data"`
+	}
+	resp.Data = []respIdentity{}
+	for _, i := range list {
+		resp.Data = append(resp.Data, respIdentity{Provider: i.Provider, Email: i.Email, CreatedAt: i.CreatedAt})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,616 @@
+package user_service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/storage"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const maxDocumentSize = 5 << 20
+
+// every one of these has to be uploaded before the application can be submitted
+var sellerDocumentKinds = []string{
+	utils.SellerDocumentGSTCertificate,
+	utils.SellerDocumentPAN,
+	utils.SellerDocumentBankProof,
+}
+
+// accepted document types with the extension they are stored with
+var documentTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// kyc documents live in the private storage and are only read back
+// through the admin document handler
+var documentStore = newDocumentStore()
+
+func newDocumentStore() storage.Storage {
+	st, err := storage.NewPrivate()
+	if err != nil {
+		log.Fatal("error setting up private document storage: ", err)
+	}
+	return st
+}
+
+type respSellerDocument struct {
+	ID              uuid.UUID  `json:"id"`
+	Kind            string     `json:"kind"`
+	ContentType     string     `json:"content_type"`
+	Status          string     `json:"status"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at"`
+	UploadedAt      time.Time  `json:"uploaded_at"`
+}
+
+type respOnboarding struct {
+	SellerID         uuid.UUID            `json:"seller_id"`
+	Status           string               `json:"status"`
+	RejectionReason  string               `json:"rejection_reason,omitempty"`
+	SubmittedAt      *time.Time           `json:"submitted_at"`
+	ReviewedAt       *time.Time           `json:"reviewed_at"`
+	Documents        []respSellerDocument `json:"documents"`
+	MissingDocuments []string             `json:"missing_documents"`
+}
+
+// sellerOnboarding builds the response of an application along with its documents
+func sellerOnboarding(q *db.Queries, o db.SellerOnboarding) (respOnboarding, error) {
+	resp := respOnboarding{
+		SellerID:         o.SellerID,
+		Status:           o.Status,
+		RejectionReason:  o.RejectionReason.String,
+		Documents:        []respSellerDocument{},
+		MissingDocuments: []string{},
+	}
+	if o.SubmittedAt.Valid {
+		resp.SubmittedAt = &o.SubmittedAt.Time
+	}
+	if o.ReviewedAt.Valid {
+		resp.ReviewedAt = &o.ReviewedAt.Time
+	}
+	docs, err := q.GetSellerDocumentsBySellerID(context.TODO(), o.SellerID)
+	if err != nil {
+		return resp, err
+	}
+	uploaded := make(map[string]bool)
+	for _, d := range docs {
+		uploaded[d.Kind] = true
+		temp := respSellerDocument{
+			ID:              d.ID,
+			Kind:            d.Kind,
+			ContentType:     d.ContentType,
+			Status:          d.Status,
+			RejectionReason: d.RejectionReason.String,
+			UploadedAt:      d.UploadedAt,
+		}
+		if d.ReviewedAt.Valid {
+			temp.ReviewedAt = &d.ReviewedAt.Time
+		}
+		resp.Documents = append(resp.Documents, temp)
+	}
+	for _, kind := range sellerDocumentKinds {
+		if !uploaded[kind] {
+			resp.MissingDocuments = append(resp.MissingDocuments, kind)
+		}
+	}
+	return resp, nil
+}
+
+func validDocumentKind(kind string) bool {
+	for _, k := range sellerDocumentKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// get the onboarding application of the seller, it is started as a draft on first visit
+func (s *Seller) GetOnboardingHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	onboarding, err := s.DB.GetOrCreateSellerOnboarding(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error fetching onboarding in GetOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding application", http.StatusInternalServerError)
+		return
+	}
+	resp, err := sellerOnboarding(s.DB, onboarding)
+	if err != nil {
+		log.Warn("error fetching documents in GetOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding documents", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// upload or replace one KYC document, the file goes in the form field 'document'
+func (s *Seller) UploadOnboardingDocumentHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	kind := r.URL.Query().Get("kind")
+	if !validDocumentKind(kind) {
+		http.Error(w, "invalid kind, use one of gst_certificate, pan, bank_proof", http.StatusBadRequest)
+		return
+	}
+	onboarding, err := s.DB.GetOrCreateSellerOnboarding(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error fetching onboarding in UploadOnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding application", http.StatusInternalServerError)
+		return
+	}
+	if onboarding.Status == utils.OnboardingStatusSubmitted {
+		http.Error(w, "application is under review, documents cannot be changed now", http.StatusBadRequest)
+		return
+	} else if onboarding.Status == utils.OnboardingStatusApproved {
+		http.Error(w, "application is already approved", http.StatusBadRequest)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxDocumentSize+(1<<20))
+	if err := r.ParseMultipartForm(maxDocumentSize); err != nil {
+		http.Error(w, "invalid multipart form or file larger than 5MB", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	file, fh, err := r.FormFile("document")
+	if err != nil {
+		http.Error(w, "no document uploaded, use the form field 'document'", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+	if fh.Size > maxDocumentSize {
+		http.Error(w, "document larger than 5MB", http.StatusBadRequest)
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(file, maxDocumentSize+1))
+	if err != nil || len(data) > maxDocumentSize {
+		http.Error(w, "unable to read file", http.StatusBadRequest)
+		return
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := documentTypes[contentType]
+	if !ok {
+		http.Error(w, "document should be a pdf, jpeg or png", http.StatusBadRequest)
+		return
+	}
+
+	// the old file is only removed once the new one is saved
+	old, oldErr := s.DB.GetSellerDocumentBySellerIDAndKind(context.TODO(), db.GetSellerDocumentBySellerIDAndKindParams{
+		SellerID: user.ID,
+		Kind:     kind,
+	})
+	key := fmt.Sprintf("kyc/%s/%s-%s%s", user.ID, kind, uuid.New().String(), ext)
+	_, err = documentStore.Put(context.TODO(), key, bytes.NewReader(data), int64(len(data)), contentType)
+	if err != nil {
+		log.Warn("error storing document in UploadOnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error storing document", http.StatusInternalServerError)
+		return
+	}
+	doc, err := s.DB.UpsertSellerDocument(context.TODO(), db.UpsertSellerDocumentParams{
+		SellerID:    user.ID,
+		Kind:        kind,
+		FileKey:     key,
+		ContentType: contentType,
+	})
+	if err != nil {
+		log.Warn("error saving document in UploadOnboardingDocumentHandler:", err.Error())
+		documentStore.Delete(context.TODO(), key)
+		http.Error(w, "internal error saving document", http.StatusInternalServerError)
+		return
+	}
+	if oldErr == nil {
+		documentStore.Delete(context.TODO(), old.FileKey)
+	}
+	if onboarding.Status == utils.OnboardingStatusRejected {
+		if err = s.DB.SetSellerOnboardingDraft(context.TODO(), user.ID); err != nil {
+			log.Warn("error reopening onboarding in UploadOnboardingDocumentHandler:", err.Error())
+		}
+	}
+
+	var resp struct {
+		Message  string             `json:"message"`
+		Document respSellerDocument `json:"document"`
+	}
+	resp.Message = "document uploaded"
+	resp.Document = respSellerDocument{
+		ID:          doc.ID,
+		Kind:        doc.Kind,
+		ContentType: doc.ContentType,
+		Status:      doc.Status,
+		UploadedAt:  doc.UploadedAt,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// submit the application for review once every document is uploaded
+func (s *Seller) SubmitOnboardingHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	onboarding, err := s.DB.GetOrCreateSellerOnboarding(context.TODO(), user.ID)
+	if err != nil {
+		log.Warn("error fetching onboarding in SubmitOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding application", http.StatusInternalServerError)
+		return
+	}
+	if onboarding.Status != utils.OnboardingStatusDraft {
+		http.Error(w, "application is already "+onboarding.Status, http.StatusBadRequest)
+		return
+	}
+	current, err := sellerOnboarding(s.DB, onboarding)
+	if err != nil {
+		log.Warn("error fetching documents in SubmitOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding documents", http.StatusInternalServerError)
+		return
+	}
+	if len(current.MissingDocuments) > 0 {
+		http.Error(w, "upload all documents before submitting, missing: "+fmt.Sprint(current.MissingDocuments), http.StatusBadRequest)
+		return
+	}
+	for _, d := range current.Documents {
+		if d.Status == utils.DocumentStatusRejected {
+			http.Error(w, "replace the rejected "+d.Kind+" document before submitting", http.StatusBadRequest)
+			return
+		}
+	}
+
+	onboarding, err = s.DB.SubmitSellerOnboarding(context.TODO(), user.ID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "application is not a draft", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error submitting onboarding in SubmitOnboardingHandler:", err.Error())
+		http.Error(w, "internal error submitting onboarding application", http.StatusInternalServerError)
+		return
+	}
+	var Err []string
+	if err = mail.SendSellerOnboardingMail(utils.OnboardingStatusSubmitted, nil, user.Email); err != nil {
+		log.Warn("error sending onboarding mail in SubmitOnboardingHandler:", err.Error())
+		Err = append(Err, "error sending confirmation mail")
+	}
+
+	var resp struct {
+		Message string   `json:"message"`
+		Status  string   `json:"status"`
+		Err     []string `json:"errors"`
+	}
+	resp.Message = "application submitted for review"
+	resp.Status = onboarding.Status
+	resp.Err = Err
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// list onboarding applications by status, submitted ones by default
+func (a *Admin) OnboardingsHandler(w http.ResponseWriter, r *http.Request) {
+	status := r.URL.Query().Get("status")
+	if status == "" {
+		status = utils.OnboardingStatusSubmitted
+	}
+	switch status {
+	case utils.OnboardingStatusDraft, utils.OnboardingStatusSubmitted, utils.OnboardingStatusApproved, utils.OnboardingStatusRejected:
+	default:
+		http.Error(w, "invalid status", http.StatusBadRequest)
+		return
+	}
+	onboardings, err := a.DB.GetSellerOnboardingsByStatus(context.TODO(), status)
+	if err != nil {
+		log.Warn("error fetching onboardings in OnboardingsHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding applications", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data []db.GetSellerOnboardingsByStatusRow `json:"data"`
+	}
+	resp.Data = onboardings
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *Admin) OnboardingHandler(w http.ResponseWriter, r *http.Request) {
+	sellerID, err := uuid.Parse(r.URL.Query().Get("seller_id"))
+	if err != nil {
+		http.Error(w, "invalid seller_id", http.StatusBadRequest)
+		return
+	}
+	onboarding, err := a.DB.GetSellerOnboardingBySellerID(context.TODO(), sellerID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "seller has not started onboarding", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching onboarding in OnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding application", http.StatusInternalServerError)
+		return
+	}
+	resp, err := sellerOnboarding(a.DB, onboarding)
+	if err != nil {
+		log.Warn("error fetching documents in OnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding documents", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// documents are streamed through here so they are only ever seen by admins
+func (a *Admin) OnboardingDocumentHandler(w http.ResponseWriter, r *http.Request) {
+	documentID, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	doc, err := a.DB.GetSellerDocumentByID(context.TODO(), documentID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "document not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching document in OnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error fetching document", http.StatusInternalServerError)
+		return
+	}
+	file, err := documentStore.Get(context.TODO(), doc.FileKey)
+	if err != nil {
+		log.Warn("error reading document from storage in OnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error reading document", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+	w.Header().Set("Content-Type", doc.ContentType)
+	io.Copy(w, file)
+}
+
+// approve or reject one document of a submitted application
+func (a *Admin) ReviewOnboardingDocumentHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		DocumentID uuid.UUID `json:"document_id"`
+		Action     string    `json:"action"`
+		Reason     string    `json:"reason"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	arg := db.ReviewSellerDocumentByIDParams{
+		ID:         req.DocumentID,
+		ReviewedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
+	}
+	switch req.Action {
+	case "approve":
+		arg.Status = utils.DocumentStatusApproved
+	case "reject":
+		if req.Reason == "" {
+			http.Error(w, "reason is required to reject a document", http.StatusBadRequest)
+			return
+		}
+		arg.Status = utils.DocumentStatusRejected
+		arg.RejectionReason = sql.NullString{String: req.Reason, Valid: true}
+	default:
+		http.Error(w, "invalid action, use approve or reject", http.StatusBadRequest)
+		return
+	}
+
+	doc, err := a.DB.GetSellerDocumentByID(context.TODO(), req.DocumentID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "document not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching document in ReviewOnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error fetching document", http.StatusInternalServerError)
+		return
+	}
+	onboarding, err := a.DB.GetSellerOnboardingBySellerID(context.TODO(), doc.SellerID)
+	if err != nil {
+		log.Warn("error fetching onboarding in ReviewOnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding application", http.StatusInternalServerError)
+		return
+	}
+	if onboarding.Status != utils.OnboardingStatusSubmitted {
+		http.Error(w, "only documents of submitted applications can be reviewed", http.StatusBadRequest)
+		return
+	}
+
+	before := doc.Status
+	doc, err = a.DB.ReviewSellerDocumentByID(context.TODO(), arg)
+	if err != nil {
+		log.Warn("error reviewing document in ReviewOnboardingDocumentHandler:", err.Error())
+		http.Error(w, "internal error reviewing document", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionSellerDocumentReview,
+		EntityType: audit.EntitySellerDocument,
+		EntityID:   doc.ID.String(),
+		Before:     map[string]any{"status": before},
+		After:      map[string]any{"status": doc.Status, "rejection_reason": arg.RejectionReason.String},
+	})
+	var resp struct {
+		Message string `json:"message"`
+		Status  string `json:"status"`
+	}
+	resp.Message = "document " + doc.Status
+	resp.Status = doc.Status
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// approve or reject a submitted application. approving needs every document approved
+// and verifies the seller, rejecting mails the seller the reasons
+func (a *Admin) ReviewOnboardingHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		SellerID uuid.UUID `json:"seller_id"`
+		Action   string    `json:"action"`
+		Reason   string    `json:"reason"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.Action != "approve" && req.Action != "reject" {
+		http.Error(w, "invalid action, use approve or reject", http.StatusBadRequest)
+		return
+	}
+	seller, err := a.DB.GetUserById(context.TODO(), req.SellerID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "seller does not exist", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching seller in ReviewOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching seller", http.StatusInternalServerError)
+		return
+	} else if seller.Role != utils.SellerRole {
+		http.Error(w, "the given details is not that of a seller", http.StatusBadRequest)
+		return
+	}
+	onboarding, err := a.DB.GetSellerOnboardingBySellerID(context.TODO(), req.SellerID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "seller has not started onboarding", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching onboarding in ReviewOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding application", http.StatusInternalServerError)
+		return
+	}
+	if onboarding.Status != utils.OnboardingStatusSubmitted {
+		http.Error(w, "application is "+onboarding.Status+", only submitted applications can be reviewed", http.StatusBadRequest)
+		return
+	}
+	current, err := sellerOnboarding(a.DB, onboarding)
+	if err != nil {
+		log.Warn("error fetching documents in ReviewOnboardingHandler:", err.Error())
+		http.Error(w, "internal error fetching onboarding documents", http.StatusInternalServerError)
+		return
+	}
+
+	// reasons for the seller are the overall reason and the reason of every rejected document
+	var reasons []string
+	if req.Reason != "" {
+		reasons = append(reasons, req.Reason)
+	}
+	for _, d := range current.Documents {
+		if req.Action == "approve" && d.Status != utils.DocumentStatusApproved {
+			http.Error(w, "every document has to be approved first, "+d.Kind+" is "+d.Status, http.StatusBadRequest)
+			return
+		}
+		if d.Status == utils.DocumentStatusRejected {
+			reasons = append(reasons, d.Kind+": "+d.RejectionReason)
+		}
+	}
+	if req.Action == "reject" && len(reasons) == 0 {
+		http.Error(w, "reason is required when no document is rejected", http.StatusBadRequest)
+		return
+	}
+
+	arg := db.ReviewSellerOnboardingParams{
+		SellerID:   req.SellerID,
+		ReviewedBy: uuid.NullUUID{UUID: user.ID, Valid: true},
+	}
+	if req.Action == "approve" {
+		arg.Status = utils.OnboardingStatusApproved
+	} else {
+		arg.Status = utils.OnboardingStatusRejected
+		arg.RejectionReason = sql.NullString{String: req.Reason, Valid: req.Reason != ""}
+	}
+
+	var Err []string
+	var Messages []string
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in ReviewOnboardingHandler:", err.Error())
+		http.Error(w, "internal error reviewing onboarding application", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := a.DB.WithTx(tx)
+	onboarding, err = qtx.ReviewSellerOnboarding(context.TODO(), arg)
+	if err == sql.ErrNoRows {
+		http.Error(w, "application is no longer submitted", http.StatusConflict)
+		return
+	} else if err != nil {
+		log.Warn("error reviewing onboarding in ReviewOnboardingHandler:", err.Error())
+		http.Error(w, "internal error reviewing onboarding application", http.StatusInternalServerError)
+		return
+	}
+	if req.Action == "approve" {
+		// an approved seller is verified and gets a wallet
+		if _, err = qtx.VerifySellerByID(context.TODO(), req.SellerID); err != nil {
+			log.Warn("error verifying seller in ReviewOnboardingHandler:", err.Error())
+			http.Error(w, "internal error verifying seller", http.StatusInternalServerError)
+			return
+		}
+		if _, err = qtx.GetWalletByUserID(context.TODO(), req.SellerID); err == sql.ErrNoRows {
+			if _, err = qtx.AddWalletByUserID(context.TODO(), req.SellerID); err != nil {
+				log.Warn("error adding wallet in ReviewOnboardingHandler:", err.Error())
+				http.Error(w, "internal error adding wallet for seller", http.StatusInternalServerError)
+				return
+			}
+			Messages = append(Messages, fmt.Sprintf("successfully added wallet for seller: %s", req.SellerID.String()))
+		} else if err != nil {
+			log.Warn("error fetching wallet in ReviewOnboardingHandler:", err.Error())
+			http.Error(w, "internal error fetching wallet of seller", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing transaction in ReviewOnboardingHandler:", err.Error())
+		http.Error(w, "internal error reviewing onboarding application", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionSellerOnboardingReview,
+		EntityType: audit.EntityUser,
+		EntityID:   req.SellerID.String(),
+		Before:     map[string]any{"status": utils.OnboardingStatusSubmitted, "user_verified": seller.UserVerified},
+		After:      map[string]any{"status": onboarding.Status, "user_verified": req.Action == "approve" || seller.UserVerified, "reasons": reasons},
+	})
+
+	if err = mail.SendSellerOnboardingMail(onboarding.Status, reasons, seller.Email); err != nil {
+		log.Warn("error sending onboarding mail in ReviewOnboardingHandler:", err.Error())
+		Err = append(Err, "error sending mail to seller")
+	}
+
+	var resp struct {
+		Status   string   `json:"status"`
+		Reasons  []string `json:"reasons"`
+		Messages []string `json:"messages"`
+		Err      []string `json:"errors"`
+	}
+	resp.Status = onboarding.Status
+	resp.Reasons = reasons
+	resp.Messages = append(Messages, "application "+onboarding.Status)
+	resp.Err = Err
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,93 @@
+package user_service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/ratelimit"
+	log "github.com/sirupsen/logrus"
+)
+
+// wrong guesses allowed against one otp before it is dropped
+const maxOTPAttempts = 5
+
+var limitStore = newLimitStore()
+
+func newLimitStore() ratelimit.Store {
+	st, err := ratelimit.New(dbConn)
+	if err != nil {
+		log.Fatal("error setting up rate limit store: ", err)
+	}
+	return st
+}
+
+// failed logins of one account, locked for 1m, 2m, 4m... up to an hour
+var loginAccountLimiter = &ratelimit.Limiter{
+	Store:  limitStore,
+	Prefix: "login:account",
+	Policy: ratelimit.Policy{MaxAttempts: 5, Window: 15 * time.Minute, Lockout: time.Minute, MaxLockout: time.Hour},
+}
+
+// failed logins from one ip across accounts, looser since users can share an ip
+var loginIPLimiter = &ratelimit.Limiter{
+	Store:  limitStore,
+	Prefix: "login:ip",
+	Policy: ratelimit.Policy{MaxAttempts: 20, Window: 15 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: time.Hour},
+}
+
+// wrong otps of one account across the otps it is sent, resending doesn't reset it
+var otpAccountLimiter = &ratelimit.Limiter{
+	Store:  limitStore,
+	Prefix: "otp:account",
+	Policy: ratelimit.Policy{MaxAttempts: 10, Window: time.Hour, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour},
+}
+
+// wrong totp or recovery codes of one account across every login challenge,
+// the password being right doesn't reset it
+var twoFactorAccountLimiter = &ratelimit.Limiter{
+	Store:  limitStore,
+	Prefix: "2fa:account",
+	Policy: ratelimit.Policy{MaxAttempts: 5, Window: 15 * time.Minute, Lockout: 5 * time.Minute, MaxLockout: 24 * time.Hour},
+}
+
+var otpIPLimiter = &ratelimit.Limiter{
+	Store:  limitStore,
+	Prefix: "otp:ip",
+	Policy: ratelimit.Policy{MaxAttempts: 20, Window: time.Hour, Lockout: 15 * time.Minute, MaxLockout: 24 * time.Hour},
+}
+
+// tooManyAttempts writes a 429 with the Retry-After header
+func tooManyAttempts(w http.ResponseWriter, wait time.Duration) {
+	secs := int(math.Ceil(wait.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	http.Error(w, fmt.Sprintf("too many failed attempts. try again in %d seconds", secs), http.StatusTooManyRequests)
+}
+
+// locked writes a 429 and returns true when id is locked out by l. the store
+// failing lets the request through so a database hiccup doesn't block every login
+func locked(w http.ResponseWriter, l *ratelimit.Limiter, id string) bool {
+	wait, err := l.Check(context.TODO(), id)
+	if err != nil {
+		log.Error("error checking rate limit "+l.Prefix+":", err.Error())
+		return false
+	}
+	if wait > 0 {
+		tooManyAttempts(w, wait)
+		return true
+	}
+	return false
+}
+
+// recordFailure counts a failed attempt of id against l
+func recordFailure(l *ratelimit.Limiter, id string) {
+	lock, err := l.Fail(context.TODO(), id)
+	if err != nil {
+		log.Error("error recording failed attempt "+l.Prefix+":", err.Error())
+	} else if lock > 0 {
+		log.Warnf("%s locked out %s for %s", l.Prefix, id, lock)
+	}
+}
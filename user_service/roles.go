@@ -0,0 +1,361 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type respRole struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Staff       bool      `json:"staff"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// validPermissions checks every permission is one the services know of
+func validPermissions(w http.ResponseWriter, permissions []string) bool {
+	for _, p := range permissions {
+		if !utils.IsPermission(p) {
+			http.Error(w, "unknown permission "+p, http.StatusBadRequest)
+			return false
+		}
+	}
+	return true
+}
+
+// setRolePermissions replaces the permissions of the role in one transaction
+func setRolePermissions(q *db.Queries, role string, permissions []string) error {
+	tx, err := dbConn.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := q.WithTx(tx)
+	if err = qtx.DeleteRolePermissions(context.TODO(), role); err != nil {
+		return err
+	}
+	for _, p := range permissions {
+		err = qtx.AddRolePermission(context.TODO(), db.AddRolePermissionParams{Role: role, Permission: p})
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// list the roles with their permissions and every permission there is
+func (a *Admin) RolesHandler(w http.ResponseWriter, r *http.Request) {
+	roles, err := a.DB.GetRoles(context.TODO())
+	if err != nil {
+		log.Warn("error fetching roles in RolesHandler:", err.Error())
+		http.Error(w, "internal error fetching roles", http.StatusInternalServerError)
+		return
+	}
+	perms, err := a.DB.GetRolePermissions(context.TODO())
+	if err != nil {
+		log.Warn("error fetching role permissions in RolesHandler:", err.Error())
+		http.Error(w, "internal error fetching roles", http.StatusInternalServerError)
+		return
+	}
+	byRole := make(map[string][]string)
+	for _, p := range perms {
+		byRole[p.Role] = append(byRole[p.Role], p.Permission)
+	}
+	var resp struct {
+		Data        []respRole `json:"data"`
+		Permissions []string   `json:"permissions"`
+	}
+	resp.Data = []respRole{}
+	for _, role := range roles {
+		permissions := byRole[role.Name]
+		if permissions == nil {
+			permissions = []string{}
+		}
+		resp.Data = append(resp.Data, respRole{
+			Name:        role.Name,
+			Description: role.Description,
+			Staff:       role.Staff,
+			Permissions: permissions,
+			CreatedAt:   role.CreatedAt,
+		})
+	}
+	resp.Permissions = utils.Permissions
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// add a staff role with a set of permissions
+func (a *Admin) AddRoleHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if !validPermissions(w, req.Permissions) {
+		return
+	}
+	_, err := a.DB.GetRoleByName(context.TODO(), req.Name)
+	if err == nil {
+		http.Error(w, "role already exists", http.StatusConflict)
+		return
+	} else if err != sql.ErrNoRows {
+		log.Warn("error fetching role in AddRoleHandler:", err.Error())
+		http.Error(w, "internal error adding role", http.StatusInternalServerError)
+		return
+	}
+	role, err := a.DB.AddRole(context.TODO(), db.AddRoleParams{Name: req.Name, Description: req.Description})
+	if err != nil {
+		// the name check of the table is the only other way this fails
+		http.Error(w, "role name should be 3 to 32 lowercase letters or underscores", http.StatusBadRequest)
+		return
+	}
+	if err = setRolePermissions(a.DB, role.Name, req.Permissions); err != nil {
+		log.Warn("error setting role permissions in AddRoleHandler:", err.Error())
+		http.Error(w, "role added. internal error setting its permissions", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionRoleAdd,
+		EntityType: audit.EntityRole,
+		EntityID:   role.Name,
+		After:      map[string]any{"description": role.Description, "permissions": req.Permissions},
+	})
+	var resp struct {
+		Message string   `json:"message"`
+		Data    respRole `json:"data"`
+	}
+	resp.Message = "role added"
+	resp.Data = respRole{
+		Name:        role.Name,
+		Description: role.Description,
+		Staff:       role.Staff,
+		Permissions: req.Permissions,
+		CreatedAt:   role.CreatedAt,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// replace the permissions of a staff role, admin always keeps all of them
+func (a *Admin) EditRolePermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Role        string   `json:"role"`
+		Permissions []string `json:"permissions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if req.Role == utils.AdminRole {
+		http.Error(w, "permissions of the admin role can't be changed", http.StatusBadRequest)
+		return
+	}
+	if !validPermissions(w, req.Permissions) {
+		return
+	}
+	role, err := a.DB.GetRoleByName(context.TODO(), req.Role)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no role with the name", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching role in EditRolePermissionsHandler:", err.Error())
+		http.Error(w, "internal error editing role", http.StatusInternalServerError)
+		return
+	} else if !role.Staff {
+		http.Error(w, "only staff roles have permissions", http.StatusBadRequest)
+		return
+	}
+	perms, err := a.DB.GetRolePermissions(context.TODO())
+	if err != nil {
+		log.Warn("error fetching role permissions in EditRolePermissionsHandler:", err.Error())
+		http.Error(w, "internal error editing role", http.StatusInternalServerError)
+		return
+	}
+	before := []string{}
+	for _, p := range perms {
+		if p.Role == role.Name {
+			before = append(before, p.Permission)
+		}
+	}
+	if err = setRolePermissions(a.DB, role.Name, req.Permissions); err != nil {
+		log.Warn("error setting role permissions in EditRolePermissionsHandler:", err.Error())
+		http.Error(w, "internal error editing role", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionRolePermissionsEdit,
+		EntityType: audit.EntityRole,
+		EntityID:   role.Name,
+		Before:     map[string]any{"permissions": before},
+		After:      map[string]any{"permissions": req.Permissions},
+	})
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("role permissions updated. they apply to users from their next token refresh"))
+}
+
+// list the roles of a user
+func (a *Admin) UserRolesHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		http.Error(w, "invalid user_id", http.StatusBadRequest)
+		return
+	}
+	roles, err := a.DB.GetRolesByUserID(context.TODO(), userID)
+	if err != nil {
+		log.Warn("error fetching user roles in UserRolesHandler:", err.Error())
+		http.Error(w, "internal error fetching user roles", http.StatusInternalServerError)
+		return
+	}
+	permissions, err := a.DB.GetPermissionsByUserID(context.TODO(), userID)
+	if err != nil {
+		log.Warn("error fetching user permissions in UserRolesHandler:", err.Error())
+		http.Error(w, "internal error fetching user roles", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		UserID      uuid.UUID `json:"user_id"`
+		Roles       []string  `json:"roles"`
+		Permissions []string  `json:"permissions"`
+	}
+	resp.UserID = userID
+	resp.Roles = append([]string{}, roles...)
+	resp.Permissions = append([]string{}, permissions...)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+type userRoleRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+// staffRoleRequest decodes the request and checks the role is a staff role and the
+// user an admin account, customers and sellers keep just their account role
+func (a *Admin) staffRoleRequest(w http.ResponseWriter, r *http.Request) (userRoleRequest, bool) {
+	var req userRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return req, false
+	}
+	role, err := a.DB.GetRoleByName(context.TODO(), req.Role)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no role with the name", http.StatusNotFound)
+		return req, false
+	} else if err != nil {
+		log.Warn("error fetching role in staffRoleRequest:", err.Error())
+		http.Error(w, "internal error fetching role", http.StatusInternalServerError)
+		return req, false
+	} else if !role.Staff {
+		http.Error(w, "account roles can't be assigned or removed", http.StatusBadRequest)
+		return req, false
+	}
+	user, err := a.DB.GetUserById(context.TODO(), req.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no user with the id", http.StatusNotFound)
+		return req, false
+	} else if err != nil {
+		log.Warn("error fetching user in staffRoleRequest:", err.Error())
+		http.Error(w, "internal error fetching user", http.StatusInternalServerError)
+		return req, false
+	} else if user.Role != utils.AdminRole {
+		http.Error(w, "staff roles can only be given to admin accounts", http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// give an admin account a staff role
+func (a *Admin) AssignRoleHandler(w http.ResponseWriter, r *http.Request) {
+	admin := helper.GetUserHelper(w, r)
+	if admin.ID == uuid.Nil {
+		return
+	}
+	req, ok := a.staffRoleRequest(w, r)
+	if !ok {
+		return
+	}
+	roles, err := a.DB.GetRolesByUserID(context.TODO(), req.UserID)
+	if err != nil {
+		log.Warn("error fetching user roles in AssignRoleHandler:", err.Error())
+		http.Error(w, "internal error assigning role", http.StatusInternalServerError)
+		return
+	}
+	for _, role := range roles {
+		if role == req.Role {
+			http.Error(w, "user already has the role", http.StatusConflict)
+			return
+		}
+	}
+	_, err = a.DB.AddUserRole(context.TODO(), db.AddUserRoleParams{
+		UserID:     req.UserID,
+		Role:       req.Role,
+		AssignedBy: uuid.NullUUID{UUID: admin.ID, Valid: true},
+	})
+	if err != nil {
+		log.Warn("error adding user role in AssignRoleHandler:", err.Error())
+		http.Error(w, "internal error assigning role", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionUserRoleAssign,
+		EntityType: audit.EntityUser,
+		EntityID:   req.UserID.String(),
+		Before:     map[string]any{"roles": roles},
+		After:      map[string]any{"roles": append(roles, req.Role)},
+	})
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("role assigned. it applies from the user's next token refresh"))
+}
+
+// take a staff role from an admin account, the last admin can't be removed
+func (a *Admin) RemoveRoleHandler(w http.ResponseWriter, r *http.Request) {
+	req, ok := a.staffRoleRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Role == utils.AdminRole {
+		n, err := a.DB.CountUsersWithRole(context.TODO(), utils.AdminRole)
+		if err != nil {
+			log.Warn("error counting admins in RemoveRoleHandler:", err.Error())
+			http.Error(w, "internal error removing role", http.StatusInternalServerError)
+			return
+		}
+		if n <= 1 {
+			http.Error(w, "can't remove the last admin", http.StatusConflict)
+			return
+		}
+	}
+	result, err := a.DB.DeleteUserRole(context.TODO(), db.DeleteUserRoleParams{UserID: req.UserID, Role: req.Role})
+	if err != nil {
+		log.Warn("error deleting user role in RemoveRoleHandler:", err.Error())
+		http.Error(w, "internal error removing role", http.StatusInternalServerError)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		http.Error(w, "user doesn't have the role", http.StatusNotFound)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionUserRoleRemove,
+		EntityType: audit.EntityUser,
+		EntityID:   req.UserID.String(),
+		Before:     map[string]any{"role": req.Role},
+	})
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("role removed. it stops applying from the user's next token refresh"))
+}
@@ -0,0 +1,200 @@
+package user_service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	middleware "github.com/amankhys/multi_vendor_ecommerce_go/pkg/middlewares"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sessions"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+type respSession struct {
+	ID         uuid.UUID `json:"id"`
+	Device     string    `json:"device"`
+	Browser    string    `json:"browser"`
+	OS         string    `json:"os"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// sessionClaims verifies the access token for the session endpoints, which are
+// open to every role so they can't go through the role checking middleware.
+// impersonation tokens get the same read only treatment as in the middleware
+func sessionClaims(w http.ResponseWriter, r *http.Request) *utils.AccessClaims {
+	token := sessions.GetAccessToken(r)
+	if token == "" {
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return nil
+	}
+	claims, err := utils.VerifyAccessToken(token)
+	if err != nil {
+		http.Error(w, "invalid or expired access token. refresh it at /auth/refresh", http.StatusUnauthorized)
+		return nil
+	}
+	if !middleware.GuardImpersonation(w, r, claims) {
+		return nil
+	}
+	return claims
+}
+
+const errImpersonatingAccount = "account settings can't be changed while impersonating"
+
+// accountClaims is sessionClaims for the handlers that change how the account is
+// reached (sign-in methods, email, phone, 2FA). impersonation tokens are refused
+// here whatever the method, the read-only guard only looks at the method
+func accountClaims(w http.ResponseWriter, r *http.Request) *utils.AccessClaims {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return nil
+	}
+	if claims.Impersonation != nil {
+		http.Error(w, errImpersonatingAccount, http.StatusForbidden)
+		return nil
+	}
+	return claims
+}
+
+// refuseImpersonation is the same check for handlers behind the auth middleware,
+// it writes a 403 and returns true for impersonation tokens
+func refuseImpersonation(w http.ResponseWriter, r *http.Request) bool {
+	claims, err := utils.VerifyAccessToken(sessions.GetAccessToken(r))
+	if err != nil || claims.Impersonation == nil {
+		return false
+	}
+	http.Error(w, errImpersonatingAccount, http.StatusForbidden)
+	return true
+}
+
+// list the active sessions of the user with the device they were started from
+func (g *Guest) SessionsHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	list, err := g.DB.GetActiveSessionsByUserID(context.TODO(), claims.UserID)
+	if err != nil {
+		log.Warn("error fetching sessions in SessionsHandler:", err.Error())
+		http.Error(w, "internal error fetching sessions", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data []respSession `json:"data"`
+	}
+	resp.Data = []respSession{}
+	for _, s := range list {
+		device, browser, os := utils.ParseUserAgent(s.UserAgent)
+		resp.Data = append(resp.Data, respSession{
+			ID:         s.ID,
+			Device:     device,
+			Browser:    browser,
+			OS:         os,
+			IPAddress:  s.IpAddress,
+			Current:    s.ID == claims.SessionID,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// log out one device, its access token stays valid until it expires
+func (g *Guest) RevokeSessionHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid session id", http.StatusBadRequest)
+		return
+	}
+	result, err := g.DB.RevokeSessionByIDAndUserID(context.TODO(), db.RevokeSessionByIDAndUserIDParams{
+		ID:     sessionID,
+		UserID: claims.UserID,
+	})
+	if err != nil {
+		log.Warn("error revoking session in RevokeSessionHandler:", err.Error())
+		http.Error(w, "internal error revoking session", http.StatusInternalServerError)
+		return
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		http.Error(w, "no active session with the id", http.StatusNotFound)
+		return
+	}
+	if sessionID == claims.SessionID {
+		sessions.DeleteAuthCookies(w)
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("session revoked"))
+}
+
+// log out every device except the one making the request
+func (g *Guest) RevokeOtherSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	claims := sessionClaims(w, r)
+	if claims == nil {
+		return
+	}
+	result, err := g.DB.RevokeOtherSessionsByUserID(context.TODO(), db.RevokeOtherSessionsByUserIDParams{
+		UserID:           claims.UserID,
+		CurrentSessionID: claims.SessionID,
+	})
+	if err != nil {
+		log.Warn("error revoking sessions in RevokeOtherSessionsHandler:", err.Error())
+		http.Error(w, "internal error revoking sessions", http.StatusInternalServerError)
+		return
+	}
+	n, _ := result.RowsAffected()
+	var resp struct {
+		Message string `json:"message"`
+		Revoked int64  `json:"revoked"`
+	}
+	resp.Message = "logged out of all other devices"
+	resp.Revoked = n
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SessionSweeperCron purges expired and long revoked sessions along with expired
+// login challenges, oauth states, idle rate limit counters and month old outbox mails,
+// run it in its own goroutine from the service main
+func SessionSweeperCron() {
+	for {
+		result, err := DB.DeleteExpiredSessions(context.TODO())
+		if err != nil {
+			log.Error("error purging expired sessions in SessionSweeperCron:", err.Error())
+		} else if n, _ := result.RowsAffected(); n > 0 {
+			log.Infof("purged %d expired sessions", n)
+		}
+		if _, err = DB.DeleteExpiredLoginChallenges(context.TODO()); err != nil {
+			log.Error("error purging expired login challenges in SessionSweeperCron:", err.Error())
+		}
+		if _, err = DB.DeleteExpiredOAuthStates(context.TODO()); err != nil {
+			log.Error("error purging expired oauth states in SessionSweeperCron:", err.Error())
+		}
+		if _, err = DB.DeleteExpiredEmailChanges(context.TODO()); err != nil {
+			log.Error("error purging expired email changes in SessionSweeperCron:", err.Error())
+		}
+		if _, err = DB.DeleteExpiredPhoneVerifications(context.TODO()); err != nil {
+			log.Error("error purging expired phone verifications in SessionSweeperCron:", err.Error())
+		}
+		if err = limitStore.Purge(context.TODO(), 24*time.Hour); err != nil {
+			log.Error("error purging rate limits in SessionSweeperCron:", err.Error())
+		}
+		if _, err = mailOutbox.Purge(context.TODO(), 30*24*time.Hour); err != nil {
+			log.Error("error purging mail outbox in SessionSweeperCron:", err.Error())
+		}
+		time.Sleep(time.Hour)
+	}
+}
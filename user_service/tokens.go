@@ -0,0 +1,170 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sessions"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+var errRefreshTokenReused = errors.New("refresh token reused")
+
+type respTokens struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// issueTokens signs an access token for the session with the roles and permissions
+// the user has right now and sets both token cookies
+func issueTokens(w http.ResponseWriter, q *db.Queries, session db.Session, role, name, email, refreshToken string) (respTokens, error) {
+	roles, err := q.GetRolesByUserID(context.TODO(), session.UserID)
+	if err != nil {
+		return respTokens{}, err
+	}
+	permissions, err := q.GetPermissionsByUserID(context.TODO(), session.UserID)
+	if err != nil {
+		return respTokens{}, err
+	}
+	accessToken, expiresAt, err := utils.CreateAccessToken(session.UserID, session.ID, role, name, email, roles, permissions)
+	if err != nil {
+		return respTokens{}, err
+	}
+	sessions.SetAuthCookies(w, accessToken, expiresAt, refreshToken, session.ExpiresAt)
+	return respTokens{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(utils.AccessTokenTTL / time.Second),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// startSession adds a session with a fresh refresh token and issues the first token pair,
+// used by every way of logging in
+func startSession(w http.ResponseWriter, r *http.Request, q *db.Queries, userID uuid.UUID, role, name, email string) (respTokens, error) {
+	refreshToken, refreshHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return respTokens{}, err
+	}
+	session, err := q.AddSession(context.TODO(), db.AddSessionParams{
+		UserID:           userID,
+		IpAddress:        utils.GetClientIPString(r),
+		UserAgent:        utils.GetUserAgent(r),
+		RefreshTokenHash: refreshHash,
+	})
+	if err != nil {
+		return respTokens{}, err
+	}
+	return issueTokens(w, q, session, role, name, email, refreshToken)
+}
+
+// refreshTokenFromRequest reads the refresh token from its cookie, or from the
+// json body for clients that don't keep cookies
+func refreshTokenFromRequest(r *http.Request) string {
+	if cookie, err := sessions.GetRefreshCookie(r); err == nil && cookie.Value != "" {
+		return cookie.Value
+	}
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	return req.RefreshToken
+}
+
+// sessionByRefreshToken finds the live session of a refresh token. a token that was
+// already rotated away means it was stolen or replayed, so its session is revoked
+func sessionByRefreshToken(q *db.Queries, refreshToken string) (db.Session, error) {
+	hash := utils.HashRefreshToken(refreshToken)
+	session, err := q.GetSessionByRefreshTokenHash(context.TODO(), hash)
+	if err == sql.ErrNoRows {
+		reused, prevErr := q.GetSessionByPreviousTokenHash(context.TODO(), sql.NullString{String: hash, Valid: true})
+		if prevErr == nil {
+			log.Warnf("refresh token reused for session: %s, revoking it", reused.ID)
+			if _, err := q.RevokeSessionByID(context.TODO(), reused.ID); err != nil {
+				log.Error("error revoking session of reused refresh token:", err.Error())
+			}
+			return db.Session{}, errRefreshTokenReused
+		}
+		return db.Session{}, sql.ErrNoRows
+	} else if err != nil {
+		return db.Session{}, err
+	}
+	if session.RevokedAt.Valid || !session.ExpiresAt.After(time.Now()) {
+		return db.Session{}, sql.ErrNoRows
+	}
+	return session, nil
+}
+
+// rotate the refresh token of the session and issue a new access token with it
+func (g *Guest) RefreshTokenHandler(w http.ResponseWriter, r *http.Request) {
+	refreshToken := refreshTokenFromRequest(r)
+	if refreshToken == "" {
+		http.Error(w, "refresh token required", http.StatusUnauthorized)
+		return
+	}
+	session, err := sessionByRefreshToken(g.DB, refreshToken)
+	if err == sql.ErrNoRows || err == errRefreshTokenReused {
+		sessions.DeleteAuthCookies(w)
+		http.Error(w, "invalid or expired session. login again", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		log.Warn("error fetching session in RefreshTokenHandler:", err.Error())
+		http.Error(w, "internal error refreshing session", http.StatusInternalServerError)
+		return
+	}
+
+	// role, permissions and blocked status are read again on every refresh so changes apply within one access token lifetime
+	user, err := g.DB.GetUserById(context.TODO(), session.UserID)
+	if err != nil {
+		log.Warn("error fetching user in RefreshTokenHandler:", err.Error())
+		http.Error(w, "internal error refreshing session", http.StatusInternalServerError)
+		return
+	}
+	if user.IsBlocked {
+		if _, err = g.DB.RevokeSessionByID(context.TODO(), session.ID); err != nil {
+			log.Warn("error revoking session of blocked user in RefreshTokenHandler:", err.Error())
+		}
+		sessions.DeleteAuthCookies(w)
+		http.Error(w, "user is blocked", http.StatusForbidden)
+		return
+	}
+
+	newToken, newHash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		log.Warn("error generating refresh token in RefreshTokenHandler:", err.Error())
+		http.Error(w, "internal error refreshing session", http.StatusInternalServerError)
+		return
+	}
+	session, err = g.DB.RotateSessionRefreshToken(context.TODO(), db.RotateSessionRefreshTokenParams{
+		ID:           session.ID,
+		OldTokenHash: session.RefreshTokenHash,
+		NewTokenHash: newHash,
+	})
+	if err == sql.ErrNoRows {
+		// another request rotated it first
+		http.Error(w, "invalid or expired session. login again", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		log.Warn("error rotating refresh token in RefreshTokenHandler:", err.Error())
+		http.Error(w, "internal error refreshing session", http.StatusInternalServerError)
+		return
+	}
+	tokens, err := issueTokens(w, g.DB, session, user.Role, user.Name, user.Email, newToken)
+	if err != nil {
+		log.Error("error signing access token in RefreshTokenHandler:", err.Error())
+		http.Error(w, "internal error refreshing session", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
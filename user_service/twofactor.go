@@ -0,0 +1,590 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/audit"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/crypt"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const loginChallengeVerify = "verify"
+const loginChallengeEnroll = "enroll"
+
+// wrong codes allowed on one login challenge before the password has to be given again
+const maxLoginChallengeAttempts = 5
+
+// 2FA is offered to the accounts that control payouts and the catalogue
+var twoFactorRoles = map[string]bool{
+	utils.SellerRole: true,
+	utils.AdminRole:  true,
+}
+
+// newTOTPSetup stores a new unconfirmed secret for the user and returns it in plain
+func newTOTPSetup(q *db.Queries, userID uuid.UUID) (string, error) {
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := crypt.Encrypt(secret)
+	if err != nil {
+		return "", err
+	}
+	_, err = q.UpsertPendingUserTOTP(context.TODO(), db.UpsertPendingUserTOTPParams{
+		UserID: userID,
+		Secret: encrypted,
+	})
+	if err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// confirmTOTPSetup checks the first code from the authenticator app and turns 2FA on,
+// the returned recovery codes are shown to the user once
+func confirmTOTPSetup(q *db.Queries, userID uuid.UUID, code string) ([]string, bool, error) {
+	totp, err := q.GetUserTOTPByUserID(context.TODO(), userID)
+	if err == sql.ErrNoRows || (err == nil && totp.Enabled) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	secret, err := crypt.Decrypt(totp.Secret)
+	if err != nil {
+		return nil, false, err
+	}
+	step, ok := utils.ValidateTOTP(secret, code, time.Now(), totp.LastUsedStep)
+	if !ok {
+		return nil, false, nil
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+	qtx := q.WithTx(tx)
+	_, err = qtx.EnableUserTOTP(context.TODO(), db.EnableUserTOTPParams{UserID: userID, Step: step})
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	codes, err := replaceRecoveryCodes(qtx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, false, err
+	}
+	return codes, true, nil
+}
+
+func replaceRecoveryCodes(q *db.Queries, userID uuid.UUID) ([]string, error) {
+	codes, hashes, err := utils.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err = q.DeleteRecoveryCodesByUserID(context.TODO(), userID); err != nil {
+		return nil, err
+	}
+	for _, h := range hashes {
+		err = q.AddRecoveryCode(context.TODO(), db.AddRecoveryCodeParams{UserID: userID, CodeHash: h})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused recovery code
+func verifySecondFactor(q *db.Queries, userID uuid.UUID, code string) (bool, error) {
+	totp, err := q.GetUserTOTPByUserID(context.TODO(), userID)
+	if err == sql.ErrNoRows || (err == nil && !totp.Enabled) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	secret, err := crypt.Decrypt(totp.Secret)
+	if err != nil {
+		return false, err
+	}
+	if step, ok := utils.ValidateTOTP(secret, code, time.Now(), totp.LastUsedStep); ok {
+		result, err := q.SetUserTOTPLastUsedStep(context.TODO(), db.SetUserTOTPLastUsedStepParams{UserID: userID, Step: step})
+		if err != nil {
+			return false, err
+		}
+		n, _ := result.RowsAffected()
+		return n == 1, nil
+	}
+	result, err := q.UseRecoveryCode(context.TODO(), db.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: utils.HashRecoveryCode(code),
+	})
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	return n == 1, nil
+}
+
+// startTwoFactorLogin is called once the password is right. when the user has 2FA,
+// or their role requires it, it answers with a login challenge instead of a session
+// and returns true. false means the session can be issued right away
+func startTwoFactorLogin(w http.ResponseWriter, q *db.Queries, userID uuid.UUID, role, email string) bool {
+	if !twoFactorRoles[role] {
+		return false
+	}
+	totp, err := q.GetUserTOTPByUserID(context.TODO(), userID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Warn("error fetching totp in startTwoFactorLogin:", err.Error())
+		http.Error(w, "internal error checking two factor authentication", http.StatusInternalServerError)
+		return true
+	}
+	enabled := err == nil && totp.Enabled
+
+	var resp struct {
+		Message        string `json:"message"`
+		TwoFactor      string `json:"two_factor"`
+		ChallengeToken string `json:"challenge_token"`
+		Secret         string `json:"secret,omitempty"`
+		OtpauthURI     string `json:"otpauth_uri,omitempty"`
+	}
+	purpose := loginChallengeVerify
+	if !enabled {
+		required, err := q.IsRole2FARequired(context.TODO(), role)
+		if err != nil {
+			log.Warn("error fetching 2fa policy in startTwoFactorLogin:", err.Error())
+			http.Error(w, "internal error checking two factor authentication", http.StatusInternalServerError)
+			return true
+		}
+		if !required {
+			return false
+		}
+		// 2FA is required but not set up, the login goes on once it is
+		purpose = loginChallengeEnroll
+		resp.Secret, err = newTOTPSetup(q, userID)
+		if err != nil {
+			log.Warn("error setting up totp in startTwoFactorLogin:", err.Error())
+			http.Error(w, "internal error setting up two factor authentication", http.StatusInternalServerError)
+			return true
+		}
+		resp.OtpauthURI = utils.TOTPProvisioningURI(utils.EcomName, email, resp.Secret)
+	}
+
+	token, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		log.Warn("error generating challenge token in startTwoFactorLogin:", err.Error())
+		http.Error(w, "internal error starting login", http.StatusInternalServerError)
+		return true
+	}
+	_, err = q.AddLoginChallenge(context.TODO(), db.AddLoginChallengeParams{
+		UserID:    userID,
+		TokenHash: hash,
+		Purpose:   purpose,
+	})
+	if err != nil {
+		log.Warn("error adding login challenge in startTwoFactorLogin:", err.Error())
+		http.Error(w, "internal error starting login", http.StatusInternalServerError)
+		return true
+	}
+	resp.ChallengeToken = token
+	resp.TwoFactor = purpose
+	if purpose == loginChallengeEnroll {
+		resp.Message = "two factor authentication is required for your account. add the secret to an authenticator app and send a code to /login/2fa"
+	} else {
+		resp.Message = "enter the code from your authenticator app, or a recovery code, at /login/2fa"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+	return true
+}
+
+// resetLoginLimits clears the failed password and code attempts of an account
+// once both factors of a login went through
+func resetLoginLimits(userID uuid.UUID, email string) {
+	if err := loginAccountLimiter.Reset(context.TODO(), email); err != nil {
+		log.Warn("error resetting login attempts:", err.Error())
+	}
+	if err := twoFactorAccountLimiter.Reset(context.TODO(), userID.String()); err != nil {
+		log.Warn("error resetting 2fa attempts:", err.Error())
+	}
+}
+
+// second step of the login, the session is only issued here once the code is verified
+func (g *Guest) LoginTwoFactorHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ChallengeToken string `json:"challenge_token"`
+		Code           string `json:"code"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil || req.ChallengeToken == "" || req.Code == "" {
+		http.Error(w, "challenge_token and code are required", http.StatusBadRequest)
+		return
+	}
+	challenge, err := g.DB.GetLoginChallengeByTokenHash(context.TODO(), utils.HashRefreshToken(req.ChallengeToken))
+	if err == sql.ErrNoRows {
+		http.Error(w, "invalid or expired login challenge. login again", http.StatusUnauthorized)
+		return
+	} else if err != nil {
+		log.Warn("error fetching login challenge in LoginTwoFactorHandler:", err.Error())
+		http.Error(w, "internal error verifying code", http.StatusInternalServerError)
+		return
+	}
+	// counted per account too, a new challenge doesn't bring fresh attempts
+	if locked(w, twoFactorAccountLimiter, challenge.UserID.String()) {
+		return
+	}
+	attempts, err := g.DB.IncLoginChallengeAttempts(context.TODO(), challenge.ID)
+	if err != nil {
+		log.Warn("error counting attempts in LoginTwoFactorHandler:", err.Error())
+		http.Error(w, "internal error verifying code", http.StatusInternalServerError)
+		return
+	}
+	if attempts > maxLoginChallengeAttempts {
+		g.DB.DeleteLoginChallengeByID(context.TODO(), challenge.ID)
+		http.Error(w, "too many wrong codes. login again", http.StatusUnauthorized)
+		return
+	}
+	user, err := g.DB.GetUserById(context.TODO(), challenge.UserID)
+	if err != nil {
+		log.Warn("error fetching user in LoginTwoFactorHandler:", err.Error())
+		http.Error(w, "internal error verifying code", http.StatusInternalServerError)
+		return
+	} else if user.IsBlocked {
+		http.Error(w, "user is blocked", http.StatusForbidden)
+		return
+	}
+
+	var recoveryCodes []string
+	var ok bool
+	if challenge.Purpose == loginChallengeEnroll {
+		recoveryCodes, ok, err = confirmTOTPSetup(g.DB, user.ID, req.Code)
+	} else {
+		ok, err = verifySecondFactor(g.DB, user.ID, req.Code)
+	}
+	if err != nil {
+		log.Warn("error verifying code in LoginTwoFactorHandler:", err.Error())
+		http.Error(w, "internal error verifying code", http.StatusInternalServerError)
+		return
+	} else if !ok {
+		recordFailure(twoFactorAccountLimiter, user.ID.String())
+		http.Error(w, "invalid code", http.StatusUnauthorized)
+		return
+	}
+	resetLoginLimits(user.ID, user.Email)
+	if err = g.DB.DeleteLoginChallengeByID(context.TODO(), challenge.ID); err != nil {
+		log.Warn("error deleting login challenge in LoginTwoFactorHandler:", err.Error())
+	}
+
+	tokens, err := startSession(w, r, g.DB, user.ID, user.Role, user.Name, user.Email)
+	if err != nil {
+		log.Warn("error starting session in LoginTwoFactorHandler:", err.Error())
+		http.Error(w, "internal error starting session", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Message       string     `json:"message"`
+		Tokens        respTokens `json:"tokens"`
+		RecoveryCodes []string   `json:"recovery_codes,omitempty"`
+	}
+	resp.Message = "successfully logged in"
+	if recoveryCodes != nil {
+		resp.Message = "two factor authentication enabled and logged in. keep the recovery codes safe, they are only shown once"
+	}
+	resp.Tokens = tokens
+	resp.RecoveryCodes = recoveryCodes
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// twoFactorClaims is accountClaims limited to the roles 2FA is offered to
+func twoFactorClaims(w http.ResponseWriter, r *http.Request) *utils.AccessClaims {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return nil
+	}
+	if !twoFactorRoles[claims.Role] {
+		http.Error(w, "two factor authentication is only available to sellers and admins", http.StatusForbidden)
+		return nil
+	}
+	return claims
+}
+
+func (g *Guest) TwoFactorStatusHandler(w http.ResponseWriter, r *http.Request) {
+	claims := twoFactorClaims(w, r)
+	if claims == nil {
+		return
+	}
+	totp, err := g.DB.GetUserTOTPByUserID(context.TODO(), claims.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		log.Warn("error fetching totp in TwoFactorStatusHandler:", err.Error())
+		http.Error(w, "internal error fetching two factor status", http.StatusInternalServerError)
+		return
+	}
+	required, err := g.DB.IsRole2FARequired(context.TODO(), claims.Role)
+	if err != nil {
+		log.Warn("error fetching 2fa policy in TwoFactorStatusHandler:", err.Error())
+		http.Error(w, "internal error fetching two factor status", http.StatusInternalServerError)
+		return
+	}
+	left, err := g.DB.GetUnusedRecoveryCodeCount(context.TODO(), claims.UserID)
+	if err != nil {
+		log.Warn("error counting recovery codes in TwoFactorStatusHandler:", err.Error())
+		http.Error(w, "internal error fetching two factor status", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Enabled           bool       `json:"enabled"`
+		EnabledAt         *time.Time `json:"enabled_at"`
+		Required          bool       `json:"required"`
+		RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+	}
+	resp.Enabled = totp.Enabled
+	if totp.EnabledAt.Valid {
+		resp.EnabledAt = &totp.EnabledAt.Time
+	}
+	resp.Required = required
+	resp.RecoveryCodesLeft = left
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// start setting up 2FA, the secret is confirmed with a code at /auth/2fa/enable
+func (g *Guest) TwoFactorSetupHandler(w http.ResponseWriter, r *http.Request) {
+	claims := twoFactorClaims(w, r)
+	if claims == nil {
+		return
+	}
+	secret, err := newTOTPSetup(g.DB, claims.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "two factor authentication is already enabled", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error setting up totp in TwoFactorSetupHandler:", err.Error())
+		http.Error(w, "internal error setting up two factor authentication", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Message    string `json:"message"`
+		Secret     string `json:"secret"`
+		OtpauthURI string `json:"otpauth_uri"`
+	}
+	resp.Message = "add the secret to an authenticator app, or show the otpauth_uri as a QR code, and confirm a code at /auth/2fa/enable"
+	resp.Secret = secret
+	resp.OtpauthURI = utils.TOTPProvisioningURI(utils.EcomName, claims.Email, secret)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (g *Guest) TwoFactorEnableHandler(w http.ResponseWriter, r *http.Request) {
+	claims := twoFactorClaims(w, r)
+	if claims == nil {
+		return
+	}
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	codes, ok, err := confirmTOTPSetup(g.DB, claims.UserID, req.Code)
+	if err != nil {
+		log.Warn("error enabling totp in TwoFactorEnableHandler:", err.Error())
+		http.Error(w, "internal error enabling two factor authentication", http.StatusInternalServerError)
+		return
+	} else if !ok {
+		http.Error(w, "invalid code, or no pending setup. start one at /auth/2fa/setup", http.StatusBadRequest)
+		return
+	}
+	var resp struct {
+		Message       string   `json:"message"`
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	resp.Message = "two factor authentication enabled. keep the recovery codes safe, they are only shown once"
+	resp.RecoveryCodes = codes
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (g *Guest) TwoFactorDisableHandler(w http.ResponseWriter, r *http.Request) {
+	claims := twoFactorClaims(w, r)
+	if claims == nil {
+		return
+	}
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	required, err := g.DB.IsRole2FARequired(context.TODO(), claims.Role)
+	if err != nil {
+		log.Warn("error fetching 2fa policy in TwoFactorDisableHandler:", err.Error())
+		http.Error(w, "internal error disabling two factor authentication", http.StatusInternalServerError)
+		return
+	} else if required {
+		http.Error(w, "two factor authentication is required for your account", http.StatusForbidden)
+		return
+	}
+	if locked(w, twoFactorAccountLimiter, claims.UserID.String()) {
+		return
+	}
+	ok, err := verifySecondFactor(g.DB, claims.UserID, req.Code)
+	if err != nil {
+		log.Warn("error verifying code in TwoFactorDisableHandler:", err.Error())
+		http.Error(w, "internal error disabling two factor authentication", http.StatusInternalServerError)
+		return
+	} else if !ok {
+		recordFailure(twoFactorAccountLimiter, claims.UserID.String())
+		http.Error(w, "invalid code", http.StatusUnauthorized)
+		return
+	}
+	if err = twoFactorAccountLimiter.Reset(context.TODO(), claims.UserID.String()); err != nil {
+		log.Warn("error resetting 2fa attempts in TwoFactorDisableHandler:", err.Error())
+	}
+	if err = g.DB.DeleteUserTOTP(context.TODO(), claims.UserID); err != nil {
+		log.Warn("error deleting totp in TwoFactorDisableHandler:", err.Error())
+		http.Error(w, "internal error disabling two factor authentication", http.StatusInternalServerError)
+		return
+	}
+	if err = g.DB.DeleteRecoveryCodesByUserID(context.TODO(), claims.UserID); err != nil {
+		log.Warn("error deleting recovery codes in TwoFactorDisableHandler:", err.Error())
+	}
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("two factor authentication disabled"))
+}
+
+// replace every recovery code, the old ones stop working
+func (g *Guest) RecoveryCodesHandler(w http.ResponseWriter, r *http.Request) {
+	claims := twoFactorClaims(w, r)
+	if claims == nil {
+		return
+	}
+	var req struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if locked(w, twoFactorAccountLimiter, claims.UserID.String()) {
+		return
+	}
+	ok, err := verifySecondFactor(g.DB, claims.UserID, req.Code)
+	if err != nil {
+		log.Warn("error verifying code in RecoveryCodesHandler:", err.Error())
+		http.Error(w, "internal error generating recovery codes", http.StatusInternalServerError)
+		return
+	} else if !ok {
+		recordFailure(twoFactorAccountLimiter, claims.UserID.String())
+		http.Error(w, "invalid code", http.StatusUnauthorized)
+		return
+	}
+	if err = twoFactorAccountLimiter.Reset(context.TODO(), claims.UserID.String()); err != nil {
+		log.Warn("error resetting 2fa attempts in RecoveryCodesHandler:", err.Error())
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in RecoveryCodesHandler:", err.Error())
+		http.Error(w, "internal error generating recovery codes", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	codes, err := replaceRecoveryCodes(g.DB.WithTx(tx), claims.UserID)
+	if err != nil {
+		log.Warn("error replacing recovery codes in RecoveryCodesHandler:", err.Error())
+		http.Error(w, "internal error generating recovery codes", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing transaction in RecoveryCodesHandler:", err.Error())
+		http.Error(w, "internal error generating recovery codes", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Message       string   `json:"message"`
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+	resp.Message = "new recovery codes generated, the old ones no longer work"
+	resp.RecoveryCodes = codes
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *Admin) TwoFactorPoliciesHandler(w http.ResponseWriter, r *http.Request) {
+	policies, err := a.DB.GetRole2FAPolicies(context.TODO())
+	if err != nil {
+		log.Warn("error fetching 2fa policies in TwoFactorPoliciesHandler:", err.Error())
+		http.Error(w, "internal error fetching 2fa policies", http.StatusInternalServerError)
+		return
+	}
+	var resp struct {
+		Data []db.Role2faPolicy `json:"data"`
+	}
+	resp.Data = policies
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// require 2FA for a role, users of the role without it set it up on their next login
+func (a *Admin) EditTwoFactorPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	user := helper.GetUserHelper(w, r)
+	if user.ID == uuid.Nil {
+		return
+	}
+	var req struct {
+		Role     string `json:"role"`
+		Required bool   `json:"required"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid data format", http.StatusBadRequest)
+		return
+	}
+	if !twoFactorRoles[req.Role] {
+		http.Error(w, "2fa can only be required for sellers and admins", http.StatusBadRequest)
+		return
+	}
+	required, err := a.DB.IsRole2FARequired(context.TODO(), req.Role)
+	if err != nil {
+		log.Warn("error fetching 2fa policy in EditTwoFactorPolicyHandler:", err.Error())
+		http.Error(w, "internal error saving 2fa policy", http.StatusInternalServerError)
+		return
+	}
+	policy, err := a.DB.UpsertRole2FAPolicy(context.TODO(), db.UpsertRole2FAPolicyParams{
+		Role:       req.Role,
+		Require2fa: req.Required,
+		UpdatedBy:  uuid.NullUUID{UUID: user.ID, Valid: true},
+	})
+	if err != nil {
+		log.Warn("error saving 2fa policy in EditTwoFactorPolicyHandler:", err.Error())
+		http.Error(w, "internal error saving 2fa policy", http.StatusInternalServerError)
+		return
+	}
+	auditLog.Record(r, audit.Entry{
+		Action:     audit.ActionTwoFactorPolicyEdit,
+		EntityType: audit.EntityTwoFactorPolicy,
+		EntityID:   policy.Role,
+		Before:     map[string]any{"require_2fa": required},
+		After:      map[string]any{"require_2fa": policy.Require2fa},
+	})
+	var resp struct {
+		Message string           `json:"message"`
+		Data    db.Role2faPolicy `json:"data"`
+	}
+	resp.Message = "2fa policy updated"
+	resp.Data = policy
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
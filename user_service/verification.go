@@ -0,0 +1,351 @@
+package user_service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	db "user_service/db/sqlc"
+
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/mail"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/sms"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/utils"
+	"github.com/amankhys/multi_vendor_ecommerce_go/pkg/validators"
+	log "github.com/sirupsen/logrus"
+)
+
+// phone verification codes are texted with the provider set in SMS_PROVIDER
+var smsProvider = newSMSProvider()
+
+func newSMSProvider() sms.Provider {
+	p, err := sms.New()
+	if err != nil {
+		log.Fatal("error setting up sms provider: ", err)
+	}
+	return p
+}
+
+// start an email change, the current password confirms it. the otp is mailed to the
+// new address and the account keeps its current email until the otp is confirmed at /account/email/confirm
+func (g *Guest) ChangeEmailHandler(w http.ResponseWriter, r *http.Request) {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return
+	}
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	req.Email = strings.TrimSpace(req.Email)
+	if !validators.ValidateEmail(req.Email) {
+		http.Error(w, "invalid email format", http.StatusBadRequest)
+		return
+	}
+	if req.Password == "" {
+		http.Error(w, "password required to change the email", http.StatusBadRequest)
+		return
+	}
+
+	user, err := g.DB.GetUserById(context.TODO(), claims.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching user in ChangeEmailHandler:", err.Error())
+		http.Error(w, "internal error fetching user", http.StatusInternalServerError)
+		return
+	}
+
+	// wrong passwords count as failed logins of the account
+	if locked(w, loginAccountLimiter, user.Email) {
+		return
+	}
+	account, err := g.DB.GetUserWithPasswordByEmail(context.TODO(), user.Email)
+	if err != nil {
+		log.Warn("error fetching user in ChangeEmailHandler:", err.Error())
+		http.Error(w, "internal error fetching user", http.StatusInternalServerError)
+		return
+	}
+	if err = utils.ComparePassword(req.Password, account.Password); err != nil {
+		recordFailure(loginAccountLimiter, user.Email)
+		http.Error(w, "wrong password", http.StatusUnauthorized)
+		return
+	}
+
+	if strings.EqualFold(user.Email, req.Email) {
+		http.Error(w, "the new email is the current email", http.StatusBadRequest)
+		return
+	}
+	_, err = g.DB.GetUserByEmail(context.TODO(), req.Email)
+	if err == nil {
+		http.Error(w, "email already in use", http.StatusConflict)
+		return
+	} else if err != sql.ErrNoRows {
+		log.Warn("error checking email in ChangeEmailHandler:", err.Error())
+		http.Error(w, "internal error checking email", http.StatusInternalServerError)
+		return
+	}
+
+	change, err := g.DB.AddEmailChange(context.TODO(), db.AddEmailChangeParams{
+		UserID:   user.ID,
+		NewEmail: req.Email,
+	})
+	if err == sql.ErrNoRows {
+		// the last otp was sent less than a minute ago
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "wait a minute before requesting another otp", http.StatusTooManyRequests)
+		return
+	} else if err != nil {
+		log.Warn("error adding email change in ChangeEmailHandler:", err.Error())
+		http.Error(w, "internal error generating otp", http.StatusInternalServerError)
+		return
+	}
+	if err = mail.SendEmailChangeOTPMail(int(change.Otp), change.ExpiresAt, change.NewEmail); err != nil {
+		log.Warn("error sending email change otp in ChangeEmailHandler:", err.Error())
+		if err = g.DB.DeleteEmailChangesByUserID(context.TODO(), user.ID); err != nil {
+			log.Warn("error deleting email change after a failed mail in ChangeEmailHandler:", err.Error())
+		}
+		http.Error(w, "internal error sending otp", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte(fmt.Sprintf("otp sent to %s. the account email stays %s until the change is confirmed", change.NewEmail, user.Email)))
+}
+
+// confirm the email change with the otp mailed to the new address
+func (g *Guest) ConfirmEmailChangeHandler(w http.ResponseWriter, r *http.Request) {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return
+	}
+	var req struct {
+		Otp int `json:"otp"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	if !validators.ValidateOTP(req.Otp) {
+		http.Error(w, "invalid OTP format", http.StatusBadRequest)
+		return
+	}
+
+	ip := utils.GetClientIPString(r)
+	account := claims.UserID.String()
+	if locked(w, otpIPLimiter, ip) || locked(w, otpAccountLimiter, account) {
+		return
+	}
+
+	change, err := g.DB.GetValidEmailChangeByUserID(context.TODO(), claims.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no pending email change. request a new otp", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching email change in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error fetching otp", http.StatusInternalServerError)
+		return
+	}
+	if req.Otp != int(change.Otp) {
+		recordFailure(otpIPLimiter, ip)
+		recordFailure(otpAccountLimiter, account)
+		attempts, err := g.DB.IncrementEmailChangeAttempts(context.TODO(), change.ID)
+		if err != nil {
+			log.Warn("error counting otp attempts in ConfirmEmailChangeHandler:", err.Error())
+		} else if attempts >= maxOTPAttempts {
+			// a used up otp drops the change, it has to be requested again
+			if err = g.DB.DeleteEmailChangesByUserID(context.TODO(), claims.UserID); err != nil {
+				log.Warn("error deleting used up email change in ConfirmEmailChangeHandler:", err.Error())
+			}
+			http.Error(w, "invalid otp. too many wrong attempts, request a new otp", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "invalid otp", http.StatusBadRequest)
+		return
+	}
+	if err = otpAccountLimiter.Reset(context.TODO(), account); err != nil {
+		log.Warn("error resetting otp attempts in ConfirmEmailChangeHandler:", err.Error())
+	}
+
+	// the address could have been taken since the otp was sent
+	_, err = g.DB.GetUserByEmail(context.TODO(), change.NewEmail)
+	if err == nil {
+		http.Error(w, "email already in use", http.StatusConflict)
+		return
+	} else if err != sql.ErrNoRows {
+		log.Warn("error checking email in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error checking email", http.StatusInternalServerError)
+		return
+	}
+
+	tx, err := dbConn.Begin()
+	if err != nil {
+		log.Warn("error starting transaction in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error changing email", http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+	qtx := g.DB.WithTx(tx)
+	user, err := qtx.GetUserById(context.TODO(), claims.UserID)
+	if err != nil {
+		log.Warn("error fetching user in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error changing email", http.StatusInternalServerError)
+		return
+	}
+	_, err = qtx.ChangeUserEmail(context.TODO(), db.ChangeUserEmailParams{
+		ID:    claims.UserID,
+		Email: change.NewEmail,
+	})
+	if err != nil {
+		log.Warn("error changing email in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error changing email", http.StatusInternalServerError)
+		return
+	}
+	if err = qtx.DeleteEmailChangesByUserID(context.TODO(), claims.UserID); err != nil {
+		log.Warn("error deleting email changes in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error changing email", http.StatusInternalServerError)
+		return
+	}
+	if err = tx.Commit(); err != nil {
+		log.Warn("error committing email change in ConfirmEmailChangeHandler:", err.Error())
+		http.Error(w, "internal error changing email", http.StatusInternalServerError)
+		return
+	}
+
+	// let the old address know in case the change wasn't made by its owner
+	err = mail.SendNotificationMail("Your email address was changed",
+		fmt.Sprintf("The email of your account was changed to %s on %s. If you did not make this change, contact support right away.",
+			change.NewEmail, time.Now().Format("02 Jan 2006, 03:04 PM MST")),
+		user.Email)
+	if err != nil {
+		log.Warn("error mailing the old address in ConfirmEmailChangeHandler:", err.Error())
+	}
+
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte(fmt.Sprintf("email changed to %s. it shows in your tokens from the next refresh", change.NewEmail)))
+}
+
+// text a code to the phone of the user, confirm it at /account/phone/confirm
+func (g *Guest) VerifyPhoneHandler(w http.ResponseWriter, r *http.Request) {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return
+	}
+	user, err := g.DB.GetUserById(context.TODO(), claims.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "user not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		log.Warn("error fetching user in VerifyPhoneHandler:", err.Error())
+		http.Error(w, "internal error fetching user", http.StatusInternalServerError)
+		return
+	}
+	if !user.Phone.Valid {
+		http.Error(w, "no phone number added. add one by editing the profile", http.StatusBadRequest)
+		return
+	}
+	if user.PhoneVerified {
+		http.Error(w, "phone number already verified", http.StatusBadRequest)
+		return
+	}
+
+	code, err := g.DB.AddPhoneVerification(context.TODO(), db.AddPhoneVerificationParams{
+		UserID: user.ID,
+		Phone:  user.Phone.Int64,
+	})
+	if err == sql.ErrNoRows {
+		// the last code was sent less than a minute ago
+		w.Header().Set("Retry-After", "60")
+		http.Error(w, "wait a minute before requesting another code", http.StatusTooManyRequests)
+		return
+	} else if err != nil {
+		log.Warn("error adding phone verification in VerifyPhoneHandler:", err.Error())
+		http.Error(w, "internal error generating code", http.StatusInternalServerError)
+		return
+	}
+	msg := fmt.Sprintf("%06d is your verification code. it expires in 10 minutes, do not share it with anyone.", code.Otp)
+	if err = smsProvider.Send(context.TODO(), sms.Number(code.Phone), msg); err != nil {
+		log.Warn("error texting verification code in VerifyPhoneHandler:", err.Error())
+		if err = g.DB.DeletePhoneVerificationsByUserID(context.TODO(), user.ID); err != nil {
+			log.Warn("error deleting phone verification after a failed sms in VerifyPhoneHandler:", err.Error())
+		}
+		http.Error(w, "internal error sending code", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte(fmt.Sprintf("verification code sent to the phone ending in %02d", code.Phone%100)))
+}
+
+// confirm the code texted to the phone, the phone counts as verified from then on
+func (g *Guest) ConfirmPhoneHandler(w http.ResponseWriter, r *http.Request) {
+	claims := accountClaims(w, r)
+	if claims == nil {
+		return
+	}
+	var req struct {
+		Otp int `json:"otp"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	if !validators.ValidateOTP(req.Otp) {
+		http.Error(w, "invalid code format", http.StatusBadRequest)
+		return
+	}
+
+	ip := utils.GetClientIPString(r)
+	account := claims.UserID.String()
+	if locked(w, otpIPLimiter, ip) || locked(w, otpAccountLimiter, account) {
+		return
+	}
+
+	code, err := g.DB.GetValidPhoneVerificationByUserID(context.TODO(), claims.UserID)
+	if err == sql.ErrNoRows {
+		http.Error(w, "no pending phone verification. request a new code", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		log.Warn("error fetching phone verification in ConfirmPhoneHandler:", err.Error())
+		http.Error(w, "internal error fetching code", http.StatusInternalServerError)
+		return
+	}
+	if req.Otp != int(code.Otp) {
+		recordFailure(otpIPLimiter, ip)
+		recordFailure(otpAccountLimiter, account)
+		attempts, err := g.DB.IncrementPhoneVerificationAttempts(context.TODO(), code.ID)
+		if err != nil {
+			log.Warn("error counting code attempts in ConfirmPhoneHandler:", err.Error())
+		} else if attempts >= maxOTPAttempts {
+			if err = g.DB.DeletePhoneVerificationsByUserID(context.TODO(), claims.UserID); err != nil {
+				log.Warn("error deleting used up phone verification in ConfirmPhoneHandler:", err.Error())
+			}
+			http.Error(w, "invalid code. too many wrong attempts, request a new code", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "invalid code", http.StatusBadRequest)
+		return
+	}
+	if err = otpAccountLimiter.Reset(context.TODO(), account); err != nil {
+		log.Warn("error resetting otp attempts in ConfirmPhoneHandler:", err.Error())
+	}
+
+	n, err := g.DB.VerifyUserPhone(context.TODO(), db.VerifyUserPhoneParams{
+		ID:    claims.UserID,
+		Phone: sql.NullInt64{Int64: code.Phone, Valid: true},
+	})
+	if err != nil {
+		log.Warn("error verifying phone in ConfirmPhoneHandler:", err.Error())
+		http.Error(w, "internal error verifying phone", http.StatusInternalServerError)
+		return
+	}
+	if err = g.DB.DeletePhoneVerificationsByUserID(context.TODO(), claims.UserID); err != nil {
+		log.Warn("error deleting phone verifications in ConfirmPhoneHandler:", err.Error())
+	}
+	if n == 0 {
+		http.Error(w, "the phone number was changed after the code was sent. request a new code", http.StatusConflict)
+		return
+	}
+
+	w.Header().Add("Content-Type", "text/plain")
+	w.Write([]byte("phone number verified"))
+}